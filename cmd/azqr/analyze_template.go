@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azqr/internal/adapters"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	analyzeTemplateCmd.PersistentFlags().StringP("exclusions", "e", "", "Exclusions file (YAML format)")
+	analyzeTemplateCmd.PersistentFlags().StringSliceP("tags", "", []string{}, "Only evaluate rules with at least one of these tags (e.g. CIS)")
+	rootCmd.AddCommand(analyzeTemplateCmd)
+}
+
+var analyzeTemplateCmd = &cobra.Command{
+	Use:   "analyze-template <file>",
+	Short: "Evaluate azqr rules against an ARM template or Bicep file",
+	Long: `Evaluate azqr rules against an ARM template or a Bicep file and print the findings as markdown table.
+Bicep files are compiled with the bicep CLI, which must be in PATH.
+Supported resources: Microsoft.DocumentDB/databaseAccounts and Microsoft.Cache/Redis.
+Recommendations relying on other resources (e.g. private endpoints) are always reported.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exclusionsFile, _ := cmd.Flags().GetString("exclusions")
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+
+		var resources []adapters.Resource
+		if strings.EqualFold(filepath.Ext(args[0]), ".bicep") {
+			compiler := adapters.NewBicepCompiler()
+			res, err := compiler.Parse(context.Background(), args[0])
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to analyze Bicep file")
+			}
+			resources = res
+		} else {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				log.Fatal().Err(err).Msgf("failed reading data from file: %s", args[0])
+			}
+			adapter := adapters.ARMTemplateAdapter{}
+			res, err := adapter.Parse(data)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to analyze template")
+			}
+			resources = res
+		}
+		if len(resources) == 0 {
+			log.Info().Msg("No supported resources found")
+			return
+		}
+
+		printFindings(resources, loadExclusions(exclusionsFile), tags)
+	},
+}
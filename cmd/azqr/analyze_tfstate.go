@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/adapters"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	analyzeTFStateCmd.PersistentFlags().StringP("exclusions", "e", "", "Exclusions file (YAML format)")
+	analyzeTFStateCmd.PersistentFlags().StringSliceP("tags", "", []string{}, "Only evaluate rules with at least one of these tags (e.g. CIS)")
+	rootCmd.AddCommand(analyzeTFStateCmd)
+}
+
+var analyzeTFStateCmd = &cobra.Command{
+	Use:   "analyze-tfstate <file>",
+	Short: "Evaluate azqr rules against a Terraform state or plan",
+	Long: `Evaluate azqr rules against the JSON representation of a Terraform state (terraform show -json)
+or plan (terraform plan -out plan && terraform show -json plan) and print the findings as markdown table.
+Supported resources: azurerm_cosmosdb_account and azurerm_redis_cache.
+Recommendations relying on other resources (e.g. private endpoints) are always reported.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exclusionsFile, _ := cmd.Flags().GetString("exclusions")
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed reading data from file: %s", args[0])
+		}
+		adapter := adapters.TFStateAdapter{}
+		resources, err := adapter.Parse(data)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to analyze Terraform file")
+		}
+		if len(resources) == 0 {
+			log.Info().Msgf("No supported resources found. Supported types: %s", strings.Join(adapter.SupportedTypes(), ", "))
+			return
+		}
+
+		printFindings(resources, loadExclusions(exclusionsFile), tags)
+	},
+}
+
+// loadExclusions - Loads the exclusions of the YAML file, if any
+func loadExclusions(exclusionsFile string) *scanners.Exclude {
+	exclusions := scanners.Filters{
+		Azqr: &scanners.AzqrFilter{
+			Exclude: &scanners.Exclude{},
+		},
+	}
+	if exclusionsFile != "" {
+		data, err := os.ReadFile(exclusionsFile)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed reading data from file: %s", exclusionsFile)
+		}
+		err = yaml.Unmarshal(data, &exclusions)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed parsing yaml from file: %s", exclusionsFile)
+		}
+	}
+	return exclusions.Azqr.Exclude
+}
+
+// printFindings - Prints the non compliant and not applicable results of the resources as markdown table
+func printFindings(resources []adapters.Resource, exclusions *scanners.Exclude, tags []string) {
+	scanContext := &scanners.ScanContext{
+		Exclusions: exclusions,
+		TagFilter:  tags,
+	}
+
+	fmt.Println("Address | Id | Category | Impact | Recommendation | Result | More Info")
+	fmt.Println("---|---|---|---|---|---|---")
+	for _, r := range resources {
+		results := adapters.Evaluate(context.Background(), r, scanContext)
+
+		keys := make([]string, 0, len(results))
+		for k := range results {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			rr := results[k]
+			result := rr.Result
+			switch {
+			case rr.NotApplicablePreDeploy:
+				result = "Not applicable before deployment"
+			case !rr.NotCompliant:
+				continue
+			}
+			fmt.Printf("%s | %s | %s | %s | %s | %s | [Learn](%s)", r.Address, rr.Id, rr.Category, rr.Impact, rr.Recommendation, result, rr.Learn)
+			fmt.Println()
+		}
+	}
+}
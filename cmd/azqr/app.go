@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/app"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(appCmd)
+}
+
+var appCmd = &cobra.Command{
+	Use:   "app",
+	Short: "Scan Azure App Service",
+	Long:  "Scan Azure App Service",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceScanners := []scanners.IAzureScanner{
+			&app.AppServiceScanner{},
+		}
+
+		scan(cmd, serviceScanners)
+	},
+}
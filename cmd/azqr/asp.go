@@ -15,12 +15,12 @@ func init() {
 
 var planCmd = &cobra.Command{
 	Use:   "asp",
-	Short: "Scan Azure App Service",
-	Long:  "Scan Azure App Service",
+	Short: "Scan Azure App Service Plan",
+	Long:  "Scan Azure App Service Plan",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		serviceScanners := []scanners.IAzureScanner{
-			&asp.AppServiceScanner{},
+			&asp.AppServicePlanScanner{},
 		}
 
 		scan(cmd, serviceScanners)
@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/bas"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(basCmd)
+}
+
+var basCmd = &cobra.Command{
+	Use:   "bas",
+	Short: "Scan Azure Bastion",
+	Long:  "Scan Azure Bastion",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceScanners := []scanners.IAzureScanner{
+			&bas.BastionScanner{},
+		}
+
+		scan(cmd, serviceScanners)
+	},
+}
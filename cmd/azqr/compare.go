@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Azure/azqr/internal"
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	compareSubscriptionsCmd.PersistentFlags().StringP("baseline", "", "", "Baseline Azure Subscription Id (e.g. production)")
+	compareSubscriptionsCmd.PersistentFlags().StringP("target", "", "", "Target Azure Subscription Id compared to the baseline (e.g. staging)")
+	compareSubscriptionsCmd.PersistentFlags().StringP("output-name", "o", "", "Output file name without extension, suffixed with _baseline and _target for the scan reports")
+	compareSubscriptionsCmd.PersistentFlags().BoolP("azure-cli-credential", "f", false, "Force the use of Azure CLI Credential")
+	compareSubscriptionsCmd.PersistentFlags().StringP("exclusions", "e", "", "Exclusions file (YAML format)")
+	compareSubscriptionsCmd.PersistentFlags().BoolP("debug", "", false, "Set log level to debug")
+	_ = compareSubscriptionsCmd.MarkPersistentFlagRequired("baseline")
+	_ = compareSubscriptionsCmd.MarkPersistentFlagRequired("target")
+	rootCmd.AddCommand(compareSubscriptionsCmd)
+}
+
+var compareSubscriptionsCmd = &cobra.Command{
+	Use:   "compare-subscriptions",
+	Short: "Compare the scan results of two subscriptions",
+	Long: `Scan a baseline and a target subscription and print, as markdown, the rules failing only in the target
+(regressions), only in the baseline (improvements) or in both. Resources are matched by type and name,
+ignoring their resource group. Resource types deployed in only one of the subscriptions are listed too.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		baselineID, _ := cmd.Flags().GetString("baseline")
+		targetID, _ := cmd.Flags().GetString("target")
+		outputName, _ := cmd.Flags().GetString("output-name")
+		forceAzureCliCredential, _ := cmd.Flags().GetBool("azure-cli-credential")
+		exclusionsFile, _ := cmd.Flags().GetString("exclusions")
+		debug, _ := cmd.Flags().GetBool("debug")
+
+		if baselineID == targetID {
+			log.Fatal().Msg("--baseline and --target must be different subscriptions")
+		}
+		if outputName == "" {
+			outputName = "azqr_compare"
+		}
+
+		scan := func(subscriptionID, suffix string) []scanners.AzureServiceResult {
+			return internal.Scan(&internal.ScanParams{
+				SubscriptionID:          subscriptionID,
+				OutputName:              fmt.Sprintf("%s_%s", outputName, suffix),
+				Mask:                    true,
+				Debug:                   debug,
+				ServiceScanners:         internal.GetScanners(),
+				ForceAzureCliCredential: forceAzureCliCredential,
+				ExclusionsFile:          exclusionsFile,
+			})
+		}
+
+		baseline := scan(baselineID, "baseline")
+		target := scan(targetID, "target")
+
+		renderers.WriteMarkdownDiff(os.Stdout, renderers.CompareSubscriptionResults(baseline, target))
+	},
+}
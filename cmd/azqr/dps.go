@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/dps"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(dpsCmd)
+}
+
+var dpsCmd = &cobra.Command{
+	Use:   "dps",
+	Short: "Scan Azure IoT Hub Device Provisioning Service",
+	Long:  "Scan Azure IoT Hub Device Provisioning Service",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceScanners := []scanners.IAzureScanner{
+			&dps.DPSScanner{},
+		}
+
+		scan(cmd, serviceScanners)
+	},
+}
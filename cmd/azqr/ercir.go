@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/ercir"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(ercirCmd)
+}
+
+var ercirCmd = &cobra.Command{
+	Use:   "ercir",
+	Short: "Scan ExpressRoute Circuit",
+	Long:  "Scan ExpressRoute Circuit",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceScanners := []scanners.IAzureScanner{
+			&ercir.ExpressRouteCircuitScanner{},
+		}
+
+		scan(cmd, serviceScanners)
+	},
+}
@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/evgt"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(evgtCmd)
+}
+
+var evgtCmd = &cobra.Command{
+	Use:   "evgt",
+	Short: "Scan Azure Event Grid Topics",
+	Long:  "Scan Azure Event Grid Topics",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceScanners := []scanners.IAzureScanner{
+			&evgt.EventGridTopicScanner{},
+		}
+
+		scan(cmd, serviceScanners)
+	},
+}
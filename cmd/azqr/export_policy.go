@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azqr/internal"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	exportPolicyCmd.PersistentFlags().StringP("output-dir", "o", "", "Directory where the policy definitions will be created (prints to stdout if empty)")
+	rootCmd.AddCommand(exportPolicyCmd)
+}
+
+var exportPolicyCmd = &cobra.Command{
+	Use:   "export-policy <rule id>...",
+	Short: "Export azqr rules as Azure Policy custom definitions",
+	Long:  "Export azqr rules as Azure Policy custom definitions with the audit effect",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+
+		rules := map[string]scanners.AzureRule{}
+		for _, scanner := range internal.GetScanners() {
+			for _, r := range scanner.GetRules() {
+				rules[strings.ToLower(r.Id)] = r
+			}
+		}
+		for _, r := range scanners.GetSharedRules() {
+			rules[strings.ToLower(r.Id)] = r
+		}
+
+		for _, id := range args {
+			rule, ok := rules[strings.ToLower(id)]
+			if !ok {
+				log.Fatal().Msgf("Rule %s not found", id)
+			}
+
+			var exportable scanners.PolicyExportable = &rule
+			definition, err := exportable.ToPolicyDefinition()
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to export policy definition")
+			}
+
+			if outputDir == "" {
+				fmt.Println(string(definition))
+				continue
+			}
+
+			file := filepath.Join(outputDir, fmt.Sprintf("azqr-%s.json", rule.Id))
+			err = os.WriteFile(file, definition, 0644)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to write policy definition: %s", file)
+			}
+			log.Info().Msgf("Policy definition for %s created: %s", rule.Id, file)
+		}
+	},
+}
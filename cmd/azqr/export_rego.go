@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	exportRegoCmd.PersistentFlags().StringP("output-dir", "o", "", "Directory where the Rego files will be created (prints to stdout if empty)")
+	rootCmd.AddCommand(exportRegoCmd)
+}
+
+var exportRegoCmd = &cobra.Command{
+	Use:   "export-rego [scanner]...",
+	Short: "Export azqr rules as Open Policy Agent (Rego) policies",
+	Long: `Export azqr rules as Open Policy Agent (Rego) policies, one package (azqr.<scanner>) per scanner,
+e.g. azqr export-rego cosmos redis. Rules that can't be expressed in Rego are exported as commented stubs.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+
+		// rules by scanner, the prefix of the rule ids
+		packages := map[string]map[string]scanners.AzureRule{}
+		add := func(rules map[string]scanners.AzureRule) {
+			for _, r := range rules {
+				scanner := strings.Split(r.Id, "-")[0]
+				if packages[scanner] == nil {
+					packages[scanner] = map[string]scanners.AzureRule{}
+				}
+				packages[scanner][r.Id] = r
+			}
+		}
+		for _, scanner := range internal.GetScanners() {
+			add(scanner.GetRules())
+		}
+		add(scanners.GetSharedRules())
+
+		names := []string{}
+		for _, a := range args {
+			if _, ok := packages[strings.ToLower(a)]; !ok {
+				log.Fatal().Msgf("Scanner %s not found", a)
+			}
+			names = append(names, strings.ToLower(a))
+		}
+		if len(names) == 0 {
+			for scanner := range packages {
+				names = append(names, scanner)
+			}
+			sort.Strings(names)
+		}
+
+		for _, scanner := range names {
+			module, err := scanners.ToRegoModule(packages[scanner])
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to export %s rules", scanner)
+			}
+			file := fmt.Sprintf("%s.rego", scanner)
+			if _, err := ast.ParseModule(file, module); err != nil {
+				log.Fatal().Err(err).Msgf("Invalid Rego generated for %s rules", scanner)
+			}
+
+			if outputDir == "" {
+				fmt.Println(module)
+				continue
+			}
+
+			file = filepath.Join(outputDir, file)
+			err = os.WriteFile(file, []byte(module), 0644)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("Failed to write Rego policy: %s", file)
+			}
+			log.Info().Msgf("Rego policy for %s rules created: %s", scanner, file)
+		}
+	},
+}
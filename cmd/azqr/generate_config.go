@@ -0,0 +1,46 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"os"
+
+	"github.com/Azure/azqr/internal"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func init() {
+	generateConfigCmd.Flags().StringP("config-output", "", "azqr-config.yaml", "Path of the generated config file")
+	rootCmd.AddCommand(generateConfigCmd)
+}
+
+var generateConfigCmd = &cobra.Command{
+	Use:   "generate-config",
+	Short: "Save the scan flags as a YAML config file",
+	Long:  "Save the scan flags passed to this command as a YAML config file. Flags left to their default value are written commented out. Flags that may hold credentials (--webhook-url and --webhook-headers) are not saved.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("config-output")
+
+		// Only the scan flags are saved, without --config: the generated file is the config
+		flags := pflag.NewFlagSet("scan", pflag.ContinueOnError)
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			if f.Name != "config-output" && f.Name != "config" && f.Name != "help" {
+				flags.AddFlag(f)
+			}
+		})
+
+		data, err := internal.GenerateConfig(flags)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to generate config")
+		}
+		// The config may still hold sensitive values, e.g. subscription ids
+		if err := os.WriteFile(output, data, 0600); err != nil {
+			log.Fatal().Err(err).Msgf("Failed to write config file %s", output)
+		}
+		log.Info().Msgf("Config written to %s", output)
+	},
+}
@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/quota"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(quotaCmd)
+}
+
+var quotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Scan Subscription Quotas",
+	Long:  "Scan Subscription Quotas",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		subscriptionScanners := []scanners.ISubscriptionScanner{
+			&quota.QuotaScanner{},
+		}
+
+		scanScopes(cmd, []scanners.IAzureScanner{}, subscriptionScanners)
+	},
+}
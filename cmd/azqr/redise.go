@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/redise"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(rediseCmd)
+}
+
+var rediseCmd = &cobra.Command{
+	Use:   "redise",
+	Short: "Scan Azure Cache for Redis Enterprise",
+	Long:  "Scan Azure Cache for Redis Enterprise",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceScanners := []scanners.IAzureScanner{
+			&redise.RedisEnterpriseScanner{},
+		}
+
+		scan(cmd, serviceScanners)
+	},
+}
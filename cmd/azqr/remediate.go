@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"context"
+	"os"
+
+	"github.com/Azure/azqr/internal"
+	"github.com/Azure/azqr/internal/remediation"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	remediateCmd.PersistentFlags().StringP("input", "i", "", "Services CSV report (<name>.services.csv) created by azqr scan with --mask=false")
+	remediateCmd.PersistentFlags().StringSliceP("rules", "r", []string{}, "Rule ids to remediate (e.g. st-009,kv-001)")
+	remediateCmd.PersistentFlags().StringP("config", "c", "", "YAML file with the remediation settings (azqr.remediation.tags and azqr.remediation.workspaceId)")
+	remediateCmd.PersistentFlags().Bool("confirm", false, "Apply the remediations. Without it azqr runs in dry-run mode and only lists them")
+	remediateCmd.PersistentFlags().BoolP("azure-cli-credential", "f", false, "Force the use of Azure CLI Credential")
+	_ = remediateCmd.MarkPersistentFlagRequired("input")
+	_ = remediateCmd.MarkPersistentFlagRequired("rules")
+	rootCmd.AddCommand(remediateCmd)
+}
+
+var remediateCmd = &cobra.Command{
+	Use:   "remediate",
+	Short: "Remediate Low impact findings (missing tags and diagnostic settings)",
+	Long:  "Remediate Low impact findings of a previous scan: adds the configured tags and enables diagnostic settings to a Log Analytics workspace",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		input, _ := cmd.Flags().GetString("input")
+		ruleIDs, _ := cmd.Flags().GetStringSlice("rules")
+		configFile, _ := cmd.Flags().GetString("config")
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		forceAzureCliCredential, _ := cmd.Flags().GetBool("azure-cli-credential")
+
+		settings := scanners.Remediation{}
+		if configFile != "" {
+			data, err := os.ReadFile(configFile)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("failed reading data from file: %s", configFile)
+			}
+			filters := scanners.Filters{}
+			err = yaml.Unmarshal(data, &filters)
+			if err != nil {
+				log.Fatal().Err(err).Msgf("failed parsing yaml from file: %s", configFile)
+			}
+			if filters.Azqr != nil && filters.Azqr.Remediation != nil {
+				settings = *filters.Azqr.Remediation
+			}
+		}
+
+		tagsRules, diagnosticsRules := remediableRules()
+		clientOptions := scanners.DefaultClientOptions()
+		remediators := []remediation.Remediator{
+			remediation.NewTagsRemediator(tagsRules, settings.Tags, clientOptions),
+			remediation.NewDiagnosticSettingsRemediator(diagnosticsRules, settings.WorkspaceID, clientOptions),
+		}
+
+		f, err := os.Open(input)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed opening file: %s", input)
+		}
+		defer f.Close()
+
+		findings, err := remediation.ReadFindings(f, ruleIDs)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("failed reading findings from file: %s", input)
+		}
+
+		var cred azcore.TokenCredential
+		if confirm {
+			if !forceAzureCliCredential {
+				cred, err = azidentity.NewDefaultAzureCredential(nil)
+			} else {
+				cred, err = azidentity.NewAzureCLICredential(nil)
+			}
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to get Azure credentials")
+			}
+		}
+
+		ctx := context.Background()
+		for _, finding := range findings {
+			var remediator remediation.Remediator
+			for _, r := range remediators {
+				if r.CanRemediate(finding.RuleID) {
+					remediator = r
+					break
+				}
+			}
+			if remediator == nil {
+				log.Warn().Msgf("No remediation available for %s on %s", finding.RuleID, finding.ResourceID)
+				continue
+			}
+
+			if !confirm {
+				log.Info().Msgf("[dry-run] Would remediate %s on %s", finding.RuleID, finding.ResourceID)
+				continue
+			}
+
+			if err := remediator.Remediate(ctx, finding.ResourceID, cred); err != nil {
+				log.Error().Err(err).Msgf("Failed to remediate %s", finding.RuleID)
+				continue
+			}
+			log.Info().Msgf("Remediated %s on %s", finding.RuleID, finding.ResourceID)
+		}
+	},
+}
+
+// remediableRules - Returns the ids of the rules whose findings can be fixed by adding tags or diagnostic settings
+func remediableRules() ([]string, []string) {
+	tagsRules := []string{}
+	diagnosticsRules := []string{}
+	for _, scanner := range internal.GetScanners() {
+		for _, r := range scanner.GetRules() {
+			switch r.AutoRemediation {
+			case scanners.AutoRemediationTags:
+				tagsRules = append(tagsRules, r.Id)
+			case scanners.AutoRemediationDiagnosticSettings:
+				diagnosticsRules = append(diagnosticsRules, r.Id)
+			}
+		}
+	}
+	return tagsRules, diagnosticsRules
+}
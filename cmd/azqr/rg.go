@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/rg"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(rgCmd)
+}
+
+var rgCmd = &cobra.Command{
+	Use:   "rg",
+	Short: "Scan Resource Groups",
+	Long:  "Scan Resource Groups",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		subscriptionScanners := []scanners.ISubscriptionScanner{
+			&rg.ResourceGroupScanner{},
+		}
+
+		scanScopes(cmd, []scanners.IAzureScanner{}, subscriptionScanners)
+	},
+}
@@ -23,15 +23,27 @@ var rootCmd = &cobra.Command{
 	Long:    `Azure Quick Review (azqr) goal is to produce a high level assessment of an Azure Subscription or Resource Group`,
 	Args:    cobra.NoArgs,
 	Version: version,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		setLogger(noColor)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		_ = cmd.Usage()
 	},
 }
 
-func Execute() {
-	output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
+func init() {
+	rootCmd.PersistentFlags().BoolP("no-color", "", false, "Disable colored output and GitHub Actions annotations")
+}
 
-	log.Logger = zerolog.New(output).With().Timestamp().Logger()
+func Execute() {
+	setLogger(false)
 
 	cobra.CheckErr(rootCmd.Execute())
 }
+
+func setLogger(noColor bool) {
+	output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339, NoColor: noColor}
+
+	log.Logger = zerolog.New(output).With().Timestamp().Logger()
+}
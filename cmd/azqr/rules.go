@@ -5,14 +5,21 @@ package azqr
 
 import (
 	"fmt"
+	"net/http"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/Azure/azqr/internal"
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
 func init() {
+	rulesCmd.PersistentFlags().StringSliceP("tags", "", []string{}, "Only print rules with at least one of these tags (e.g. CIS)")
+	rulesCmd.PersistentFlags().BoolP("validate-urls", "", false, "Check the rule URLs with HEAD requests and report the ones not found")
+	rulesCmd.AddCommand(rulesListCmd)
 	rootCmd.AddCommand(rulesCmd)
 }
 
@@ -22,32 +29,133 @@ var rulesCmd = &cobra.Command{
 	Long:  "Print all azqr rules as markdown table",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		serviceScanners := internal.GetScanners()
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+		validateURLs, _ := cmd.Flags().GetBool("validate-urls")
+		if validateURLs {
+			validateRuleURLs(tags)
+			return
+		}
+		printRules(tags)
+	},
+}
 
-		fmt.Println("#  | Id | Category | Impact | Recommendation | More Info")
-		fmt.Println("---|---|---|---|---|---")
+var rulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print azqr rules, optionally filtered by tags",
+	Long:  "Print azqr rules as markdown table, optionally filtered by tags",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+		validateURLs, _ := cmd.Flags().GetBool("validate-urls")
+		if validateURLs {
+			validateRuleURLs(tags)
+			return
+		}
+		printRules(tags)
+	},
+}
 
-		i := 0
-		for _, scanner := range serviceScanners {
-			rulesMap := scanner.GetRules()
+func printRules(tags []string) {
+	serviceScanners := internal.GetScanners()
 
-			rules := map[string]scanners.AzureRule{}
-			for _, r := range rulesMap {
-				rules[r.Id] = r
-			}
+	fmt.Println("#  | Id | Category | Impact | Recommendation | More Info")
+	fmt.Println("---|---|---|---|---|---")
 
-			keys := make([]string, 0, len(rules))
-			for k := range rules {
-				keys = append(keys, k)
+	i := 0
+	rulesMaps := []map[string]scanners.AzureRule{}
+	for _, scanner := range serviceScanners {
+		rulesMaps = append(rulesMaps, scanner.GetRules())
+	}
+	for _, scanner := range internal.GetSubscriptionScanners() {
+		rulesMaps = append(rulesMaps, scanner.GetRules())
+	}
+	rulesMaps = append(rulesMaps, scanners.GetSharedRules())
+
+	for _, rulesMap := range rulesMaps {
+		rules := map[string]scanners.AzureRule{}
+		for _, r := range rulesMap {
+			if !r.HasAnyTag(tags) {
+				continue
 			}
-			sort.Strings(keys)
+			rules[r.Id] = r
+		}
 
-			for _, k := range keys {
-				rule := rules[k]
-				i++
-				fmt.Printf("%s | %s | %s | %s | %s | [Learn](%s)", fmt.Sprint(i), rule.Id, rule.Category, rule.Impact, rule.Recommendation, rule.Url)
-				fmt.Println()
+		keys := make([]string, 0, len(rules))
+		for k := range rules {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			rule := rules[k]
+			i++
+			fmt.Printf("%s | %s | %s | %s | %s | [Learn](%s)", fmt.Sprint(i), rule.Id, rule.Category, rule.Impact, rule.Recommendation, rule.Url)
+			fmt.Println()
+		}
+	}
+}
+
+// validateRuleURLs - Makes a HEAD request to the URL of each rule and reports the ones returning 404
+func validateRuleURLs(tags []string) {
+	rulesByURL := map[string][]string{}
+	rulesMaps := []map[string]scanners.AzureRule{scanners.GetSharedRules()}
+	for _, scanner := range internal.GetScanners() {
+		rulesMaps = append(rulesMaps, scanner.GetRules())
+	}
+	for _, scanner := range internal.GetSubscriptionScanners() {
+		rulesMaps = append(rulesMaps, scanner.GetRules())
+	}
+	for _, rulesMap := range rulesMaps {
+		for _, r := range rulesMap {
+			if r.Url == "" || !r.HasAnyTag(tags) {
+				continue
 			}
+			rulesByURL[r.Url] = append(rulesByURL[r.Url], r.Id)
 		}
-	},
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	notFound := []string{}
+	failed := 0
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 10)
+	for url := range rulesByURL {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			resp, err := client.Head(url)
+			if err != nil {
+				log.Warn().Err(err).Msgf("Failed to validate %s", url)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusNotFound {
+				mu.Lock()
+				notFound = append(notFound, url)
+				mu.Unlock()
+			}
+		}(url)
+	}
+	wg.Wait()
+
+	sort.Strings(notFound)
+	for _, url := range notFound {
+		ids := rulesByURL[url]
+		sort.Strings(ids)
+		fmt.Printf("404 %s (%v)\n", url, ids)
+	}
+	if len(notFound) > 0 {
+		log.Fatal().Msgf("%d of %d rule URLs not found", len(notFound), len(rulesByURL))
+	}
+	if failed > 0 {
+		log.Fatal().Msgf("%d of %d rule URLs could not be validated", failed, len(rulesByURL))
+	}
+	log.Info().Msgf("All %d rule URLs are valid", len(rulesByURL))
 }
@@ -5,23 +5,50 @@ package azqr
 
 import (
 	"github.com/Azure/azqr/internal"
+	"github.com/Azure/azqr/internal/renderers"
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/quota"
+	"github.com/Azure/azqr/internal/scanners/rg"
 
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
 func init() {
+	scanCmd.PersistentFlags().StringP("config", "", "", "YAML config file created by generate-config. Flags passed on the command line override its values")
 	scanCmd.PersistentFlags().StringP("subscription-id", "s", "", "Azure Subscription Id")
 	scanCmd.PersistentFlags().StringP("resource-group", "g", "", "Azure Resource Group (Use with --subscription-id)")
+	scanCmd.PersistentFlags().StringP("scope", "", "", "Subscription, Resource Group or resource id to scan (e.g. /subscriptions/<id>/resourceGroups/<rg>/providers/Microsoft.Cache/Redis/<name>)")
 	scanCmd.PersistentFlags().BoolP("defender", "d", true, "Scan Defender Status")
 	scanCmd.PersistentFlags().BoolP("advisor", "a", true, "Scan Azure Advisor Recommendations")
 	scanCmd.PersistentFlags().BoolP("costs", "c", false, "Scan Azure Costs")
 	scanCmd.PersistentFlags().BoolP("excel", "x", false, "Create excel report")
+	scanCmd.PersistentFlags().BoolP("resource-graph", "", false, "Create a NDJSON report with a Resource Graph style record for each resource")
+	scanCmd.PersistentFlags().StringP("output-template", "", "", "Go text/template file used to create an additional report (e.g. examples/templates/jira.txt.tmpl)")
 	scanCmd.PersistentFlags().StringP("output-name", "o", "", "Output file name without extension")
 	scanCmd.PersistentFlags().BoolP("mask", "m", true, "Mask the subscription id in the report")
 	scanCmd.PersistentFlags().BoolP("azure-cli-credential", "f", false, "Force the use of Azure CLI Credential")
 	scanCmd.PersistentFlags().BoolP("debug", "", false, "Set log level to debug")
 	scanCmd.PersistentFlags().StringP("exclusions", "e", "", "Exclusions file (YAML format)")
+	scanCmd.PersistentFlags().BoolP("summary-only", "", false, "Only generate the scan summary, without individual resource results")
+	scanCmd.PersistentFlags().BoolP("show-created-at", "", false, "Include the resource creation time in the services table")
+	scanCmd.PersistentFlags().BoolP("show-remediation", "", false, "Include the command fixing each finding in the services table and markdown summary")
+	scanCmd.PersistentFlags().BoolP("parallel-rules", "", false, "Evaluate the rules of each resource concurrently")
+	scanCmd.PersistentFlags().BoolP("defender-integration", "", false, "Flag findings already reported as unhealthy by Microsoft Defender for Cloud recommendations")
+	scanCmd.PersistentFlags().StringSliceP("tags", "", []string{}, "Only evaluate rules with at least one of these tags (e.g. CIS)")
+	scanCmd.PersistentFlags().StringP("fail-on", "", "", "Exit with code 1 when a rule with this impact or a higher one fails (Critical, High, Medium or Low)")
+	scanCmd.PersistentFlags().BoolP("fail-on-scanner-error", "", false, "Abort the scan when a scanner fails instead of reporting the error at the end")
+	scanCmd.PersistentFlags().BoolP("strict-init", "", false, "Abort the scan when a scanner fails to initialize instead of skipping it")
+	scanCmd.PersistentFlags().StringP("webhook-url", "", "", "Post a JSON summary of the scan results to this URL when the scan completes")
+	scanCmd.PersistentFlags().StringArrayP("webhook-headers", "", []string{}, "Header added to the webhook request, in the \"Name: value\" format (can be repeated)")
+	scanCmd.PersistentFlags().Float64P("quota-threshold-high", "", 90, "Quota usage percentage above which quotas are reported with High impact")
+	scanCmd.PersistentFlags().Float64P("quota-threshold-medium", "", 80, "Quota usage percentage above which quotas are reported with Medium impact")
+	scanCmd.Flags().BoolP("include-resource-groups", "", false, "Also evaluate the rules for the Resource Groups of each subscription")
+	scanCmd.Flags().BoolP("include-quotas", "", false, "Also check the compute and network quotas of each subscription")
+
+	// generate-config accepts the scan flags to save them
+	generateConfigCmd.Flags().AddFlagSet(scanCmd.PersistentFlags())
+	generateConfigCmd.Flags().AddFlagSet(scanCmd.Flags())
 
 	rootCmd.AddCommand(scanCmd)
 }
@@ -32,12 +59,53 @@ var scanCmd = &cobra.Command{
 	Long:  "Scan Azure Resources",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		loadConfig(cmd)
 		serviceScanners := internal.GetScanners()
-		scan(cmd, serviceScanners)
+		includeResourceGroups, _ := cmd.Flags().GetBool("include-resource-groups")
+		includeQuotas, _ := cmd.Flags().GetBool("include-quotas")
+		subscriptionScanners := []scanners.ISubscriptionScanner{}
+		for _, s := range internal.GetSubscriptionScanners() {
+			if _, ok := s.(*rg.ResourceGroupScanner); ok && !includeResourceGroups {
+				continue
+			}
+			if _, ok := s.(*quota.QuotaScanner); ok && !includeQuotas {
+				continue
+			}
+			subscriptionScanners = append(subscriptionScanners, s)
+		}
+		scanScopes(cmd, serviceScanners, subscriptionScanners)
 	},
 }
 
 func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
+	loadConfig(cmd)
+	scanScopes(cmd, serviceScanners, nil)
+}
+
+// loadConfig - Sets the flags of the command from the --config file
+func loadConfig(cmd *cobra.Command) {
+	configFile, _ := cmd.Flags().GetString("config")
+	if configFile == "" {
+		return
+	}
+	config, err := internal.LoadConfig(configFile)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Failed to load config file %s", configFile)
+	}
+	// The service commands (e.g. scan redis) don't have the flags local to scan, e.g. --include-quotas
+	if cmd.Name() != "scan" {
+		for name := range config {
+			if cmd.Parent().LocalNonPersistentFlags().Lookup(name) != nil {
+				delete(config, name)
+			}
+		}
+	}
+	if err := config.Apply(cmd.Flags()); err != nil {
+		log.Fatal().Err(err).Msgf("Failed to load config file %s", configFile)
+	}
+}
+
+func scanScopes(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner, subscriptionScanners []scanners.ISubscriptionScanner) {
 	subscriptionID, _ := cmd.Flags().GetString("subscription-id")
 	resourceGroupName, _ := cmd.Flags().GetString("resource-group")
 	outputFileName, _ := cmd.Flags().GetString("output-name")
@@ -45,10 +113,48 @@ func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
 	advisor, _ := cmd.Flags().GetBool("advisor")
 	cost, _ := cmd.Flags().GetBool("costs")
 	xlsx, _ := cmd.Flags().GetBool("excel")
+	resourceGraph, _ := cmd.Flags().GetBool("resource-graph")
+	outputTemplate, _ := cmd.Flags().GetString("output-template")
 	mask, _ := cmd.Flags().GetBool("mask")
 	debug, _ := cmd.Flags().GetBool("debug")
 	forceAzureCliCredential, _ := cmd.Flags().GetBool("azure-cli-credential")
 	exclusionFile, _ := cmd.Flags().GetString("exclusions")
+	summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+	showCreatedAt, _ := cmd.Flags().GetBool("show-created-at")
+	showRemediation, _ := cmd.Flags().GetBool("show-remediation")
+	quotaThresholdHigh, _ := cmd.Flags().GetFloat64("quota-threshold-high")
+	quotaThresholdMedium, _ := cmd.Flags().GetFloat64("quota-threshold-medium")
+	parallelRules, _ := cmd.Flags().GetBool("parallel-rules")
+	tags, _ := cmd.Flags().GetStringSlice("tags")
+	defenderIntegration, _ := cmd.Flags().GetBool("defender-integration")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	webhookURL, _ := cmd.Flags().GetString("webhook-url")
+	failOnScannerError, _ := cmd.Flags().GetBool("fail-on-scanner-error")
+	strictInit, _ := cmd.Flags().GetBool("strict-init")
+	scope, _ := cmd.Flags().GetString("scope")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+
+	var failOnImpact scanners.ImpactType
+	if failOn != "" {
+		var err error
+		failOnImpact, err = scanners.ParseImpact(failOn)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid --fail-on")
+		}
+	}
+
+	resourceID := ""
+	if scope != "" {
+		if subscriptionID != "" || resourceGroupName != "" {
+			log.Fatal().Msg("--scope can't be used with --subscription-id or --resource-group")
+		}
+		var err error
+		subscriptionID, resourceGroupName, resourceID, err = scanners.ResolveScopeToScanTargets(scope)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to resolve scope")
+		}
+	}
+	webhookHeaders, _ := cmd.Flags().GetStringArray("webhook-headers")
 
 	params := internal.ScanParams{
 		SubscriptionID:          subscriptionID,
@@ -58,12 +164,35 @@ func scan(cmd *cobra.Command, serviceScanners []scanners.IAzureScanner) {
 		Advisor:                 advisor,
 		Cost:                    cost,
 		Xlsx:                    xlsx,
+		ResourceGraph:           resourceGraph,
+		OutputTemplate:          outputTemplate,
 		Mask:                    mask,
 		Debug:                   debug,
 		ServiceScanners:         serviceScanners,
 		ForceAzureCliCredential: forceAzureCliCredential,
 		ExclusionsFile:          exclusionFile,
+		SummaryOnly:             summaryOnly,
+		ShowCreatedAt:           showCreatedAt,
+		ParallelRules:           parallelRules,
+		Tags:                    tags,
+		DefenderIntegration:     defenderIntegration,
+		NoColor:                 noColor,
+		WebhookURL:              webhookURL,
+		WebhookHeaders:          webhookHeaders,
+		FailOnScannerError:      failOnScannerError,
+		StrictInit:              strictInit,
+		SubscriptionScanners:    subscriptionScanners,
+		ResourceID:              resourceID,
+		ShowRemediation:         showRemediation,
+		QuotaThresholdHigh:      quotaThresholdHigh,
+		QuotaThresholdMedium:    quotaThresholdMedium,
 	}
 
-	internal.Scan(&params)
+	results := internal.Scan(&params)
+
+	if failOnImpact != "" {
+		if failed := renderers.Summarize(results).FailedAtLeast(failOnImpact); failed > 0 {
+			log.Fatal().Msgf("%d rules with %s impact or higher failed", failed, failOnImpact)
+		}
+	}
 }
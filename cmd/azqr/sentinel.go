@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azqr
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/sentinel"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(sentinelCmd)
+}
+
+var sentinelCmd = &cobra.Command{
+	Use:   "sentinel",
+	Short: "Scan Microsoft Sentinel",
+	Long:  "Scan Microsoft Sentinel",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		serviceScanners := []scanners.IAzureScanner{
+			&sentinel.SentinelScanner{},
+		}
+
+		scan(cmd, serviceScanners)
+	},
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/cosmos"
+	"github.com/Azure/azqr/internal/scanners/redis"
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
+)
+
+// unknownSubscriptionID - Subscription used in the IDs of resources not created yet
+const unknownSubscriptionID = "00000000-0000-0000-0000-000000000000"
+
+type (
+	// Resource - Infrastructure as code resource mapped to the ARM SDK type evaluated by the azqr rules
+	Resource struct {
+		Address string
+		Type    string
+		Target  interface{}
+		Scanner scanners.IAzureScanner
+	}
+
+	// armType - Scanner and ARM SDK type used to evaluate an ARM resource type
+	armType struct {
+		scanner   func() scanners.IAzureScanner
+		target    func() interface{}
+		normalize func(target interface{})
+	}
+)
+
+// armTypes - Supported ARM resource types (lower case)
+var armTypes = map[string]armType{
+	"microsoft.documentdb/databaseaccounts": {
+		scanner:   func() scanners.IAzureScanner { return &cosmos.CosmosDBScanner{} },
+		target:    func() interface{} { return &armcosmos.DatabaseAccountGetResults{} },
+		normalize: normalizeCosmosAccount,
+	},
+	"microsoft.cache/redis": {
+		scanner:   func() scanners.IAzureScanner { return &redis.RedisScanner{} },
+		target:    func() interface{} { return &armredis.ResourceInfo{} },
+		normalize: normalizeRedisCache,
+	},
+}
+
+// Evaluate - Evaluates the scanner rules against the resource. Rules needing data only available after
+// deployment are not evaluated and their results are marked as NotApplicablePreDeploy.
+func Evaluate(ctx context.Context, r Resource, scanContext *scanners.ScanContext) map[string]scanners.AzureRuleResult {
+	rules := map[string]scanners.AzureRule{}
+	notApplicable := map[string]scanners.AzureRuleResult{}
+	for k, rule := range r.Scanner.GetRules() {
+		if !rule.RequiresDeployment {
+			rules[k] = rule
+			continue
+		}
+		if scanContext.Exclusions.IsRecommendationExcluded(rule.Id) || !rule.HasAnyTag(scanContext.TagFilter) {
+			continue
+		}
+		notApplicable[k] = scanners.AzureRuleResult{
+			Id:                     rule.Id,
+			Category:               rule.Category,
+			Recommendation:         rule.Recommendation,
+			Impact:                 rule.Impact,
+			Learn:                  rule.Url,
+			NotApplicablePreDeploy: true,
+		}
+	}
+
+	engine := scanners.RuleEngine{}
+	results := engine.EvaluateRules(ctx, rules, r.Target, scanContext)
+	for k, v := range notApplicable {
+		results[k] = v
+	}
+	return results
+}
+
+// placeholderID - Returns the ID of a resource not created yet
+func placeholderID(resourceGroup, resourceType, name string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s/%s", unknownSubscriptionID, resourceGroup, resourceType, name)
+}
+
+// normalizeCosmosAccount - Sets the properties dereferenced by the cosmos rules when they are not defined
+func normalizeCosmosAccount(target interface{}) {
+	c := target.(*armcosmos.DatabaseAccountGetResults)
+	if c.Properties == nil {
+		c.Properties = &armcosmos.DatabaseAccountGetProperties{}
+	}
+	if c.Properties.DatabaseAccountOfferType == nil {
+		c.Properties.DatabaseAccountOfferType = to.Ptr("Standard")
+	}
+	for _, l := range c.Properties.Locations {
+		if l.IsZoneRedundant == nil {
+			l.IsZoneRedundant = to.Ptr(false)
+		}
+	}
+}
+
+// normalizeRedisCache - Sets the properties dereferenced by the redis rules when they are not defined
+func normalizeRedisCache(target interface{}) {
+	c := target.(*armredis.ResourceInfo)
+	if c.Properties == nil {
+		c.Properties = &armredis.Properties{}
+	}
+	if c.Properties.SKU == nil {
+		c.Properties.SKU = &armredis.SKU{}
+	}
+	if c.Properties.SKU.Name == nil {
+		c.Properties.SKU.Name = to.Ptr(armredis.SKUName(""))
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// parameterExpression - Template expression referencing only a parameter, e.g. [parameters('name')]
+var parameterExpression = regexp.MustCompile(`^\[parameters\('([^']+)'\)\]$`)
+
+type (
+	// ARMTemplateAdapter - Reads ARM templates and Bicep files compiled to JSON (az bicep build)
+	ARMTemplateAdapter struct{}
+
+	armTemplate struct {
+		Parameters map[string]armParameter `json:"parameters"`
+		// Resources - Array of resources, or object of symbolic names to resources with languageVersion 2.0
+		Resources json.RawMessage `json:"resources"`
+	}
+
+	armParameter struct {
+		DefaultValue interface{} `json:"defaultValue"`
+	}
+)
+
+// Parse - Returns the supported resources of an ARM template, including child resources and the
+// templates of nested deployments (Bicep modules). Parameters are replaced with their default values,
+// other template expressions can't be resolved before deployment and are ignored.
+func (a *ARMTemplateAdapter) Parse(data []byte) ([]Resource, error) {
+	template := armTemplate{}
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse ARM template: %w", err)
+	}
+	if len(template.Resources) == 0 {
+		return nil, fmt.Errorf("no resources found, expected an ARM template or a compiled Bicep file")
+	}
+	return parseTemplate(template, "")
+}
+
+func parseTemplate(template armTemplate, parentType string) ([]Resource, error) {
+	resources, err := templateResources(template.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := map[string]interface{}{}
+	for k, p := range template.Parameters {
+		parameters[strings.ToLower(k)] = p.DefaultValue
+	}
+
+	res := []Resource{}
+	for _, r := range resources {
+		// nested templates with the inner scope (Bicep modules) only use their own parameters
+		inner := innerTemplate(r)
+		r = resolveParameters(r, parameters).(map[string]interface{})
+		if inner != nil {
+			r["properties"].(map[string]interface{})["template"] = inner
+		}
+		if boolValue(r, "existing") {
+			continue
+		}
+		resourceType := stringValue(r, "type")
+		if parentType != "" && !strings.Contains(resourceType, "/") {
+			resourceType = parentType + "/" + resourceType
+		}
+
+		if strings.EqualFold(resourceType, "Microsoft.Resources/deployments") {
+			nested, err := nestedTemplate(r)
+			if err != nil {
+				return nil, err
+			}
+			if nested != nil {
+				nestedResources, err := parseTemplate(*nested, "")
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, nestedResources...)
+			}
+			continue
+		}
+
+		if children, ok := r["resources"]; ok {
+			data, err := json.Marshal(children)
+			if err != nil {
+				return nil, err
+			}
+			childResources, err := parseTemplate(armTemplate{Parameters: template.Parameters, Resources: data}, resourceType)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, childResources...)
+		}
+
+		t, ok := armTypes[strings.ToLower(resourceType)]
+		if !ok {
+			continue
+		}
+		target, err := templateTarget(r, resourceType, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map %s %s: %w", resourceType, stringValue(r, "name"), err)
+		}
+		res = append(res, Resource{
+			Address: fmt.Sprintf("%s/%s", resourceType, stringValue(r, "name")),
+			Type:    resourceType,
+			Target:  target,
+			Scanner: t.scanner(),
+		})
+	}
+	return res, nil
+}
+
+// templateResources - Returns the resources of a template, sorted by symbolic name for languageVersion 2.0
+func templateResources(data json.RawMessage) ([]map[string]interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	list := []map[string]interface{}{}
+	if err := json.Unmarshal(data, &list); err == nil {
+		return list, nil
+	}
+
+	symbolic := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(data, &symbolic); err != nil {
+		return nil, fmt.Errorf("failed to parse template resources: %w", err)
+	}
+	names := make([]string, 0, len(symbolic))
+	for k := range symbolic {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		list = append(list, symbolic[k])
+	}
+	return list, nil
+}
+
+// nestedTemplate - Returns the inline template of a deployment, nil for linked templates.
+// Parameter values set by the deployment replace the default values of the template.
+func nestedTemplate(r map[string]interface{}) (*armTemplate, error) {
+	properties, _ := r["properties"].(map[string]interface{})
+	inline, ok := properties["template"]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(inline)
+	if err != nil {
+		return nil, err
+	}
+	template := armTemplate{}
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, err
+	}
+
+	values, _ := properties["parameters"].(map[string]interface{})
+	for k, v := range values {
+		p, _ := v.(map[string]interface{})
+		if value, ok := p["value"]; ok && !isExpression(value) {
+			if template.Parameters == nil {
+				template.Parameters = map[string]armParameter{}
+			}
+			template.Parameters[k] = armParameter{DefaultValue: value}
+		}
+	}
+	return &template, nil
+}
+
+// innerTemplate - Returns the nested template of a deployment evaluated with the inner scope
+func innerTemplate(r map[string]interface{}) interface{} {
+	properties, _ := r["properties"].(map[string]interface{})
+	options, _ := properties["expressionEvaluationOptions"].(map[string]interface{})
+	if !strings.EqualFold(stringValue(options, "scope"), "inner") {
+		return nil
+	}
+	return properties["template"]
+}
+
+// templateTarget - Unmarshals the template resource into the ARM SDK type
+func templateTarget(r map[string]interface{}, resourceType string, t armType) (interface{}, error) {
+	resource := map[string]interface{}{}
+	for _, k := range []string{"properties", "sku", "zones", "kind", "identity"} {
+		if v, ok := r[k]; ok {
+			resource[k] = removeExpressions(v)
+		}
+	}
+	if tags, ok := r["tags"].(map[string]interface{}); ok {
+		resource["tags"] = tags
+	}
+	name := stringValue(r, "name")
+	resource["id"] = placeholderID("template", resourceType, name)
+	resource["name"] = name
+	resource["type"] = resourceType
+	resource["location"] = stringValue(r, "location")
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	target := t.target()
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, err
+	}
+	t.normalize(target)
+	return target, nil
+}
+
+// resolveParameters - Replaces [parameters('name')] expressions with the parameter default values
+func resolveParameters(v interface{}, parameters map[string]interface{}) interface{} {
+	switch value := v.(type) {
+	case string:
+		if m := parameterExpression.FindStringSubmatch(value); m != nil {
+			if p, ok := parameters[strings.ToLower(m[1])]; ok && p != nil {
+				return p
+			}
+		}
+		return value
+	case map[string]interface{}:
+		res := map[string]interface{}{}
+		for k, item := range value {
+			res[k] = resolveParameters(item, parameters)
+		}
+		return res
+	case []interface{}:
+		res := []interface{}{}
+		for _, item := range value {
+			res = append(res, resolveParameters(item, parameters))
+		}
+		return res
+	}
+	return v
+}
+
+// removeExpressions - Removes the template expressions that could not be resolved, so they don't break
+// the unmarshaling of non string properties
+func removeExpressions(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		res := map[string]interface{}{}
+		for k, item := range value {
+			if isExpression(item) {
+				continue
+			}
+			res[k] = removeExpressions(item)
+		}
+		return res
+	case []interface{}:
+		res := []interface{}{}
+		for _, item := range value {
+			if isExpression(item) {
+				continue
+			}
+			res = append(res, removeExpressions(item))
+		}
+		return res
+	}
+	return v
+}
+
+// isExpression - Returns true for template expressions, strings starting with [ but not with the [[ escape
+func isExpression(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && strings.HasPrefix(s, "[") && !strings.HasPrefix(s, "[[") && strings.HasSuffix(s, "]")
+}
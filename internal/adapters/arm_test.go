@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package adapters
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+)
+
+func parseTemplateFixture(t *testing.T, file string) map[string]Resource {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", file, err)
+	}
+	adapter := ARMTemplateAdapter{}
+	resources, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("ARMTemplateAdapter.Parse() error = %v", err)
+	}
+	res := map[string]Resource{}
+	for _, r := range resources {
+		res[r.Address] = r
+	}
+	return res
+}
+
+func TestARMTemplateAdapter_Parse_Template(t *testing.T) {
+	resources := parseTemplateFixture(t, "testdata/template.json")
+	if len(resources) != 1 {
+		t.Fatalf("ARMTemplateAdapter.Parse() returned %d resources, want 1", len(resources))
+	}
+
+	r, ok := resources["Microsoft.DocumentDB/databaseAccounts/cosmos-app"]
+	if !ok {
+		t.Fatalf("ARMTemplateAdapter.Parse() cosmos-app not found, parameter not resolved")
+	}
+	account := r.Target.(*armcosmos.DatabaseAccountGetResults)
+	if account.Properties.DisableKeyBasedMetadataWriteAccess != nil {
+		t.Errorf("ARMTemplateAdapter.Parse() unresolved expression not removed")
+	}
+
+	results := Evaluate(context.Background(), r, &scanners.ScanContext{Exclusions: &scanners.Exclude{}})
+	tests := []struct {
+		rule          string
+		broken        bool
+		notApplicable bool
+	}{
+		{"cosmos-001", false, true},
+		{"cosmos-016", false, true},
+		{"cosmos-002", true, false},
+		{"cosmos-004", true, false},
+		{"cosmos-007", true, false},
+		{"cosmos-008", false, false},
+		{"cosmos-009", true, false},
+		{"cosmos-015", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			result, ok := results[tt.rule]
+			if !ok {
+				t.Fatalf("rule %s not evaluated", tt.rule)
+			}
+			if result.Error != nil {
+				t.Fatalf("rule %s error = %v", tt.rule, result.Error)
+			}
+			if result.NotCompliant != tt.broken {
+				t.Errorf("rule %s NotCompliant = %v, want %v", tt.rule, result.NotCompliant, tt.broken)
+			}
+			if result.NotApplicablePreDeploy != tt.notApplicable {
+				t.Errorf("rule %s NotApplicablePreDeploy = %v, want %v", tt.rule, result.NotApplicablePreDeploy, tt.notApplicable)
+			}
+		})
+	}
+}
+
+func TestARMTemplateAdapter_Parse_Bicep(t *testing.T) {
+	resources := parseTemplateFixture(t, "testdata/bicep.json")
+	if len(resources) != 2 {
+		t.Fatalf("ARMTemplateAdapter.Parse() returned %d resources, want 2 (existing resources are skipped)", len(resources))
+	}
+
+	tests := []struct {
+		address string
+		rule    string
+		want    bool
+	}{
+		{"Microsoft.Cache/Redis/redis-app", "redis-002", false},
+		{"Microsoft.Cache/Redis/redis-app", "redis-007", false},
+		{"Microsoft.Cache/Redis/redis-app", "redis-008", false},
+		{"Microsoft.Cache/Redis/redis-app", "redis-009", false},
+		{"Microsoft.Cache/Redis/redis-module", "redis-002", true},
+		{"Microsoft.Cache/Redis/redis-module", "redis-008", true},
+		{"Microsoft.Cache/Redis/redis-module", "redis-009", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address+"/"+tt.rule, func(t *testing.T) {
+			r, ok := resources[tt.address]
+			if !ok {
+				t.Fatalf("ARMTemplateAdapter.Parse() %s not found", tt.address)
+			}
+			results := Evaluate(context.Background(), r, &scanners.ScanContext{Exclusions: &scanners.Exclude{}})
+			if results[tt.rule].NotCompliant != tt.want {
+				t.Errorf("rule %s NotCompliant = %v, want %v", tt.rule, results[tt.rule].NotCompliant, tt.want)
+			}
+		})
+	}
+}
+
+func TestARMTemplateAdapter_Parse_Invalid(t *testing.T) {
+	adapter := ARMTemplateAdapter{}
+	for _, data := range []string{"not json", `{"parameters": {}}`} {
+		if _, err := adapter.Parse([]byte(data)); err == nil {
+			t.Errorf("ARMTemplateAdapter.Parse(%q) expected error", data)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package adapters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// bicepModule - Module declaration with a local path, e.g. module storage './storage.bicep' = {
+var bicepModule = regexp.MustCompile(`(?m)^\s*module\s+\w+\s+'([^']+)'`)
+
+// BicepCompiler - Compiles Bicep files to ARM templates with the bicep CLI
+type BicepCompiler struct {
+	cacheDir string
+	// run - Runs the bicep CLI and returns its standard output
+	run func(ctx context.Context, args ...string) ([]byte, error)
+}
+
+// NewBicepCompiler - Creates a BicepCompiler caching the compiled templates in the temp directory
+func NewBicepCompiler() *BicepCompiler {
+	return &BicepCompiler{
+		cacheDir: filepath.Join(os.TempDir(), "azqr-bicep"),
+		run:      runBicep,
+	}
+}
+
+// Parse - Compiles the Bicep file and returns its supported resources
+func (c *BicepCompiler) Parse(ctx context.Context, file string) ([]Resource, error) {
+	template, err := c.Compile(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	adapter := ARMTemplateAdapter{}
+	return adapter.Parse(template)
+}
+
+// Compile - Returns the ARM template of the Bicep file. bicep build inlines the local modules as
+// nested deployments, so the compiled template is cached by the SHA256 of the file and its modules.
+func (c *BicepCompiler) Compile(ctx context.Context, file string) ([]byte, error) {
+	key, err := bicepHash(file)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := filepath.Join(c.cacheDir, key+".json")
+	if data, err := os.ReadFile(cached); err == nil {
+		log.Debug().Msgf("Using compiled template %s for %s", cached, file)
+		return data, nil
+	}
+
+	data, err := c.run(ctx, "build", "--stdout", file)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
+		log.Warn().Err(err).Msg("Failed to cache compiled template")
+		return data, nil
+	}
+	if err := os.WriteFile(cached, data, 0644); err != nil {
+		log.Warn().Err(err).Msg("Failed to cache compiled template")
+	}
+	return data, nil
+}
+
+func runBicep(ctx context.Context, args ...string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, "bicep", args...).Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("bicep CLI not found in PATH. Install it with 'az bicep install' and add its folder (~/.azure/bin) to PATH, " +
+				"or see https://learn.microsoft.com/en-us/azure/azure-resource-manager/bicep/install")
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("bicep build failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// bicepHash - Returns the SHA256 of the Bicep file and of the local modules it references, recursively
+func bicepHash(file string) (string, error) {
+	files := map[string][]byte{}
+	if err := readBicepModules(file, files); err != nil {
+		return "", err
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		h.Write([]byte(p))
+		h.Write(files[p])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func readBicepModules(file string, files map[string][]byte) error {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	if _, ok := files[path]; ok {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	files[path] = data
+
+	for _, m := range bicepModule.FindAllStringSubmatch(string(data), -1) {
+		// registry (br:) and template spec (ts:) modules are restored by bicep build
+		if strings.Contains(m[1], ":") {
+			continue
+		}
+		if err := readBicepModules(filepath.Join(filepath.Dir(path), m[1]), files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
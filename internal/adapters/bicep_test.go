@@ -0,0 +1,91 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type fakeBicep struct {
+	template []byte
+	calls    [][]string
+}
+
+func (f *fakeBicep) run(ctx context.Context, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	return f.template, nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestBicepCompiler_Parse(t *testing.T) {
+	template, err := os.ReadFile("testdata/bicep.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.bicep")
+	module := filepath.Join(dir, "modules", "cache.bicep")
+	if err := os.Mkdir(filepath.Join(dir, "modules"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, main, "module data './modules/cache.bicep' = {\n  name: 'data'\n}\nmodule acr 'br/public:avm/res/container-registry/registry:0.1.0' = {\n  name: 'acr'\n}\n")
+	writeFile(t, module, "param cacheName string\n")
+
+	bicep := &fakeBicep{template: template}
+	compiler := &BicepCompiler{cacheDir: filepath.Join(dir, "cache"), run: bicep.run}
+
+	resources, err := compiler.Parse(context.Background(), main)
+	if err != nil {
+		t.Fatalf("BicepCompiler.Parse() error = %v", err)
+	}
+	if len(resources) != 2 {
+		t.Fatalf("BicepCompiler.Parse() returned %d resources, want 2", len(resources))
+	}
+	if want := [][]string{{"build", "--stdout", main}}; !reflect.DeepEqual(bicep.calls, want) {
+		t.Errorf("bicep called with %v, want %v", bicep.calls, want)
+	}
+
+	// unchanged files are read from the cache
+	if _, err := compiler.Parse(context.Background(), main); err != nil {
+		t.Fatalf("BicepCompiler.Parse() error = %v", err)
+	}
+	if len(bicep.calls) != 1 {
+		t.Errorf("bicep called %d times, want 1", len(bicep.calls))
+	}
+
+	// changing a module compiles again
+	writeFile(t, module, "param cacheName string = 'redis'\n")
+	if _, err := compiler.Parse(context.Background(), main); err != nil {
+		t.Fatalf("BicepCompiler.Parse() error = %v", err)
+	}
+	if len(bicep.calls) != 2 {
+		t.Errorf("bicep called %d times, want 2", len(bicep.calls))
+	}
+}
+
+func TestBicepCompiler_Compile_MissingModule(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.bicep")
+	writeFile(t, main, "module data './missing.bicep' = {\n  name: 'data'\n}\n")
+
+	bicep := &fakeBicep{}
+	compiler := &BicepCompiler{cacheDir: filepath.Join(dir, "cache"), run: bicep.run}
+	if _, err := compiler.Compile(context.Background(), main); err == nil {
+		t.Errorf("BicepCompiler.Compile() expected error")
+	}
+	if len(bicep.calls) != 0 {
+		t.Errorf("bicep called %d times, want 0", len(bicep.calls))
+	}
+}
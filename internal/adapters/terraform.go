@@ -0,0 +1,266 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type (
+	// TFStateAdapter - Reads the JSON representation of a Terraform state or plan (terraform show -json)
+	TFStateAdapter struct{}
+
+	tfDocument struct {
+		Values        *tfValues `json:"values"`
+		PlannedValues *tfValues `json:"planned_values"`
+	}
+
+	tfValues struct {
+		RootModule tfModule `json:"root_module"`
+	}
+
+	tfModule struct {
+		Resources    []tfResource `json:"resources"`
+		ChildModules []tfModule   `json:"child_modules"`
+	}
+
+	tfResource struct {
+		Address string                 `json:"address"`
+		Mode    string                 `json:"mode"`
+		Type    string                 `json:"type"`
+		Values  map[string]interface{} `json:"values"`
+	}
+
+	tfMapping struct {
+		armType string
+		convert func(values map[string]interface{}) map[string]interface{}
+	}
+)
+
+var tfMappings = map[string]tfMapping{
+	"azurerm_cosmosdb_account": {
+		armType: "Microsoft.DocumentDB/databaseAccounts",
+		convert: cosmosAccountProperties,
+	},
+	"azurerm_redis_cache": {
+		armType: "Microsoft.Cache/Redis",
+		convert: redisCacheProperties,
+	},
+}
+
+// Parse - Returns the supported managed resources of a Terraform state or plan. Plans are read from
+// planned_values, so resources are evaluated as they will be after terraform apply.
+func (a *TFStateAdapter) Parse(data []byte) ([]Resource, error) {
+	doc := tfDocument{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse terraform json: %w", err)
+	}
+
+	values := doc.PlannedValues
+	if values == nil {
+		values = doc.Values
+	}
+	if values == nil {
+		return nil, fmt.Errorf("no values or planned_values found, use the output of terraform show -json")
+	}
+
+	resources := []Resource{}
+	for _, r := range moduleResources(values.RootModule) {
+		mapping, ok := tfMappings[r.Type]
+		if !ok || r.Mode != "managed" {
+			continue
+		}
+		t := armTypes[strings.ToLower(mapping.armType)]
+		target, err := toARM(r, mapping, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map %s: %w", r.Address, err)
+		}
+		resources = append(resources, Resource{
+			Address: r.Address,
+			Type:    r.Type,
+			Target:  target,
+			Scanner: t.scanner(),
+		})
+	}
+	return resources, nil
+}
+
+// SupportedTypes - Returns the Terraform resource types supported by the adapter
+func (a *TFStateAdapter) SupportedTypes() []string {
+	types := []string{}
+	for t := range tfMappings {
+		types = append(types, t)
+	}
+	return types
+}
+
+func moduleResources(m tfModule) []tfResource {
+	resources := m.Resources
+	for _, child := range m.ChildModules {
+		resources = append(resources, moduleResources(child)...)
+	}
+	return resources
+}
+
+// toARM - Builds the ARM representation of the resource and unmarshals it into the ARM SDK type
+func toARM(r tfResource, mapping tfMapping, t armType) (interface{}, error) {
+	name := stringValue(r.Values, "name")
+	id := stringValue(r.Values, "id")
+	if id == "" {
+		id = placeholderID(stringValue(r.Values, "resource_group_name"), mapping.armType, name)
+	}
+
+	resource := map[string]interface{}{
+		"id":         id,
+		"name":       name,
+		"type":       mapping.armType,
+		"location":   stringValue(r.Values, "location"),
+		"tags":       r.Values["tags"],
+		"properties": mapping.convert(r.Values),
+	}
+	if zones, ok := r.Values["zones"].([]interface{}); ok {
+		resource["zones"] = zones
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	target := t.target()
+	if err := json.Unmarshal(data, target); err != nil {
+		return nil, err
+	}
+	t.normalize(target)
+	return target, nil
+}
+
+func cosmosAccountProperties(values map[string]interface{}) map[string]interface{} {
+	locations := []interface{}{}
+	for _, l := range listValue(values, "geo_location") {
+		location, _ := l.(map[string]interface{})
+		locations = append(locations, map[string]interface{}{
+			"locationName":     stringValue(location, "location"),
+			"failoverPriority": location["failover_priority"],
+			"isZoneRedundant":  boolValue(location, "zone_redundant"),
+		})
+	}
+
+	// ip_range_filter is a comma separated string in azurerm 3.x and a list in 4.x
+	ipRules := []interface{}{}
+	ranges := []string{}
+	switch f := values["ip_range_filter"].(type) {
+	case string:
+		ranges = strings.Split(f, ",")
+	case []interface{}:
+		for _, r := range f {
+			if s, ok := r.(string); ok {
+				ranges = append(ranges, s)
+			}
+		}
+	}
+	for _, r := range ranges {
+		if r = strings.TrimSpace(r); r != "" {
+			ipRules = append(ipRules, map[string]interface{}{"ipAddressOrRange": r})
+		}
+	}
+
+	vnetRules := []interface{}{}
+	for _, v := range listValue(values, "virtual_network_rule") {
+		rule, _ := v.(map[string]interface{})
+		vnetRules = append(vnetRules, map[string]interface{}{"id": stringValue(rule, "id")})
+	}
+
+	cors := []interface{}{}
+	for _, c := range listValue(values, "cors_rule") {
+		rule, _ := c.(map[string]interface{})
+		cors = append(cors, map[string]interface{}{
+			"allowedOrigins": joinValues(rule, "allowed_origins"),
+			"allowedMethods": joinValues(rule, "allowed_methods"),
+		})
+	}
+
+	capabilities := []interface{}{}
+	for _, c := range listValue(values, "capabilities") {
+		capability, _ := c.(map[string]interface{})
+		capabilities = append(capabilities, map[string]interface{}{"name": stringValue(capability, "name")})
+	}
+
+	publicNetworkAccess := "Enabled"
+	if enabled, ok := values["public_network_access_enabled"].(bool); ok && !enabled {
+		publicNetworkAccess = "Disabled"
+	}
+
+	return map[string]interface{}{
+		"databaseAccountOfferType":           stringValue(values, "offer_type"),
+		"capabilities":                       capabilities,
+		"locations":                          locations,
+		"disableLocalAuth":                   boolValue(values, "local_authentication_disabled"),
+		"disableKeyBasedMetadataWriteAccess": !boolValueOr(values, "access_key_metadata_writes_enabled", true),
+		"enableAutomaticFailover":            boolValue(values, "automatic_failover_enabled") || boolValue(values, "enable_automatic_failover"),
+		"isVirtualNetworkFilterEnabled":      boolValue(values, "is_virtual_network_filter_enabled"),
+		"publicNetworkAccess":                publicNetworkAccess,
+		"ipRules":                            ipRules,
+		"virtualNetworkRules":                vnetRules,
+		"cors":                               cors,
+	}
+}
+
+func redisCacheProperties(values map[string]interface{}) map[string]interface{} {
+	publicNetworkAccess := "Enabled"
+	if enabled, ok := values["public_network_access_enabled"].(bool); ok && !enabled {
+		publicNetworkAccess = "Disabled"
+	}
+
+	properties := map[string]interface{}{
+		"sku": map[string]interface{}{
+			"name":     stringValue(values, "sku_name"),
+			"family":   stringValue(values, "family"),
+			"capacity": values["capacity"],
+		},
+		// enable_non_ssl_port was renamed to non_ssl_port_enabled in azurerm 4.x
+		"enableNonSslPort":    boolValue(values, "non_ssl_port_enabled") || boolValue(values, "enable_non_ssl_port"),
+		"publicNetworkAccess": publicNetworkAccess,
+	}
+	if v := stringValue(values, "minimum_tls_version"); v != "" {
+		properties["minimumTlsVersion"] = v
+	}
+	if v := stringValue(values, "subnet_id"); v != "" {
+		properties["subnetId"] = v
+	}
+	return properties
+}
+
+func stringValue(values map[string]interface{}, key string) string {
+	s, _ := values[key].(string)
+	return s
+}
+
+func boolValue(values map[string]interface{}, key string) bool {
+	return boolValueOr(values, key, false)
+}
+
+func boolValueOr(values map[string]interface{}, key string, defaultValue bool) bool {
+	if b, ok := values[key].(bool); ok {
+		return b
+	}
+	return defaultValue
+}
+
+func listValue(values map[string]interface{}, key string) []interface{} {
+	l, _ := values[key].([]interface{})
+	return l
+}
+
+// joinValues - Returns a terraform list of strings as the comma separated string used by ARM
+func joinValues(values map[string]interface{}, key string) string {
+	items := []string{}
+	for _, v := range listValue(values, key) {
+		if s, ok := v.(string); ok {
+			items = append(items, s)
+		}
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package adapters
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis"
+)
+
+func parseFixture(t *testing.T, file string) map[string]Resource {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", file, err)
+	}
+	adapter := TFStateAdapter{}
+	resources, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("TFStateAdapter.Parse() error = %v", err)
+	}
+	res := map[string]Resource{}
+	for _, r := range resources {
+		res[r.Address] = r
+	}
+	return res
+}
+
+func evaluate(t *testing.T, r Resource) map[string]scanners.AzureRuleResult {
+	t.Helper()
+	return Evaluate(context.Background(), r, &scanners.ScanContext{Exclusions: &scanners.Exclude{}})
+}
+
+func TestTFStateAdapter_Parse_Plan(t *testing.T) {
+	resources := parseFixture(t, "testdata/plan.json")
+	if len(resources) != 2 {
+		t.Fatalf("TFStateAdapter.Parse() returned %d resources, want 2", len(resources))
+	}
+
+	db, ok := resources["module.data.azurerm_cosmosdb_account.db"]
+	if !ok {
+		t.Fatalf("TFStateAdapter.Parse() cosmos account in child module not found")
+	}
+	account := db.Target.(*armcosmos.DatabaseAccountGetResults)
+	if *account.ID != "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-app/providers/Microsoft.DocumentDB/databaseAccounts/cosmos-app" {
+		t.Errorf("TFStateAdapter.Parse() ID = %s", *account.ID)
+	}
+
+	tests := []struct {
+		address string
+		rule    string
+		want    bool
+	}{
+		{"module.data.azurerm_cosmosdb_account.db", "cosmos-002", false},
+		{"module.data.azurerm_cosmosdb_account.db", "cosmos-006", false},
+		{"module.data.azurerm_cosmosdb_account.db", "cosmos-007", true},
+		{"module.data.azurerm_cosmosdb_account.db", "cosmos-008", true},
+		{"module.data.azurerm_cosmosdb_account.db", "cosmos-014", true},
+		{"module.data.azurerm_cosmosdb_account.db", "cosmos-015", true},
+		{"azurerm_redis_cache.redis", "redis-002", true},
+		{"azurerm_redis_cache.redis", "redis-006", false},
+		{"azurerm_redis_cache.redis", "redis-007", false},
+		{"azurerm_redis_cache.redis", "redis-008", false},
+		{"azurerm_redis_cache.redis", "redis-009", false},
+	}
+	results := map[string]map[string]scanners.AzureRuleResult{}
+	for address, r := range resources {
+		results[address] = evaluate(t, r)
+	}
+	// cosmos-009 depends on cosmos-008, which fails as local authentication is enabled
+	if _, ok := results["module.data.azurerm_cosmosdb_account.db"]["cosmos-009"]; ok {
+		t.Errorf("rule cosmos-009 evaluated although cosmos-008 is not compliant")
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, ok := results[tt.address][tt.rule]
+			if !ok {
+				t.Fatalf("rule %s not evaluated for %s", tt.rule, tt.address)
+			}
+			if r.Error != nil {
+				t.Fatalf("rule %s error = %v", tt.rule, r.Error)
+			}
+			if r.NotCompliant != tt.want {
+				t.Errorf("rule %s NotCompliant = %v, want %v", tt.rule, r.NotCompliant, tt.want)
+			}
+		})
+	}
+}
+
+func TestTFStateAdapter_Parse_State(t *testing.T) {
+	resources := parseFixture(t, "testdata/state.json")
+	if len(resources) != 1 {
+		t.Fatalf("TFStateAdapter.Parse() returned %d resources, want 1 (data sources are skipped)", len(resources))
+	}
+
+	r := resources["azurerm_redis_cache.redis"]
+	cache := r.Target.(*armredis.ResourceInfo)
+	if *cache.ID != "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/rg-app/providers/Microsoft.Cache/Redis/cache-app" {
+		t.Errorf("TFStateAdapter.Parse() ID = %s", *cache.ID)
+	}
+
+	results := evaluate(t, r)
+	for rule, want := range map[string]bool{"redis-002": false, "redis-007": true, "redis-008": true, "redis-009": true} {
+		if results[rule].NotCompliant != want {
+			t.Errorf("rule %s NotCompliant = %v, want %v", rule, results[rule].NotCompliant, want)
+		}
+	}
+}
+
+func TestTFStateAdapter_Parse_Invalid(t *testing.T) {
+	adapter := TFStateAdapter{}
+	for _, data := range []string{"not json", `{"format_version": "1.0"}`} {
+		if _, err := adapter.Parse([]byte(data)); err == nil {
+			t.Errorf("TFStateAdapter.Parse(%q) expected error", data)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Config - Values of the scan flags saved by the generate-config command, by flag name
+type Config map[string]interface{}
+
+// secretFlags - Flags that may hold credentials, never saved to the config file. Webhook URLs
+// usually embed an access token and webhook headers an Authorization header.
+var secretFlags = map[string]bool{
+	"webhook-url":     true,
+	"webhook-headers": true,
+}
+
+// NewConfig - Returns the values of the flags changed from their defaults, except the secret ones
+func NewConfig(flags *pflag.FlagSet) Config {
+	config := Config{}
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Changed && !secretFlags[f.Name] {
+			config[f.Name] = flagValue(f)
+		}
+	})
+	return config
+}
+
+// GenerateConfig - Returns the YAML config of the flags. Flags changed from their defaults are set,
+// the others are commented out with their default value. Secret flags are only listed, commented out,
+// without their value. Flags are sorted by name, so the output only depends on the flag values.
+func GenerateConfig(flags *pflag.FlagSet) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("# azqr configuration, flags commented out are set to their default value\n")
+
+	config := NewConfig(flags)
+	if len(config) > 0 {
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+
+	var err error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if err != nil {
+			return
+		}
+		if f.Changed && secretFlags[f.Name] {
+			buf.WriteString("# " + f.Name + ": not saved, it may contain credentials\n")
+			return
+		}
+		if f.Changed {
+			return
+		}
+		var data []byte
+		data, err = yaml.Marshal(Config{f.Name: parseFlagValue(f.Value.Type(), f.DefValue)})
+		for _, line := range strings.SplitAfter(string(data), "\n") {
+			if line != "" {
+				buf.WriteString("# " + line)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadConfig - Reads a YAML config file written by generate-config
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := Config{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Apply - Sets the flags from the config. Flags already passed on the command line keep their value.
+func (c Config) Apply(flags *pflag.FlagSet) error {
+	for name, value := range c {
+		f := flags.Lookup(name)
+		if f == nil {
+			return fmt.Errorf("unknown flag in config: %s", name)
+		}
+		if f.Changed {
+			continue
+		}
+		values, ok := value.([]interface{})
+		if !ok {
+			if err := flags.Set(name, fmt.Sprint(value)); err != nil {
+				return fmt.Errorf("invalid value for %s in config: %w", name, err)
+			}
+			continue
+		}
+		slice, ok := f.Value.(pflag.SliceValue)
+		if !ok {
+			return fmt.Errorf("invalid value for %s in config: not a list flag", name)
+		}
+		s := []string{}
+		for _, v := range values {
+			s = append(s, fmt.Sprint(v))
+		}
+		if err := slice.Replace(s); err != nil {
+			return fmt.Errorf("invalid value for %s in config: %w", name, err)
+		}
+		f.Changed = true
+	}
+	return nil
+}
+
+// flagValue - Returns the current value of the flag with its YAML type
+func flagValue(f *pflag.Flag) interface{} {
+	if s, ok := f.Value.(pflag.SliceValue); ok {
+		return s.GetSlice()
+	}
+	return parseFlagValue(f.Value.Type(), f.Value.String())
+}
+
+// parseFlagValue - Converts the string representation of a flag value to its YAML type
+func parseFlagValue(flagType, value string) interface{} {
+	switch flagType {
+	case "bool":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case "int", "int32", "int64":
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	case "float32", "float64":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "stringSlice", "stringArray":
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		if value == "" {
+			return []string{}
+		}
+		return strings.Split(value, ",")
+	}
+	return value
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func testConfigFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("scan", pflag.ContinueOnError)
+	flags.StringP("subscription-id", "s", "", "")
+	flags.BoolP("mask", "m", true, "")
+	flags.BoolP("debug", "", false, "")
+	flags.StringSliceP("tags", "", []string{}, "")
+	flags.Float64P("quota-threshold-high", "", 90, "")
+	return flags
+}
+
+func TestGenerateConfig(t *testing.T) {
+	flags := testConfigFlags()
+	err := flags.Parse([]string{"-s", "sub", "--mask=false", "--tags", "CIS,identity", "--quota-threshold-high", "95"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := GenerateConfig(flags)
+	if err != nil {
+		t.Fatalf("GenerateConfig() error = %v", err)
+	}
+	want := `# azqr configuration, flags commented out are set to their default value
+mask: false
+quota-threshold-high: 95
+subscription-id: sub
+tags:
+    - CIS
+    - identity
+# debug: false
+`
+	if string(data) != want {
+		t.Errorf("GenerateConfig() = %s, want %s", data, want)
+	}
+
+	again, _ := GenerateConfig(flags)
+	if string(again) != string(data) {
+		t.Errorf("GenerateConfig() is not idempotent: %s", again)
+	}
+}
+
+func TestGenerateConfig_SecretFlags(t *testing.T) {
+	flags := testConfigFlags()
+	flags.StringP("webhook-url", "", "", "")
+	flags.StringArrayP("webhook-headers", "", []string{}, "")
+	err := flags.Parse([]string{"-s", "sub", "--webhook-url", "https://hooks.example.com/secret", "--webhook-headers", "Authorization: Bearer secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := GenerateConfig(flags)
+	if err != nil {
+		t.Fatalf("GenerateConfig() error = %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("GenerateConfig() = %s, want the secret flag values left out", data)
+	}
+	for _, want := range []string{"# webhook-url: not saved", "# webhook-headers: not saved"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("GenerateConfig() = %s, want it to contain %q", data, want)
+		}
+	}
+}
+
+func TestGenerateConfig_RoundTrip(t *testing.T) {
+	flags := testConfigFlags()
+	err := flags.Parse([]string{"-s", "sub", "--debug", "--tags", "CIS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := GenerateConfig(flags)
+	if err != nil {
+		t.Fatalf("GenerateConfig() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "azqr-config.yaml")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(config, Config{"subscription-id": "sub", "debug": true, "tags": []interface{}{"CIS"}}) {
+		t.Errorf("LoadConfig() = %v", config)
+	}
+
+	// Applying the loaded config to new flags generates the same config
+	parsed := testConfigFlags()
+	if err := config.Apply(parsed); err != nil {
+		t.Fatalf("Config.Apply() error = %v", err)
+	}
+	roundTrip, err := GenerateConfig(parsed)
+	if err != nil {
+		t.Fatalf("GenerateConfig() error = %v", err)
+	}
+	if string(roundTrip) != string(data) {
+		t.Errorf("GenerateConfig() round trip = %s, want %s", roundTrip, data)
+	}
+}
+
+func TestConfig_Apply_CommandLineWins(t *testing.T) {
+	flags := testConfigFlags()
+	err := flags.Parse([]string{"-s", "cli", "--tags", "CIS,Security"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := Config{"subscription-id": "config", "debug": true, "tags": []interface{}{"Other"}}
+	if err := config.Apply(flags); err != nil {
+		t.Fatalf("Config.Apply() error = %v", err)
+	}
+
+	subscriptionID, _ := flags.GetString("subscription-id")
+	debug, _ := flags.GetBool("debug")
+	tags, _ := flags.GetStringSlice("tags")
+	if subscriptionID != "cli" || !debug || !reflect.DeepEqual(tags, []string{"CIS", "Security"}) {
+		t.Errorf("Config.Apply() subscription-id = %s, debug = %v, tags = %v", subscriptionID, debug, tags)
+	}
+}
+
+func TestConfig_Apply_UnknownFlag(t *testing.T) {
+	config := Config{"subscription": "sub"}
+	if err := config.Apply(testConfigFlags()); err == nil {
+		t.Error("Config.Apply() expected an error for an unknown flag")
+	}
+}
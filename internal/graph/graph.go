@@ -5,6 +5,7 @@ package graph
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -35,6 +36,21 @@ func NewGraphQuery(cred azcore.TokenCredential) *GraphQuery {
 }
 
 func (q *GraphQuery) Query(ctx context.Context, query string, subscriptionIDs []*string) *GraphResult {
+	result, err := q.Run(ctx, query, subscriptionIDs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to run Resource Graph query")
+		return nil
+	}
+	return result
+}
+
+// Run - Runs the query and returns its error instead of exiting, for optional checks that should not stop the scan.
+// Resource Graph throttles requests per tenant, so callers should run a query once per subscription rather than per resource group.
+func (q *GraphQuery) Run(ctx context.Context, query string, subscriptionIDs []*string) (*GraphResult, error) {
+	if q.client == nil {
+		return nil, errors.New("Resource Graph client not initialized")
+	}
+
 	format := arg.ResultFormatObjectArray
 	request := arg.QueryRequest{
 		Subscriptions: subscriptionIDs,
@@ -45,10 +61,6 @@ func (q *GraphQuery) Query(ctx context.Context, query string, subscriptionIDs []
 		},
 	}
 
-	if q.client == nil {
-		log.Fatal().Msg("Resource Graph client not initialized")
-	}
-
 	result := GraphResult{}
 	result.Data = make([]interface{}, 0)
 	var skipToken *string = nil
@@ -56,14 +68,12 @@ func (q *GraphQuery) Query(ctx context.Context, query string, subscriptionIDs []
 		request.Options.SkipToken = skipToken
 		// Run the query and get the results
 		results, err := q.client.Resources(ctx, request, nil)
-		if err == nil {
-			result.Count = *results.TotalRecords
-			result.Data = append(result.Data, results.Data.([]interface{})...)
-			skipToken = results.SkipToken
-		} else {
-			log.Fatal().Err(err).Msg("Failed to run Resource Graph query")
-			return nil
+		if err != nil {
+			return nil, err
 		}
+		result.Count = *results.TotalRecords
+		result.Data = append(result.Data, results.Data.([]interface{})...)
+		skipToken = results.SkipToken
 	}
-	return &result
+	return &result, nil
 }
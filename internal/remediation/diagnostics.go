@@ -0,0 +1,69 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+)
+
+// DiagnosticSettingName - Name of the diagnostic setting created by the DiagnosticSettingsRemediator
+const DiagnosticSettingName = "azqr"
+
+// DiagnosticSettingsRemediator - Enables a default diagnostic setting sending all logs and metrics to a Log Analytics workspace
+type DiagnosticSettingsRemediator struct {
+	rules         map[string]bool
+	workspaceID   string
+	clientOptions *arm.ClientOptions
+}
+
+// NewDiagnosticSettingsRemediator - Creates a DiagnosticSettingsRemediator for the given diagnostic settings rules
+func NewDiagnosticSettingsRemediator(ruleIDs []string, workspaceID string, clientOptions *arm.ClientOptions) *DiagnosticSettingsRemediator {
+	return &DiagnosticSettingsRemediator{
+		rules:         ruleSet(ruleIDs),
+		workspaceID:   workspaceID,
+		clientOptions: clientOptions,
+	}
+}
+
+// CanRemediate - Returns true for the diagnostic settings rules, when a workspace is configured
+func (r *DiagnosticSettingsRemediator) CanRemediate(ruleID string) bool {
+	return r.workspaceID != "" && r.rules[strings.ToLower(ruleID)]
+}
+
+// Remediate - Creates or updates the azqr diagnostic setting of the resource, so it can be applied repeatedly
+func (r *DiagnosticSettingsRemediator) Remediate(ctx context.Context, resourceID string, cred azcore.TokenCredential) error {
+	client, err := armmonitor.NewDiagnosticSettingsClient(cred, r.clientOptions)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CreateOrUpdate(ctx, resourceID, DiagnosticSettingName, armmonitor.DiagnosticSettingsResource{
+		Properties: &armmonitor.DiagnosticSettings{
+			WorkspaceID: to.Ptr(r.workspaceID),
+			Logs: []*armmonitor.LogSettings{
+				{
+					CategoryGroup: to.Ptr("allLogs"),
+					Enabled:       to.Ptr(true),
+				},
+			},
+			Metrics: []*armmonitor.MetricSettings{
+				{
+					Category: to.Ptr("AllMetrics"),
+					Enabled:  to.Ptr(true),
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to enable diagnostic settings for %s: %w", resourceID, err)
+	}
+	return nil
+}
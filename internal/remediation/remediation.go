@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package remediation
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+type (
+	// Remediator - Interface for the remediation of azqr findings
+	Remediator interface {
+		CanRemediate(ruleID string) bool
+		Remediate(ctx context.Context, resourceID string, cred azcore.TokenCredential) error
+	}
+
+	// Finding - Non compliant rule result read from a services report
+	Finding struct {
+		ResourceID string
+		RuleID     string
+	}
+)
+
+// ReadFindings - Reads the non compliant results of the given rules from a services CSV report.
+// The report must be created with --mask=false, so it has the Resource Id column.
+func ReadFindings(r io.Reader, ruleIDs []string) ([]Finding, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return []Finding{}, nil
+	}
+
+	columns := map[string]int{}
+	for i, h := range records[0] {
+		columns[h] = i
+	}
+	for _, c := range []string{"Compliant", "RId"} {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("column %s not found, expected an azqr services report", c)
+		}
+	}
+	if _, ok := columns["Resource Id"]; !ok {
+		return nil, fmt.Errorf("column Resource Id not found, run the scan with --mask=false")
+	}
+
+	rules := ruleSet(ruleIDs)
+
+	findings := []Finding{}
+	for _, row := range records[1:] {
+		ruleID := row[columns["RId"]]
+		if row[columns["Compliant"]] != "false" || !rules[strings.ToLower(ruleID)] {
+			continue
+		}
+		findings = append(findings, Finding{
+			ResourceID: row[columns["Resource Id"]],
+			RuleID:     ruleID,
+		})
+	}
+	return findings, nil
+}
+
+func subscriptionID(resourceID string) string {
+	parts := strings.Split(resourceID, "/")
+	for i, p := range parts {
+		if strings.EqualFold(p, "subscriptions") && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func ruleSet(ruleIDs []string) map[string]bool {
+	rules := map[string]bool{}
+	for _, id := range ruleIDs {
+		rules[strings.ToLower(id)] = true
+	}
+	return rules
+}
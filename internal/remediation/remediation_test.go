@@ -0,0 +1,177 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package remediation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const resourceID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+type request struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+type fakeTransport struct {
+	requests []request
+}
+
+func (f *fakeTransport) Do(req *http.Request) (*http.Response, error) {
+	r := request{method: req.Method, path: req.URL.Path}
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.body); err != nil {
+			return nil, err
+		}
+	}
+	f.requests = append(f.requests, r)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString("{}")),
+		Request:    req,
+	}, nil
+}
+
+func clientOptions(transport *fakeTransport) *arm.ClientOptions {
+	return &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Transport: transport,
+		},
+	}
+}
+
+func TestTagsRemediator_Remediate(t *testing.T) {
+	transport := &fakeTransport{}
+	r := NewTagsRemediator([]string{"st-008"}, map[string]string{"env": "prod"}, clientOptions(transport))
+
+	if !r.CanRemediate("ST-008") || r.CanRemediate("st-001") {
+		t.Fatalf("TagsRemediator.CanRemediate() returned unexpected results")
+	}
+
+	// Applying twice must send the same merge request
+	for i := 0; i < 2; i++ {
+		if err := r.Remediate(context.Background(), resourceID, fakeCredential{}); err != nil {
+			t.Fatalf("TagsRemediator.Remediate() error = %v", err)
+		}
+	}
+
+	want := map[string]interface{}{
+		"operation":  "Merge",
+		"properties": map[string]interface{}{"tags": map[string]interface{}{"env": "prod"}},
+	}
+	if len(transport.requests) != 2 {
+		t.Fatalf("TagsRemediator.Remediate() sent %d requests, want 2", len(transport.requests))
+	}
+	for _, req := range transport.requests {
+		if req.method != http.MethodPatch || req.path != resourceID+"/providers/Microsoft.Resources/tags/default" {
+			t.Errorf("TagsRemediator.Remediate() request = %s %s", req.method, req.path)
+		}
+		if !reflect.DeepEqual(req.body, want) {
+			t.Errorf("TagsRemediator.Remediate() body = %v, want %v", req.body, want)
+		}
+	}
+}
+
+func TestDiagnosticSettingsRemediator_Remediate(t *testing.T) {
+	transport := &fakeTransport{}
+	workspaceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.OperationalInsights/workspaces/log"
+	r := NewDiagnosticSettingsRemediator([]string{"st-001"}, workspaceID, clientOptions(transport))
+
+	if !r.CanRemediate("st-001") || NewDiagnosticSettingsRemediator([]string{"st-001"}, "", nil).CanRemediate("st-001") {
+		t.Fatalf("DiagnosticSettingsRemediator.CanRemediate() returned unexpected results")
+	}
+
+	if err := r.Remediate(context.Background(), resourceID, fakeCredential{}); err != nil {
+		t.Fatalf("DiagnosticSettingsRemediator.Remediate() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"workspaceId": workspaceID,
+			"logs":        []interface{}{map[string]interface{}{"categoryGroup": "allLogs", "enabled": true}},
+			"metrics":     []interface{}{map[string]interface{}{"category": "AllMetrics", "enabled": true}},
+		},
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("DiagnosticSettingsRemediator.Remediate() sent %d requests, want 1", len(transport.requests))
+	}
+	req := transport.requests[0]
+	if req.method != http.MethodPut || req.path != resourceID+"/providers/Microsoft.Insights/diagnosticSettings/azqr" {
+		t.Errorf("DiagnosticSettingsRemediator.Remediate() request = %s %s", req.method, req.path)
+	}
+	if !reflect.DeepEqual(req.body, want) {
+		t.Errorf("DiagnosticSettingsRemediator.Remediate() body = %v, want %v", req.body, want)
+	}
+}
+
+func TestReadFindings(t *testing.T) {
+	header := "Subscription,Subscription Name,Resource Group,Location,Type,Service Name,Compliant,Impact,Category,Recommendation,Result,Learn,RId,Resource Id\n"
+	databaseID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/rg/providers/microsoft.sql/servers/sql/databases/sqldb"
+	tests := []struct {
+		name    string
+		csv     string
+		want    []Finding
+		wantErr bool
+	}{
+		{
+			name: "non compliant findings of the selected rules",
+			csv: header +
+				"00000000-0000-0000-0000-000000000000,sub,rg,westeurope,Microsoft.Storage/storageAccounts,st,false,Low,Governance,Storage should have tags,,,st-008," + resourceID + "\n" +
+				"00000000-0000-0000-0000-000000000000,sub,rg,westeurope,Microsoft.Storage/storageAccounts,st2,true,Low,Governance,Storage should have tags,,,st-008," + resourceID + "2\n" +
+				"00000000-0000-0000-0000-000000000000,sub,rg,westeurope,Microsoft.Storage/storageAccounts,st,false,High,Security,Storage should use private endpoints,,,st-002," + resourceID + "\n",
+			want: []Finding{{ResourceID: resourceID, RuleID: "st-008"}},
+		},
+		{
+			name: "nested resource",
+			csv: header +
+				"00000000-0000-0000-0000-000000000000,sub,rg,westeurope,Microsoft.Sql/servers/databases,sqldb,false,Low,Governance,SQL Database should have tags,,,st-008," + databaseID + "\n",
+			want: []Finding{{ResourceID: databaseID, RuleID: "st-008"}},
+		},
+		{
+			name:    "masked report",
+			csv:     strings.TrimSuffix(header, ",Resource Id\n") + "\nxxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxx0000,sub,rg,westeurope,Microsoft.Storage/storageAccounts,st,false,Low,Governance,Storage should have tags,,,st-008\n",
+			wantErr: true,
+		},
+		{
+			name:    "not a services report",
+			csv:     "Subscription,Name\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFindings(strings.NewReader(tt.csv), []string{"st-008"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadFindings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadFindings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
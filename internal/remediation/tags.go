@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// TagsRemediator - Adds the required tags to resources without tags
+type TagsRemediator struct {
+	rules         map[string]bool
+	tags          map[string]string
+	clientOptions *arm.ClientOptions
+}
+
+// NewTagsRemediator - Creates a TagsRemediator for the given tags rules
+func NewTagsRemediator(ruleIDs []string, tags map[string]string, clientOptions *arm.ClientOptions) *TagsRemediator {
+	return &TagsRemediator{
+		rules:         ruleSet(ruleIDs),
+		tags:          tags,
+		clientOptions: clientOptions,
+	}
+}
+
+// CanRemediate - Returns true for the tags rules, when required tags are configured
+func (r *TagsRemediator) CanRemediate(ruleID string) bool {
+	return len(r.tags) > 0 && r.rules[strings.ToLower(ruleID)]
+}
+
+// Remediate - Merges the required tags into the resource tags. Existing tags are kept, so it can be applied repeatedly.
+func (r *TagsRemediator) Remediate(ctx context.Context, resourceID string, cred azcore.TokenCredential) error {
+	client, err := armresources.NewTagsClient(subscriptionID(resourceID), cred, r.clientOptions)
+	if err != nil {
+		return err
+	}
+
+	tags := map[string]*string{}
+	for k, v := range r.tags {
+		tags[k] = to.Ptr(v)
+	}
+
+	_, err = client.UpdateAtScope(ctx, resourceID, armresources.TagsPatchResource{
+		Operation: to.Ptr(armresources.TagsPatchOperationMerge),
+		Properties: &armresources.Tags{
+			Tags: tags,
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to add tags to %s: %w", resourceID, err)
+	}
+	return nil
+}
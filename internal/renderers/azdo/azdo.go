@@ -0,0 +1,89 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azdo
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// AzureDevOpsFormatter - Writes the findings as Azure DevOps logging commands, shown as pipeline issues
+type AzureDevOpsFormatter struct {
+	Out        io.Writer
+	WorkingDir string
+}
+
+// IsAzureDevOps - Returns true when azqr runs in an Azure Pipelines job
+func IsAzureDevOps() bool {
+	return strings.EqualFold(os.Getenv("TF_BUILD"), "true")
+}
+
+// NewAzureDevOpsFormatter - Creates an AzureDevOpsFormatter writing to stdout and to the default working directory
+func NewAzureDevOpsFormatter() *AzureDevOpsFormatter {
+	return &AzureDevOpsFormatter{
+		Out:        os.Stdout,
+		WorkingDir: os.Getenv("SYSTEM_DEFAULTWORKINGDIRECTORY"),
+	}
+}
+
+// CreateAzureDevOpsReport - Writes an issue per non compliant rule, the failing counts as pipeline
+// variables (azqr.failingHighCount, azqr.failingMediumCount and azqr.failingLowCount) and the summary
+func (f *AzureDevOpsFormatter) CreateAzureDevOpsReport(data *renderers.ReportData) {
+	counts := map[string]int{"High": 0, "Medium": 0, "Low": 0}
+	for _, d := range data.MainData {
+		for _, r := range d.Rules {
+			if !r.NotCompliant {
+				continue
+			}
+			issueType, level := issue(r.Impact)
+			counts[level]++
+
+			message := fmt.Sprintf("%s (%s) in resource group %s: %s [%s] %s", d.ServiceName, d.Type, d.ResourceGroup, r.Recommendation, r.Id, r.Learn)
+			if level == "Low" {
+				message = "[Low] " + message
+			}
+			fmt.Fprintf(f.Out, "##vso[task.logissue type=%s]%s\n", issueType, escapeData(message))
+		}
+	}
+
+	for _, level := range []string{"High", "Medium", "Low"} {
+		fmt.Fprintf(f.Out, "##vso[task.setvariable variable=azqr.failing%sCount]%d\n", level, counts[level])
+	}
+
+	if f.WorkingDir == "" {
+		return
+	}
+	summaryFile := filepath.Join(f.WorkingDir, "azqr-summary.md")
+	file, err := os.Create(summaryFile)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create the Azure DevOps summary")
+		return
+	}
+	defer file.Close()
+	renderers.WriteMarkdownSummary(file, data)
+	fmt.Fprintf(f.Out, "##vso[task.uploadsummary]%s\n", escapeData(summaryFile))
+}
+
+// issue - Returns the issue type and level of the impact: error for High, warning for Medium and Low
+func issue(impact scanners.ImpactType) (string, string) {
+	switch impact {
+	case scanners.ImpactCritical, scanners.ImpactHigh:
+		return "error", "High"
+	case scanners.ImpactMedium:
+		return "warning", "Medium"
+	}
+	return "warning", "Low"
+}
+
+// escapeData - Escapes the message of a logging command
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
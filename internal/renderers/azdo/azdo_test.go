@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package azdo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestAzureDevOpsFormatter_CreateAzureDevOpsReport(t *testing.T) {
+	t.Setenv("TF_BUILD", "True")
+	t.Setenv("SYSTEM_DEFAULTWORKINGDIRECTORY", t.TempDir())
+
+	if !IsAzureDevOps() {
+		t.Fatalf("IsAzureDevOps() = false, want true")
+	}
+
+	data := &renderers.ReportData{
+		MainData: []scanners.AzureServiceResult{
+			{
+				ResourceGroup: "rg-app",
+				ServiceName:   "redis-app",
+				Type:          "Microsoft.Cache/Redis",
+				Rules: map[string]scanners.AzureRuleResult{
+					"redis-008": {Id: "redis-008", Impact: scanners.ImpactHigh, Recommendation: "Redis should not enable non SSL ports", Learn: "https://learn.microsoft.com", NotCompliant: true},
+					"redis-009": {Id: "redis-009", Impact: scanners.ImpactHigh, Recommendation: "Redis should enforce TLS >= 1.2", NotCompliant: true},
+					"redis-007": {Id: "redis-007", Impact: scanners.ImpactLow, Recommendation: "Redis should have tags", NotCompliant: true},
+					"redis-002": {Id: "redis-002", Impact: scanners.ImpactHigh, Recommendation: "Redis should have availability zones enabled", NotCompliant: false},
+				},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	f := NewAzureDevOpsFormatter()
+	f.Out = &out
+	f.CreateAzureDevOpsReport(data)
+
+	for _, want := range []string{
+		"##vso[task.logissue type=error]redis-app (Microsoft.Cache/Redis) in resource group rg-app: Redis should not enable non SSL ports [redis-008] https://learn.microsoft.com",
+		"##vso[task.logissue type=warning][Low] redis-app",
+		"##vso[task.setvariable variable=azqr.failingHighCount]2",
+		"##vso[task.setvariable variable=azqr.failingMediumCount]0",
+		"##vso[task.setvariable variable=azqr.failingLowCount]1",
+		"##vso[task.uploadsummary]",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("CreateAzureDevOpsReport() output does not contain %s:\n%s", want, out.String())
+		}
+	}
+
+	summary, err := os.ReadFile(filepath.Join(f.WorkingDir, "azqr-summary.md"))
+	if err != nil {
+		t.Fatalf("failed to read summary: %v", err)
+	}
+	if !strings.Contains(string(summary), "| High | redis-app | rg-app |") {
+		t.Errorf("summary = %s", summary)
+	}
+}
+
+func TestEscapeData(t *testing.T) {
+	if got, want := escapeData("100%\nok"), "100%AZP25%0Aok"; got != want {
+		t.Errorf("escapeData() = %s, want %s", got, want)
+	}
+}
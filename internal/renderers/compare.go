@@ -0,0 +1,176 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+type (
+	// SubscriptionDiff - Differences between the rule results of a baseline and a target subscription
+	SubscriptionDiff struct {
+		// Regressions - Rules failing in the target but not in the baseline
+		Regressions []RuleDiff
+		// Improvements - Rules failing in the baseline but not in the target
+		Improvements []RuleDiff
+		// Consistent - Rules failing in both subscriptions
+		Consistent []RuleDiff
+		// MissingInTarget - Resource types deployed in the baseline only
+		MissingInTarget []string
+		// MissingInBaseline - Resource types deployed in the target only
+		MissingInBaseline []string
+	}
+
+	// RuleDiff - Rule result of a resource matched by type and name in both subscriptions
+	RuleDiff struct {
+		Type           string
+		ServiceName    string
+		Id             string
+		Recommendation string
+		Impact         scanners.ImpactType
+	}
+)
+
+// CompareSubscriptionResults - Compares the rule results of the resources with the same type and name,
+// ignoring their subscription and resource group
+func CompareSubscriptionResults(baseline, target []scanners.AzureServiceResult) SubscriptionDiff {
+	diff := SubscriptionDiff{
+		Regressions:       []RuleDiff{},
+		Improvements:      []RuleDiff{},
+		Consistent:        []RuleDiff{},
+		MissingInTarget:   []string{},
+		MissingInBaseline: []string{},
+	}
+
+	baselineResources, baselineTypes := indexResults(baseline)
+	targetResources, targetTypes := indexResults(target)
+
+	for t := range baselineTypes {
+		if !targetTypes[t] {
+			diff.MissingInTarget = append(diff.MissingInTarget, t)
+		}
+	}
+	for t := range targetTypes {
+		if !baselineTypes[t] {
+			diff.MissingInBaseline = append(diff.MissingInBaseline, t)
+		}
+	}
+
+	for key, b := range baselineResources {
+		t, ok := targetResources[key]
+		if !ok {
+			continue
+		}
+
+		ids := map[string]bool{}
+		for id := range b.Rules {
+			ids[id] = true
+		}
+		for id := range t.Rules {
+			ids[id] = true
+		}
+
+		for id := range ids {
+			br, bok := b.Rules[id]
+			tr, tok := t.Rules[id]
+			failsInBaseline := bok && br.NotCompliant
+			failsInTarget := tok && tr.NotCompliant
+
+			r := tr
+			if !tok {
+				r = br
+			}
+			d := RuleDiff{
+				Type:           t.Type,
+				ServiceName:    t.ServiceName,
+				Id:             id,
+				Recommendation: r.Recommendation,
+				Impact:         r.Impact,
+			}
+
+			switch {
+			case failsInBaseline && failsInTarget:
+				diff.Consistent = append(diff.Consistent, d)
+			case failsInTarget:
+				diff.Regressions = append(diff.Regressions, d)
+			case failsInBaseline:
+				diff.Improvements = append(diff.Improvements, d)
+			}
+		}
+	}
+
+	sortRuleDiffs(diff.Regressions)
+	sortRuleDiffs(diff.Improvements)
+	sortRuleDiffs(diff.Consistent)
+	sort.Strings(diff.MissingInTarget)
+	sort.Strings(diff.MissingInBaseline)
+
+	return diff
+}
+
+// WriteMarkdownDiff - Writes the differences between the subscriptions as markdown
+func WriteMarkdownDiff(w io.Writer, diff SubscriptionDiff) {
+	fmt.Fprintln(w, "## Azure Quick Review - Subscription Comparison")
+	fmt.Fprintln(w)
+	writeRuleDiffs(w, "Regressions (failing in target only)", diff.Regressions)
+	writeRuleDiffs(w, "Improvements (failing in baseline only)", diff.Improvements)
+	writeRuleDiffs(w, "Consistent findings (failing in both)", diff.Consistent)
+	writeTypes(w, "Resource types missing in target", diff.MissingInTarget)
+	writeTypes(w, "Resource types missing in baseline", diff.MissingInBaseline)
+}
+
+func writeRuleDiffs(w io.Writer, title string, diffs []RuleDiff) {
+	fmt.Fprintf(w, "### %s: %d\n", title, len(diffs))
+	fmt.Fprintln(w)
+	if len(diffs) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "| Impact | Type | Resource | Recommendation | Id |")
+	fmt.Fprintln(w, "|---|---|---|---|---|")
+	for _, d := range diffs {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n", d.Impact, d.Type, escapeCell(d.ServiceName), escapeCell(d.Recommendation), d.Id)
+	}
+	fmt.Fprintln(w)
+}
+
+func writeTypes(w io.Writer, title string, types []string) {
+	if len(types) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "### %s: %d\n", title, len(types))
+	fmt.Fprintln(w)
+	for _, t := range types {
+		fmt.Fprintf(w, "* %s\n", t)
+	}
+	fmt.Fprintln(w)
+}
+
+func indexResults(results []scanners.AzureServiceResult) (map[string]*scanners.AzureServiceResult, map[string]bool) {
+	resources := map[string]*scanners.AzureServiceResult{}
+	types := map[string]bool{}
+	for i := range results {
+		r := &results[i]
+		t := strings.ToLower(r.Type)
+		types[t] = true
+		resources[t+"/"+strings.ToLower(r.ServiceName)] = r
+	}
+	return resources, types
+}
+
+func sortRuleDiffs(diffs []RuleDiff) {
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Type != diffs[j].Type {
+			return diffs[i].Type < diffs[j].Type
+		}
+		if diffs[i].ServiceName != diffs[j].ServiceName {
+			return diffs[i].ServiceName < diffs[j].ServiceName
+		}
+		return diffs[i].Id < diffs[j].Id
+	})
+}
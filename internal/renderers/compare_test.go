@@ -0,0 +1,74 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestCompareSubscriptionResults(t *testing.T) {
+	baseline := []scanners.AzureServiceResult{
+		{
+			SubscriptionID: "prod",
+			ResourceGroup:  "rg-prod",
+			Type:           "Microsoft.Cache/Redis",
+			ServiceName:    "cache",
+			Rules: map[string]scanners.AzureRuleResult{
+				"redis-001": {Id: "redis-001", NotCompliant: false},
+				"redis-002": {Id: "redis-002", NotCompliant: true},
+				"redis-003": {Id: "redis-003", NotCompliant: true},
+			},
+		},
+		{
+			SubscriptionID: "prod",
+			ResourceGroup:  "rg-prod",
+			Type:           "Microsoft.KeyVault/vaults",
+			ServiceName:    "vault",
+		},
+	}
+	target := []scanners.AzureServiceResult{
+		{
+			SubscriptionID: "staging",
+			ResourceGroup:  "rg-staging",
+			Type:           "Microsoft.Cache/Redis",
+			ServiceName:    "cache",
+			Rules: map[string]scanners.AzureRuleResult{
+				"redis-001": {Id: "redis-001", NotCompliant: true},
+				"redis-002": {Id: "redis-002", NotCompliant: false},
+				"redis-003": {Id: "redis-003", NotCompliant: true},
+			},
+		},
+		{
+			SubscriptionID: "staging",
+			ResourceGroup:  "rg-staging",
+			Type:           "Microsoft.Cache/Redis",
+			ServiceName:    "other",
+			Rules: map[string]scanners.AzureRuleResult{
+				"redis-001": {Id: "redis-001", NotCompliant: true},
+			},
+		},
+		{
+			SubscriptionID: "staging",
+			ResourceGroup:  "rg-staging",
+			Type:           "Microsoft.DocumentDB/databaseAccounts",
+			ServiceName:    "cosmos",
+		},
+	}
+
+	want := SubscriptionDiff{
+		Regressions:       []RuleDiff{{Type: "Microsoft.Cache/Redis", ServiceName: "cache", Id: "redis-001"}},
+		Improvements:      []RuleDiff{{Type: "Microsoft.Cache/Redis", ServiceName: "cache", Id: "redis-002"}},
+		Consistent:        []RuleDiff{{Type: "Microsoft.Cache/Redis", ServiceName: "cache", Id: "redis-003"}},
+		MissingInTarget:   []string{"microsoft.keyvault/vaults"},
+		MissingInBaseline: []string{"microsoft.documentdb/databaseaccounts"},
+	}
+
+	got := CompareSubscriptionResults(baseline, target)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareSubscriptionResults() = %v, want %v", got, want)
+	}
+}
@@ -14,7 +14,14 @@ import (
 )
 
 func CreateCsvReport(data *renderers.ReportData) {
-	records := data.ServicesTable()
+	records := data.SummaryTable()
+	writeData(records, data.OutputFileName, "summary")
+
+	if data.SummaryOnly {
+		return
+	}
+
+	records = data.ServicesTable()
 	writeData(records, data.OutputFileName, "services")
 
 	records = data.DefenderTable()
@@ -24,11 +24,17 @@ func CreateExcelReport(data *renderers.ReportData) {
 		}
 	}()
 
-	renderRecommendations(f, data)
-	renderServices(f, data)
-	renderDefender(f, data)
-	renderAdvisor(f, data)
-	renderCosts(f, data)
+	if data.SummaryOnly {
+		renderSummary(f, data)
+		_ = f.DeleteSheet("Sheet1")
+	} else {
+		renderRecommendations(f, data)
+		renderSummary(f, data)
+		renderServices(f, data)
+		renderDefender(f, data)
+		renderAdvisor(f, data)
+		renderCosts(f, data)
+	}
 
 	if err := f.SaveAs(filename); err != nil {
 		log.Fatal().Err(err).Msg("Failed to save Excel file")
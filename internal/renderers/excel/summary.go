@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package excel
+
+import (
+	_ "image/png"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/rs/zerolog/log"
+	"github.com/xuri/excelize/v2"
+)
+
+func renderSummary(f *excelize.File, data *renderers.ReportData) {
+	if len(data.MainData) > 0 {
+		_, err := f.NewSheet("Summary")
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to create Summary sheet")
+		}
+
+		records := data.SummaryTable()
+		headers := records[0]
+		records = records[1:]
+
+		createFirstRow(f, "Summary", headers)
+
+		currentRow := 4
+		for _, row := range records {
+			currentRow += 1
+			cell, err := excelize.CoordinatesToCellName(1, currentRow)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to get cell")
+			}
+			err = f.SetSheetRow("Summary", cell, &row)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to set row")
+			}
+		}
+
+		configureSheet(f, "Summary", headers, currentRow)
+	} else {
+		log.Info().Msg("Skipping Summary. No data to render")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package github
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// GitHubActionsFormatter - Writes the findings as GitHub Actions workflow commands, shown as annotations
+type GitHubActionsFormatter struct {
+	Out         io.Writer
+	SummaryFile string
+}
+
+// IsGitHubActions - Returns true when azqr runs in a GitHub Actions workflow
+func IsGitHubActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// NewGitHubActionsFormatter - Creates a GitHubActionsFormatter writing to stdout and to the job summary, if any
+func NewGitHubActionsFormatter() *GitHubActionsFormatter {
+	return &GitHubActionsFormatter{
+		Out:         os.Stdout,
+		SummaryFile: os.Getenv("GITHUB_STEP_SUMMARY"),
+	}
+}
+
+// CreateGitHubActionsReport - Writes an annotation per non compliant rule and the job summary
+func (f *GitHubActionsFormatter) CreateGitHubActionsReport(data *renderers.ReportData) {
+	for _, d := range data.MainData {
+		for _, r := range d.Rules {
+			if !r.NotCompliant {
+				continue
+			}
+			fmt.Fprintf(f.Out, "::%s title=%s::%s\n", command(r.Impact), escapeProperty(r.Recommendation),
+				escapeData(fmt.Sprintf("%s (%s) in resource group %s: %s [%s] %s", d.ServiceName, d.Type, d.ResourceGroup, r.Recommendation, r.Id, r.Learn)))
+		}
+	}
+
+	if f.SummaryFile == "" {
+		return
+	}
+	file, err := os.OpenFile(f.SummaryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to open the GitHub job summary")
+		return
+	}
+	defer file.Close()
+	renderers.WriteMarkdownSummary(file, data)
+}
+
+// command - Returns the workflow command of the impact: error for High, warning for Medium and notice for Low
+func command(impact scanners.ImpactType) string {
+	switch impact {
+	case scanners.ImpactCritical, scanners.ImpactHigh:
+		return "error"
+	case scanners.ImpactMedium:
+		return "warning"
+	}
+	return "notice"
+}
+
+// escapeData - Escapes the message of a workflow command
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty - Escapes a property (e.g. title) of a workflow command
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
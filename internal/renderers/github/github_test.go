@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package github
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func testReportData() *renderers.ReportData {
+	return &renderers.ReportData{
+		MainData: []scanners.AzureServiceResult{
+			{
+				ResourceGroup: "rg-app",
+				ServiceName:   "cosmos-app",
+				Type:          "Microsoft.DocumentDB/databaseAccounts",
+				Rules: map[string]scanners.AzureRuleResult{
+					"cosmos-004": {Id: "cosmos-004", Impact: scanners.ImpactHigh, Recommendation: "CosmosDB should have private endpoints enabled", Learn: "https://learn.microsoft.com", NotCompliant: true},
+					"cosmos-014": {Id: "cosmos-014", Impact: scanners.ImpactMedium, Recommendation: "CosmosDB CORS should not allow all origins", NotCompliant: true},
+					"cosmos-007": {Id: "cosmos-007", Impact: scanners.ImpactLow, Recommendation: "CosmosDB should have tags", NotCompliant: true},
+					"cosmos-008": {Id: "cosmos-008", Impact: scanners.ImpactHigh, Recommendation: "CosmosDB should have local authentication disabled", NotCompliant: false},
+				},
+			},
+		},
+	}
+}
+
+func TestGitHubActionsFormatter_CreateGitHubActionsReport(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	summaryFile := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryFile)
+
+	if !IsGitHubActions() {
+		t.Fatalf("IsGitHubActions() = false, want true")
+	}
+
+	var out bytes.Buffer
+	f := NewGitHubActionsFormatter()
+	f.Out = &out
+	f.CreateGitHubActionsReport(testReportData())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("CreateGitHubActionsReport() wrote %d annotations, want 3: %v", len(lines), lines)
+	}
+	want := "::error title=CosmosDB should have private endpoints enabled::cosmos-app (Microsoft.DocumentDB/databaseAccounts) in resource group rg-app: CosmosDB should have private endpoints enabled [cosmos-004] https://learn.microsoft.com"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("CreateGitHubActionsReport() output = %s, want %s", out.String(), want)
+	}
+	for _, prefix := range []string{"::warning title=CosmosDB CORS", "::notice title=CosmosDB should have tags"} {
+		if !strings.Contains(out.String(), prefix) {
+			t.Errorf("CreateGitHubActionsReport() output does not contain %s", prefix)
+		}
+	}
+
+	summary, err := os.ReadFile(summaryFile)
+	if err != nil {
+		t.Fatalf("failed to read job summary: %v", err)
+	}
+	if !strings.Contains(string(summary), "| High | cosmos-app | rg-app | [CosmosDB should have private endpoints enabled](https://learn.microsoft.com) | cosmos-004 |") {
+		t.Errorf("job summary = %s", summary)
+	}
+}
+
+func TestIsGitHubActions(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+	if IsGitHubActions() {
+		t.Errorf("IsGitHubActions() = true, want false")
+	}
+}
+
+func TestEscapeProperty(t *testing.T) {
+	got := escapeProperty("a:b,c%\nd")
+	if want := "a%3Ab%2Cc%25%0Ad"; got != want {
+		t.Errorf("escapeProperty() = %s, want %s", got, want)
+	}
+}
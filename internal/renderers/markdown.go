@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdownSummary - Writes the compliance score and a table of the non compliant rules as markdown
+func WriteMarkdownSummary(w io.Writer, data *ReportData) {
+	summary := Summarize(data.MainData)
+	fmt.Fprintln(w, "## Azure Quick Review")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Compliance score: **%.1f%%** (%d of %d rules passed on %d resources)\n", summary.ComplianceScore, summary.Passed, summary.Rules, summary.Resources)
+	fmt.Fprintln(w)
+	if summary.Errors > 0 {
+		fmt.Fprintf(w, "%d rules could not be evaluated and are not counted.\n", summary.Errors)
+		fmt.Fprintln(w)
+	}
+	if summary.Exempted > 0 {
+		fmt.Fprintf(w, "%d findings exempted by Azure Policy are counted as passed.\n", summary.Exempted)
+		fmt.Fprintln(w)
+	}
+	if summary.Failed == 0 {
+		return
+	}
+
+	if data.ShowRemediation {
+		fmt.Fprintln(w, "| Impact | Resource | Resource Group | Recommendation | Id | Remediation |")
+		fmt.Fprintln(w, "|---|---|---|---|---|---|")
+	} else {
+		fmt.Fprintln(w, "| Impact | Resource | Resource Group | Recommendation | Id |")
+		fmt.Fprintln(w, "|---|---|---|---|---|")
+	}
+	for _, d := range data.MainData {
+		for _, r := range d.Rules {
+			if !r.NotCompliant {
+				continue
+			}
+			recommendation := escapeCell(r.Recommendation)
+			if r.TimedOut {
+				recommendation = "[TIMEOUT] " + recommendation
+			}
+			if data.ShowRemediation {
+				remediation := ""
+				if c := d.RemediationCommand(r); c != "" {
+					remediation = fmt.Sprintf("`%s`", escapeCell(c))
+				}
+				fmt.Fprintf(w, "| %s | %s | %s | [%s](%s) | %s | %s |\n", r.Impact, escapeCell(d.ServiceName), escapeCell(d.ResourceGroup), recommendation, r.Learn, r.Id, remediation)
+				continue
+			}
+			fmt.Fprintf(w, "| %s | %s | %s | [%s](%s) | %s |\n", r.Impact, escapeCell(d.ServiceName), escapeCell(d.ResourceGroup), recommendation, r.Learn, r.Id)
+		}
+	}
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
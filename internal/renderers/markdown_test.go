@@ -0,0 +1,76 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestWriteMarkdownSummary_TimedOut(t *testing.T) {
+	data := &ReportData{
+		MainData: []scanners.AzureServiceResult{
+			{
+				ResourceGroup: "rg",
+				ServiceName:   "kv-app",
+				Rules: map[string]scanners.AzureRuleResult{
+					"kv-012": {Id: "kv-012", Impact: scanners.ImpactHigh, Recommendation: "Key Vault keys should have a rotation policy", NotCompliant: true, Result: scanners.RuleTimedOutResult, TimedOut: true},
+					"kv-008": {Id: "kv-008", Impact: scanners.ImpactHigh, Recommendation: "Key Vault should have purge protection enabled", NotCompliant: true},
+				},
+			},
+		},
+	}
+
+	if got, want := Summarize(data.MainData).TimedOutRules, []string{"kv-012 (kv-app)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() TimedOutRules = %v, want %v", got, want)
+	}
+
+	var buf bytes.Buffer
+	WriteMarkdownSummary(&buf, data)
+	out := buf.String()
+	if !strings.Contains(out, "[[TIMEOUT] Key Vault keys should have a rotation policy]") {
+		t.Errorf("WriteMarkdownSummary() does not flag the timed out rule:\n%s", out)
+	}
+	if strings.Contains(out, "[TIMEOUT] Key Vault should have purge protection") {
+		t.Errorf("WriteMarkdownSummary() flags a rule that did not time out:\n%s", out)
+	}
+}
+
+func TestWriteMarkdownSummary_Errors(t *testing.T) {
+	data := &ReportData{
+		MainData: []scanners.AzureServiceResult{
+			{
+				ResourceGroup: "rg",
+				ServiceName:   "st-app",
+				Rules: map[string]scanners.AzureRuleResult{
+					"st-012": {Id: "st-012", Impact: scanners.ImpactMedium, Error: errors.New("lifecycle policy not available")},
+					"st-007": {Id: "st-007", Impact: scanners.ImpactHigh},
+					"st-008": {Id: "st-008", Impact: scanners.ImpactHigh, NotCompliant: true},
+				},
+			},
+		},
+	}
+
+	s := Summarize(data.MainData)
+	if s.Errors != 1 || s.Rules != 2 || s.Passed != 1 || s.Failed != 1 || s.ComplianceScore != 50 {
+		t.Errorf("Summarize() = %+v, want the errored rule left out of the counts and the score", s)
+	}
+	if c := s.ByImpact[scanners.ImpactMedium]; c != nil {
+		t.Errorf("Summarize() ByImpact[Medium] = %+v, want no count for the errored rule", c)
+	}
+	if got := compliant(data.MainData[0].Rules["st-012"]); got != "error" {
+		t.Errorf("compliant() = %s, want error", got)
+	}
+
+	var buf bytes.Buffer
+	WriteMarkdownSummary(&buf, data)
+	if out := buf.String(); !strings.Contains(out, "1 rules could not be evaluated") {
+		t.Errorf("WriteMarkdownSummary() does not report the errored rule:\n%s", out)
+	}
+}
@@ -5,21 +5,39 @@ package renderers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Azure/azqr/internal/scanners"
 )
 
 type ReportData struct {
-	OutputFileName string
-	Mask           bool
-	MainData       []scanners.AzureServiceResult
-	DefenderData   []scanners.DefenderResult
-	AdvisorData    []scanners.AdvisorResult
-	CostData       *scanners.CostResult
+	OutputFileName  string
+	Mask            bool
+	SummaryOnly     bool
+	ShowCreatedAt   bool
+	ShowDefender    bool
+	ShowRemediation bool
+	MainData        []scanners.AzureServiceResult
+	DefenderData    []scanners.DefenderResult
+	AdvisorData     []scanners.AdvisorResult
+	CostData        *scanners.CostResult
 }
 
 func (rd *ReportData) ServicesTable() [][]string {
 	headers := []string{"Subscription", "Subscription Name", "Resource Group", "Location", "Type", "Service Name", "Compliant", "Impact", "Category", "Recommendation", "Result", "Learn", "RId"}
+	// Resource ids include the subscription id, so they are only listed in unmasked reports
+	if !rd.Mask {
+		headers = append(headers, "Resource Id")
+	}
+	if rd.ShowCreatedAt {
+		headers = append(headers, "Created At")
+	}
+	if rd.ShowDefender {
+		headers = append(headers, "Tracked by Defender")
+	}
+	if rd.ShowRemediation {
+		headers = append(headers, "Remediation")
+	}
 
 	rbroken := [][]string{}
 	rok := [][]string{}
@@ -32,7 +50,7 @@ func (rd *ReportData) ServicesTable() [][]string {
 				scanners.ParseLocation(d.Location),
 				d.Type,
 				d.ServiceName,
-				fmt.Sprintf("%t", !r.NotCompliant),
+				compliant(r),
 				string(r.Impact),
 				string(r.Category),
 				r.Recommendation,
@@ -40,6 +58,22 @@ func (rd *ReportData) ServicesTable() [][]string {
 				r.Learn,
 				r.Id,
 			}
+			if !rd.Mask {
+				row = append(row, d.ResourceID())
+			}
+			if rd.ShowCreatedAt {
+				createdAt := ""
+				if d.CreatedAt != nil {
+					createdAt = d.CreatedAt.UTC().Format(time.RFC3339)
+				}
+				row = append(row, createdAt)
+			}
+			if rd.ShowDefender {
+				row = append(row, fmt.Sprintf("%t", r.AlreadyTrackedByDefender))
+			}
+			if rd.ShowRemediation {
+				row = append(row, d.RemediationCommand(r))
+			}
 			if r.NotCompliant {
 				rbroken = append([][]string{row}, rbroken...)
 			} else {
@@ -53,6 +87,18 @@ func (rd *ReportData) ServicesTable() [][]string {
 	return rows
 }
 
+// compliant - Returns the value of the Compliant column: true, false, exempted by Azure Policy or
+// error when the rule could not be evaluated
+func compliant(r scanners.AzureRuleResult) string {
+	if r.Error != nil {
+		return "error"
+	}
+	if r.PolicyExempted {
+		return "exempted"
+	}
+	return fmt.Sprintf("%t", !r.NotCompliant)
+}
+
 func (rd *ReportData) CostTable() [][]string {
 	headers := []string{"From", "To", "Subscription", "Subscription Name", "ServiceName", "Value", "Currency"}
 
@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package resourcegraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// Record - Scan results of a resource, with the top level fields of an Azure Resource Graph resource
+	Record struct {
+		SubscriptionID string     `json:"subscriptionId"`
+		ResourceGroup  string     `json:"resourceGroup"`
+		ResourceType   string     `json:"resourceType"`
+		ResourceID     string     `json:"resourceId"`
+		Name           string     `json:"name"`
+		Location       string     `json:"location"`
+		Properties     Properties `json:"properties"`
+	}
+
+	// Properties - azqr results of the resource
+	Properties struct {
+		Findings  []Finding  `json:"findings"`
+		Compliant int        `json:"compliant"`
+		Failed    int        `json:"failed"`
+		CreatedAt *time.Time `json:"createdAt,omitempty"`
+	}
+
+	// Finding - Result of a rule evaluated on the resource
+	Finding struct {
+		RuleID         string `json:"ruleId"`
+		Category       string `json:"category"`
+		Recommendation string `json:"recommendation"`
+		Impact         string `json:"impact"`
+		Compliant      bool   `json:"compliant"`
+		Result         string `json:"result,omitempty"`
+		// Error - Set when the rule could not be evaluated. The finding is then neither compliant nor failed.
+		Error string `json:"error,omitempty"`
+		Learn string `json:"learn"`
+	}
+)
+
+// NewRecords - Returns a record for each scanned resource. Subscription ids are masked like in the reports.
+func NewRecords(data *renderers.ReportData) []Record {
+	records := []Record{}
+	for _, d := range data.MainData {
+		subscriptionID := scanners.MaskSubscriptionID(d.SubscriptionID, data.Mask)
+		record := Record{
+			SubscriptionID: subscriptionID,
+			ResourceGroup:  d.ResourceGroup,
+			ResourceType:   strings.ToLower(d.Type),
+			ResourceID:     strings.Replace(d.ResourceID(), strings.ToLower(d.SubscriptionID), strings.ToLower(subscriptionID), 1),
+			Name:           d.ServiceName,
+			Location:       d.Location,
+			Properties:     Properties{Findings: []Finding{}, CreatedAt: d.CreatedAt},
+		}
+
+		ids := make([]string, 0, len(d.Rules))
+		for id := range d.Rules {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			r := d.Rules[id]
+			finding := Finding{
+				RuleID:         r.Id,
+				Category:       string(r.Category),
+				Recommendation: r.Recommendation,
+				Impact:         string(r.Impact),
+				Compliant:      !r.NotCompliant && r.Error == nil,
+				Result:         r.Result,
+				Learn:          r.Learn,
+			}
+			if r.Error != nil {
+				finding.Error = r.Error.Error()
+			}
+			record.Properties.Findings = append(record.Properties.Findings, finding)
+			switch {
+			case r.Error != nil:
+				// Neither compliant nor failed
+			case r.NotCompliant:
+				record.Properties.Failed++
+			default:
+				record.Properties.Compliant++
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Write - Writes the records as newline-delimited JSON, one resource per line
+func Write(w io.Writer, data *renderers.ReportData) error {
+	enc := json.NewEncoder(w)
+	for _, r := range NewRecords(data) {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateResourceGraphReport - Creates the <output>.resourcegraph.ndjson report
+func CreateResourceGraphReport(data *renderers.ReportData) {
+	filename := fmt.Sprintf("%s.resourcegraph.ndjson", data.OutputFileName)
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating ndjson:")
+	}
+	defer f.Close()
+
+	if err := Write(f, data); err != nil {
+		log.Fatal().Err(err).Msg("error writing ndjson:")
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package resourcegraph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestWrite(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	data := &renderers.ReportData{
+		Mask: true,
+		MainData: []scanners.AzureServiceResult{
+			{
+				SubscriptionID: "00000000-0000-0000-0000-000000000001",
+				ResourceGroup:  "rg-app",
+				Location:       "westeurope",
+				Type:           "Microsoft.Cache/Redis",
+				ServiceName:    "redis-app",
+				CreatedAt:      &createdAt,
+				Rules: map[string]scanners.AzureRuleResult{
+					"redis-008": {Id: "redis-008", Category: scanners.RulesCategorySecurity, Impact: scanners.ImpactHigh, NotCompliant: true, Result: "TLS 1.0", Learn: "https://learn"},
+					"redis-002": {Id: "redis-002", Category: scanners.RulesCategoryHighAvailability, Impact: scanners.ImpactHigh},
+				},
+			},
+			{
+				SubscriptionID: "00000000-0000-0000-0000-000000000001",
+				ResourceGroup:  "rg-app",
+				Location:       "westeurope",
+				Type:           "Microsoft.KeyVault/vaults",
+				ServiceName:    "kv-app",
+				Rules: map[string]scanners.AzureRuleResult{
+					"kv-012": {Id: "kv-012", Category: scanners.RulesCategorySecurity, Impact: scanners.ImpactMedium, Error: errors.New("keys not available")},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, data); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	lines := []string{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 2 {
+		t.Fatalf("Write() wrote %d lines, want 2", len(lines))
+	}
+
+	records := []Record{}
+	for i, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Fatalf("Write() line %d is not valid JSON: %s", i, line)
+		}
+		r := Record{}
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("json.Unmarshal() line %d error = %v", i, err)
+		}
+		records = append(records, r)
+	}
+
+	want := Record{
+		SubscriptionID: "xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001",
+		ResourceGroup:  "rg-app",
+		ResourceType:   "microsoft.cache/redis",
+		ResourceID:     "/subscriptions/xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001/resourcegroups/rg-app/providers/microsoft.cache/redis/redis-app",
+		Name:           "redis-app",
+		Location:       "westeurope",
+		Properties: Properties{
+			Findings: []Finding{
+				{RuleID: "redis-002", Category: "High Availability", Impact: "High", Compliant: true},
+				{RuleID: "redis-008", Category: "Security", Impact: "High", Compliant: false, Result: "TLS 1.0", Learn: "https://learn"},
+			},
+			Compliant: 1,
+			Failed:    1,
+			CreatedAt: &createdAt,
+		},
+	}
+	if !reflect.DeepEqual(records[0], want) {
+		t.Errorf("Write() record = %+v, want %+v", records[0], want)
+	}
+	wantErrored := Finding{RuleID: "kv-012", Category: "Security", Impact: "Medium", Error: "keys not available"}
+	if len(records[1].Properties.Findings) != 1 || records[1].Properties.Findings[0] != wantErrored || records[1].Properties.Compliant != 0 || records[1].Properties.Failed != 0 {
+		t.Errorf("Write() record = %+v, want kv-app with an errored finding not counted", records[1])
+	}
+	if strings.Contains(lines[1], "createdAt") {
+		t.Errorf("Write() line = %s, want no createdAt when the creation time is not known", lines[1])
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+type (
+	// ScanSummary - Aggregated view of the scan results
+	ScanSummary struct {
+		Resources int
+		Rules     int
+		Passed    int
+		Failed    int
+		Exempted  int
+		// Errors - Rule evaluations that failed with an error. They are not counted in Rules, Passed or Failed.
+		Errors          int
+		ComplianceScore float64
+		ByImpact        map[scanners.ImpactType]*SummaryCount
+		ByCategory      map[scanners.RulesCategory]*SummaryCount
+		TopFailedRules  []*FailedRuleCount
+		Subscriptions   map[string]*SubscriptionSummary
+		// TimedOutRules - Rules whose evaluation timed out, as "<rule id> (<resource name>)"
+		TimedOutRules []string
+	}
+
+	// SummaryCount - Pass and fail counts of a group of rules
+	SummaryCount struct {
+		Passed int
+		Failed int
+	}
+
+	// FailedRuleCount - Number of resources failing a rule
+	FailedRuleCount struct {
+		Id             string
+		Recommendation string
+		Count          int
+	}
+
+	// SubscriptionSummary - Pass and fail counts of a subscription
+	SubscriptionSummary struct {
+		SubscriptionName string
+		Resources        int
+		SummaryCount
+	}
+)
+
+// Summarize - Aggregates the scan results by impact, category, rule and subscription
+func Summarize(results []scanners.AzureServiceResult) ScanSummary {
+	summary := ScanSummary{
+		ByImpact:       map[scanners.ImpactType]*SummaryCount{},
+		ByCategory:     map[scanners.RulesCategory]*SummaryCount{},
+		TopFailedRules: []*FailedRuleCount{},
+		Subscriptions:  map[string]*SubscriptionSummary{},
+		TimedOutRules:  []string{},
+	}
+
+	failedRules := map[string]*FailedRuleCount{}
+	for _, r := range results {
+		summary.Resources++
+
+		sub, ok := summary.Subscriptions[r.SubscriptionID]
+		if !ok {
+			sub = &SubscriptionSummary{SubscriptionName: r.SubscriptionName}
+			summary.Subscriptions[r.SubscriptionID] = sub
+		}
+		sub.Resources++
+
+		for _, rr := range r.Rules {
+			if rr.Error != nil {
+				summary.Errors++
+				continue
+			}
+			summary.Rules++
+
+			impact, ok := summary.ByImpact[rr.Impact]
+			if !ok {
+				impact = &SummaryCount{}
+				summary.ByImpact[rr.Impact] = impact
+			}
+			category, ok := summary.ByCategory[rr.Category]
+			if !ok {
+				category = &SummaryCount{}
+				summary.ByCategory[rr.Category] = category
+			}
+
+			if !rr.NotCompliant {
+				if rr.PolicyExempted {
+					summary.Exempted++
+				}
+				summary.Passed++
+				impact.Passed++
+				category.Passed++
+				sub.Passed++
+				continue
+			}
+
+			if rr.TimedOut {
+				summary.TimedOutRules = append(summary.TimedOutRules, fmt.Sprintf("%s (%s)", rr.Id, r.ServiceName))
+			}
+
+			summary.Failed++
+			impact.Failed++
+			category.Failed++
+			sub.Failed++
+
+			failed, ok := failedRules[rr.Id]
+			if !ok {
+				failed = &FailedRuleCount{Id: rr.Id, Recommendation: rr.Recommendation}
+				failedRules[rr.Id] = failed
+			}
+			failed.Count++
+		}
+	}
+
+	for _, f := range failedRules {
+		summary.TopFailedRules = append(summary.TopFailedRules, f)
+	}
+	sort.Slice(summary.TopFailedRules, func(i, j int) bool {
+		if summary.TopFailedRules[i].Count == summary.TopFailedRules[j].Count {
+			return summary.TopFailedRules[i].Id < summary.TopFailedRules[j].Id
+		}
+		return summary.TopFailedRules[i].Count > summary.TopFailedRules[j].Count
+	})
+	if len(summary.TopFailedRules) > 10 {
+		summary.TopFailedRules = summary.TopFailedRules[:10]
+	}
+
+	sort.Strings(summary.TimedOutRules)
+	summary.ComplianceScore = complianceScore(summary.Passed, summary.Rules)
+
+	return summary
+}
+
+// FailedAtLeast - Returns the number of failed rules with the given impact or a higher one
+func (s ScanSummary) FailedAtLeast(threshold scanners.ImpactType) int {
+	failed := 0
+	for impact, c := range s.ByImpact {
+		if impact.AtLeast(threshold) {
+			failed += c.Failed
+		}
+	}
+	return failed
+}
+
+// ImpactLabel - Returns the name of the impact for the terminal output, Critical in red and bold
+// unless colors are disabled
+func ImpactLabel(impact scanners.ImpactType, noColor bool) string {
+	if impact == scanners.ImpactCritical && !noColor {
+		return "\x1b[1;31m" + string(impact) + "\x1b[0m"
+	}
+	return string(impact)
+}
+
+func complianceScore(passed, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return math.Round(float64(passed)/float64(total)*1000) / 10
+}
+
+func (rd *ReportData) SummaryTable() [][]string {
+	headers := []string{"Group", "Name", "Resources", "Passed", "Failed", "Compliance Score"}
+
+	s := Summarize(rd.MainData)
+	row := func(group, name, resources string, c SummaryCount) []string {
+		return []string{
+			group,
+			name,
+			resources,
+			fmt.Sprint(c.Passed),
+			fmt.Sprint(c.Failed),
+			fmt.Sprintf("%.1f", complianceScore(c.Passed, c.Passed+c.Failed)),
+		}
+	}
+
+	rows := [][]string{
+		row("Total", "All", fmt.Sprint(s.Resources), SummaryCount{Passed: s.Passed, Failed: s.Failed}),
+	}
+
+	for _, impact := range scanners.Impacts {
+		if c, ok := s.ByImpact[impact]; ok {
+			rows = append(rows, row("Impact", string(impact), "", *c))
+		}
+	}
+
+	categories := []string{}
+	for c := range s.ByCategory {
+		categories = append(categories, string(c))
+	}
+	sort.Strings(categories)
+	for _, c := range categories {
+		rows = append(rows, row("Category", c, "", *s.ByCategory[scanners.RulesCategory(c)]))
+	}
+
+	subscriptions := []string{}
+	for id := range s.Subscriptions {
+		subscriptions = append(subscriptions, id)
+	}
+	sort.Strings(subscriptions)
+	for _, id := range subscriptions {
+		sub := s.Subscriptions[id]
+		name := fmt.Sprintf("%s (%s)", sub.SubscriptionName, scanners.MaskSubscriptionID(id, rd.Mask))
+		rows = append(rows, row("Subscription", name, fmt.Sprint(sub.Resources), sub.SummaryCount))
+	}
+
+	for _, f := range s.TopFailedRules {
+		rows = append(rows, []string{"Top Failed Rule", fmt.Sprintf("%s: %s", f.Id, f.Recommendation), "", "", fmt.Sprint(f.Count), ""})
+	}
+
+	rows = append([][]string{headers}, rows...)
+	return rows
+}
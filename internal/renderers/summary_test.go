@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package renderers
+
+import (
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestScanSummary_FailedAtLeast(t *testing.T) {
+	results := []scanners.AzureServiceResult{
+		{
+			ServiceName: "evgd",
+			Rules: map[string]scanners.AzureRuleResult{
+				"evgd-009": {Id: "evgd-009", Impact: scanners.ImpactCritical, NotCompliant: true},
+				"evgd-004": {Id: "evgd-004", Impact: scanners.ImpactHigh, NotCompliant: true},
+				"evgd-008": {Id: "evgd-008", Impact: scanners.ImpactMedium},
+				"evgd-007": {Id: "evgd-007", Impact: scanners.ImpactLow, NotCompliant: true},
+			},
+		},
+	}
+
+	s := Summarize(results)
+	tests := []struct {
+		threshold scanners.ImpactType
+		want      int
+	}{
+		{scanners.ImpactCritical, 1},
+		{scanners.ImpactHigh, 2},
+		{scanners.ImpactMedium, 2},
+		{scanners.ImpactLow, 3},
+	}
+	for _, tt := range tests {
+		if got := s.FailedAtLeast(tt.threshold); got != tt.want {
+			t.Errorf("ScanSummary.FailedAtLeast(%s) = %d, want %d", tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestImpactLabel(t *testing.T) {
+	if got, want := ImpactLabel(scanners.ImpactCritical, false), "\x1b[1;31mCritical\x1b[0m"; got != want {
+		t.Errorf("ImpactLabel(Critical) = %q, want %q", got, want)
+	}
+	if got := ImpactLabel(scanners.ImpactCritical, true); got != "Critical" {
+		t.Errorf("ImpactLabel(Critical, noColor) = %q", got)
+	}
+	if got := ImpactLabel(scanners.ImpactHigh, false); got != "High" {
+		t.Errorf("ImpactLabel(High) = %q", got)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package template
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// Data - Data passed to the output templates
+	Data struct {
+		Results  []scanners.AzureServiceResult
+		Summary  renderers.ScanSummary
+		Findings []Finding
+	}
+
+	// Finding - Rule result with the resource it was evaluated on
+	Finding struct {
+		scanners.AzureRuleResult
+		SubscriptionID   string
+		SubscriptionName string
+		ResourceGroup    string
+		Location         string
+		Type             string
+		ServiceName      string
+	}
+)
+
+// Funcs - Returns the functions available in the output templates
+func Funcs() texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"filterByImpact":   filterByImpact,
+		"filterByCategory": filterByCategory,
+		"filterByScanner":  filterByScanner,
+		"filterFailed":     filterFailed,
+		"groupBy":          groupBy,
+		"sortBy":           sortBy,
+		"csv":              csvField,
+	}
+}
+
+// Parse - Parses the template file, so template errors are reported before the scan starts
+func Parse(path string) (*texttemplate.Template, error) {
+	return texttemplate.New(filepath.Base(path)).Funcs(Funcs()).ParseFiles(path)
+}
+
+// NewData - Returns the template data of the report. Subscription ids, also in the resource ids, are masked
+// like in the reports.
+func NewData(data *renderers.ReportData) Data {
+	d := Data{
+		Results:  []scanners.AzureServiceResult{},
+		Summary:  renderers.Summarize(data.MainData),
+		Findings: []Finding{},
+	}
+	for _, r := range data.MainData {
+		subscriptionID := scanners.MaskSubscriptionID(r.SubscriptionID, data.Mask)
+		r.ID = replaceSubscriptionID(r.ID, r.SubscriptionID, subscriptionID)
+		r.SubscriptionID = subscriptionID
+		d.Results = append(d.Results, r)
+
+		ids := make([]string, 0, len(r.Rules))
+		for id := range r.Rules {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			d.Findings = append(d.Findings, Finding{
+				AzureRuleResult:  r.Rules[id],
+				SubscriptionID:   r.SubscriptionID,
+				SubscriptionName: r.SubscriptionName,
+				ResourceGroup:    r.ResourceGroup,
+				Location:         r.Location,
+				Type:             r.Type,
+				ServiceName:      r.ServiceName,
+			})
+		}
+	}
+	return d
+}
+
+// replaceSubscriptionID - Replaces the subscription id in the resource id, ignoring case
+func replaceSubscriptionID(id, subscriptionID, replacement string) string {
+	i := strings.Index(strings.ToLower(id), strings.ToLower(subscriptionID))
+	if subscriptionID == "" || i < 0 {
+		return id
+	}
+	return id[:i] + replacement + id[i+len(subscriptionID):]
+}
+
+// Execute - Executes the template with the report data
+func Execute(w io.Writer, t *texttemplate.Template, data *renderers.ReportData) error {
+	return t.Execute(w, NewData(data))
+}
+
+// CreateTemplateReport - Creates the report of the template, named after the template file without
+// its .tmpl extension (e.g. jira.txt.tmpl creates <output>.jira.txt)
+func CreateTemplateReport(data *renderers.ReportData, t *texttemplate.Template) {
+	filename := fmt.Sprintf("%s.%s", data.OutputFileName, strings.TrimSuffix(t.Name(), ".tmpl"))
+	log.Info().Msgf("Generating Report: %s", filename)
+
+	f, err := os.Create(filename)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error creating template report:")
+	}
+	defer f.Close()
+
+	if err := Execute(f, t, data); err != nil {
+		log.Fatal().Err(err).Msg("error executing template:")
+	}
+}
+
+// filterByImpact - Returns the findings with the given impact (e.g. High)
+func filterByImpact(impact string, findings []Finding) []Finding {
+	return filter(findings, func(f Finding) bool {
+		return strings.EqualFold(string(f.Impact), impact)
+	})
+}
+
+// filterByCategory - Returns the findings of the given category (e.g. Security)
+func filterByCategory(category string, findings []Finding) []Finding {
+	return filter(findings, func(f Finding) bool {
+		return strings.EqualFold(string(f.Category), category)
+	})
+}
+
+// filterByScanner - Returns the findings of the rules of a scanner, identified by the rule id prefix (e.g. redis)
+func filterByScanner(scanner string, findings []Finding) []Finding {
+	return filter(findings, func(f Finding) bool {
+		return strings.HasPrefix(strings.ToLower(f.Id), strings.ToLower(scanner)+"-")
+	})
+}
+
+// filterFailed - Returns the findings of the rules that are not compliant
+func filterFailed(findings []Finding) []Finding {
+	return filter(findings, func(f Finding) bool {
+		return f.NotCompliant
+	})
+}
+
+// groupBy - Groups the findings by the value of a field. Templates range over the groups sorted by value.
+func groupBy(field string, findings []Finding) (map[string][]Finding, error) {
+	groups := map[string][]Finding{}
+	for _, f := range findings {
+		v, err := fieldValue(f, field)
+		if err != nil {
+			return nil, err
+		}
+		groups[v] = append(groups[v], f)
+	}
+	return groups, nil
+}
+
+// sortBy - Returns the findings sorted by the value of a field, keeping the order of equal findings
+func sortBy(field string, findings []Finding) ([]Finding, error) {
+	if _, err := fieldValue(Finding{}, field); err != nil {
+		return nil, err
+	}
+	sorted := append([]Finding{}, findings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := fieldValue(sorted[i], field)
+		b, _ := fieldValue(sorted[j], field)
+		return a < b
+	})
+	return sorted, nil
+}
+
+// csvField - Quotes a value as a CSV field when needed
+func csvField(v string) string {
+	if !strings.ContainsAny(v, ",\"\r\n") {
+		return v
+	}
+	return `"` + strings.ReplaceAll(v, `"`, `""`) + `"`
+}
+
+func filter(findings []Finding, keep func(Finding) bool) []Finding {
+	res := []Finding{}
+	for _, f := range findings {
+		if keep(f) {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// fieldValue - Returns the value of a finding field usable with groupBy and sortBy
+func fieldValue(f Finding, field string) (string, error) {
+	switch field {
+	case "Id":
+		return f.Id, nil
+	case "Impact":
+		return string(f.Impact), nil
+	case "Category":
+		return string(f.Category), nil
+	case "Recommendation":
+		return f.Recommendation, nil
+	case "SubscriptionID":
+		return f.SubscriptionID, nil
+	case "SubscriptionName":
+		return f.SubscriptionName, nil
+	case "ResourceGroup":
+		return f.ResourceGroup, nil
+	case "Location":
+		return f.Location, nil
+	case "Type":
+		return f.Type, nil
+	case "ServiceName":
+		return f.ServiceName, nil
+	}
+	return "", fmt.Errorf("unsupported field %q", field)
+}
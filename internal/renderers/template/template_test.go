@@ -0,0 +1,166 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package template
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func fixture() *renderers.ReportData {
+	return &renderers.ReportData{
+		Mask: true,
+		MainData: []scanners.AzureServiceResult{
+			{
+				SubscriptionID: "00000000-0000-0000-0000-000000000001",
+				ID:             "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg-app/providers/Microsoft.Cache/Redis/redis-app",
+				ResourceGroup:  "rg-app",
+				Type:           "Microsoft.Cache/Redis",
+				ServiceName:    "redis-app",
+				Rules: map[string]scanners.AzureRuleResult{
+					"redis-008": {Id: "redis-008", Category: scanners.RulesCategorySecurity, Impact: scanners.ImpactHigh, Recommendation: "Redis should enforce TLS >= 1.2", NotCompliant: true},
+					"redis-007": {Id: "redis-007", Category: scanners.RulesCategoryGovernance, Impact: scanners.ImpactLow, Recommendation: "Redis should have tags", NotCompliant: true},
+				},
+			},
+			{
+				SubscriptionID: "00000000-0000-0000-0000-000000000001",
+				ID:             "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg-data/providers/Microsoft.KeyVault/vaults/kv-data",
+				ResourceGroup:  "rg-data",
+				Type:           "Microsoft.KeyVault/vaults",
+				ServiceName:    "kv-data",
+				Rules: map[string]scanners.AzureRuleResult{
+					"kv-008": {Id: "kv-008", Category: scanners.RulesCategorySecurity, Impact: scanners.ImpactHigh, Recommendation: "Key Vault should have purge protection enabled", NotCompliant: false},
+				},
+			},
+		},
+	}
+}
+
+func execute(t *testing.T, text string) string {
+	t.Helper()
+	tmpl, err := texttemplate.New("test").Funcs(Funcs()).Parse(text)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Execute(&buf, tmpl, fixture()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "summary and results",
+			template: `{{ .Summary.Resources }} resources, {{ .Summary.Failed }} failed{{ range .Results }}; {{ .ServiceName }} {{ .SubscriptionID }}{{ end }}`,
+			want:     "2 resources, 2 failed; redis-app xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001; kv-data xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001",
+		},
+		{
+			name:     "masked resource ids",
+			template: `{{ range .Results }}{{ .ID }};{{ end }}`,
+			want:     "/subscriptions/xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001/resourceGroups/rg-app/providers/Microsoft.Cache/Redis/redis-app;/subscriptions/xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001/resourceGroups/rg-data/providers/Microsoft.KeyVault/vaults/kv-data;",
+		},
+		{
+			name:     "filterByImpact",
+			template: `{{ range .Findings | filterByImpact "High" }}{{ .Id }} {{ .Impact }},{{ end }}`,
+			want:     "redis-008 High,kv-008 High,",
+		},
+		{
+			name:     "filterByCategory",
+			template: `{{ range .Findings | filterByCategory "Governance" }}{{ .Id }},{{ end }}`,
+			want:     "redis-007,",
+		},
+		{
+			name:     "filterByScanner",
+			template: `{{ range .Findings | filterByScanner "kv" }}{{ .Id }} {{ .ServiceName }},{{ end }}`,
+			want:     "kv-008 kv-data,",
+		},
+		{
+			name:     "filterFailed",
+			template: `{{ range .Findings | filterFailed }}{{ .Id }},{{ end }}`,
+			want:     "redis-007,redis-008,",
+		},
+		{
+			name:     "groupBy",
+			template: `{{ range $rg, $findings := .Findings | groupBy "ResourceGroup" }}{{ $rg }}={{ len $findings }},{{ end }}`,
+			want:     "rg-app=2,rg-data=1,",
+		},
+		{
+			name:     "sortBy",
+			template: `{{ range .Findings | sortBy "Impact" }}{{ .Id }},{{ end }}`,
+			want:     "redis-008,kv-008,redis-007,",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execute(t, tt.template); got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_UnsupportedField(t *testing.T) {
+	tmpl := texttemplate.Must(texttemplate.New("test").Funcs(Funcs()).Parse(`{{ range .Findings | sortBy "Color" }}{{ end }}`))
+	var buf bytes.Buffer
+	if err := Execute(&buf, tmpl, fixture()); err == nil {
+		t.Error("Execute() error = nil, want an error for an unsupported field")
+	}
+}
+
+func TestParse_Examples(t *testing.T) {
+	files, err := filepath.Glob("../../../examples/templates/*.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no example templates found")
+	}
+	for _, file := range files {
+		tmpl, err := Parse(file)
+		if err != nil {
+			t.Errorf("Parse(%s) error = %v", file, err)
+			continue
+		}
+		if err := Execute(&bytes.Buffer{}, tmpl, fixture()); err != nil {
+			t.Errorf("Execute(%s) error = %v", file, err)
+		}
+	}
+}
+
+func TestCreateTemplateReport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ids.txt.tmpl")
+	if err := os.WriteFile(path, []byte(`{{ range .Findings }}{{ .Id }}
+{{ end }}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := Parse(path)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	data := fixture()
+	data.OutputFileName = filepath.Join(dir, "azqr_report")
+	CreateTemplateReport(data, tmpl)
+
+	got, err := os.ReadFile(filepath.Join(dir, "azqr_report.ids.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "redis-007\nredis-008\nkv-008\n"; string(got) != want {
+		t.Errorf("CreateTemplateReport() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// SchemaVersion - Version of the webhook payload
+const SchemaVersion = "1.0"
+
+const (
+	maxAttempts = 3
+	timeout     = 30 * time.Second
+)
+
+// retryDelay - Delay before retrying a request that failed with a server error, doubled on each attempt
+var retryDelay = 2 * time.Second
+
+type (
+	// Payload - Body of the webhook sent when a scan completes
+	Payload struct {
+		SchemaVersion   string        `json:"schemaVersion"`
+		ScanTimestamp   time.Time     `json:"scanTimestamp"`
+		SubscriptionIDs []string      `json:"subscriptionIds"`
+		Resources       int           `json:"resources"`
+		Findings        FindingCounts `json:"findings"`
+		ComplianceScore float64       `json:"complianceScore"`
+		ReportURL       string        `json:"reportUrl,omitempty"`
+	}
+
+	// FindingCounts - Number of non compliant rules by impact
+	FindingCounts struct {
+		Critical int `json:"critical"`
+		High     int `json:"high"`
+		Medium   int `json:"medium"`
+		Low      int `json:"low"`
+		Total    int `json:"total"`
+	}
+)
+
+// NewPayload - Creates the webhook payload of the scan results. Subscription ids are masked like in the reports.
+func NewPayload(data *renderers.ReportData, subscriptionIDs []string, scanTimestamp time.Time) Payload {
+	summary := renderers.Summarize(data.MainData)
+
+	findings := FindingCounts{Total: summary.Failed}
+	for impact, count := range summary.ByImpact {
+		switch impact {
+		case scanners.ImpactCritical:
+			findings.Critical = count.Failed
+		case scanners.ImpactHigh:
+			findings.High = count.Failed
+		case scanners.ImpactMedium:
+			findings.Medium = count.Failed
+		case scanners.ImpactLow:
+			findings.Low = count.Failed
+		}
+	}
+
+	ids := []string{}
+	for _, id := range subscriptionIDs {
+		ids = append(ids, scanners.MaskSubscriptionID(id, data.Mask))
+	}
+
+	return Payload{
+		SchemaVersion:   SchemaVersion,
+		ScanTimestamp:   scanTimestamp.UTC(),
+		SubscriptionIDs: ids,
+		Resources:       summary.Resources,
+		Findings:        findings,
+		ComplianceScore: summary.ComplianceScore,
+	}
+}
+
+// ParseHeaders - Parses headers in the "Name: value" format
+func ParseHeaders(headers []string) (map[string]string, error) {
+	res := map[string]string{}
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid header %q, expected \"Name: value\"", h)
+		}
+		res[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return res, nil
+}
+
+// Send - Posts the payload to the url, retrying on server errors
+func Send(ctx context.Context, url string, headers map[string]string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: timeout}
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := post(ctx, client, url, headers, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == maxAttempts {
+			return err
+		}
+
+		log.Debug().Err(err).Msgf("Retrying webhook in %s", delay)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post - Sends the request, returning true if it can be retried
+func post(ctx context.Context, client *http.Client, url string, headers map[string]string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 500 {
+		return true, fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	if resp.StatusCode >= 300 {
+		return false, fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestSend(t *testing.T) {
+	retryDelay = 0
+
+	data := &renderers.ReportData{
+		Mask: true,
+		MainData: []scanners.AzureServiceResult{
+			{
+				SubscriptionID: "00000000-0000-0000-0000-000000000001",
+				ServiceName:    "redis-app",
+				Rules: map[string]scanners.AzureRuleResult{
+					"redis-008": {Id: "redis-008", Impact: scanners.ImpactHigh, NotCompliant: true},
+					"redis-007": {Id: "redis-007", Impact: scanners.ImpactLow, NotCompliant: true},
+					"redis-002": {Id: "redis-002", Impact: scanners.ImpactHigh, NotCompliant: false},
+				},
+			},
+		},
+	}
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	payload := NewPayload(data, []string{"00000000-0000-0000-0000-000000000001"}, timestamp)
+
+	tests := []struct {
+		name     string
+		statuses []int
+		wantErr  bool
+		wantReqs int
+	}{
+		{name: "ok", statuses: []int{http.StatusOK}, wantErr: false, wantReqs: 1},
+		{name: "retries server errors", statuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent}, wantErr: false, wantReqs: 3},
+		{name: "gives up after max attempts", statuses: []int{500, 500, 500, 500}, wantErr: true, wantReqs: maxAttempts},
+		{name: "does not retry client errors", statuses: []int{http.StatusUnauthorized}, wantErr: true, wantReqs: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs := 0
+			var got Payload
+			var headers http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers = r.Header
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("failed to decode payload: %v", err)
+				}
+				w.WriteHeader(tt.statuses[reqs])
+				reqs++
+			}))
+			defer server.Close()
+
+			err := Send(context.Background(), server.URL, map[string]string{"Authorization": "Bearer token"}, payload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if reqs != tt.wantReqs {
+				t.Errorf("Send() requests = %d, want %d", reqs, tt.wantReqs)
+			}
+			if headers.Get("Authorization") != "Bearer token" || headers.Get("Content-Type") != "application/json" {
+				t.Errorf("Send() headers = %v", headers)
+			}
+
+			want := Payload{
+				SchemaVersion:   "1.0",
+				ScanTimestamp:   timestamp,
+				SubscriptionIDs: []string{"xxxxxxxx-xxxx-xxxx-xxxx-xxxxx0000001"},
+				Resources:       1,
+				Findings:        FindingCounts{High: 1, Low: 1, Total: 2},
+				ComplianceScore: payload.ComplianceScore,
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Send() payload = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "valid", headers: []string{"Authorization: Bearer a:b", "X-Team:ops"}, want: map[string]string{"Authorization": "Bearer a:b", "X-Team": "ops"}},
+		{name: "missing separator", headers: []string{"Authorization"}, wantErr: true},
+		{name: "empty name", headers: []string{": value"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHeaders(tt.headers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHeaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
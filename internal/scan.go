@@ -7,13 +7,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"reflect"
+	"strings"
 	"sync"
+	texttemplate "text/template"
 	"time"
 
 	"github.com/Azure/azqr/internal/renderers"
+	"github.com/Azure/azqr/internal/renderers/azdo"
 	"github.com/Azure/azqr/internal/renderers/csv"
 	"github.com/Azure/azqr/internal/renderers/excel"
+	"github.com/Azure/azqr/internal/renderers/github"
+	"github.com/Azure/azqr/internal/renderers/resourcegraph"
+	"github.com/Azure/azqr/internal/renderers/template"
+	"github.com/Azure/azqr/internal/renderers/webhook"
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azqr/internal/to"
 	"github.com/rs/zerolog"
@@ -22,12 +31,12 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/subscription/armsubscription"
 
+	"github.com/Azure/azqr/internal/scanners/aa"
 	"github.com/Azure/azqr/internal/scanners/adf"
 	"github.com/Azure/azqr/internal/scanners/afd"
 	"github.com/Azure/azqr/internal/scanners/afw"
@@ -35,10 +44,12 @@ import (
 	"github.com/Azure/azqr/internal/scanners/aks"
 	"github.com/Azure/azqr/internal/scanners/amg"
 	"github.com/Azure/azqr/internal/scanners/apim"
+	"github.com/Azure/azqr/internal/scanners/app"
 	"github.com/Azure/azqr/internal/scanners/appcs"
 	"github.com/Azure/azqr/internal/scanners/appi"
 	"github.com/Azure/azqr/internal/scanners/as"
 	"github.com/Azure/azqr/internal/scanners/asp"
+	"github.com/Azure/azqr/internal/scanners/bas"
 	"github.com/Azure/azqr/internal/scanners/ca"
 	"github.com/Azure/azqr/internal/scanners/cae"
 	"github.com/Azure/azqr/internal/scanners/ci"
@@ -47,7 +58,10 @@ import (
 	"github.com/Azure/azqr/internal/scanners/cr"
 	"github.com/Azure/azqr/internal/scanners/dbw"
 	"github.com/Azure/azqr/internal/scanners/dec"
+	"github.com/Azure/azqr/internal/scanners/dps"
+	"github.com/Azure/azqr/internal/scanners/ercir"
 	"github.com/Azure/azqr/internal/scanners/evgd"
+	"github.com/Azure/azqr/internal/scanners/evgt"
 	"github.com/Azure/azqr/internal/scanners/evh"
 	"github.com/Azure/azqr/internal/scanners/kv"
 	"github.com/Azure/azqr/internal/scanners/lb"
@@ -55,8 +69,12 @@ import (
 	"github.com/Azure/azqr/internal/scanners/maria"
 	"github.com/Azure/azqr/internal/scanners/mysql"
 	"github.com/Azure/azqr/internal/scanners/psql"
+	"github.com/Azure/azqr/internal/scanners/quota"
 	"github.com/Azure/azqr/internal/scanners/redis"
+	"github.com/Azure/azqr/internal/scanners/redise"
+	"github.com/Azure/azqr/internal/scanners/rg"
 	"github.com/Azure/azqr/internal/scanners/sb"
+	"github.com/Azure/azqr/internal/scanners/sentinel"
 	"github.com/Azure/azqr/internal/scanners/sigr"
 	"github.com/Azure/azqr/internal/scanners/sql"
 	"github.com/Azure/azqr/internal/scanners/st"
@@ -66,6 +84,7 @@ import (
 	"github.com/Azure/azqr/internal/scanners/vm"
 	"github.com/Azure/azqr/internal/scanners/vmss"
 	"github.com/Azure/azqr/internal/scanners/vnet"
+	"github.com/Azure/azqr/internal/scanners/vwan"
 	"github.com/Azure/azqr/internal/scanners/wps"
 )
 
@@ -78,13 +97,46 @@ type ScanParams struct {
 	Cost                    bool
 	Mask                    bool
 	Xlsx                    bool
+	ResourceGraph           bool
+	OutputTemplate          string
 	Debug                   bool
 	ServiceScanners         []scanners.IAzureScanner
 	ForceAzureCliCredential bool
 	ExclusionsFile          string
+	SummaryOnly             bool
+	ShowCreatedAt           bool
+	ParallelRules           bool
+	Tags                    []string
+	DefenderIntegration     bool
+	NoColor                 bool
+	WebhookURL              string
+	WebhookHeaders          []string
+	FailOnScannerError      bool
+	StrictInit              bool
+	SubscriptionScanners    []scanners.ISubscriptionScanner
+	ResourceID              string
+	ShowRemediation         bool
+	QuotaThresholdHigh      float64
+	QuotaThresholdMedium    float64
 }
 
-func Scan(params *ScanParams) {
+// ScanError - Error of a scanner that could not scan a resource group, or the subscription
+// scope when ResourceGroup is empty
+type ScanError struct {
+	Scanner       string
+	ResourceGroup string
+	Err           error
+}
+
+func (e ScanError) scope() string {
+	if e.ResourceGroup == "" {
+		return "the subscription"
+	}
+	return fmt.Sprintf("resource group %s", e.ResourceGroup)
+}
+
+// Scan - Scans the subscriptions, writes the reports and returns the rule results
+func Scan(params *ScanParams) []scanners.AzureServiceResult {
 	subscriptionID := params.SubscriptionID
 	resourceGroupName := params.ResourceGroup
 	outputFileName := params.OutputName
@@ -96,6 +148,17 @@ func Scan(params *ScanParams) {
 	debug := params.Debug
 	forceAzureCliCredential := params.ForceAzureCliCredential
 	exclusionsFile := params.ExclusionsFile
+	summaryOnly := params.SummaryOnly
+	showCreatedAt := params.ShowCreatedAt
+	parallelRules := params.ParallelRules
+	tags := params.Tags
+	defenderIntegration := params.DefenderIntegration
+	noColor := params.NoColor
+	webhookURL := params.WebhookURL
+	failOnScannerError := params.FailOnScannerError
+	strictInit := params.StrictInit
+	resourceID := params.ResourceID
+	scanTimestamp := time.Now()
 
 	// Default level for this example is info, unless debug flag is present
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
@@ -108,6 +171,31 @@ func Scan(params *ScanParams) {
 		log.Fatal().Msg("Resource Group name can only be used with a Subscription Id")
 	}
 
+	webhookHeaders, err := webhook.ParseHeaders(params.WebhookHeaders)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to parse webhook headers")
+	}
+
+	var outputTemplate *texttemplate.Template
+	if params.OutputTemplate != "" {
+		outputTemplate, err = template.Parse(params.OutputTemplate)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to parse output template")
+		}
+	}
+
+	if resourceID != "" {
+		params.ServiceScanners, err = resourceScanners(params.ServiceScanners, resourceID)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Failed to scan resource %s", resourceID)
+		}
+	}
+
+	err = validateRuleDependencies(params.ServiceScanners, params.SubscriptionScanners)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid rule dependencies")
+	}
+
 	outputFile := outputFileName
 	if outputFile == "" {
 		current_time := time.Now()
@@ -140,8 +228,12 @@ func Scan(params *ScanParams) {
 		}
 	}
 
+	customRules, err := scanners.NewCELRuleEngine(exclusions.Azqr.CustomRules)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to compile custom rules")
+	}
+
 	var cred azcore.TokenCredential
-	var err error
 	if !forceAzureCliCredential {
 		cred, err = azidentity.NewDefaultAzureCredential(nil)
 		if err != nil {
@@ -156,26 +248,12 @@ func Scan(params *ScanParams) {
 
 	ctx := context.Background()
 
-	clientOptions := &arm.ClientOptions{
-		ClientOptions: policy.ClientOptions{
-			Retry: policy.RetryOptions{
-				RetryDelay:    20 * time.Millisecond,
-				MaxRetries:    3,
-				MaxRetryDelay: 10 * time.Minute,
-			},
-		},
-	}
+	clientOptions := scanners.DefaultClientOptions()
 
-	subscriptions := map[string]string{}
-	subs, err := listSubscriptions(ctx, cred, clientOptions)
+	subscriptions, err := resolveSubscriptions(ctx, subscriptionID, cred, clientOptions)
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to list subscriptions")
 	}
-	for _, s := range subs {
-		if subscriptionID == "" || subscriptionID == *s.SubscriptionID {
-			subscriptions[*s.SubscriptionID] = *s.DisplayName
-		}
-	}
 
 	var ruleResults []scanners.AzureServiceResult
 	var defenderResults []scanners.DefenderResult
@@ -190,15 +268,22 @@ func Scan(params *ScanParams) {
 	defenderScanner := scanners.DefenderScanner{}
 	peScanner := scanners.PrivateEndpointScanner{}
 	pipScanner := scanners.PublicIPScanner{}
+	fwpScanner := scanners.FirewallPolicyScanner{}
+	lockScanner := scanners.LockScanner{}
+	sentinelScanner := sentinel.SentinelScanner{}
+	exemptionScanner := scanners.PolicyExemptionScanner{}
 	diagnosticsScanner := scanners.DiagnosticSettingsScanner{}
 	advisorScanner := scanners.AdvisorScanner{}
 	costScanner := scanners.CostScanner{}
 
+	scannedSubscriptions := []string{}
+	scanErrors := []ScanError{}
 	for s, sn := range subscriptions {
 		if exclusions.Azqr.Exclude.IsSubscriptionExcluded(s) {
 			log.Info().Msgf("Skipping subscriptions/...%s", s[29:])
 			continue
 		}
+		scannedSubscriptions = append(scannedSubscriptions, s)
 
 		resourceGroups := []string{}
 		if resourceGroupName != "" {
@@ -256,10 +341,11 @@ func Scan(params *ScanParams) {
 		if err != nil {
 			log.Fatal().Err(err).Msg("Failed to initialize Diagnostic Settings Scanner")
 		}
-		diagResults, err := diagnosticsScanner.ListResourcesWithDiagnosticSettings()
+		diagResults, diagCategories, err := diagnosticsScanner.ListResourcesWithDiagnosticSettings()
 		if err != nil {
 			if shouldSkipError(err) {
 				diagResults = map[string]bool{}
+				diagCategories = map[string][]string{}
 			} else {
 				log.Fatal().Err(err).Msg("Failed to list resources with Diagnostic Settings")
 			}
@@ -278,54 +364,163 @@ func Scan(params *ScanParams) {
 			}
 		}
 
-		scanContext := scanners.ScanContext{
-			Exclusions:          exclusions.Azqr.Exclude,
-			PrivateEndpoints:    peResults,
-			DiagnosticsSettings: diagResults,
-			PublicIPs:           pips,
+		var idps map[string]string
+		if isScannerSelected(params.ServiceScanners, func(s scanners.IAzureScanner) bool {
+			_, ok := s.(*afw.FirewallScanner)
+			return ok
+		}) {
+			err = fwpScanner.Init(config)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to initialize Firewall Policy Scanner")
+			}
+			idps, err = fwpScanner.ListFirewallPolicyIDPS()
+			if err != nil {
+				if shouldSkipError(err) {
+					idps = map[string]string{}
+				} else {
+					log.Fatal().Err(err).Msg("Failed to list Firewall Policies")
+				}
+			}
 		}
 
-		for _, a := range params.ServiceScanners {
-			err := a.Init(config)
+		var locks map[string]bool
+		if scanners.IsSharedRuleSelected("lock-001", exclusions.Azqr.Exclude, tags) {
+			err = lockScanner.Init(config)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to initialize scanner")
+				log.Fatal().Err(err).Msg("Failed to initialize Lock Scanner")
+			}
+			// lock-001 is skipped when the locks can't be listed
+			locks, err = lockScanner.ListResourceLocks()
+			if err != nil {
+				if shouldSkipError(err) {
+					locks = nil
+				} else {
+					log.Fatal().Err(err).Msg("Failed to list Resource Locks")
+				}
 			}
 		}
 
-		for _, r := range resourceGroups {
-			var wg sync.WaitGroup
-			ch := make(chan []scanners.AzureServiceResult, 5)
-			wg.Add(len(params.ServiceScanners))
-
-			go func() {
-				wg.Wait()
-				close(ch)
-			}()
+		var sentinelWorkspaces map[string]bool
+		if isScannerSelected(params.ServiceScanners, func(s scanners.IAzureScanner) bool {
+			_, ok := s.(*sentinel.SentinelScanner)
+			return ok
+		}) {
+			err = sentinelScanner.Init(config)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to initialize Sentinel Scanner")
+			}
+			sentinelWorkspaces, err = sentinelScanner.ListSentinelWorkspaces()
+			if err != nil {
+				if shouldSkipError(err) {
+					sentinelWorkspaces = map[string]bool{}
+				} else {
+					log.Fatal().Err(err).Msg("Failed to list Microsoft Sentinel workspaces")
+				}
+			}
+		}
 
-			for _, s := range params.ServiceScanners {
-				go func(r string, s scanners.IAzureScanner) {
-					defer wg.Done()
+		err = exemptionScanner.Init(config)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize Policy Exemption Scanner")
+		}
+		exemptions, err := exemptionScanner.ListPolicyExemptions()
+		if err != nil {
+			if shouldSkipError(err) {
+				exemptions = map[string][]string{}
+			} else {
+				log.Fatal().Err(err).Msg("Failed to list Policy Exemptions")
+			}
+		}
 
-					res, err := retry(3, 10*time.Millisecond, s, r, &scanContext)
-					if err != nil {
-						cancel()
-						log.Fatal().Err(err).Msg("Failed to scan")
-					}
-					ch <- res
-				}(r, s)
+		err = defenderScanner.Init(config)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to initialize Defender Scanner")
+		}
+		var crVulnAssessments map[string]bool
+		if isScannerSelected(params.ServiceScanners, func(s scanners.IAzureScanner) bool {
+			_, ok := s.(*cr.ContainerRegistryScanner)
+			return ok
+		}) {
+			// cr-012 is skipped when the assessments can't be listed
+			crVulnAssessments, err = defenderScanner.ListContainerRegistryVulnAssessments()
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to list Container Registry vulnerability assessments, skipping cr-012")
+				crVulnAssessments = nil
 			}
+		}
 
-			for i := 0; i < len(params.ServiceScanners); i++ {
-				res := <-ch
-				for _, r := range res {
-					if exclusions.Azqr.Exclude.IsServiceExcluded(r.ResourceID()) {
-						continue
-					}
-					ruleResults = append(ruleResults, r)
+		var defenderRecommendations map[string][]string
+		if defenderIntegration {
+			defenderRecommendations, err = defenderScanner.ListActiveRecommendations()
+			if err != nil {
+				if shouldSkipError(err) {
+					defenderRecommendations = map[string][]string{}
+				} else {
+					log.Fatal().Err(err).Msg("Failed to list Defender recommendations")
 				}
 			}
 		}
 
+		scanContext := scanners.ScanContext{
+			Exclusions:                       exclusions.Azqr.Exclude,
+			PrivateEndpoints:                 peResults,
+			DiagnosticsSettings:              diagResults,
+			DiagnosticsSettingsCategories:    diagCategories,
+			PublicIPs:                        pips,
+			FirewallPolicyIDPS:               idps,
+			ResourceLocks:                    locks,
+			Locks:                            exclusions.Azqr.Locks,
+			ParallelRules:                    parallelRules,
+			TagFilter:                        tags,
+			CustomRules:                      customRules,
+			DefenderRecommendations:          defenderRecommendations,
+			RuleURLOverrides:                 exclusions.Azqr.RuleURLOverrides,
+			RequiredTags:                     exclusions.Azqr.RequiredTags,
+			PolicyExemptions:                 exemptions,
+			QuotaThresholdHigh:               params.QuotaThresholdHigh,
+			QuotaThresholdMedium:             params.QuotaThresholdMedium,
+			ContainerRegistryVulnAssessments: crVulnAssessments,
+			SentinelWorkspaces:               sentinelWorkspaces,
+		}
+
+		serviceScanners, initErrs := initServiceScanners(ctx, params.ServiceScanners, config)
+		if strictInit && len(initErrs) > 0 {
+			log.Fatal().Err(initErrs[0].Err).Msgf("Failed to initialize %s", initErrs[0].Scanner)
+		}
+		for _, e := range initErrs {
+			log.Warn().Err(e.Err).Msgf("Failed to initialize %s, skipping it", e.Scanner)
+		}
+		scanErrors = append(scanErrors, initErrs...)
+
+		// Subscription scope resources are only scanned when the whole subscription is
+		subscriptionScanners := params.SubscriptionScanners
+		if resourceGroupName != "" {
+			subscriptionScanners = nil
+		}
+		for _, a := range subscriptionScanners {
+			err := a.Init(config)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to initialize scanner")
+			}
+		}
+
+		res, errs := scanSubscription(resourceGroups, serviceScanners, subscriptionScanners, &scanContext, failOnScannerError)
+		if failOnScannerError && len(errs) > 0 {
+			cancel()
+			log.Fatal().Err(errs[0].Err).Msgf("%s failed to scan %s", errs[0].Scanner, errs[0].scope())
+		}
+		scanErrors = append(scanErrors, errs...)
+
+		for _, r := range res {
+			if exclusions.Azqr.Exclude.IsServiceExcluded(r.ResourceID()) {
+				continue
+			}
+			if resourceID != "" && !strings.EqualFold(r.ResourceID(), resourceID) {
+				continue
+			}
+			ruleResults = append(ruleResults, r)
+		}
+
 		if defender {
 			err = defenderScanner.Init(config)
 			if err != nil {
@@ -375,26 +570,171 @@ func Scan(params *ScanParams) {
 		}
 	}
 
+	if resourceID != "" && len(ruleResults) == 0 {
+		log.Fatal().Msgf("Resource %s not found", resourceID)
+	}
+
 	reportData := renderers.ReportData{
-		OutputFileName: outputFile,
-		Mask:           mask,
-		MainData:       ruleResults,
-		DefenderData:   defenderResults,
-		AdvisorData:    advisorResults,
-		CostData:       costResult,
+		OutputFileName:  outputFile,
+		Mask:            mask,
+		SummaryOnly:     summaryOnly,
+		ShowCreatedAt:   showCreatedAt,
+		ShowDefender:    defenderIntegration,
+		ShowRemediation: params.ShowRemediation,
+		MainData:        ruleResults,
+		DefenderData:    defenderResults,
+		AdvisorData:     advisorResults,
+		CostData:        costResult,
 	}
 
 	if createXlsx {
 		excel.CreateExcelReport(&reportData)
 	}
 
+	if params.ResourceGraph {
+		resourcegraph.CreateResourceGraphReport(&reportData)
+	}
+
+	if outputTemplate != nil {
+		template.CreateTemplateReport(&reportData, outputTemplate)
+	}
+
 	csv.CreateCsvReport(&reportData)
 
+	if github.IsGitHubActions() && !noColor {
+		github.NewGitHubActionsFormatter().CreateGitHubActionsReport(&reportData)
+	}
+
+	if azdo.IsAzureDevOps() {
+		azdo.NewAzureDevOpsFormatter().CreateAzureDevOpsReport(&reportData)
+	}
+
+	summary := renderers.Summarize(ruleResults)
+	log.Info().Msgf("Compliance score: %.1f%% (%d of %d rules passed on %d resources)", summary.ComplianceScore, summary.Passed, summary.Rules, summary.Resources)
+	for _, impact := range scanners.Impacts {
+		if c, ok := summary.ByImpact[impact]; ok && c.Failed > 0 {
+			log.Info().Msgf("%s impact: %d rules failed", renderers.ImpactLabel(impact, noColor), c.Failed)
+		}
+	}
+	if len(summary.TimedOutRules) > 0 {
+		log.Warn().Msgf("%d rule evaluations timed out and are reported as not compliant: %s", len(summary.TimedOutRules), strings.Join(summary.TimedOutRules, ", "))
+	}
+
+	if webhookURL != "" {
+		payload := webhook.NewPayload(&reportData, scannedSubscriptions, scanTimestamp)
+		if err := webhook.Send(ctx, webhookURL, webhookHeaders, payload); err != nil {
+			log.Error().Err(err).Msg("Failed to send scan summary to webhook")
+		} else {
+			log.Info().Msg("Scan summary sent to webhook")
+		}
+	}
+
+	if len(scanErrors) > 0 {
+		log.Warn().Msgf("%d scanner errors, results may be incomplete:", len(scanErrors))
+		for _, e := range scanErrors {
+			log.Warn().Err(e.Err).Msgf("%s failed to scan %s", e.Scanner, e.scope())
+		}
+	}
+
 	log.Info().Msg("Scan completed.")
+	return ruleResults
+}
+
+// isScannerSelected - Returns true if one of the service scanners matches
+func isScannerSelected(serviceScanners []scanners.IAzureScanner, match func(scanners.IAzureScanner) bool) bool {
+	for _, s := range serviceScanners {
+		if match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// initServiceScanners - Initializes the scanners concurrently, returning the initialized ones and
+// a ScanError for each scanner that failed to initialize
+func initServiceScanners(ctx context.Context, serviceScanners []scanners.IAzureScanner, config *scanners.ScannerConfig) ([]scanners.IAzureScanner, []ScanError) {
+	initialized := []scanners.IAzureScanner{}
+	scanErrors := []ScanError{}
+	for i, err := range scanners.ParallelInitScanners(ctx, serviceScanners, config) {
+		if err != nil {
+			scanErrors = append(scanErrors, ScanError{
+				Scanner: strings.TrimPrefix(fmt.Sprintf("%T", serviceScanners[i]), "*"),
+				Err:     err,
+			})
+			continue
+		}
+		initialized = append(initialized, serviceScanners[i])
+	}
+	return initialized, scanErrors
+}
+
+// scanSubscription - Runs the service scanners on each resource group, then the subscription scanners
+// once. When failFast is set, it stops at the first resource group with errors.
+func scanSubscription(resourceGroups []string, serviceScanners []scanners.IAzureScanner, subscriptionScanners []scanners.ISubscriptionScanner, scanContext *scanners.ScanContext, failFast bool) ([]scanners.AzureServiceResult, []ScanError) {
+	results := []scanners.AzureServiceResult{}
+	scanErrors := []ScanError{}
+
+	for _, r := range resourceGroups {
+		res, errs := scanResourceGroup(r, serviceScanners, scanContext)
+		results = append(results, res...)
+		scanErrors = append(scanErrors, errs...)
+		if failFast && len(errs) > 0 {
+			return results, scanErrors
+		}
+	}
+
+	for _, s := range subscriptionScanners {
+		res, err := s.ScanSubscriptionScope(scanContext)
+		if err != nil && !shouldSkipError(err) {
+			scanErrors = append(scanErrors, ScanError{
+				Scanner: strings.TrimPrefix(fmt.Sprintf("%T", s), "*"),
+				Err:     err,
+			})
+			if failFast {
+				return results, scanErrors
+			}
+			continue
+		}
+		results = append(results, res...)
+	}
+
+	return results, scanErrors
+}
+
+// scanResourceGroup - Runs all scanners on the resource group concurrently. Errors of a scanner
+// are returned as ScanErrors and do not stop the others.
+func scanResourceGroup(resourceGroup string, serviceScanners []scanners.IAzureScanner, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, []ScanError) {
+	results := []scanners.AzureServiceResult{}
+	scanErrors := []ScanError{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, s := range serviceScanners {
+		wg.Add(1)
+		go func(s scanners.IAzureScanner) {
+			defer wg.Done()
+
+			res, err := retry(3, 10*time.Millisecond, s, resourceGroup, scanContext)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				scanErrors = append(scanErrors, ScanError{
+					Scanner:       strings.TrimPrefix(fmt.Sprintf("%T", s), "*"),
+					ResourceGroup: resourceGroup,
+					Err:           err,
+				})
+				return
+			}
+			results = append(results, res...)
+		}(s)
+	}
+	wg.Wait()
+
+	return results, scanErrors
 }
 
+// retry - Scans the resource group, retrying transient errors (throttling, server errors)
 func retry(attempts int, sleep time.Duration, a scanners.IAzureScanner, r string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
-	var err error
 	for i := 0; ; i++ {
 		res, err := a.Scan(r, scanContext)
 		if err == nil {
@@ -407,9 +747,13 @@ func retry(attempts int, sleep time.Duration, a scanners.IAzureScanner, r string
 
 		errAsString := err.Error()
 
+		if !isTransientError(err) {
+			return nil, err
+		}
+
 		if i >= (attempts - 1) {
 			log.Info().Msgf("Retry limit reached. Error: %s", errAsString)
-			break
+			return nil, err
 		}
 
 		log.Debug().Msgf("Retrying after error: %s", errAsString)
@@ -417,7 +761,6 @@ func retry(attempts int, sleep time.Duration, a scanners.IAzureScanner, r string
 		time.Sleep(sleep)
 		sleep *= 2
 	}
-	return nil, err
 }
 
 func checkExistenceResourceGroup(ctx context.Context, subscriptionID string, resourceGroupName string, cred azcore.TokenCredential, options *arm.ClientOptions) (bool, error) {
@@ -452,6 +795,35 @@ func listResourceGroup(ctx context.Context, subscriptionID string, cred azcore.T
 	return resourceGroups, nil
 }
 
+// resolveSubscriptions - Returns the display names of the subscriptions to scan by id. When subscriptionID
+// is set but not returned by the list API, its display name is read with a Get request.
+func resolveSubscriptions(ctx context.Context, subscriptionID string, cred azcore.TokenCredential, options *arm.ClientOptions) (map[string]string, error) {
+	subscriptions := map[string]string{}
+	subs, err := listSubscriptions(ctx, cred, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range subs {
+		if subscriptionID == "" || strings.EqualFold(subscriptionID, *s.SubscriptionID) {
+			subscriptions[*s.SubscriptionID] = *s.DisplayName
+		}
+	}
+
+	if subscriptionID != "" && len(subscriptions) == 0 {
+		client, err := armsubscription.NewSubscriptionsClient(cred, options)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Get(ctx, subscriptionID, nil)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions[*resp.SubscriptionID] = *resp.DisplayName
+	}
+
+	return subscriptions, nil
+}
+
 func listSubscriptions(ctx context.Context, cred azcore.TokenCredential, options *arm.ClientOptions) ([]*armsubscription.Subscription, error) {
 	client, err := armsubscription.NewSubscriptionsClient(cred, options)
 	if err != nil {
@@ -477,6 +849,15 @@ func listSubscriptions(ctx context.Context, cred azcore.TokenCredential, options
 	return subscriptions, nil
 }
 
+// isTransientError - Returns false for ARM errors that will not succeed on retry, like 403 Forbidden
+func isTransientError(err error) bool {
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
 func shouldSkipError(err error) bool {
 	var respErr *azcore.ResponseError
 	if errors.As(err, &respErr) {
@@ -489,9 +870,118 @@ func shouldSkipError(err error) bool {
 	return false
 }
 
+// validateRuleDependencies - Returns an error if the DependsOn graph of a scanner's rules has a cycle
+func validateRuleDependencies(serviceScanners []scanners.IAzureScanner, subscriptionScanners []scanners.ISubscriptionScanner) error {
+	rules := []map[string]scanners.AzureRule{}
+	for _, s := range serviceScanners {
+		rules = append(rules, s.GetRules())
+	}
+	for _, s := range subscriptionScanners {
+		rules = append(rules, s.GetRules())
+	}
+	for _, r := range rules {
+		if err := scanners.ValidateRuleDependencies(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSubscriptionScanners - Returns the scanners of resources at subscription scope
+func GetSubscriptionScanners() []scanners.ISubscriptionScanner {
+	return []scanners.ISubscriptionScanner{
+		&rg.ResourceGroupScanner{},
+		&quota.QuotaScanner{},
+	}
+}
+
+// resourceTypeScanners - Scanner of each resource type (lower case), used to scan a single resource.
+// Child resources reported by a scanner (e.g. SQL databases) map to the scanner of their parent.
+var resourceTypeScanners = map[string]scanners.IAzureScanner{
+	"microsoft.databricks/workspaces":                &dbw.DatabricksScanner{},
+	"microsoft.automation/automationaccounts":        &aa.AutomationScanner{},
+	"microsoft.datafactory/factories":                &adf.DataFactoryScanner{},
+	"microsoft.cdn/profiles":                         &afd.FrontDoorScanner{},
+	"microsoft.network/azurefirewalls":               &afw.FirewallScanner{},
+	"microsoft.network/applicationgateways":          &agw.ApplicationGatewayScanner{},
+	"microsoft.containerservice/managedclusters":     &aks.AKSScanner{},
+	"microsoft.dashboard/grafana":                    &amg.ManagedGrafanaScanner{},
+	"microsoft.apimanagement/service":                &apim.APIManagementScanner{},
+	"microsoft.appconfiguration/configurationstores": &appcs.AppConfigurationScanner{},
+	"microsoft.insights/components":                  &appi.AppInsightsScanner{},
+	"microsoft.analysisservices/servers":             &as.AnalysisServicesScanner{},
+	"microsoft.network/bastionhosts":                 &bas.BastionScanner{},
+	"microsoft.app/managedenvironments":              &cae.ContainerAppsEnvironmentScanner{},
+	"microsoft.app/containerapps":                    &ca.ContainerAppsScanner{},
+	"microsoft.containerinstance/containergroups":    &ci.ContainerInstanceScanner{},
+	"microsoft.cognitiveservices/accounts":           &cog.CognitiveScanner{},
+	"microsoft.documentdb/databaseaccounts":          &cosmos.CosmosDBScanner{},
+	"microsoft.containerregistry/registries":         &cr.ContainerRegistryScanner{},
+	"microsoft.kusto/clusters":                       &dec.DataExplorerScanner{},
+	"microsoft.devices/provisioningservices":         &dps.DPSScanner{},
+	"microsoft.network/expressroutecircuits":         &ercir.ExpressRouteCircuitScanner{},
+	"microsoft.eventgrid/domains":                    &evgd.EventGridScanner{},
+	"microsoft.eventgrid/topics":                     &evgt.EventGridTopicScanner{},
+	"microsoft.eventhub/namespaces":                  &evh.EventHubScanner{},
+	"microsoft.keyvault/vaults":                      &kv.KeyVaultScanner{},
+	"microsoft.network/loadbalancers":                &lb.LoadBalancerScanner{},
+	"microsoft.logic/workflows":                      &logic.LogicAppScanner{},
+	"microsoft.dbformariadb/servers":                 &maria.MariaScanner{},
+	"microsoft.dbformariadb/servers/databases":       &maria.MariaScanner{},
+	"microsoft.dbformysql/flexibleservers":           &mysql.MySQLFlexibleScanner{},
+	"microsoft.dbformysql/servers":                   &mysql.MySQLScanner{},
+	"microsoft.web/serverfarms":                      &asp.AppServicePlanScanner{},
+	"microsoft.web/sites":                            &app.AppServiceScanner{},
+	"microsoft.dbforpostgresql/flexibleservers":      &psql.PostgreFlexibleScanner{},
+	"microsoft.dbforpostgresql/servers":              &psql.PostgreScanner{},
+	"microsoft.cache/redis":                          &redis.RedisScanner{},
+	"microsoft.cache/redisenterprise":                &redise.RedisEnterpriseScanner{},
+	"microsoft.servicebus/namespaces":                &sb.ServiceBusScanner{},
+	"microsoft.operationalinsights/workspaces":       &sentinel.SentinelScanner{},
+	"microsoft.signalrservice/signalr":               &sigr.SignalRScanner{},
+	"microsoft.sql/servers":                          &sql.SQLScanner{},
+	"microsoft.sql/servers/databases":                &sql.SQLScanner{},
+	"microsoft.sql/servers/elasticpools":             &sql.SQLScanner{},
+	"microsoft.synapse/workspaces":                   &synw.SynapseWorkspaceScanner{},
+	"microsoft.synapse/workspaces/sqlpools":          &synw.SynapseWorkspaceScanner{},
+	"microsoft.synapse/workspaces/bigdatapools":      &synw.SynapseWorkspaceScanner{},
+	"microsoft.network/trafficmanagerprofiles":       &traf.TrafficManagerScanner{},
+	"microsoft.storage/storageaccounts":              &st.StorageScanner{},
+	"microsoft.compute/virtualmachines":              &vm.VirtualMachineScanner{},
+	"microsoft.compute/virtualmachinescalesets":      &vmss.VirtualMachineScaleSetScanner{},
+	"microsoft.network/virtualnetworks":              &vnet.VirtualNetworkScanner{},
+	"microsoft.network/virtualnetworkgateways":       &vgw.VirtualNetworkGatewayScanner{},
+	"microsoft.network/virtualwans":                  &vwan.VirtualWanScanner{},
+	"microsoft.signalrservice/webpubsub":             &wps.WebPubSubScanner{},
+}
+
+// resourceScanners - Returns the service scanners of the type of the resource, so scanning a single
+// resource only runs its scanner
+func resourceScanners(serviceScanners []scanners.IAzureScanner, resourceID string) ([]scanners.IAzureScanner, error) {
+	id, err := arm.ParseResourceID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	scanner, ok := resourceTypeScanners[strings.ToLower(id.ResourceType.String())]
+	if !ok {
+		return nil, fmt.Errorf("resource type %s is not supported", id.ResourceType.String())
+	}
+	res := []scanners.IAzureScanner{}
+	for _, s := range serviceScanners {
+		if reflect.TypeOf(s) == reflect.TypeOf(scanner) {
+			res = append(res, s)
+		}
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("the scanner of resource type %s is not selected", id.ResourceType.String())
+	}
+	return res, nil
+}
+
 func GetScanners() []scanners.IAzureScanner {
 	return []scanners.IAzureScanner{
 		&dbw.DatabricksScanner{},
+		&aa.AutomationScanner{},
 		&adf.DataFactoryScanner{},
 		&afd.FrontDoorScanner{},
 		&afw.FirewallScanner{},
@@ -502,6 +992,7 @@ func GetScanners() []scanners.IAzureScanner {
 		&appcs.AppConfigurationScanner{},
 		&appi.AppInsightsScanner{},
 		&as.AnalysisServicesScanner{},
+		&bas.BastionScanner{},
 		&cae.ContainerAppsEnvironmentScanner{},
 		&ca.ContainerAppsScanner{},
 		&ci.ContainerInstanceScanner{},
@@ -509,7 +1000,10 @@ func GetScanners() []scanners.IAzureScanner {
 		&cosmos.CosmosDBScanner{},
 		&cr.ContainerRegistryScanner{},
 		&dec.DataExplorerScanner{},
+		&dps.DPSScanner{},
+		&ercir.ExpressRouteCircuitScanner{},
 		&evgd.EventGridScanner{},
+		&evgt.EventGridTopicScanner{},
 		&evh.EventHubScanner{},
 		&kv.KeyVaultScanner{},
 		&lb.LoadBalancerScanner{},
@@ -517,11 +1011,14 @@ func GetScanners() []scanners.IAzureScanner {
 		&maria.MariaScanner{},
 		&mysql.MySQLFlexibleScanner{},
 		&mysql.MySQLScanner{},
-		&asp.AppServiceScanner{},
+		&asp.AppServicePlanScanner{},
+		&app.AppServiceScanner{},
 		&psql.PostgreFlexibleScanner{},
 		&psql.PostgreScanner{},
 		&redis.RedisScanner{},
+		&redise.RedisEnterpriseScanner{},
 		&sb.ServiceBusScanner{},
+		&sentinel.SentinelScanner{},
 		&sigr.SignalRScanner{},
 		&sql.SQLScanner{},
 		&synw.SynapseWorkspaceScanner{},
@@ -0,0 +1,342 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/scanners/kv"
+	"github.com/Azure/azqr/internal/scanners/sql"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeScanner struct {
+	name  string
+	errs  []error
+	calls int
+}
+
+func (s *fakeScanner) Init(config *scanners.ScannerConfig) error {
+	return nil
+}
+
+func (s *fakeScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{}
+}
+
+func (s *fakeScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return nil, err
+	}
+	return []scanners.AzureServiceResult{{ResourceGroup: resourceGroupName, ServiceName: s.name}}, nil
+}
+
+func TestScanResourceGroup(t *testing.T) {
+	forbidden := &azcore.ResponseError{StatusCode: http.StatusForbidden, ErrorCode: "AuthorizationFailed"}
+	throttled := &azcore.ResponseError{StatusCode: http.StatusTooManyRequests}
+
+	tests := []struct {
+		name        string
+		errs        []error
+		wantResults int
+		wantErrors  int
+		wantCalls   int
+	}{
+		{name: "no error", errs: nil, wantResults: 2, wantErrors: 0, wantCalls: 1},
+		{name: "permanent error is not retried", errs: []error{forbidden}, wantResults: 1, wantErrors: 1, wantCalls: 1},
+		{name: "transient error is retried", errs: []error{throttled}, wantResults: 2, wantErrors: 0, wantCalls: 2},
+		{name: "transient error after retries", errs: []error{throttled, throttled, throttled}, wantResults: 1, wantErrors: 1, wantCalls: 3},
+		{name: "network error is retried", errs: []error{errors.New("connection reset")}, wantResults: 2, wantErrors: 0, wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing := &fakeScanner{name: "failing", errs: tt.errs}
+			next := &fakeScanner{name: "next"}
+
+			results, errs := scanResourceGroup("rg", []scanners.IAzureScanner{failing, next}, &scanners.ScanContext{})
+			if len(results) != tt.wantResults {
+				t.Errorf("scanResourceGroup() returned %d results, want %d", len(results), tt.wantResults)
+			}
+			if len(errs) != tt.wantErrors {
+				t.Fatalf("scanResourceGroup() returned %d errors, want %d", len(errs), tt.wantErrors)
+			}
+			if failing.calls != tt.wantCalls {
+				t.Errorf("failing scanner called %d times, want %d", failing.calls, tt.wantCalls)
+			}
+			if next.calls != 1 {
+				t.Errorf("next scanner called %d times, want 1", next.calls)
+			}
+			for _, e := range errs {
+				if e.Scanner != "internal.fakeScanner" || e.ResourceGroup != "rg" || e.Err == nil {
+					t.Errorf("scanResourceGroup() error = %+v", e)
+				}
+			}
+		})
+	}
+}
+
+type fakeSubscriptionScanner struct {
+	err   error
+	calls int
+}
+
+func (s *fakeSubscriptionScanner) Init(config *scanners.ScannerConfig) error {
+	return nil
+}
+
+func (s *fakeSubscriptionScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{}
+}
+
+func (s *fakeSubscriptionScanner) ScanSubscriptionScope(scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []scanners.AzureServiceResult{{ServiceName: "subscription"}}, nil
+}
+
+func TestScanSubscription(t *testing.T) {
+	forbidden := &azcore.ResponseError{StatusCode: http.StatusForbidden, ErrorCode: "AuthorizationFailed"}
+	resourceGroups := []string{"rg-1", "rg-2", "rg-3"}
+
+	tests := []struct {
+		name                 string
+		serviceErrs          []error
+		subscriptionErr      error
+		failFast             bool
+		wantResults          int
+		wantErrors           int
+		wantServiceCalls     int
+		wantSubscriptionCall int
+	}{
+		{name: "no error", wantResults: 4, wantServiceCalls: 3, wantSubscriptionCall: 1},
+		{name: "subscription scanner error", subscriptionErr: forbidden, wantResults: 3, wantErrors: 1, wantServiceCalls: 3, wantSubscriptionCall: 1},
+		{name: "service scanner error", serviceErrs: []error{forbidden}, wantResults: 3, wantErrors: 1, wantServiceCalls: 3, wantSubscriptionCall: 1},
+		{name: "fail fast", serviceErrs: []error{forbidden}, failFast: true, wantResults: 0, wantErrors: 1, wantServiceCalls: 1, wantSubscriptionCall: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeScanner{name: "service", errs: tt.serviceErrs}
+			subscription := &fakeSubscriptionScanner{err: tt.subscriptionErr}
+
+			results, errs := scanSubscription(resourceGroups, []scanners.IAzureScanner{service}, []scanners.ISubscriptionScanner{subscription}, &scanners.ScanContext{}, tt.failFast)
+			if len(results) != tt.wantResults {
+				t.Errorf("scanSubscription() returned %d results, want %d", len(results), tt.wantResults)
+			}
+			if len(errs) != tt.wantErrors {
+				t.Errorf("scanSubscription() returned %d errors, want %d", len(errs), tt.wantErrors)
+			}
+			if service.calls != tt.wantServiceCalls {
+				t.Errorf("service scanner called %d times, want %d", service.calls, tt.wantServiceCalls)
+			}
+			if subscription.calls != tt.wantSubscriptionCall {
+				t.Errorf("subscription scanner called %d times, want %d", subscription.calls, tt.wantSubscriptionCall)
+			}
+		})
+	}
+}
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// fakeSubscriptionsTransport - Serves the subscriptions list and get APIs
+type fakeSubscriptionsTransport struct {
+	list string
+	gets int
+}
+
+func (f *fakeSubscriptionsTransport) Do(req *http.Request) (*http.Response, error) {
+	body := f.list
+	if req.URL.Path != "/subscriptions" {
+		f.gets++
+		id := strings.TrimPrefix(req.URL.Path, "/subscriptions/")
+		body = `{"subscriptionId": "` + id + `", "displayName": "Hidden"}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestResolveSubscriptions(t *testing.T) {
+	list := `{"value": [
+		{"subscriptionId": "00000000-0000-0000-0000-000000000001", "displayName": "Production", "state": "Enabled"},
+		{"subscriptionId": "00000000-0000-0000-0000-000000000002", "displayName": "Development", "state": "Enabled"}
+	]}`
+
+	tests := []struct {
+		name           string
+		subscriptionID string
+		want           map[string]string
+		wantGets       int
+	}{
+		{
+			name: "all subscriptions",
+			want: map[string]string{
+				"00000000-0000-0000-0000-000000000001": "Production",
+				"00000000-0000-0000-0000-000000000002": "Development",
+			},
+		},
+		{
+			name:           "listed subscription",
+			subscriptionID: "00000000-0000-0000-0000-000000000002",
+			want:           map[string]string{"00000000-0000-0000-0000-000000000002": "Development"},
+		},
+		{
+			name:           "subscription not listed",
+			subscriptionID: "00000000-0000-0000-0000-000000000003",
+			want:           map[string]string{"00000000-0000-0000-0000-000000000003": "Hidden"},
+			wantGets:       1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &fakeSubscriptionsTransport{list: list}
+			options := &arm.ClientOptions{ClientOptions: policy.ClientOptions{Transport: transport}}
+
+			got, err := resolveSubscriptions(context.Background(), tt.subscriptionID, fakeCredential{}, options)
+			if err != nil {
+				t.Fatalf("resolveSubscriptions() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveSubscriptions() = %v, want %v", got, tt.want)
+			}
+			if transport.gets != tt.wantGets {
+				t.Errorf("resolveSubscriptions() made %d get requests, want %d", transport.gets, tt.wantGets)
+			}
+		})
+	}
+}
+
+type cyclicScanner struct {
+	fakeScanner
+}
+
+func (s *cyclicScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"test-001": {Id: "test-001", DependsOn: []string{"test-002"}},
+		"test-002": {Id: "test-002", DependsOn: []string{"test-001"}},
+	}
+}
+
+func TestValidateRuleDependencies(t *testing.T) {
+	if err := validateRuleDependencies(GetScanners(), GetSubscriptionScanners()); err != nil {
+		t.Errorf("validateRuleDependencies() registered scanners error = %v", err)
+	}
+	if err := validateRuleDependencies([]scanners.IAzureScanner{&cyclicScanner{}}, nil); err == nil {
+		t.Error("validateRuleDependencies() expected a cycle error")
+	}
+}
+
+func TestAutoRemediation(t *testing.T) {
+	for _, scanner := range GetScanners() {
+		for _, r := range scanner.GetRules() {
+			switch {
+			case strings.HasPrefix(r.Remediation, "az tag update"):
+				if r.AutoRemediation != scanners.AutoRemediationTags {
+					t.Errorf("%s AutoRemediation = %q, want %q", r.Id, r.AutoRemediation, scanners.AutoRemediationTags)
+				}
+			case strings.HasPrefix(r.Remediation, "az monitor diagnostic-settings create"):
+				if r.AutoRemediation != scanners.AutoRemediationDiagnosticSettings {
+					t.Errorf("%s AutoRemediation = %q, want %q", r.Id, r.AutoRemediation, scanners.AutoRemediationDiagnosticSettings)
+				}
+			case r.AutoRemediation != "":
+				t.Errorf("%s AutoRemediation = %q, want no remediation", r.Id, r.AutoRemediation)
+			}
+		}
+	}
+}
+
+func TestRemediationCommands(t *testing.T) {
+	for _, scanner := range GetScanners() {
+		for _, r := range scanner.GetRules() {
+			if r.Remediation == "" {
+				continue
+			}
+			if !strings.HasPrefix(r.Remediation, "az ") {
+				t.Errorf("%s Remediation = %q, want an Azure CLI command", r.Id, r.Remediation)
+			}
+			if !strings.Contains(r.Remediation, "{resource_id}") && !strings.Contains(r.Remediation, "{resource_group}") {
+				t.Errorf("%s Remediation = %q, want a {resource_id} or {resource_group} placeholder", r.Id, r.Remediation)
+			}
+		}
+	}
+}
+
+type failingInitScanner struct {
+	fakeScanner
+}
+
+func (s *failingInitScanner) Init(config *scanners.ScannerConfig) error {
+	return errors.New("init failed")
+}
+
+func TestInitServiceScanners(t *testing.T) {
+	ok1 := &fakeScanner{name: "ok1"}
+	ok2 := &fakeScanner{name: "ok2"}
+	serviceScanners := []scanners.IAzureScanner{ok1, &failingInitScanner{}, ok2}
+
+	initialized, errs := initServiceScanners(context.Background(), serviceScanners, &scanners.ScannerConfig{})
+	if !reflect.DeepEqual(initialized, []scanners.IAzureScanner{ok1, ok2}) {
+		t.Errorf("initServiceScanners() initialized = %v, want the scanners without init errors", initialized)
+	}
+	if len(errs) != 1 || errs[0].Scanner != "internal.failingInitScanner" {
+		t.Errorf("initServiceScanners() errors = %v, want one error for internal.failingInitScanner", errs)
+	}
+
+	results, _ := scanSubscription([]string{"rg"}, initialized, nil, &scanners.ScanContext{}, false)
+	if len(results) != 2 {
+		t.Errorf("scanSubscription() returned %d results, want 2", len(results))
+	}
+}
+
+func TestResourceScanners(t *testing.T) {
+	serviceScanners := GetScanners()
+	for _, s := range serviceScanners {
+		found := false
+		for _, r := range resourceTypeScanners {
+			if reflect.TypeOf(r) == reflect.TypeOf(s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("resourceTypeScanners has no resource type for %T", s)
+		}
+	}
+
+	got, err := resourceScanners(serviceScanners, "/subscriptions/x/resourceGroups/rg/providers/Microsoft.Sql/servers/sql/databases/db")
+	if err != nil || len(got) != 1 {
+		t.Fatalf("resourceScanners() = %v, %v, want the SQL scanner", got, err)
+	}
+	if _, ok := got[0].(*sql.SQLScanner); !ok {
+		t.Errorf("resourceScanners() = %T, want *sql.SQLScanner", got[0])
+	}
+
+	if _, err := resourceScanners(serviceScanners, "/subscriptions/x/resourceGroups/rg/providers/Microsoft.Foo/bars/bar"); err == nil {
+		t.Error("resourceScanners() expected an error for an unsupported resource type")
+	}
+	if _, err := resourceScanners([]scanners.IAzureScanner{&kv.KeyVaultScanner{}}, "/subscriptions/x/resourceGroups/rg/providers/Microsoft.Cache/Redis/redis"); err == nil {
+		t.Error("resourceScanners() expected an error when the scanner of the resource type is not selected")
+	}
+}
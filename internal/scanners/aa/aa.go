@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package aa
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// runAsConnectionType - Connection type of the service principal connections created by Run As accounts
+const runAsConnectionType = "AzureServicePrincipal"
+
+// AutomationScanner - Scanner for Azure Automation Accounts
+type AutomationScanner struct {
+	config *scanners.ScannerConfig
+	client *scanners.RESTClient
+}
+
+// Init - Initializes the AutomationScanner
+func (c *AutomationScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	c.config = config
+	var err error
+	c.client, err = scanners.NewRESTClient(config, apiVersion)
+	return err
+}
+
+// Scan - Scans all Automation Accounts in a Resource Group
+func (c *AutomationScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(c.config.SubscriptionID, resourceGroupName, "Automation Account")
+
+	accounts, err := c.listAccounts(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := c.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	scanContext.AutomationRunAsConnections = map[string]int{}
+
+	for _, account := range accounts {
+		connections, err := c.listConnections(*account.ID)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to list connections of Automation Account %s", *account.Name)
+		} else {
+			scanContext.AutomationRunAsConnections[strings.ToLower(*account.ID)] = countRunAsConnections(connections)
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, account, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   c.config.SubscriptionID,
+			SubscriptionName: c.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *account.Name,
+			ID:               *account.ID,
+			Type:             *account.Type,
+			Location:         *account.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(account),
+		})
+	}
+	return results, nil
+}
+
+// countRunAsConnections - Returns the number of service principal (Run As) connections
+func countRunAsConnections(connections []*Connection) int {
+	count := 0
+	for _, conn := range connections {
+		if conn.Properties != nil && conn.Properties.ConnectionType != nil && conn.Properties.ConnectionType.Name != nil &&
+			strings.EqualFold(*conn.Properties.ConnectionType.Name, runAsConnectionType) {
+			count++
+		}
+	}
+	return count
+}
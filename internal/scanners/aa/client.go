@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package aa
+
+import (
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// The armautomation module is not a dependency of azqr, so the scanner talks to the
+// Microsoft.Automation/automationAccounts REST API through scanners.RESTClient, using only the fields required by the rules.
+
+const apiVersion = "2023-11-01"
+
+type (
+	// Account - Automation Account
+	Account struct {
+		ID         *string            `json:"id"`
+		Name       *string            `json:"name"`
+		Type       *string            `json:"type"`
+		Location   *string            `json:"location"`
+		Tags       map[string]*string `json:"tags"`
+		Identity   *Identity          `json:"identity"`
+		Properties *AccountProperties `json:"properties"`
+		SystemData *SystemData        `json:"systemData"`
+	}
+
+	// SystemData - Metadata about the creation of the resource
+	SystemData struct {
+		CreatedAt *time.Time `json:"createdAt"`
+	}
+
+	// Identity - Managed identity of an Automation Account
+	Identity struct {
+		Type *string `json:"type"`
+	}
+
+	// AccountProperties - Automation Account properties
+	AccountProperties struct {
+		PublicNetworkAccess        *bool         `json:"publicNetworkAccess"`
+		PrivateEndpointConnections []interface{} `json:"privateEndpointConnections"`
+	}
+
+	// Connection - Connection asset of an Automation Account
+	Connection struct {
+		Name       *string               `json:"name"`
+		Properties *ConnectionProperties `json:"properties"`
+	}
+
+	// ConnectionProperties - Connection asset properties
+	ConnectionProperties struct {
+		ConnectionType *ConnectionType `json:"connectionType"`
+	}
+
+	// ConnectionType - Type of a connection asset (e.g. AzureServicePrincipal)
+	ConnectionType struct {
+		Name *string `json:"name"`
+	}
+
+	accountList struct {
+		Value    []*Account `json:"value"`
+		NextLink *string    `json:"nextLink"`
+	}
+
+	connectionList struct {
+		Value    []*Connection `json:"value"`
+		NextLink *string       `json:"nextLink"`
+	}
+)
+
+func (c *AutomationScanner) listAccounts(resourceGroupName string) ([]*Account, error) {
+	accounts := make([]*Account, 0)
+	next := c.client.URL("subscriptions", c.config.SubscriptionID, "resourceGroups", resourceGroupName, "providers/Microsoft.Automation/automationAccounts")
+	for next != "" {
+		page := accountList{}
+		if err := c.client.Get(c.config.Ctx, next, &page); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, page.Value...)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return accounts, nil
+}
+
+func (c *AutomationScanner) listConnections(accountID string) ([]*Connection, error) {
+	connections := make([]*Connection, 0)
+	next := c.client.URL(accountID, "connections")
+	for next != "" {
+		page := connectionList{}
+		if err := c.client.Get(c.config.Ctx, next, &page); err != nil {
+			return nil, err
+		}
+		connections = append(connections, page.Value...)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return connections, nil
+}
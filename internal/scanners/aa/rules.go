@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package aa
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// GetRules - Returns the rules for the AutomationScanner
+func (c *AutomationScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"aa-001": {
+			Id:             "aa-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Automation Account should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				account := target.(*Account)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*account.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/automation/automation-manage-send-joblogs-log-analytics",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"aa-002": {
+			Id:             "aa-002",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Automation Account should use Managed Identities",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				account := target.(*Account)
+				return account.Identity == nil || account.Identity.Type == nil || strings.EqualFold(*account.Identity.Type, "None"), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/automation/automation-security-overview#managed-identities",
+		},
+		"aa-003": {
+			Id:             "aa-003",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Automation Account should not use Run As accounts, migrate them to Managed Identities",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				account := target.(*Account)
+				count, ok := scanContext.AutomationRunAsConnections[strings.ToLower(*account.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("connections not available for %s", *account.Name)
+				}
+				return count > 0, strconv.Itoa(count), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/automation/migrate-run-as-accounts-managed-identity",
+		},
+		"aa-004": {
+			Id:             "aa-004",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Automation Account should have private endpoints enabled",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				account := target.(*Account)
+				pe := account.Properties != nil && len(account.Properties.PrivateEndpointConnections) > 0
+				return !pe, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/automation/how-to/private-link-security",
+		},
+		"aa-005": {
+			Id:             "aa-005",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Automation Account Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				account := target.(*Account)
+				caf := strings.HasPrefix(*account.Name, "aa-")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"aa-006": {
+			Id:             "aa-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Automation Account should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				account := target.(*Account)
+				broken, result := scanners.EvaluateTagPolicy(account.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package aa
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+)
+
+func TestAutomationScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "AutomationScanner DiagnosticSettings",
+			fields: fields{
+				rule: "aa-001",
+				target: &Account{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AutomationScanner Managed Identity",
+			fields: fields{
+				rule: "aa-002",
+				target: &Account{
+					Identity: &Identity{
+						Type: to.Ptr("SystemAssigned"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AutomationScanner without Managed Identity",
+			fields: fields{
+				rule: "aa-002",
+				target: &Account{
+					Identity: &Identity{
+						Type: to.Ptr("None"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AutomationScanner Run As connections",
+			fields: fields{
+				rule: "aa-003",
+				target: &Account{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("aa-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					AutomationRunAsConnections: map[string]int{
+						"test": 2,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "2",
+			},
+		},
+		{
+			name: "AutomationScanner without Run As connections",
+			fields: fields{
+				rule: "aa-003",
+				target: &Account{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("aa-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					AutomationRunAsConnections: map[string]int{
+						"test": 0,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "0",
+			},
+		},
+		{
+			name: "AutomationScanner Private Endpoint",
+			fields: fields{
+				rule: "aa-004",
+				target: &Account{
+					Properties: &AccountProperties{
+						PrivateEndpointConnections: []interface{}{
+							map[string]interface{}{"id": "test"},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AutomationScanner CAF",
+			fields: fields{
+				rule: "aa-005",
+				target: &Account{
+					Name: to.Ptr("aa-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AutomationScanner tags",
+			fields: fields{
+				rule: "aa-006",
+				target: &Account{
+					Tags: map[string]*string{"env": to.Ptr("prod")},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AutomationScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AutomationScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AutomationScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutomationScanner_RunAsConnections_NotAvailable(t *testing.T) {
+	s := &AutomationScanner{}
+	rules := s.GetRules()
+	_, _, err := rules["aa-003"].Eval(context.Background(), &Account{ID: to.Ptr("test"), Name: to.Ptr("aa-test")}, &scanners.ScanContext{})
+	if err == nil {
+		t.Error("AutomationScanner Rule.Eval() error = nil, want an error when the connections are not available")
+	}
+}
+
+func TestCountRunAsConnections(t *testing.T) {
+	connections := []*Connection{
+		{Name: to.Ptr("AzureRunAsConnection"), Properties: &ConnectionProperties{ConnectionType: &ConnectionType{Name: to.Ptr("AzureServicePrincipal")}}},
+		{Name: to.Ptr("classic"), Properties: &ConnectionProperties{ConnectionType: &ConnectionType{Name: to.Ptr("AzureClassicCertificate")}}},
+		{Name: to.Ptr("empty")},
+	}
+	if got := countRunAsConnections(connections); got != 1 {
+		t.Errorf("countRunAsConnections() = %d, want 1", got)
+	}
+}
+
+func TestAutomationScanner_CreatedAt(t *testing.T) {
+	account := &Account{}
+	err := json.Unmarshal([]byte(`{"name":"aa","systemData":{"createdAt":"2024-01-02T03:04:05.1234567Z"}}`), account)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 123456700, time.UTC)
+	got := scanners.GetCreatedAt(account)
+	if got == nil || !got.Equal(want) {
+		t.Errorf("GetCreatedAt() = %v, want %v", got, want)
+	}
+}
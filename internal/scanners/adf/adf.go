@@ -4,21 +4,41 @@
 package adf
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/datafactory/armdatafactory"
 )
 
 // DataFactoryScanner - Scanner for Data Factory
 type DataFactoryScanner struct {
-	config          *scanners.ScannerConfig
-	factoriesClient *armdatafactory.FactoriesClient
+	config                 *scanners.ScannerConfig
+	factoriesClient        *armdatafactory.FactoriesClient
+	linkedServicesClient   *armdatafactory.LinkedServicesClient
+	managedVNetsClient     *armdatafactory.ManagedVirtualNetworksClient
+	managedEndpointsClient *armdatafactory.ManagedPrivateEndpointsClient
 }
 
 // Init - Initializes the DataFactory Scanner
 func (a *DataFactoryScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.factoriesClient, err = armdatafactory.NewFactoriesClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.linkedServicesClient, err = armdatafactory.NewLinkedServicesClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.managedVNetsClient, err = armdatafactory.NewManagedVirtualNetworksClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.managedEndpointsClient, err = armdatafactory.NewManagedPrivateEndpointsClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
 	return err
 }
 
@@ -34,8 +54,34 @@ func (a *DataFactoryScanner) Scan(resourceGroupName string, scanContext *scanner
 	rules := a.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.DataFactoryLinkedServiceCounts = map[string]int{}
+	scanContext.DataFactoryManagedPrivateEndpointCounts = map[string]int{}
+
 	for _, g := range factories {
-		rr := engine.EvaluateRules(rules, g, scanContext)
+		id := strings.ToLower(*g.ID)
+		linkedServices, err := a.countLinkedServices(resourceGroupName, *g.Name)
+		if err != nil {
+			return nil, err
+		}
+		scanContext.DataFactoryLinkedServiceCounts[id] = linkedServices
+
+		vnets, err := a.listManagedVirtualNetworks(resourceGroupName, *g.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(vnets) > 0 {
+			endpoints := 0
+			for _, vnet := range vnets {
+				count, err := a.countManagedPrivateEndpoints(resourceGroupName, *g.Name, vnet)
+				if err != nil {
+					return nil, err
+				}
+				endpoints += count
+			}
+			scanContext.DataFactoryManagedPrivateEndpointCounts[id] = endpoints
+		}
+
+		rr := engine.EvaluateRules(a.config.Ctx, rules, g, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
@@ -44,7 +90,9 @@ func (a *DataFactoryScanner) Scan(resourceGroupName string, scanContext *scanner
 			Location:         *g.Location,
 			Type:             *g.Type,
 			ServiceName:      *g.Name,
+			ID:               *g.ID,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(g),
 		})
 	}
 	return results, nil
@@ -63,3 +111,47 @@ func (a *DataFactoryScanner) listFactories(resourceGroupName string) ([]*armdata
 	}
 	return factories, nil
 }
+
+func (a *DataFactoryScanner) countLinkedServices(resourceGroupName, factoryName string) (int, error) {
+	pager := a.linkedServicesClient.NewListByFactoryPager(resourceGroupName, factoryName, nil)
+
+	count := 0
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return 0, err
+		}
+		count += len(resp.Value)
+	}
+	return count, nil
+}
+
+func (a *DataFactoryScanner) listManagedVirtualNetworks(resourceGroupName, factoryName string) ([]string, error) {
+	pager := a.managedVNetsClient.NewListByFactoryPager(resourceGroupName, factoryName, nil)
+
+	vnets := make([]string, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range resp.Value {
+			vnets = append(vnets, *v.Name)
+		}
+	}
+	return vnets, nil
+}
+
+func (a *DataFactoryScanner) countManagedPrivateEndpoints(resourceGroupName, factoryName, managedVirtualNetworkName string) (int, error) {
+	pager := a.managedEndpointsClient.NewListByFactoryPager(resourceGroupName, factoryName, managedVirtualNetworkName, nil)
+
+	count := 0
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return 0, err
+		}
+		count += len(resp.Value)
+	}
+	return count, nil
+}
@@ -4,6 +4,8 @@
 package adf
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,22 +20,26 @@ func (a *DataFactoryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Azure Data Factory should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armdatafactory.Factory)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/data-factory/monitor-configure-diagnostics",
+			Url:             "https://learn.microsoft.com/en-us/azure/data-factory/monitor-configure-diagnostics",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"adf-002": {
 			Id:             "adf-002",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Data Factory should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armdatafactory.Factory)
 				_, pe := scanContext.PrivateEndpoints[*i.ID]
-				return !pe, ""
+				return !pe, "", nil
 			},
 		},
 		"adf-003": {
@@ -41,8 +47,8 @@ func (a *DataFactoryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Data Factory SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.99%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.99%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -51,10 +57,10 @@ func (a *DataFactoryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Data Factory Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdatafactory.Factory)
 				caf := strings.HasPrefix(*c.Name, "adf")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -63,11 +69,32 @@ func (a *DataFactoryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Data Factory should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdatafactory.Factory)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"adf-009": {
+			Id:             "adf-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Data Factory with managed virtual network should use managed private endpoints for all linked services",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armdatafactory.Factory)
+				id := strings.ToLower(*c.ID)
+				endpoints, ok := scanContext.DataFactoryManagedPrivateEndpointCounts[id]
+				if !ok {
+					return false, "", nil
+				}
+				linkedServices := scanContext.DataFactoryLinkedServiceCounts[id]
+				return endpoints < linkedServices, fmt.Sprintf("%d/%d", endpoints, linkedServices), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/data-factory/managed-virtual-network-private-endpoint",
 		},
 	}
 }
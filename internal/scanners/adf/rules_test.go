@@ -4,6 +4,7 @@
 package adf
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -89,12 +90,75 @@ func TestDataExplorerScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "DataFactoryScanner managed private endpoints missing",
+			fields: fields{
+				rule: "adf-009",
+				target: &armdatafactory.Factory{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DataFactoryLinkedServiceCounts: map[string]int{
+						"test": 3,
+					},
+					DataFactoryManagedPrivateEndpointCounts: map[string]int{
+						"test": 1,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "1/3",
+			},
+		},
+		{
+			name: "DataFactoryScanner managed private endpoints for all linked services",
+			fields: fields{
+				rule: "adf-009",
+				target: &armdatafactory.Factory{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DataFactoryLinkedServiceCounts: map[string]int{
+						"test": 3,
+					},
+					DataFactoryManagedPrivateEndpointCounts: map[string]int{
+						"test": 3,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "3/3",
+			},
+		},
+		{
+			name: "DataFactoryScanner without managed virtual network",
+			fields: fields{
+				rule: "adf-009",
+				target: &armdatafactory.Factory{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DataFactoryLinkedServiceCounts: map[string]int{
+						"test": 3,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &DataFactoryScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("DataFactoryScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
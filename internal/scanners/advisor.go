@@ -20,6 +20,9 @@ type AdvisorScanner struct {
 
 // Init - Initializes the Advisor Scanner
 func (s *AdvisorScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	s.config = config
 	var err error
 	s.client, err = armadvisor.NewRecommendationsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -4,6 +4,7 @@
 package afd
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,20 +19,23 @@ func (a *FrontDoorScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Azure FrontDoor should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armcdn.Profile)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/frontdoor/standard-premium/how-to-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/frontdoor/standard-premium/how-to-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"afd-003": {
 			Id:             "afd-003",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure FrontDoor SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.99%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.99%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/cdn/",
 		},
@@ -40,9 +44,9 @@ func (a *FrontDoorScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure FrontDoor SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcdn.Profile)
-				return false, string(*c.SKU.Name)
+				return false, string(*c.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/frontdoor/standard-premium/tier-comparison",
 		},
@@ -51,10 +55,10 @@ func (a *FrontDoorScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure FrontDoor Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcdn.Profile)
 				caf := strings.HasPrefix(*c.Name, "afd")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -63,11 +67,14 @@ func (a *FrontDoorScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure FrontDoor should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcdn.Profile)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 	}
 }
@@ -4,6 +4,7 @@
 package afd
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -92,7 +93,10 @@ func TestFrontDoorScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &FrontDoorScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("FrontDoorScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
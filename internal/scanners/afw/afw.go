@@ -4,21 +4,33 @@
 package afw
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/rs/zerolog/log"
 )
 
 // FirewallScanner - Scanner for Azure Firewall
 type FirewallScanner struct {
-	config *scanners.ScannerConfig
-	client *armnetwork.AzureFirewallsClient
+	config       *scanners.ScannerConfig
+	client       *armnetwork.AzureFirewallsClient
+	groupsClient *armnetwork.FirewallPolicyRuleCollectionGroupsClient
 }
 
 // Init - Initializes the Azure Firewall
 func (a *FirewallScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.client, err = armnetwork.NewAzureFirewallsClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.groupsClient, err = armnetwork.NewFirewallPolicyRuleCollectionGroupsClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
 	return err
 }
 
@@ -34,17 +46,34 @@ func (a *FirewallScanner) Scan(resourceGroupName string, scanContext *scanners.S
 	rules := a.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.FirewallPolicyRuleGroups = map[string][]scanners.FirewallRuleCollectionGroup{}
+
 	for _, g := range gateways {
-		rr := engine.EvaluateRules(rules, g, scanContext)
+		if g.Properties != nil && g.Properties.FirewallPolicy != nil && g.Properties.FirewallPolicy.ID != nil {
+			policyID := strings.ToLower(*g.Properties.FirewallPolicy.ID)
+			if _, ok := scanContext.FirewallPolicyRuleGroups[policyID]; !ok {
+				// afw-010 and afw-011 are skipped for the firewall when its policy can't be read
+				groups, err := a.listRuleCollectionGroups(*g.Properties.FirewallPolicy.ID)
+				if err != nil {
+					log.Warn().Err(err).Msgf("Failed to list the rule collection groups of Firewall Policy %s", *g.Properties.FirewallPolicy.ID)
+				} else {
+					scanContext.FirewallPolicyRuleGroups[policyID] = groups
+				}
+			}
+		}
+
+		rr := engine.EvaluateRules(a.config.Ctx, rules, g, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
-			SubscriptionID: a.config.SubscriptionID,
+			SubscriptionID:   a.config.SubscriptionID,
 			SubscriptionName: a.config.SubscriptionName,
-			ResourceGroup:  resourceGroupName,
-			Location:       *g.Location,
-			Type:           *g.Type,
-			ServiceName:    *g.Name,
-			Rules:          rr,
+			ResourceGroup:    resourceGroupName,
+			Location:         *g.Location,
+			Type:             *g.Type,
+			ServiceName:      *g.Name,
+			ID:               *g.ID,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(g),
 		})
 	}
 	return results, nil
@@ -63,3 +92,42 @@ func (a *FirewallScanner) list(resourceGroupName string) ([]*armnetwork.AzureFir
 	}
 	return services, nil
 }
+
+func (a *FirewallScanner) listRuleCollectionGroups(policyID string) ([]scanners.FirewallRuleCollectionGroup, error) {
+	id, err := arm.ParseResourceID(policyID)
+	if err != nil {
+		return nil, err
+	}
+	// The policy may belong to another subscription (e.g. a parent policy managed centrally)
+	client := a.groupsClient
+	if !strings.EqualFold(id.SubscriptionID, a.config.SubscriptionID) {
+		client, err = armnetwork.NewFirewallPolicyRuleCollectionGroupsClient(id.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pager := client.NewListPager(id.ResourceGroupName, id.Name, nil)
+	groups := []scanners.FirewallRuleCollectionGroup{}
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range resp.Value {
+			group := scanners.FirewallRuleCollectionGroup{
+				Name: *g.Name,
+			}
+			if g.Properties != nil {
+				group.Priority = g.Properties.Priority
+				for _, c := range g.Properties.RuleCollections {
+					if _, ok := c.(*armnetwork.FirewallPolicyNatRuleCollection); ok {
+						group.HasDNAT = true
+					}
+				}
+			}
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
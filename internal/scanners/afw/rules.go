@@ -4,6 +4,9 @@
 package afw
 
 import (
+	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -17,22 +20,25 @@ func (a *FirewallScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Azure Firewall should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armnetwork.AzureFirewall)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://docs.microsoft.com/en-us/azure/firewall/logs-and-metrics",
+			Url:             "https://docs.microsoft.com/en-us/azure/firewall/logs-and-metrics",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"afw-002": {
 			Id:             "afw-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Firewall should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.AzureFirewall)
 				zones := len(g.Zones) > 1
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/firewall/features#availability-zones",
 		},
@@ -41,14 +47,14 @@ func (a *FirewallScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Firewall SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.AzureFirewall)
 				sla := "99.95%"
 				if len(g.Zones) > 1 {
 					sla = "99.99%"
 				}
 
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -57,9 +63,9 @@ func (a *FirewallScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Firewall SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.AzureFirewall)
-				return false, string(*c.Properties.SKU.Name)
+				return false, string(*c.Properties.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/firewall/choose-firewall-sku",
 		},
@@ -68,10 +74,10 @@ func (a *FirewallScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Firewall Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.AzureFirewall)
 				caf := strings.HasPrefix(*c.Name, "afw")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -80,11 +86,115 @@ func (a *FirewallScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Firewall should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.AzureFirewall)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
+		"afw-009": {
+			Id:             "afw-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Firewall Premium should have IDPS set to Deny mode",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.AzureFirewall)
+				if c.Properties == nil || c.Properties.SKU == nil || c.Properties.SKU.Tier == nil ||
+					*c.Properties.SKU.Tier != armnetwork.AzureFirewallSKUTierPremium {
+					return false, "", nil
+				}
+				mode := ""
+				if c.Properties.FirewallPolicy != nil && c.Properties.FirewallPolicy.ID != nil {
+					mode = scanContext.FirewallPolicyIDPS[strings.ToLower(*c.Properties.FirewallPolicy.ID)]
+				}
+				broken := mode != string(armnetwork.FirewallPolicyIntrusionDetectionStateTypeDeny)
+				return broken, mode, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/firewall/premium-features#idps",
+		},
+		"afw-010": {
+			Id:             "afw-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Firewall Policy rule collection groups should have unique priorities",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.AzureFirewall)
+				groups, err := ruleCollectionGroups(c, scanContext)
+				if err != nil {
+					return false, "", err
+				}
+				byPriority := map[int32][]string{}
+				for _, g := range groups {
+					if g.Priority != nil {
+						byPriority[*g.Priority] = append(byPriority[*g.Priority], g.Name)
+					}
+				}
+				priorities := []int{}
+				for p, names := range byPriority {
+					if len(names) > 1 {
+						priorities = append(priorities, int(p))
+					}
+				}
+				sort.Ints(priorities)
+				conflicts := []string{}
+				for _, p := range priorities {
+					names := byPriority[int32(p)]
+					sort.Strings(names)
+					conflicts = append(conflicts, fmt.Sprintf("%d (%s)", p, strings.Join(names, ", ")))
+				}
+				return len(conflicts) > 0, strings.Join(conflicts, "; "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/firewall/policy-rule-sets",
+		},
+		"afw-011": {
+			Id:             "afw-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Firewall with public IP addresses should have a DNAT rule collection",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.AzureFirewall)
+				if c.Properties == nil || c.Properties.FirewallPolicy == nil || !hasPublicIP(c) {
+					return false, "", nil
+				}
+				groups, err := ruleCollectionGroups(c, scanContext)
+				if err != nil {
+					return false, "", err
+				}
+				for _, g := range groups {
+					if g.HasDNAT {
+						return false, "", nil
+					}
+				}
+				return true, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/firewall/tutorial-firewall-dnat-policy",
+		},
+	}
+}
+
+// ruleCollectionGroups - Returns the rule collection groups of the firewall's policy, or an error if
+// the policy could not be read
+func ruleCollectionGroups(c *armnetwork.AzureFirewall, scanContext *scanners.ScanContext) ([]scanners.FirewallRuleCollectionGroup, error) {
+	if c.Properties == nil || c.Properties.FirewallPolicy == nil || c.Properties.FirewallPolicy.ID == nil {
+		return nil, nil
+	}
+	groups, ok := scanContext.FirewallPolicyRuleGroups[strings.ToLower(*c.Properties.FirewallPolicy.ID)]
+	if !ok {
+		return nil, fmt.Errorf("rule collection groups not available for Firewall Policy %s", *c.Properties.FirewallPolicy.ID)
+	}
+	return groups, nil
+}
+
+// hasPublicIP - Returns true if the firewall has a public IP address, in a virtual network or a virtual hub
+func hasPublicIP(c *armnetwork.AzureFirewall) bool {
+	for _, ip := range c.Properties.IPConfigurations {
+		if ip.Properties != nil && ip.Properties.PublicIPAddress != nil {
+			return true
+		}
 	}
+	hub := c.Properties.HubIPAddresses
+	return hub != nil && hub.PublicIPs != nil && hub.PublicIPs.Count != nil && *hub.PublicIPs.Count > 0
 }
@@ -4,6 +4,7 @@
 package afw
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -118,12 +119,255 @@ func TestFirewallScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "FirewallScanner IDPS Standard",
+			fields: fields{
+				rule: "afw-009",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						SKU: &armnetwork.AzureFirewallSKU{
+							Tier: to.Ptr(armnetwork.AzureFirewallSKUTierStandard),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyIDPS: map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/alert": "Alert",
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/deny":  "Deny",
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "FirewallScanner IDPS without policy",
+			fields: fields{
+				rule: "afw-009",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						SKU: &armnetwork.AzureFirewallSKU{
+							Tier: to.Ptr(armnetwork.AzureFirewallSKUTierPremium),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyIDPS: map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/alert": "Alert",
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/deny":  "Deny",
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "FirewallScanner IDPS Alert",
+			fields: fields{
+				rule: "afw-009",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						SKU: &armnetwork.AzureFirewallSKU{
+							Tier: to.Ptr(armnetwork.AzureFirewallSKUTierPremium),
+						},
+						FirewallPolicy: &armnetwork.SubResource{
+							ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/alert"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyIDPS: map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/alert": "Alert",
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/deny":  "Deny",
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "Alert",
+			},
+		},
+		{
+			name: "FirewallScanner IDPS Off",
+			fields: fields{
+				rule: "afw-009",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						SKU: &armnetwork.AzureFirewallSKU{
+							Tier: to.Ptr(armnetwork.AzureFirewallSKUTierPremium),
+						},
+						FirewallPolicy: &armnetwork.SubResource{
+							ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/off"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyIDPS: map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/off": "Off",
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "Off",
+			},
+		},
+		{
+			name: "FirewallScanner IDPS Deny",
+			fields: fields{
+				rule: "afw-009",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						SKU: &armnetwork.AzureFirewallSKU{
+							Tier: to.Ptr(armnetwork.AzureFirewallSKUTierPremium),
+						},
+						FirewallPolicy: &armnetwork.SubResource{
+							ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/deny"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyIDPS: map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/alert": "Alert",
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/deny":  "Deny",
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "Deny",
+			},
+		},
+		{
+			name: "FirewallScanner rule collection groups with duplicate priorities",
+			fields: fields{
+				rule: "afw-010",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/Policy")},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyRuleGroups: map[string][]scanners.FirewallRuleCollectionGroup{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/policy": {
+							{Name: "network", Priority: to.Ptr(int32(200))},
+							{Name: "dnat", Priority: to.Ptr(int32(100))},
+							{Name: "application", Priority: to.Ptr(int32(200))},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "200 (application, network)",
+			},
+		},
+		{
+			name: "FirewallScanner rule collection groups with unique priorities",
+			fields: fields{
+				rule: "afw-010",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/policy")},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyRuleGroups: map[string][]scanners.FirewallRuleCollectionGroup{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/policy": {
+							{Name: "network", Priority: to.Ptr(int32(200))},
+							{Name: "dnat", Priority: to.Ptr(int32(100))},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "FirewallScanner internet facing without DNAT rule collection",
+			fields: fields{
+				rule: "afw-011",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/policy")},
+						IPConfigurations: []*armnetwork.AzureFirewallIPConfiguration{
+							{
+								Properties: &armnetwork.AzureFirewallIPConfigurationPropertiesFormat{
+									PublicIPAddress: &armnetwork.SubResource{ID: to.Ptr("pip")},
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyRuleGroups: map[string][]scanners.FirewallRuleCollectionGroup{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/policy": {
+							{Name: "network", Priority: to.Ptr(int32(200))},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "FirewallScanner virtual hub with DNAT rule collection",
+			fields: fields{
+				rule: "afw-011",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/policy")},
+						HubIPAddresses: &armnetwork.HubIPAddresses{
+							PublicIPs: &armnetwork.HubPublicIPAddresses{Count: to.Ptr(int32(1))},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					FirewallPolicyRuleGroups: map[string][]scanners.FirewallRuleCollectionGroup{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/firewallpolicies/policy": {
+							{Name: "dnat", Priority: to.Ptr(int32(100)), HasDNAT: true},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "FirewallScanner private firewall without DNAT rule collection",
+			fields: fields{
+				rule: "afw-011",
+				target: &armnetwork.AzureFirewall{
+					Properties: &armnetwork.AzureFirewallPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/policy")},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &FirewallScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("FirewallScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -134,3 +378,24 @@ func TestFirewallScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestFirewallScanner_RuleCollectionGroups_NotAvailable(t *testing.T) {
+	target := &armnetwork.AzureFirewall{
+		Properties: &armnetwork.AzureFirewallPropertiesFormat{
+			FirewallPolicy: &armnetwork.SubResource{
+				ID: to.Ptr("/subscriptions/y/resourceGroups/rg/providers/Microsoft.Network/firewallPolicies/parent"),
+			},
+			IPConfigurations: []*armnetwork.AzureFirewallIPConfiguration{
+				{Properties: &armnetwork.AzureFirewallIPConfigurationPropertiesFormat{PublicIPAddress: &armnetwork.SubResource{}}},
+			},
+		},
+	}
+
+	s := &FirewallScanner{}
+	rules := s.GetRules()
+	for _, id := range []string{"afw-010", "afw-011"} {
+		if _, _, err := rules[id].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+			t.Errorf("FirewallScanner Rule.Eval() %s error = nil, want an error when the policy is not available", id)
+		}
+	}
+}
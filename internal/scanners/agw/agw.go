@@ -4,21 +4,33 @@
 package agw
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/rs/zerolog/log"
 )
 
 // ApplicationGatewayScanner - Scanner for Application Gateways
 type ApplicationGatewayScanner struct {
 	config         *scanners.ScannerConfig
 	gatewaysClient *armnetwork.ApplicationGatewaysClient
+	policiesClient *armnetwork.WebApplicationFirewallPoliciesClient
 }
 
 // Init - Initializes the ApplicationGatewayAnalyzer
 func (a *ApplicationGatewayScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.gatewaysClient, err = armnetwork.NewApplicationGatewaysClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.policiesClient, err = armnetwork.NewWebApplicationFirewallPoliciesClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
 	return err
 }
 
@@ -34,17 +46,34 @@ func (a *ApplicationGatewayScanner) Scan(resourceGroupName string, scanContext *
 	rules := a.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.ApplicationGatewayWAFPolicies = map[string]scanners.WAFConfiguration{}
+
 	for _, g := range gateways {
-		rr := engine.EvaluateRules(rules, g, scanContext)
+		if g.Properties != nil && g.Properties.FirewallPolicy != nil && g.Properties.FirewallPolicy.ID != nil {
+			policyID := strings.ToLower(*g.Properties.FirewallPolicy.ID)
+			if _, ok := scanContext.ApplicationGatewayWAFPolicies[policyID]; !ok {
+				// agw-009 and agw-010 are skipped when the policy can't be read
+				waf, err := a.getWAFPolicy(*g.Properties.FirewallPolicy.ID)
+				if err != nil {
+					log.Warn().Err(err).Msgf("Failed to get WAF policy of Application Gateway %s", *g.Name)
+				} else {
+					scanContext.ApplicationGatewayWAFPolicies[policyID] = waf
+				}
+			}
+		}
+
+		rr := engine.EvaluateRules(a.config.Ctx, rules, g, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
 			SubscriptionName: a.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *g.Name,
+			ID:               *g.ID,
 			Type:             *g.Type,
 			Location:         *g.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(g),
 		})
 	}
 	return results, nil
@@ -62,3 +91,40 @@ func (a *ApplicationGatewayScanner) listGateways(resourceGroupName string) ([]*a
 	}
 	return results, nil
 }
+
+func (a *ApplicationGatewayScanner) getWAFPolicy(policyID string) (scanners.WAFConfiguration, error) {
+	waf := scanners.WAFConfiguration{}
+	id, err := arm.ParseResourceID(policyID)
+	if err != nil {
+		return waf, err
+	}
+	client := a.policiesClient
+	if !strings.EqualFold(id.SubscriptionID, a.config.SubscriptionID) {
+		client, err = armnetwork.NewWebApplicationFirewallPoliciesClient(id.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+		if err != nil {
+			return waf, err
+		}
+	}
+
+	resp, err := client.Get(a.config.Ctx, id.ResourceGroupName, id.Name, nil)
+	if err != nil {
+		return waf, err
+	}
+	if resp.Properties == nil {
+		return waf, nil
+	}
+	if resp.Properties.PolicySettings != nil && resp.Properties.PolicySettings.Mode != nil {
+		waf.Mode = string(*resp.Properties.PolicySettings.Mode)
+	}
+	if resp.Properties.ManagedRules != nil {
+		for _, r := range resp.Properties.ManagedRules.ManagedRuleSets {
+			// Bot protection rule sets are evaluated alongside the core rule set
+			if r.RuleSetType == nil || r.RuleSetVersion == nil || strings.EqualFold(*r.RuleSetType, "Microsoft_BotManagerRuleSet") {
+				continue
+			}
+			waf.RuleSetType = *r.RuleSetType
+			waf.RuleSetVersion = *r.RuleSetVersion
+		}
+	}
+	return waf, nil
+}
@@ -4,6 +4,9 @@
 package agw
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,10 +21,10 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryScalability,
 			Recommendation: "Application Gateway: Ensure autoscaling is used with a minimum of 2 instances",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
 				autoscale := g.Properties.AutoscaleConfiguration != nil && g.Properties.AutoscaleConfiguration.MinCapacity != nil && *g.Properties.AutoscaleConfiguration.MinCapacity >= 2
-				return !autoscale, ""
+				return !autoscale, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/application-gateway/application-gateway-autoscaling-zone-redundant",
 		},
@@ -30,7 +33,7 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Application Gateway: Secure all incoming connections with SSL",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
 				sslPort := false
 				for _, port := range g.Properties.FrontendPorts {
@@ -42,7 +45,7 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 
 				sslEnabled := sslPort && g.Properties.SSLCertificates != nil && len(g.Properties.SSLCertificates) > 0
 
-				return !sslEnabled, ""
+				return !sslEnabled, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/well-architected/services/networking/azure-application-gateway#security",
 		},
@@ -51,10 +54,10 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Application Gateway: Enable WAF policies",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
 				waf := g.Properties.WebApplicationFirewallConfiguration != nil && g.Properties.WebApplicationFirewallConfiguration.Enabled != nil && *g.Properties.WebApplicationFirewallConfiguration.Enabled
-				return !waf, ""
+				return !waf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/application-gateway/features#web-application-firewall",
 		},
@@ -63,10 +66,10 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Application Gateway: Use Application GW V2 instead of V1",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
 				v2 := g.Properties.SKU != nil && g.Properties.SKU.Name != nil && strings.Contains(string(*g.Properties.SKU.Name), "_v2")
-				return !v2, ""
+				return !v2, "", nil
 			},
 			Url: "https://azure.microsoft.com/en-us/updates/application-gateway-v1-will-be-retired-on-28-april-2026-transition-to-application-gateway-v2/",
 		},
@@ -75,10 +78,10 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Application Gateway: Monitor and Log the configurations and traffic",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armnetwork.ApplicationGateway)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/application-gateway/application-gateway-diagnostics#diagnostic-logging",
 		},
@@ -87,10 +90,10 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Application Gateway should have availability zones enabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
 				zones := g.Zones != nil && len(g.Zones) > 1
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/application-gateway/application-gateway-autoscaling-zone-redundant",
 		},
@@ -99,11 +102,11 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Application Gateway: Plan for backend maintenance by using connection draining",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
 
 				if g.Properties.BackendHTTPSettingsCollection == nil {
-					return false, ""
+					return false, "", nil
 				}
 
 				draining := true
@@ -114,7 +117,7 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 					}
 				}
 
-				return !draining, ""
+				return !draining, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/application-gateway/features#connection-draining",
 		},
@@ -123,8 +126,8 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Application Gateway SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.95%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.95%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/application-gateway/",
 		},
@@ -133,9 +136,9 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Application Gateway SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
-				return false, string(*g.Properties.SKU.Name)
+				return false, string(*g.Properties.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/application-gateway/understanding-pricing",
 		},
@@ -144,10 +147,10 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Application Gateway Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.ApplicationGateway)
 				caf := strings.HasPrefix(*g.Name, "agw")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -156,11 +159,103 @@ func (a *ApplicationGatewayScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Application Gateway should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.ApplicationGateway)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
+		"agw-009": {
+			Id:             "agw-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Application Gateway WAF should be in Prevention mode",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.ApplicationGateway)
+				waf, err := wafConfiguration(g, scanContext)
+				if err != nil {
+					return false, "", err
+				}
+				if waf == nil {
+					return false, "", nil
+				}
+				return strings.EqualFold(waf.Mode, string(armnetwork.ApplicationGatewayFirewallModeDetection)), waf.Mode, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/web-application-firewall/ag/ag-overview#waf-modes",
+		},
+		"agw-010": {
+			Id:             "agw-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Application Gateway WAF should use OWASP CRS 3.2 or a newer rule set",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.ApplicationGateway)
+				waf, err := wafConfiguration(g, scanContext)
+				if err != nil {
+					return false, "", err
+				}
+				if waf == nil || waf.RuleSetVersion == "" {
+					return false, "", nil
+				}
+				result := fmt.Sprintf("%s %s", waf.RuleSetType, waf.RuleSetVersion)
+				// The Microsoft Default Rule Set (DRS) 2.x supersedes OWASP CRS 3.2
+				if !strings.EqualFold(waf.RuleSetType, "OWASP") {
+					return false, result, nil
+				}
+				return ruleSetVersionBelow(waf.RuleSetVersion, 3, 2), result, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/web-application-firewall/ag/application-gateway-crs-rulegroups-rules",
+		},
+	}
+}
+
+// wafConfiguration - Returns the WAF settings of the gateway, read from its WAF policy when associated
+// or from the inline WAF configuration otherwise. Returns nil when the gateway has no WAF enabled, and
+// an error when the associated WAF policy could not be read.
+func wafConfiguration(g *armnetwork.ApplicationGateway, scanContext *scanners.ScanContext) (*scanners.WAFConfiguration, error) {
+	if g.Properties == nil {
+		return nil, nil
+	}
+	if g.Properties.FirewallPolicy != nil && g.Properties.FirewallPolicy.ID != nil {
+		waf, ok := scanContext.ApplicationGatewayWAFPolicies[strings.ToLower(*g.Properties.FirewallPolicy.ID)]
+		if !ok {
+			return nil, fmt.Errorf("WAF policy %s not available for %s", *g.Properties.FirewallPolicy.ID, *g.Name)
+		}
+		return &waf, nil
+	}
+	c := g.Properties.WebApplicationFirewallConfiguration
+	if c == nil || c.Enabled == nil || !*c.Enabled {
+		return nil, nil
+	}
+	waf := scanners.WAFConfiguration{}
+	if c.FirewallMode != nil {
+		waf.Mode = string(*c.FirewallMode)
+	}
+	if c.RuleSetType != nil {
+		waf.RuleSetType = *c.RuleSetType
+	}
+	if c.RuleSetVersion != nil {
+		waf.RuleSetVersion = *c.RuleSetVersion
+	}
+	return &waf, nil
+}
+
+// ruleSetVersionBelow - Returns true if the major.minor rule set version is lower than the given one
+func ruleSetVersionBelow(version string, major, minor int) bool {
+	parts := strings.Split(version, ".")
+	vMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return true
+	}
+	vMinor := 0
+	if len(parts) > 1 {
+		vMinor, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return true
+		}
 	}
+	return vMajor < major || (vMajor == major && vMinor < minor)
 }
@@ -4,6 +4,7 @@
 package agw
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -104,12 +105,158 @@ func TestApplicationGatewayScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "ApplicationGatewayScanner WAF in Detection mode",
+			fields: fields{
+				rule: "agw-009",
+				target: &armnetwork.ApplicationGateway{
+					Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+						WebApplicationFirewallConfiguration: &armnetwork.ApplicationGatewayWebApplicationFirewallConfiguration{
+							Enabled:      to.Ptr(true),
+							FirewallMode: to.Ptr(armnetwork.ApplicationGatewayFirewallModeDetection),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "Detection",
+			},
+		},
+		{
+			name: "ApplicationGatewayScanner WAF policy in Prevention mode",
+			fields: fields{
+				rule: "agw-009",
+				target: &armnetwork.ApplicationGateway{
+					Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/ApplicationGatewayWebApplicationFirewallPolicies/Policy")},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ApplicationGatewayWAFPolicies: map[string]scanners.WAFConfiguration{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/applicationgatewaywebapplicationfirewallpolicies/policy": {
+							Mode: "Prevention",
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "Prevention",
+			},
+		},
+		{
+			name: "ApplicationGatewayScanner WAF disabled",
+			fields: fields{
+				rule: "agw-009",
+				target: &armnetwork.ApplicationGateway{
+					Properties: &armnetwork.ApplicationGatewayPropertiesFormat{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ApplicationGatewayScanner WAF with CRS 3.0",
+			fields: fields{
+				rule: "agw-010",
+				target: &armnetwork.ApplicationGateway{
+					Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+						WebApplicationFirewallConfiguration: &armnetwork.ApplicationGatewayWebApplicationFirewallConfiguration{
+							Enabled:        to.Ptr(true),
+							RuleSetType:    to.Ptr("OWASP"),
+							RuleSetVersion: to.Ptr("3.0"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "OWASP 3.0",
+			},
+		},
+		{
+			name: "ApplicationGatewayScanner WAF with CRS 2.2.9",
+			fields: fields{
+				rule: "agw-010",
+				target: &armnetwork.ApplicationGateway{
+					Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+						WebApplicationFirewallConfiguration: &armnetwork.ApplicationGatewayWebApplicationFirewallConfiguration{
+							Enabled:        to.Ptr(true),
+							RuleSetType:    to.Ptr("OWASP"),
+							RuleSetVersion: to.Ptr("2.2.9"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "OWASP 2.2.9",
+			},
+		},
+		{
+			name: "ApplicationGatewayScanner WAF policy with CRS 3.2",
+			fields: fields{
+				rule: "agw-010",
+				target: &armnetwork.ApplicationGateway{
+					Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/ApplicationGatewayWebApplicationFirewallPolicies/policy")},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ApplicationGatewayWAFPolicies: map[string]scanners.WAFConfiguration{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/applicationgatewaywebapplicationfirewallpolicies/policy": {
+							Mode:           "Prevention",
+							RuleSetType:    "OWASP",
+							RuleSetVersion: "3.2",
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "OWASP 3.2",
+			},
+		},
+		{
+			name: "ApplicationGatewayScanner WAF policy with DRS 2.1",
+			fields: fields{
+				rule: "agw-010",
+				target: &armnetwork.ApplicationGateway{
+					Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+						FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/ApplicationGatewayWebApplicationFirewallPolicies/policy")},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ApplicationGatewayWAFPolicies: map[string]scanners.WAFConfiguration{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/applicationgatewaywebapplicationfirewallpolicies/policy": {
+							Mode:           "Prevention",
+							RuleSetType:    "Microsoft_DefaultRuleSet",
+							RuleSetVersion: "2.1",
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "Microsoft_DefaultRuleSet 2.1",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ApplicationGatewayScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ApplicationGatewayScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -120,3 +267,20 @@ func TestApplicationGatewayScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestApplicationGatewayScanner_WAFPolicy_NotAvailable(t *testing.T) {
+	target := &armnetwork.ApplicationGateway{
+		Name: to.Ptr("agw"),
+		Properties: &armnetwork.ApplicationGatewayPropertiesFormat{
+			FirewallPolicy: &armnetwork.SubResource{ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/ApplicationGatewayWebApplicationFirewallPolicies/policy")},
+		},
+	}
+
+	s := &ApplicationGatewayScanner{}
+	rules := s.GetRules()
+	for _, id := range []string{"agw-009", "agw-010"} {
+		if _, _, err := rules[id].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+			t.Errorf("ApplicationGatewayScanner Rule.Eval() %s error = nil, want an error when the WAF policy is not available", id)
+		}
+	}
+}
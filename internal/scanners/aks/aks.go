@@ -4,8 +4,11 @@
 package aks
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
+	"github.com/rs/zerolog/log"
 )
 
 // AKSScanner - Scanner for AKS Clusters
@@ -16,6 +19,9 @@ type AKSScanner struct {
 
 // Init - Initializes the AKSScanner
 func (a *AKSScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.clustersClient, err = armcontainerservice.NewManagedClustersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,8 +41,9 @@ func (a *AKSScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 	results := []scanners.AzureServiceResult{}
 
 	for _, c := range clusters {
+		a.loadStableVersion(*c.Location, scanContext)
 
-		rr := engine.EvaluateRules(rules, c, scanContext)
+		rr := engine.EvaluateRules(a.config.Ctx, rules, c, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
@@ -45,7 +52,9 @@ func (a *AKSScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 			Location:         *c.Location,
 			Type:             *c.Type,
 			ServiceName:      *c.Name,
+			ID:               *c.ID,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(c),
 		})
 	}
 
@@ -65,3 +74,37 @@ func (a *AKSScanner) listClusters(resourceGroupName string) ([]*armcontainerserv
 	}
 	return clusters, nil
 }
+
+// loadStableVersion - Adds the latest generally available Kubernetes minor version of the location
+// to the scan context, if not already loaded
+func (a *AKSScanner) loadStableVersion(location string, scanContext *scanners.ScanContext) {
+	location = strings.ToLower(location)
+	scanContext.RLock()
+	_, ok := scanContext.AKSStableVersions[location]
+	scanContext.RUnlock()
+	if ok {
+		return
+	}
+
+	version := ""
+	resp, err := a.clustersClient.ListKubernetesVersions(a.config.Ctx, location, nil)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to list AKS Kubernetes versions in %s", location)
+	} else {
+		for _, v := range resp.Values {
+			if v.Version == nil || (v.IsPreview != nil && *v.IsPreview) {
+				continue
+			}
+			if compareMinorVersions(*v.Version, version) > 0 {
+				version = *v.Version
+			}
+		}
+	}
+
+	scanContext.Lock()
+	defer scanContext.Unlock()
+	if scanContext.AKSStableVersions == nil {
+		scanContext.AKSStableVersions = map[string]string{}
+	}
+	scanContext.AKSStableVersions[location] = version
+}
@@ -4,10 +4,11 @@
 package aks
 
 import (
+	"context"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
-	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v4"
 )
 
@@ -19,19 +20,22 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "AKS Cluster should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armcontainerservice.ManagedCluster)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/aks/monitor-aks#collect-resource-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/aks/monitor-aks#collect-resource-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"aks-002": {
 			Id:             "aks-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AKS Cluster should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				cluster := target.(*armcontainerservice.ManagedCluster)
 				zones := true
 				for _, profile := range cluster.Properties.AgentPoolProfiles {
@@ -40,7 +44,7 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 						break
 					}
 				}
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/availability-zones",
 		},
@@ -49,7 +53,7 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AKS Cluster should have an SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 
 				zones := true
@@ -71,7 +75,7 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 						sla = "99.95%"
 					}
 				}
-				return sla == "None", sla
+				return sla == "None", sla, nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/free-standard-pricing-tiers#uptime-sla-terms-and-conditions",
 		},
@@ -80,10 +84,10 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AKS Cluster should be private",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				pe := c.Properties.APIServerAccessProfile != nil && c.Properties.APIServerAccessProfile.EnablePrivateCluster != nil && *c.Properties.APIServerAccessProfile.EnablePrivateCluster
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/private-clusters",
 		},
@@ -92,13 +96,13 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AKS Production Cluster should use Standard SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				sku := "Free"
 				if c.SKU != nil && c.SKU.Tier != nil {
 					sku = string(*c.SKU.Tier)
 				}
-				return sku == "Free", sku
+				return sku == "Free", sku, nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/free-standard-pricing-tiers",
 		},
@@ -107,10 +111,10 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "AKS Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				caf := strings.HasPrefix(*c.Name, "aks")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -119,10 +123,10 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AKS should integrate authentication with AAD (Managed)",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				aad := c.Properties.AADProfile != nil && c.Properties.AADProfile.Managed != nil && *c.Properties.AADProfile.Managed
-				return !aad, ""
+				return !aad, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/managed-azure-ad",
 		},
@@ -131,10 +135,10 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AKS should be RBAC enabled.",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				rbac := *c.Properties.EnableRBAC
-				return !rbac, ""
+				return !rbac, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/aks/manage-azure-rbac",
 		},
@@ -143,13 +147,13 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AKS should have local accounts disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 
 				if c.Properties.DisableLocalAccounts != nil && *c.Properties.DisableLocalAccounts {
-					return false, ""
+					return false, "", nil
 				}
-				return true, ""
+				return true, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/manage-local-accounts-managed-azure-ad#disable-local-accounts",
 		},
@@ -158,11 +162,11 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AKS should have httpApplicationRouting disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				p, exists := c.Properties.AddonProfiles["httpApplicationRouting"]
 				broken := exists && *p.Enabled
-				return broken, ""
+				return broken, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/aks/http-application-routing",
 		},
@@ -171,12 +175,12 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "AKS should have Monitoring enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				m := c.Properties.AzureMonitorProfile != nil && c.Properties.AzureMonitorProfile.Metrics != nil && c.Properties.AzureMonitorProfile.Metrics.Enabled != nil && *c.Properties.AzureMonitorProfile.Metrics.Enabled
 				i, exists := c.Properties.AddonProfiles["omsagent"]
 				broken := !exists || !*i.Enabled || !m
-				return broken, ""
+				return broken, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/azure-monitor/insights/container-insights-overview",
 		},
@@ -185,10 +189,10 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AKS should have outbound type set to user defined routing",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				broken := c.Properties.NetworkProfile.OutboundType == nil || *c.Properties.NetworkProfile.OutboundType != armcontainerservice.OutboundTypeUserDefinedRouting
-				return broken, ""
+				return broken, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/aks/limit-egress-traffic",
 		},
@@ -197,10 +201,10 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryScalability,
 			Recommendation: "AKS should avoid using kubenet network plugin",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				out := *c.Properties.NetworkProfile.NetworkPlugin == armcontainerservice.NetworkPluginKubenet
-				return out, ""
+				return out, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/aks/operator-best-practices-network",
 		},
@@ -209,18 +213,18 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryScalability,
 			Recommendation: "AKS should have autoscaler enabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				if c.Properties.AgentPoolProfiles != nil {
 					for _, p := range c.Properties.AgentPoolProfiles {
 						if p.EnableAutoScaling != nil {
-							return !*p.EnableAutoScaling, ""
+							return !*p.EnableAutoScaling, "", nil
 						} else {
-							return true, ""
+							return true, "", nil
 						}
 					}
 				}
-				return true, ""
+				return true, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/aks/concepts-scale",
 		},
@@ -229,27 +233,30 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "AKS should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"aks-016": {
 			Id:             "aks-016",
 			Category:       scanners.RulesCategoryScalability,
 			Recommendation: "AKS Node Pools should have MaxSurge set",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				defaultMaxSurge := false
 				for _, profile := range c.Properties.AgentPoolProfiles {
-					if profile.UpgradeSettings == nil || profile.UpgradeSettings.MaxSurge == nil || (profile.UpgradeSettings.MaxSurge == to.Ptr("1")) {
+					if profile.UpgradeSettings == nil || profile.UpgradeSettings.MaxSurge == nil || *profile.UpgradeSettings.MaxSurge == "" || *profile.UpgradeSettings.MaxSurge == "0" || *profile.UpgradeSettings.MaxSurge == "1" {
 						defaultMaxSurge = true
 						break
 					}
 				}
-				return defaultMaxSurge, ""
+				return defaultMaxSurge, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/operator-best-practices-run-at-scale#cluster-upgrade-considerations-and-best-practices",
 		},
@@ -258,11 +265,11 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryOtherBestPractices,
 			Recommendation: "AKS: Enable GitOps when using DevOps frameworks",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				g, exists := c.Properties.AddonProfiles["gitops"]
 				broken := !exists || !*g.Enabled
-				return broken, ""
+				return broken, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/architecture/guide/aks/aks-cicd-github-actions-and-gitops",
 		},
@@ -271,14 +278,14 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AKS: Configure system nodepool count",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				for _, profile := range c.Properties.AgentPoolProfiles {
 					if profile.Mode != nil && *profile.Mode == armcontainerservice.AgentPoolModeSystem && (profile.MinCount == nil || *profile.MinCount < 2) {
-						return true, ""
+						return true, "", nil
 					}
 				}
-				return false, ""
+				return false, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/aks/use-system-pools?tabs=azure-cli",
 		},
@@ -287,14 +294,14 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AKS: Configure user nodepool count",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				for _, profile := range c.Properties.AgentPoolProfiles {
 					if profile.Mode != nil && *profile.Mode == armcontainerservice.AgentPoolModeUser && (profile.MinCount == nil || *profile.MinCount < 2) {
-						return true, ""
+						return true, "", nil
 					}
 				}
-				return false, ""
+				return false, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/well-architected/service-guides/azure-kubernetes-service#design-checklist",
 		},
@@ -303,21 +310,153 @@ func (a *AKSScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AKS: system node pool should have taint: CriticalAddonsOnly=true:NoSchedule",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerservice.ManagedCluster)
 				for _, profile := range c.Properties.AgentPoolProfiles {
 					if profile.Mode != nil && *profile.Mode == armcontainerservice.AgentPoolModeSystem {
 						for _, taint := range profile.NodeTaints {
 							if strings.Contains(*taint, "CriticalAddonsOnly=true:NoSchedule") {
-								return false, ""
+								return false, "", nil
 							}
 						}
 						break
 					}
 				}
-				return true, ""
+				return true, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/aks/use-system-pools?tabs=azure-cli#system-and-user-node-pools",
 		},
+		"aks-021": {
+			Id:             "aks-021",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "AKS should have an automatic upgrade channel configured",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				channel := upgradeChannel(target.(*armcontainerservice.ManagedCluster))
+				return channel == armcontainerservice.UpgradeChannelNone, string(channel), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/aks/auto-upgrade-cluster",
+		},
+		"aks-022": {
+			Id:             "aks-022",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "AKS automatic upgrade channel should upgrade minor versions (stable or rapid)",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				channel := upgradeChannel(target.(*armcontainerservice.ManagedCluster))
+				broken := channel == armcontainerservice.UpgradeChannelPatch || channel == armcontainerservice.UpgradeChannelNodeImage
+				return broken, string(channel), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/aks/auto-upgrade-cluster#cluster-auto-upgrade-channels",
+		},
+		"aks-023": {
+			Id:             "aks-023",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "AKS Kubernetes version should be within 2 minor versions of the latest stable release",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerservice.ManagedCluster)
+				if c.Location == nil || c.Properties == nil {
+					return false, "", nil
+				}
+				version := c.Properties.CurrentKubernetesVersion
+				if version == nil {
+					version = c.Properties.KubernetesVersion
+				}
+				stable := scanContext.AKSStableVersions[strings.ToLower(*c.Location)]
+				if version == nil || stable == "" {
+					return false, "", nil
+				}
+				return compareMinorVersions(stable, *version) > 2, *version, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/aks/supported-kubernetes-versions#kubernetes-version-support-policy",
+		},
+		"aks-024": {
+			Id:             "aks-024",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "AKS should have workload identity enabled",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerservice.ManagedCluster)
+				enabled := c.Properties != nil && c.Properties.SecurityProfile != nil && c.Properties.SecurityProfile.WorkloadIdentity != nil &&
+					c.Properties.SecurityProfile.WorkloadIdentity.Enabled != nil && *c.Properties.SecurityProfile.WorkloadIdentity.Enabled
+				return !enabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/aks/workload-identity-overview",
+		},
+		"aks-025": {
+			Id:             "aks-025",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "AKS should not use the deprecated pod-managed identity (AAD Pod Identity), migrate to workload identity",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerservice.ManagedCluster)
+				enabled := c.Properties != nil && c.Properties.PodIdentityProfile != nil &&
+					c.Properties.PodIdentityProfile.Enabled != nil && *c.Properties.PodIdentityProfile.Enabled
+				return enabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/aks/workload-identity-migrate-from-pod-identity",
+		},
+		"aks-026": {
+			Id:             "aks-026",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "AKS should enforce pod security with Azure Policy (Gatekeeper) or pod security policy",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerservice.ManagedCluster)
+				if c.Properties == nil {
+					return true, "", nil
+				}
+				if c.Properties.EnablePodSecurityPolicy != nil && *c.Properties.EnablePodSecurityPolicy {
+					return false, "", nil
+				}
+				p, exists := c.Properties.AddonProfiles["azurepolicy"]
+				broken := !exists || p.Enabled == nil || !*p.Enabled
+				return broken, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/aks/use-azure-policy",
+		},
+	}
+}
+
+// upgradeChannel - Returns the automatic upgrade channel of the cluster, none if not set
+func upgradeChannel(c *armcontainerservice.ManagedCluster) armcontainerservice.UpgradeChannel {
+	if c.Properties == nil || c.Properties.AutoUpgradeProfile == nil || c.Properties.AutoUpgradeProfile.UpgradeChannel == nil {
+		return armcontainerservice.UpgradeChannelNone
+	}
+	return *c.Properties.AutoUpgradeProfile.UpgradeChannel
+}
+
+// compareMinorVersions - Returns the number of minor versions a is ahead of b (e.g. 1.30 and 1.27.3 return 3),
+// counting a major version as 100 minor versions. Versions that can't be parsed (e.g. empty) are the oldest.
+func compareMinorVersions(a, b string) int {
+	minor := func(v string) (int, int, bool) {
+		parts := strings.Split(v, ".")
+		if len(parts) < 2 {
+			return 0, 0, false
+		}
+		major, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return 0, 0, false
+		}
+		minor, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, false
+		}
+		return major, minor, true
+	}
+
+	aMajor, aMinor, aOk := minor(a)
+	bMajor, bMinor, bOk := minor(b)
+	switch {
+	case !aOk && !bOk:
+		return 0
+	case !bOk:
+		return 1
+	case !aOk:
+		return -1
+	case aMajor != bMajor:
+		return (aMajor - bMajor) * 100
 	}
+	return aMinor - bMinor
 }
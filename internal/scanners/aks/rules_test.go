@@ -4,6 +4,7 @@
 package aks
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -765,12 +766,368 @@ func TestAKSScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "AKSScanner Max Surge 0",
+			fields: fields{
+				rule: "aks-016",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AgentPoolProfiles: []*armcontainerservice.ManagedClusterAgentPoolProfile{
+							{
+								UpgradeSettings: &armcontainerservice.AgentPoolUpgradeSettings{
+									MaxSurge: to.Ptr("0"),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Max Surge 33%",
+			fields: fields{
+				rule: "aks-016",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AgentPoolProfiles: []*armcontainerservice.ManagedClusterAgentPoolProfile{
+							{
+								UpgradeSettings: &armcontainerservice.AgentPoolUpgradeSettings{
+									MaxSurge: to.Ptr("33%"),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Upgrade Channel not set",
+			fields: fields{
+				rule: "aks-021",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "none",
+			},
+		},
+		{
+			name: "AKSScanner Upgrade Channel none",
+			fields: fields{
+				rule: "aks-021",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AutoUpgradeProfile: &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+							UpgradeChannel: to.Ptr(armcontainerservice.UpgradeChannelNone),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "none",
+			},
+		},
+		{
+			name: "AKSScanner Upgrade Channel patch",
+			fields: fields{
+				rule: "aks-021",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AutoUpgradeProfile: &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+							UpgradeChannel: to.Ptr(armcontainerservice.UpgradeChannelPatch),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "patch",
+			},
+		},
+		{
+			name: "AKSScanner Upgrade Channel patch minor versions",
+			fields: fields{
+				rule: "aks-022",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AutoUpgradeProfile: &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+							UpgradeChannel: to.Ptr(armcontainerservice.UpgradeChannelPatch),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "patch",
+			},
+		},
+		{
+			name: "AKSScanner Upgrade Channel node-image minor versions",
+			fields: fields{
+				rule: "aks-022",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AutoUpgradeProfile: &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+							UpgradeChannel: to.Ptr(armcontainerservice.UpgradeChannelNodeImage),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "node-image",
+			},
+		},
+		{
+			name: "AKSScanner Upgrade Channel stable minor versions",
+			fields: fields{
+				rule: "aks-022",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AutoUpgradeProfile: &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+							UpgradeChannel: to.Ptr(armcontainerservice.UpgradeChannelStable),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "stable",
+			},
+		},
+		{
+			name: "AKSScanner Upgrade Channel none minor versions",
+			fields: fields{
+				rule: "aks-022",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AutoUpgradeProfile: &armcontainerservice.ManagedClusterAutoUpgradeProfile{
+							UpgradeChannel: to.Ptr(armcontainerservice.UpgradeChannelNone),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "none",
+			},
+		},
+		{
+			name: "AKSScanner Kubernetes version N-2",
+			fields: fields{
+				rule: "aks-023",
+				target: &armcontainerservice.ManagedCluster{
+					Location: to.Ptr("WestEurope"),
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						CurrentKubernetesVersion: to.Ptr("1.28.5"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					AKSStableVersions: map[string]string{
+						"westeurope": "1.30",
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "1.28.5",
+			},
+		},
+		{
+			name: "AKSScanner Kubernetes version N-3",
+			fields: fields{
+				rule: "aks-023",
+				target: &armcontainerservice.ManagedCluster{
+					Location: to.Ptr("WestEurope"),
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						CurrentKubernetesVersion: to.Ptr("1.27.9"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					AKSStableVersions: map[string]string{
+						"westeurope": "1.30",
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "1.27.9",
+			},
+		},
+		{
+			name: "AKSScanner Kubernetes version unknown stable release",
+			fields: fields{
+				rule: "aks-023",
+				target: &armcontainerservice.ManagedCluster{
+					Location: to.Ptr("WestEurope"),
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						CurrentKubernetesVersion: to.Ptr("1.27.9"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Workload Identity enabled",
+			fields: fields{
+				rule: "aks-024",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						SecurityProfile: &armcontainerservice.ManagedClusterSecurityProfile{
+							WorkloadIdentity: &armcontainerservice.ManagedClusterSecurityProfileWorkloadIdentity{
+								Enabled: to.Ptr(true),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Workload Identity disabled",
+			fields: fields{
+				rule: "aks-024",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						SecurityProfile: &armcontainerservice.ManagedClusterSecurityProfile{},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Pod Identity enabled",
+			fields: fields{
+				rule: "aks-025",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						PodIdentityProfile: &armcontainerservice.ManagedClusterPodIdentityProfile{
+							Enabled: to.Ptr(true),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Pod Identity disabled",
+			fields: fields{
+				rule: "aks-025",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						PodIdentityProfile: &armcontainerservice.ManagedClusterPodIdentityProfile{
+							Enabled: to.Ptr(false),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Pod Security with Azure Policy",
+			fields: fields{
+				rule: "aks-026",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AddonProfiles: map[string]*armcontainerservice.ManagedClusterAddonProfile{
+							"azurepolicy": {
+								Enabled: to.Ptr(true),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Pod Security with Pod Security Policy",
+			fields: fields{
+				rule: "aks-026",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						EnablePodSecurityPolicy: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AKSScanner Pod Security not enforced",
+			fields: fields{
+				rule: "aks-026",
+				target: &armcontainerservice.ManagedCluster{
+					Properties: &armcontainerservice.ManagedClusterProperties{
+						AddonProfiles: map[string]*armcontainerservice.ManagedClusterAddonProfile{
+							"azurepolicy": {
+								Enabled: to.Ptr(false),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &AKSScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AKSScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
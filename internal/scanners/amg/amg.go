@@ -16,6 +16,9 @@ type ManagedGrafanaScanner struct {
 
 // Init - Initializes the ManagedGrafanaScanner Scanner
 func (a *ManagedGrafanaScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.grafanaClient, _ = armdashboard.NewGrafanaClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
@@ -35,7 +38,7 @@ func (a *ManagedGrafanaScanner) Scan(resourceGroupName string, scanContext *scan
 	results := []scanners.AzureServiceResult{}
 
 	for _, g := range workspaces {
-		rr := engine.EvaluateRules(rules, g, scanContext)
+		rr := engine.EvaluateRules(a.config.Ctx, rules, g, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
@@ -44,7 +47,9 @@ func (a *ManagedGrafanaScanner) Scan(resourceGroupName string, scanContext *scan
 			Location:         *g.Location,
 			Type:             *g.Type,
 			ServiceName:      *g.Name,
+			ID:               *g.ID,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(g),
 		})
 	}
 	return results, nil
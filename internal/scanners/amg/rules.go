@@ -4,6 +4,7 @@
 package amg
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,10 +19,10 @@ func (a *ManagedGrafanaScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Managed Grafana name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdashboard.ManagedGrafana)
 				caf := strings.HasPrefix(*c.Name, "amg")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -30,7 +31,7 @@ func (a *ManagedGrafanaScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Managed Grafana SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdashboard.ManagedGrafana)
 				sku := ""
 				if c.SKU != nil && c.SKU.Name != nil {
@@ -40,7 +41,7 @@ func (a *ManagedGrafanaScanner) GetRules() map[string]scanners.AzureRule {
 				if strings.Contains(sku, "standard") {
 					sla = "99.9%"
 				}
-				return sla == "None", sla
+				return sla == "None", sla, nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -49,31 +50,35 @@ func (a *ManagedGrafanaScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Managed Grafana should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdashboard.ManagedGrafana)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"amg-004": {
 			Id:             "amg-004",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Managed Grafana should disable public network access",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdashboard.ManagedGrafana)
-				return *c.Properties.PublicNetworkAccess == armdashboard.PublicNetworkAccessEnabled, ""
+				return *c.Properties.PublicNetworkAccess == armdashboard.PublicNetworkAccessEnabled, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
+			Url:         "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
+			Remediation: "az grafana update --ids {resource_id} --public-network-access Disabled",
 		},
 		"amg-005": {
 			Id:             "amg-005",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Managed Grafana should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdashboard.ManagedGrafana)
-				return *c.Properties.ZoneRedundancy == armdashboard.ZoneRedundancyDisabled, ""
+				return *c.Properties.ZoneRedundancy == armdashboard.ZoneRedundancyDisabled, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/managed-grafana/high-availability",
 		},
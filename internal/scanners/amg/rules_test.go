@@ -4,6 +4,7 @@
 package amg
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -137,7 +138,10 @@ func TestManagedGrafanaScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ManagedGrafanaScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ManagedGrafanaScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -4,21 +4,32 @@
 package apim
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/apimanagement/armapimanagement"
+	"github.com/rs/zerolog/log"
 )
 
 // APIManagementScanner - Scanner for API Management Services
 type APIManagementScanner struct {
 	config        *scanners.ScannerConfig
 	serviceClient *armapimanagement.ServiceClient
+	apiClient     *armapimanagement.APIClient
 }
 
 // Init - Initializes the APIManagementScanner
 func (a *APIManagementScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.serviceClient, err = armapimanagement.NewServiceClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.apiClient, err = armapimanagement.NewAPIClient(config.SubscriptionID, config.Cred, config.ClientOptions)
 	return err
 }
 
@@ -34,17 +45,30 @@ func (a *APIManagementScanner) Scan(resourceGroupName string, scanContext *scann
 	rules := a.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	if scanContext.APIMAPIs == nil {
+		scanContext.APIMAPIs = map[string][]*armapimanagement.APIContract{}
+	}
+
 	for _, s := range services {
-		rr := engine.EvaluateRules(rules, s, scanContext)
+		apis, err := a.listAPIs(resourceGroupName, *s.Name)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to list APIs of APIM %s", *s.Name)
+		} else {
+			scanContext.APIMAPIs[strings.ToLower(*s.ID)] = apis
+		}
+
+		rr := engine.EvaluateRules(a.config.Ctx, rules, s, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
-			SubscriptionID: a.config.SubscriptionID,
+			SubscriptionID:   a.config.SubscriptionID,
 			SubscriptionName: a.config.SubscriptionName,
-			ResourceGroup:  resourceGroupName,
-			ServiceName:    *s.Name,
-			Type:           *s.Type,
-			Location:       *s.Location,
-			Rules:          rr,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *s.Name,
+			ID:               *s.ID,
+			Type:             *s.Type,
+			Location:         *s.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(s),
 		})
 	}
 	return results, nil
@@ -63,3 +87,23 @@ func (a *APIManagementScanner) listServices(resourceGroupName string) ([]*armapi
 	}
 	return services, nil
 }
+
+// listAPIs - Lists the current revision of the APIs of the service
+func (a *APIManagementScanner) listAPIs(resourceGroupName, serviceName string) ([]*armapimanagement.APIContract, error) {
+	pager := a.apiClient.NewListByServicePager(resourceGroupName, serviceName, nil)
+
+	apis := make([]*armapimanagement.APIContract, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range resp.Value {
+			if api.Properties != nil && api.Properties.IsCurrent != nil && !*api.Properties.IsCurrent {
+				continue
+			}
+			apis = append(apis, api)
+		}
+	}
+	return apis, nil
+}
@@ -4,6 +4,8 @@
 package apim
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -19,22 +21,25 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "APIM should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armapimanagement.ServiceResource)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-use-azure-monitor#resource-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-use-azure-monitor#resource-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"apim-002": {
 			Id:             "apim-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "APIM should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				a := target.(*armapimanagement.ServiceResource)
 				zones := len(a.Zones) > 0
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/reliability/migrate-api-mgt",
 		},
@@ -43,17 +48,14 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "APIM should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				a := target.(*armapimanagement.ServiceResource)
-				sku := string(*a.SKU.Name)
-				sla := "99.95%"
-				if strings.Contains(sku, "Premium") && (len(a.Zones) > 0 || len(a.Properties.AdditionalLocations) > 0) {
-					sla = "99.99%"
-				} else if strings.Contains(sku, "Developer") {
-					sla = "None"
+				vnetType := ""
+				if a.Properties.VirtualNetworkType != nil {
+					vnetType = string(*a.Properties.VirtualNetworkType)
 				}
-
-				return sla == "None", sla
+				sla := ComputeAPIMSLA(string(*a.SKU.Name), vnetType, len(a.Zones) > 0, len(a.Properties.AdditionalLocations) > 0)
+				return sla == "None", sla, nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/api-management/",
 		},
@@ -62,10 +64,11 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "APIM should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				a := target.(*armapimanagement.ServiceResource)
 				pe := len(a.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/api-management/private-endpoint",
 		},
@@ -74,10 +77,10 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure APIM SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				a := target.(*armapimanagement.ServiceResource)
 				sku := string(*a.SKU.Name)
-				return strings.Contains(sku, "Developer"), sku
+				return strings.Contains(sku, "Developer"), sku, nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-features",
 		},
@@ -86,10 +89,10 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "APIM should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapimanagement.ServiceResource)
 				caf := strings.HasPrefix(*c.Name, "apim")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -98,20 +101,24 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "APIM should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapimanagement.ServiceResource)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"apim-008": {
 			Id:             "apim-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "APIM should use Managed Identities",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapimanagement.ServiceResource)
-				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armapimanagement.ApimIdentityTypeNone, ""
+				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armapimanagement.ApimIdentityTypeNone, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-use-managed-service-identity",
 		},
@@ -120,7 +127,8 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "APIM should only accept a minimum of TLS 1.2",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "data-protection"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				notAllowed := []string{
 					"Microsoft.WindowsAzure.ApiManagement.Gateway.Security.Protocols.Tls10",
 					"Microsoft.WindowsAzure.ApiManagement.Gateway.Security.Protocols.Tls11",
@@ -135,14 +143,14 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 					for _, v := range notAllowed {
 						broken := c.Properties.CustomProperties[v] == nil || strings.ToLower(*c.Properties.CustomProperties[v]) == "true"
 						if broken {
-							return broken, ""
+							return broken, "", nil
 						}
 					}
 				} else {
-					return true, ""
+					return true, "", nil
 				}
 
-				return false, ""
+				return false, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-manage-protocols-ciphers",
 		},
@@ -151,7 +159,7 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "APIM should should not accept weak or deprecated ciphers.",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				notAllowed := []string{
 					"Microsoft.WindowsAzure.ApiManagement.Gateway.Security.Ciphers.TripleDes168",
 					"Microsoft.WindowsAzure.ApiManagement.Gateway.Security.Ciphers.TLS_RSA_WITH_AES_128_CBC_SHA",
@@ -168,14 +176,14 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 					for _, v := range notAllowed {
 						broken := c.Properties.CustomProperties[v] == nil || strings.ToLower(*c.Properties.CustomProperties[v]) == "true"
 						if broken {
-							return broken, ""
+							return broken, "", nil
 						}
 					}
 				} else {
-					return true, ""
+					return true, "", nil
 				}
 
-				return false, ""
+				return false, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-manage-protocols-ciphers",
 		},
@@ -184,19 +192,19 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "APIM: Renew expiring certificates",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapimanagement.ServiceResource)
 				if c.Properties.HostnameConfigurations != nil {
 					for _, v := range c.Properties.HostnameConfigurations {
 						if v.Certificate != nil && v.Certificate.Expiry != nil {
 							days := time.Until(*v.Certificate.Expiry).Hours() / 24
 							if days <= 30 {
-								return true, ""
+								return true, "", nil
 							}
 						}
 					}
 				}
-				return false, ""
+				return false, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/api-management/configure-custom-domain?tabs=custom",
 		},
@@ -205,11 +213,116 @@ func (a *APIManagementScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "APIM: Migrate instance hosted on the stv1 platform to stv2",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapimanagement.ServiceResource)
-				return *c.Properties.PlatformVersion == armapimanagement.PlatformVersionStv1, ""
+				return *c.Properties.PlatformVersion == armapimanagement.PlatformVersionStv1, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/api-management/migrate-stv1-to-stv2?tabs=portal",
 		},
+		"apim-013": {
+			Id:             "apim-013",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "APIM should be integrated with a virtual network",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armapimanagement.ServiceResource)
+				none := c.Properties.VirtualNetworkType == nil || *c.Properties.VirtualNetworkType == armapimanagement.VirtualNetworkTypeNone
+				return none, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/api-management/virtual-network-concepts",
+		},
+		"apim-014": {
+			Id:             "apim-014",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "APIM should use Internal virtual network mode to keep the gateway endpoint private",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armapimanagement.ServiceResource)
+				external := c.Properties.VirtualNetworkType != nil && *c.Properties.VirtualNetworkType == armapimanagement.VirtualNetworkTypeExternal
+				return external, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-using-with-internal-vnet",
+		},
+		"apim-015": {
+			Id:             "apim-015",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "APIM APIs should require a subscription",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armapimanagement.ServiceResource)
+				return evalAPIs(c, scanContext, func(p *armapimanagement.APIContractProperties) bool {
+					return p.SubscriptionRequired == nil || !*p.SubscriptionRequired
+				})
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-subscriptions",
+		},
+		"apim-016": {
+			Id:             "apim-016",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "APIM APIs should call their backend over HTTPS",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armapimanagement.ServiceResource)
+				return evalAPIs(c, scanContext, func(p *armapimanagement.APIContractProperties) bool {
+					if p.ServiceURL == nil || *p.ServiceURL == "" {
+						return false
+					}
+					url := strings.ToLower(*p.ServiceURL)
+					return !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "wss://")
+				})
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-mutual-certificates",
+		},
+		"apim-017": {
+			Id:             "apim-017",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "APIM APIs should have OAuth 2.0 or OpenID Connect authorization configured",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armapimanagement.ServiceResource)
+				return evalAPIs(c, scanContext, func(p *armapimanagement.APIContractProperties) bool {
+					return !hasUserAuthorization(p.AuthenticationSettings)
+				})
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/api-management/api-management-howto-protect-backend-with-aad",
+		},
+	}
+}
+
+// evalAPIs - Returns true, with the names of the APIs, if some APIs of the service are broken
+func evalAPIs(c *armapimanagement.ServiceResource, scanContext *scanners.ScanContext, broken func(p *armapimanagement.APIContractProperties) bool) (bool, string, error) {
+	apis, ok := scanContext.APIMAPIs[strings.ToLower(*c.ID)]
+	if !ok {
+		return false, "", fmt.Errorf("APIs not available for %s", *c.Name)
+	}
+	names := []string{}
+	for _, api := range apis {
+		if api.Properties != nil && api.Name != nil && broken(api.Properties) {
+			names = append(names, *api.Name)
+		}
+	}
+	return len(names) > 0, strings.Join(names, ", "), nil
+}
+
+// hasUserAuthorization - Returns true if an OAuth 2.0 authorization server or an OpenID Connect provider is configured
+func hasUserAuthorization(settings *armapimanagement.AuthenticationSettingsContract) bool {
+	if settings == nil {
+		return false
+	}
+	if settings.OAuth2 != nil && settings.OAuth2.AuthorizationServerID != nil && *settings.OAuth2.AuthorizationServerID != "" {
+		return true
+	}
+	return settings.Openid != nil && settings.Openid.OpenidProviderID != nil && *settings.Openid.OpenidProviderID != ""
+}
+
+// ComputeAPIMSLA - Returns the SLA of an APIM instance for the given SKU tier and virtual network type.
+// Premium instances get 99.99% with Internal VNet integration, availability zones or additional locations.
+func ComputeAPIMSLA(tier string, vnetType string, zoneRedundant bool, multiRegion bool) string {
+	if strings.Contains(tier, "Developer") {
+		return "None"
+	}
+	if strings.Contains(tier, "Premium") && (zoneRedundant || multiRegion || strings.EqualFold(vnetType, string(armapimanagement.VirtualNetworkTypeInternal))) {
+		return "99.99%"
 	}
+	return "99.95%"
 }
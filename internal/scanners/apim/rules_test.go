@@ -4,6 +4,7 @@
 package apim
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
@@ -14,6 +15,44 @@ import (
 )
 
 func TestAPIManagementScanner_Rules(t *testing.T) {
+	apisContext := &scanners.ScanContext{
+		APIMAPIs: map[string][]*armapimanagement.APIContract{
+			"test": {
+				{
+					Name: to.Ptr("orders"),
+					Properties: &armapimanagement.APIContractProperties{
+						SubscriptionRequired: to.Ptr(true),
+						ServiceURL:           to.Ptr("https://orders.contoso.com"),
+						AuthenticationSettings: &armapimanagement.AuthenticationSettingsContract{
+							Openid: &armapimanagement.OpenIDAuthenticationSettingsContract{
+								OpenidProviderID: to.Ptr("entra"),
+							},
+						},
+					},
+				},
+				{
+					Name: to.Ptr("legacy"),
+					Properties: &armapimanagement.APIContractProperties{
+						SubscriptionRequired: to.Ptr(false),
+						ServiceURL:           to.Ptr("http://legacy.contoso.com"),
+					},
+				},
+				{
+					Name: to.Ptr("notifications"),
+					Properties: &armapimanagement.APIContractProperties{
+						SubscriptionRequired: to.Ptr(true),
+						ServiceURL:           to.Ptr("wss://notifications.contoso.com"),
+						AuthenticationSettings: &armapimanagement.AuthenticationSettingsContract{
+							OAuth2: &armapimanagement.OAuth2AuthenticationSettingsContract{
+								AuthorizationServerID: to.Ptr("oauth"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	type fields struct {
 		rule        string
 		target      interface{}
@@ -356,12 +395,153 @@ func TestAPIManagementScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "APIManagementScanner VNET None",
+			fields: fields{
+				rule: "apim-013",
+				target: &armapimanagement.ServiceResource{
+					Properties: &armapimanagement.ServiceProperties{
+						VirtualNetworkType: to.Ptr(armapimanagement.VirtualNetworkTypeNone),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "APIManagementScanner VNET not set",
+			fields: fields{
+				rule: "apim-013",
+				target: &armapimanagement.ServiceResource{
+					Properties: &armapimanagement.ServiceProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "APIManagementScanner VNET Internal",
+			fields: fields{
+				rule: "apim-013",
+				target: &armapimanagement.ServiceResource{
+					Properties: &armapimanagement.ServiceProperties{
+						VirtualNetworkType: to.Ptr(armapimanagement.VirtualNetworkTypeInternal),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "APIManagementScanner VNET External",
+			fields: fields{
+				rule: "apim-014",
+				target: &armapimanagement.ServiceResource{
+					Properties: &armapimanagement.ServiceProperties{
+						VirtualNetworkType: to.Ptr(armapimanagement.VirtualNetworkTypeExternal),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "APIManagementScanner VNET Internal mode",
+			fields: fields{
+				rule: "apim-014",
+				target: &armapimanagement.ServiceResource{
+					Properties: &armapimanagement.ServiceProperties{
+						VirtualNetworkType: to.Ptr(armapimanagement.VirtualNetworkTypeInternal),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "APIManagementScanner APIs subscription required",
+			fields: fields{
+				rule: "apim-015",
+				target: &armapimanagement.ServiceResource{
+					ID: to.Ptr("test"),
+				},
+				scanContext: apisContext,
+			},
+			want: want{
+				broken: true,
+				result: "legacy",
+			},
+		},
+		{
+			name: "APIManagementScanner APIs HTTPS backend",
+			fields: fields{
+				rule: "apim-016",
+				target: &armapimanagement.ServiceResource{
+					ID: to.Ptr("test"),
+				},
+				scanContext: apisContext,
+			},
+			want: want{
+				broken: true,
+				result: "legacy",
+			},
+		},
+		{
+			name: "APIManagementScanner APIs OAuth 2.0 or OpenID Connect",
+			fields: fields{
+				rule: "apim-017",
+				target: &armapimanagement.ServiceResource{
+					ID: to.Ptr("test"),
+				},
+				scanContext: apisContext,
+			},
+			want: want{
+				broken: true,
+				result: "legacy",
+			},
+		},
+		{
+			name: "APIManagementScanner without APIs",
+			fields: fields{
+				rule: "apim-015",
+				target: &armapimanagement.ServiceResource{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					APIMAPIs: map[string][]*armapimanagement.APIContract{
+						"test": {},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &APIManagementScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("APIManagementScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -372,3 +552,40 @@ func TestAPIManagementScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeAPIMSLA(t *testing.T) {
+	tests := []struct {
+		name          string
+		tier          string
+		vnetType      string
+		zoneRedundant bool
+		multiRegion   bool
+		want          string
+	}{
+		{"Developer", "Developer", "Internal", false, false, "None"},
+		{"Premium Internal", "Premium", "Internal", false, false, "99.99%"},
+		{"Premium External", "Premium", "External", false, false, "99.95%"},
+		{"Premium zones", "Premium", "None", true, false, "99.99%"},
+		{"Premium additional locations", "Premium", "None", false, true, "99.99%"},
+		{"Standard", "Standard", "None", false, false, "99.95%"},
+		{"Standard zones", "Standard", "None", true, false, "99.95%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeAPIMSLA(tt.tier, tt.vnetType, tt.zoneRedundant, tt.multiRegion); got != tt.want {
+				t.Errorf("ComputeAPIMSLA() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIManagementScanner_APIs_NotAvailable(t *testing.T) {
+	s := &APIManagementScanner{}
+	rules := s.GetRules()
+	for _, id := range []string{"apim-015", "apim-016", "apim-017"} {
+		_, _, err := rules[id].Eval(context.Background(), &armapimanagement.ServiceResource{ID: to.Ptr("test"), Name: to.Ptr("apim-test")}, &scanners.ScanContext{})
+		if err == nil {
+			t.Errorf("APIManagementScanner %s Rule.Eval() error = nil, want an error when the APIs are not available", id)
+		}
+	}
+}
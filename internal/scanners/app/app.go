@@ -0,0 +1,239 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// AppServiceScanner - Scanner for App Services (Web Apps, Functions and Logic Apps Standard)
+type AppServiceScanner struct {
+	config             *scanners.ScannerConfig
+	plansClient        *armappservice.PlansClient
+	sitesClient        *armappservice.WebAppsClient
+	certificatesClient *armappservice.CertificatesClient
+	// certificates - Expiry dates by upper case thumbprint of the certificates of each resource group, listed when first needed
+	certificates map[string]map[string]time.Time
+}
+
+// Init - Initializes the AppServiceScanner
+func (a *AppServiceScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	a.config = config
+	var err error
+	a.plansClient, err = armappservice.NewPlansClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.sitesClient, err = armappservice.NewWebAppsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.certificatesClient, err = armappservice.NewCertificatesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.certificates = map[string]map[string]time.Time{}
+	return nil
+}
+
+// Scan - Scans all App Services in a Resource Group
+func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(a.config.SubscriptionID, resourceGroupName, "App Service")
+
+	sites, err := a.listSites(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	appRules := a.getAppRules()
+	functionRules := a.getFunctionRules()
+	logicRules := a.getLogicRules()
+	results := []scanners.AzureServiceResult{}
+
+	scanContext.AppServicePlans = map[string]*armappservice.Plan{}
+	scanContext.AppServiceSlots = map[string]int{}
+
+	for _, s := range sites {
+		select {
+		case <-a.config.Ctx.Done():
+			return nil, a.config.Ctx.Err()
+		default:
+		}
+		config, err := a.sitesClient.GetConfiguration(a.config.Ctx, resourceGroupName, *s.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+		scanContext.SiteConfig = &config
+
+		if s.Properties != nil && s.Properties.ServerFarmID != nil {
+			planID := strings.ToLower(*s.Properties.ServerFarmID)
+			if _, ok := scanContext.AppServicePlans[planID]; !ok {
+				plan, err := a.getPlan(*s.Properties.ServerFarmID)
+				if err != nil {
+					return nil, err
+				}
+				scanContext.AppServicePlans[planID] = plan
+			}
+		}
+
+		slots, err := a.countSlots(resourceGroupName, *s.Name)
+		if err != nil {
+			return nil, err
+		}
+		scanContext.AppServiceSlots[strings.ToLower(*s.ID)] = slots
+
+		certificates, err := a.getBoundCertificates(resourceGroupName, s)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to get the certificates bound to App Service %s", *s.Name)
+		}
+		scanContext.AppServiceCertificates = certificates
+
+		var rr map[string]scanners.AzureRuleResult
+		// https://learn.microsoft.com/en-us/azure/azure-functions/functions-app-settings
+		kind := strings.ToLower(*s.Kind)
+		switch kind {
+		case "functionapp,linux", "functionapp":
+			rr = engine.EvaluateRules(a.config.Ctx, functionRules, s, scanContext)
+		case "functionapp,workflowapp":
+			rr = engine.EvaluateRules(a.config.Ctx, logicRules, s, scanContext)
+		default:
+			rr = engine.EvaluateRules(a.config.Ctx, appRules, s, scanContext)
+		}
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   a.config.SubscriptionID,
+			SubscriptionName: a.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *s.Name,
+			ID:               *s.ID,
+			Type:             *s.Type,
+			Location:         *s.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(s),
+		})
+	}
+	return results, nil
+}
+
+func (a *AppServiceScanner) listSites(resourceGroupName string) ([]*armappservice.Site, error) {
+	pager := a.sitesClient.NewListByResourceGroupPager(resourceGroupName, nil)
+	results := []*armappservice.Site{}
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, resp.Value...)
+	}
+	return results, nil
+}
+
+func (a *AppServiceScanner) getPlan(planID string) (*armappservice.Plan, error) {
+	id, err := arm.ParseResourceID(planID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.plansClient.Get(a.config.Ctx, id.ResourceGroupName, id.Name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Plan, nil
+}
+
+func (a *AppServiceScanner) countSlots(resourceGroupName, siteName string) (int, error) {
+	pager := a.sitesClient.NewListSlotsPager(resourceGroupName, siteName, nil)
+	count := 0
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return 0, err
+		}
+		count += len(resp.Value)
+	}
+	return count, nil
+}
+
+// getBoundCertificates - Returns the expiry dates, by upper case thumbprint, of the certificates bound to the
+// host names of the site. Certificates live in the resource group of the App Service Plan (its webspace).
+func (a *AppServiceScanner) getBoundCertificates(resourceGroupName string, site *armappservice.Site) (map[string]time.Time, error) {
+	thumbprints, err := a.listBindingThumbprints(resourceGroupName, *site.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := map[string]time.Time{}
+	if len(thumbprints) == 0 {
+		return certificates, nil
+	}
+
+	certificateResourceGroup := resourceGroupName
+	if site.Properties != nil && site.Properties.ServerFarmID != nil {
+		if id, err := arm.ParseResourceID(*site.Properties.ServerFarmID); err == nil {
+			certificateResourceGroup = id.ResourceGroupName
+		}
+	}
+	available, err := a.listCertificates(certificateResourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range thumbprints {
+		if expiry, ok := available[t]; ok {
+			certificates[t] = expiry
+		}
+	}
+	return certificates, nil
+}
+
+// listBindingThumbprints - Returns the upper case thumbprints of the certificates bound to the site host names
+func (a *AppServiceScanner) listBindingThumbprints(resourceGroupName, siteName string) ([]string, error) {
+	pager := a.sitesClient.NewListHostNameBindingsPager(resourceGroupName, siteName, nil)
+	thumbprints := []string{}
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range resp.Value {
+			if b.Properties == nil || b.Properties.Thumbprint == nil || *b.Properties.Thumbprint == "" {
+				continue
+			}
+			thumbprints = append(thumbprints, strings.ToUpper(*b.Properties.Thumbprint))
+		}
+	}
+	return thumbprints, nil
+}
+
+// listCertificates - Returns the expiry dates, by upper case thumbprint, of the certificates of the resource group
+func (a *AppServiceScanner) listCertificates(resourceGroupName string) (map[string]time.Time, error) {
+	key := strings.ToLower(resourceGroupName)
+	if certificates, ok := a.certificates[key]; ok {
+		return certificates, nil
+	}
+
+	pager := a.certificatesClient.NewListByResourceGroupPager(resourceGroupName, nil)
+	certificates := map[string]time.Time{}
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range resp.Value {
+			if c.Properties == nil || c.Properties.Thumbprint == nil || c.Properties.ExpirationDate == nil {
+				continue
+			}
+			certificates[strings.ToUpper(*c.Properties.Thumbprint)] = *c.Properties.ExpirationDate
+		}
+	}
+	a.certificates[key] = certificates
+	return certificates, nil
+}
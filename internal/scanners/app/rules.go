@@ -0,0 +1,593 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+)
+
+const (
+	certificateExpiryDaysHigh   = 7
+	certificateExpiryDaysMedium = 30
+)
+
+// GetRules - Returns the rules for the AppServiceScanner
+func (a *AppServiceScanner) GetRules() map[string]scanners.AzureRule {
+	result := a.getAppRules()
+	for k, v := range a.getFunctionRules() {
+		result[k] = v
+	}
+	for k, v := range a.getLogicRules() {
+		result[k] = v
+	}
+	return result
+}
+
+func (a *AppServiceScanner) getAppRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"app-001": {
+			Id:             "app-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "App Service should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armappservice.Site)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/app-service/troubleshoot-diagnostic-logs#send-logs-to-azure-monitor",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"app-002": {
+			Id:             "app-002",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "App Service should be hosted on a zone redundant Premium v3 plan",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				plan := getPlan(c, scanContext)
+				if plan == nil || plan.Properties == nil {
+					return true, "", nil
+				}
+				zones := plan.Properties.ZoneRedundant != nil && *plan.Properties.ZoneRedundant
+				return !zones, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/reliability/migrate-app-service",
+		},
+		"app-003": {
+			Id:             "app-003",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "App Service should have a SLA",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				sla := "None"
+				if tier := getPlanTier(c, scanContext); tier != "" && tier != "free" && tier != "shared" {
+					sla = "99.95%"
+				}
+				return sla == "None", sla, nil
+			},
+			Url: "https://www.azure.cn/en-us/support/sla/app-service/",
+		},
+		"app-004": {
+			Id:             "app-004",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service should have private endpoints enabled",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*armappservice.Site)
+				_, pe := scanContext.PrivateEndpoints[*i.ID]
+				return !pe, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/networking/private-endpoint",
+		},
+		"app-005": {
+			Id:             "app-005",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "App Service should use deployment slots to swap into production",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				slots := scanContext.AppServiceSlots[strings.ToLower(*c.ID)]
+				return slots == 0, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/deploy-staging-slots",
+		},
+		"app-006": {
+			Id:             "app-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "App Service Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				caf := strings.HasPrefix(*c.Name, "app")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"app-007": {
+			Id:             "app-007",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service should use HTTPS only",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				h := c.Properties != nil && c.Properties.HTTPSOnly != nil && *c.Properties.HTTPSOnly
+				return !h, "", nil
+			},
+			Url:         "https://learn.microsoft.com/azure/app-service/configure-ssl-bindings#enforce-https",
+			Remediation: "az webapp update --ids {resource_id} --https-only true",
+		},
+		"app-008": {
+			Id:             "app-008",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "App Service should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"app-009": {
+			Id:             "app-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service should use VNET integration",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties == nil || c.Properties.VirtualNetworkSubnetID == nil || len(*c.Properties.VirtualNetworkSubnetID) == 0, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
+		},
+		"app-010": {
+			Id:             "app-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service should have VNET Route all enabled for VNET integration",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties == nil || c.Properties.VnetRouteAllEnabled == nil || !*c.Properties.VnetRouteAllEnabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
+		},
+		"app-011": {
+			Id:             "app-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service should use TLS 1.2",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "data-protection"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				broken := scanContext.SiteConfig.Properties.MinTLSVersion == nil || *scanContext.SiteConfig.Properties.MinTLSVersion != armappservice.SupportedTLSVersionsOne2
+				return broken, "", nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/app-service/overview-tls",
+			Remediation: "az webapp config set --ids {resource_id} --min-tls-version 1.2",
+		},
+		"app-012": {
+			Id:             "app-012",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service remote debugging should be disabled",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				broken := scanContext.SiteConfig.Properties.RemoteDebuggingEnabled == nil || *scanContext.SiteConfig.Properties.RemoteDebuggingEnabled
+				return broken, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/visualstudio/debugger/remote-debugging-azure-app-service?view=vs-2022#enable-remote-debugging",
+		},
+		"app-013": {
+			Id:             "app-013",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service should not allow insecure FTP",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				broken := scanContext.SiteConfig.Properties.FtpsState == nil || *scanContext.SiteConfig.Properties.FtpsState == armappservice.FtpsStateAllAllowed
+				return broken, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/deploy-ftp?tabs=portal",
+		},
+		"app-014": {
+			Id:             "app-014",
+			Category:       scanners.RulesCategoryScalability,
+			Recommendation: "App Service should have Always On enabled",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				// Always On is not available on Free, Shared and Consumption (Dynamic) plans.
+				c := target.(*armappservice.Site)
+				switch getPlanTier(c, scanContext) {
+				case "free", "shared", "dynamic":
+					return false, "", nil
+				}
+				broken := scanContext.SiteConfig.Properties.AlwaysOn == nil || !*scanContext.SiteConfig.Properties.AlwaysOn
+				return broken, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/configure-common?tabs=portal",
+		},
+		"app-015": {
+			Id:             "app-015",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "App Service should avoid using Client Affinity",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties.ClientAffinityEnabled != nil && *c.Properties.ClientAffinityEnabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/azure-app-service/reliability#checklist",
+		},
+		"app-016": {
+			Id:             "app-016",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service should use Managed Identities",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				// c := target.(*armappservice.Site)
+				// c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armappservice.ManagedServiceIdentityTypeNone
+				// not working because SDK set's Identity to nil even when configured.
+				ok := scanContext.SiteConfig.Properties.ManagedServiceIdentityID != nil || scanContext.SiteConfig.Properties.XManagedServiceIdentityID != nil
+				return !ok, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-managed-identity?tabs=portal%2Chttp",
+		},
+		"app-017": {
+			Id:             "app-017",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "App Service TLS certificates should not expire within 30 days",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "data-protection"},
+			ResultImpact:   certificateExpiryImpact,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				if scanContext.AppServiceCertificates == nil {
+					return false, "", fmt.Errorf("certificates not available for %s", *c.Name)
+				}
+				days, ok := daysToCertificateExpiry(scanContext.AppServiceCertificates)
+				if !ok {
+					return false, "", nil
+				}
+				return days <= certificateExpiryDaysMedium, strconv.Itoa(days), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/configure-ssl-certificate#renew-an-expiring-certificate",
+		},
+	}
+}
+
+func (a *AppServiceScanner) getFunctionRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"func-001": {
+			Id:             "func-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Function should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armappservice.Site)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-functions/functions-monitor-log-analytics?tabs=csharp",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"func-004": {
+			Id:             "func-004",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Function should have private endpoints enabled",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*armappservice.Site)
+				_, pe := scanContext.PrivateEndpoints[*i.ID]
+				return !pe, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-functions/functions-create-vnet",
+		},
+		"func-006": {
+			Id:             "func-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Function Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				caf := strings.HasPrefix(*c.Name, "func")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"func-007": {
+			Id:             "func-007",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Function should use HTTPS only",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				h := c.Properties != nil && c.Properties.HTTPSOnly != nil && *c.Properties.HTTPSOnly
+				return !h, "", nil
+			},
+			Url:         "https://learn.microsoft.com/azure/app-service/configure-ssl-bindings#enforce-https",
+			Remediation: "az functionapp update --ids {resource_id} --set httpsOnly=true",
+		},
+		"func-008": {
+			Id:             "func-008",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Function should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"func-009": {
+			Id:             "func-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Function should use VNET integration",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties == nil || c.Properties.VirtualNetworkSubnetID == nil || len(*c.Properties.VirtualNetworkSubnetID) == 0, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
+		},
+		"func-010": {
+			Id:             "func-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Function should have VNET Route all enabled for VNET integration",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties == nil || c.Properties.VnetRouteAllEnabled == nil || !*c.Properties.VnetRouteAllEnabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
+		},
+		"func-011": {
+			Id:             "func-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Function should use TLS 1.2",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "data-protection"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				broken := scanContext.SiteConfig.Properties.MinTLSVersion == nil || *scanContext.SiteConfig.Properties.MinTLSVersion != armappservice.SupportedTLSVersionsOne2
+				return broken, "", nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/app-service/overview-tls",
+			Remediation: "az functionapp config set --ids {resource_id} --min-tls-version 1.2",
+		},
+		"func-012": {
+			Id:             "func-012",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Function remote debugging should be disabled",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				broken := scanContext.SiteConfig.Properties.RemoteDebuggingEnabled == nil || *scanContext.SiteConfig.Properties.RemoteDebuggingEnabled
+				return broken, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/visualstudio/debugger/remote-debugging-azure-app-service?view=vs-2022#enable-remote-debugging",
+		},
+		"func-013": {
+			Id:             "func-013",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Function should avoid using Client Affinity",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties.ClientAffinityEnabled != nil && *c.Properties.ClientAffinityEnabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/azure-app-service/reliability#checklist",
+		},
+		"func-014": {
+			Id:             "func-014",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Function should use Managed Identities",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				// c := target.(*armappservice.Site)
+				// c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armappservice.ManagedServiceIdentityTypeNone
+				// not working because SDK set's Identity to nil even when configured.
+				ok := scanContext.SiteConfig.Properties.ManagedServiceIdentityID != nil || scanContext.SiteConfig.Properties.XManagedServiceIdentityID != nil
+				return !ok, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-managed-identity?tabs=portal%2Chttp",
+		},
+	}
+}
+
+func (a *AppServiceScanner) getLogicRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"logics-001": {
+			Id:             "logics-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Logic App should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armappservice.Site)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/logic-apps/monitor-workflows-collect-diagnostic-data",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"logics-004": {
+			Id:             "logics-004",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App should have private endpoints enabled",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*armappservice.Site)
+				_, pe := scanContext.PrivateEndpoints[*i.ID]
+				return !pe, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/logic-apps/secure-single-tenant-workflow-virtual-network-private-endpoint",
+		},
+		"logics-006": {
+			Id:             "logics-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Logic App Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				caf := strings.HasPrefix(*c.Name, "logic")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"logics-007": {
+			Id:             "logics-007",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App should use HTTPS only",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				h := c.Properties != nil && c.Properties.HTTPSOnly != nil && *c.Properties.HTTPSOnly
+				return !h, "", nil
+			},
+			Url:         "https://learn.microsoft.com/azure/app-service/configure-ssl-bindings#enforce-https",
+			Remediation: "az resource update --ids {resource_id} --set properties.httpsOnly=true",
+		},
+		"logics-008": {
+			Id:             "logics-008",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Logic App should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"logics-009": {
+			Id:             "logics-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App should use VNET integration",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties == nil || c.Properties.VirtualNetworkSubnetID == nil || len(*c.Properties.VirtualNetworkSubnetID) == 0, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
+		},
+		"logics-010": {
+			Id:             "logics-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App should have VNET Route all enabled for VNET integration",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties == nil || c.Properties.VnetRouteAllEnabled == nil || !*c.Properties.VnetRouteAllEnabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-vnet-integration",
+		},
+		"logics-011": {
+			Id:             "logics-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App should use TLS 1.2",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "data-protection"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				broken := scanContext.SiteConfig.Properties.MinTLSVersion == nil || *scanContext.SiteConfig.Properties.MinTLSVersion != armappservice.SupportedTLSVersionsOne2
+				return broken, "", nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/app-service/overview-tls",
+			Remediation: "az resource update --ids {resource_id}/config/web --set properties.minTlsVersion=1.2",
+		},
+		"logics-012": {
+			Id:             "logics-012",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App remote debugging should be disabled",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				broken := scanContext.SiteConfig.Properties.RemoteDebuggingEnabled == nil || *scanContext.SiteConfig.Properties.RemoteDebuggingEnabled
+				return broken, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/visualstudio/debugger/remote-debugging-azure-app-service?view=vs-2022#enable-remote-debugging",
+		},
+		"logics-013": {
+			Id:             "logics-013",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Logic App should avoid using Client Affinity",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armappservice.Site)
+				return c.Properties.ClientAffinityEnabled != nil && *c.Properties.ClientAffinityEnabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/azure-app-service/reliability#checklist",
+		},
+		"logics-014": {
+			Id:             "logics-014",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App should use Managed Identities",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				// c := target.(*armappservice.Site)
+				// c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armappservice.ManagedServiceIdentityTypeNone
+				// not working because SDK set's Identity to nil even when configured.
+				ok := scanContext.SiteConfig.Properties.ManagedServiceIdentityID != nil || scanContext.SiteConfig.Properties.XManagedServiceIdentityID != nil
+				return !ok, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/app-service/overview-managed-identity?tabs=portal%2Chttp",
+		},
+	}
+}
+
+func getPlan(site *armappservice.Site, scanContext *scanners.ScanContext) *armappservice.Plan {
+	if site.Properties == nil || site.Properties.ServerFarmID == nil {
+		return nil
+	}
+	return scanContext.AppServicePlans[strings.ToLower(*site.Properties.ServerFarmID)]
+}
+
+func getPlanTier(site *armappservice.Site, scanContext *scanners.ScanContext) string {
+	plan := getPlan(site, scanContext)
+	if plan == nil || plan.SKU == nil || plan.SKU.Tier == nil {
+		return ""
+	}
+	return strings.ToLower(*plan.SKU.Tier)
+}
+
+// daysToCertificateExpiry - Returns the days left until the first bound certificate of the site expires
+func daysToCertificateExpiry(certificates map[string]time.Time) (int, bool) {
+	found := false
+	days := 0
+	for _, expiry := range certificates {
+		d := int(time.Until(expiry).Hours() / 24)
+		if !found || d < days {
+			days = d
+			found = true
+		}
+	}
+	return days, found
+}
+
+// certificateExpiryImpact - Certificates expiring within 7 days are High impact, within 30 days Medium
+func certificateExpiryImpact(result string) scanners.ImpactType {
+	days, err := strconv.Atoi(result)
+	if err == nil && days > certificateExpiryDaysHigh {
+		return scanners.ImpactMedium
+	}
+	return scanners.ImpactHigh
+}
@@ -0,0 +1,1175 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+)
+
+func TestAppServiceScanner_AppRules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "AppServiceScanner DiagnosticSettings",
+			fields: fields{
+				rule: "app-001",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Availability Zones",
+			fields: fields{
+				rule: "app-002",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						ServerFarmID: to.Ptr("plan"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					AppServicePlans: map[string]*armappservice.Plan{
+						"plan": {
+							Properties: &armappservice.PlanProperties{
+								ZoneRedundant: to.Ptr(true),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Availability Zones without plan",
+			fields: fields{
+				rule:        "app-002",
+				target:      &armappservice.Site{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner SLA",
+			fields: fields{
+				rule: "app-003",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						ServerFarmID: to.Ptr("plan"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					AppServicePlans: map[string]*armappservice.Plan{
+						"plan": {
+							SKU: &armappservice.SKUDescription{
+								Tier: to.Ptr("PremiumV3"),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "99.95%",
+			},
+		},
+		{
+			name: "AppServiceScanner SLA Free",
+			fields: fields{
+				rule: "app-003",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						ServerFarmID: to.Ptr("plan"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					AppServicePlans: map[string]*armappservice.Plan{
+						"plan": {
+							SKU: &armappservice.SKUDescription{
+								Tier: to.Ptr("Free"),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "None",
+			},
+		},
+		{
+			name: "AppServiceScanner Private Endpoint",
+			fields: fields{
+				rule: "app-004",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					PrivateEndpoints: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Deployment Slots",
+			fields: fields{
+				rule: "app-005",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					AppServiceSlots: map[string]int{
+						"test": 1,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner No Deployment Slots",
+			fields: fields{
+				rule: "app-005",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner CAF",
+			fields: fields{
+				rule: "app-006",
+				target: &armappservice.Site{
+					Name: to.Ptr("app-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner HTTPS only",
+			fields: fields{
+				rule: "app-007",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						HTTPSOnly: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner HTTPS only not set",
+			fields: fields{
+				rule: "app-007",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Integration",
+			fields: fields{
+				rule: "app-009",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VirtualNetworkSubnetID: to.Ptr("test"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Integration disabled",
+			fields: fields{
+				rule: "app-009",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VirtualNetworkSubnetID: nil,
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route all",
+			fields: fields{
+				rule: "app-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route disabled",
+			fields: fields{
+				rule: "app-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route nil",
+			fields: fields{
+				rule: "app-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: nil,
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner HTTPS only without properties",
+			fields: fields{
+				rule:        "app-007",
+				target:      &armappservice.Site{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Integration without properties",
+			fields: fields{
+				rule:        "app-009",
+				target:      &armappservice.Site{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route all without properties",
+			fields: fields{
+				rule:        "app-010",
+				target:      &armappservice.Site{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner TLS 1.2",
+			fields: fields{
+				rule:   "app-011",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								MinTLSVersion: to.Ptr(armappservice.SupportedTLSVersionsOne2),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Remote Debugging",
+			fields: fields{
+				rule:   "app-012",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								RemoteDebuggingEnabled: to.Ptr(true),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Insecure FTP",
+			fields: fields{
+				rule:   "app-013",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								FtpsState: to.Ptr(armappservice.FtpsStateAllAllowed),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Always On",
+			fields: fields{
+				rule:   "app-014",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								AlwaysOn: to.Ptr(false),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner AlwaysOn on Consumption plan",
+			fields: fields{
+				rule: "app-014",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						ServerFarmID: to.Ptr("plan"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					AppServicePlans: map[string]*armappservice.Plan{
+						"plan": {
+							SKU: &armappservice.SKUDescription{
+								Tier: to.Ptr("Dynamic"),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Client Affinity Enabled",
+			fields: fields{
+				rule: "app-015",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						ClientAffinityEnabled: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Managed Identity None",
+			fields: fields{
+				rule:   "app-016",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								ManagedServiceIdentityID: nil,
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Managed Identity",
+			fields: fields{
+				rule:   "app-016",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								ManagedServiceIdentityID: to.Ptr(int32(1)),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Certificate expires within 30 days",
+			fields: fields{
+				rule: "app-017",
+				target: &armappservice.Site{
+					Name: to.Ptr("app"),
+				},
+				scanContext: &scanners.ScanContext{
+					AppServiceCertificates: map[string]time.Time{
+						"ABC": time.Now().Add(10*24*time.Hour + time.Hour),
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "10",
+			},
+		},
+		{
+			name: "AppServiceScanner Certificate does not expire within 30 days",
+			fields: fields{
+				rule: "app-017",
+				target: &armappservice.Site{
+					Name: to.Ptr("app"),
+				},
+				scanContext: &scanners.ScanContext{
+					AppServiceCertificates: map[string]time.Time{
+						"ABC": time.Now().Add(60*24*time.Hour + time.Hour),
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "60",
+			},
+		},
+		{
+			name: "AppServiceScanner First certificate to expire is reported",
+			fields: fields{
+				rule: "app-017",
+				target: &armappservice.Site{
+					Name: to.Ptr("app"),
+				},
+				scanContext: &scanners.ScanContext{
+					AppServiceCertificates: map[string]time.Time{
+						"ABC": time.Now().Add(60*24*time.Hour + time.Hour),
+						"DEF": time.Now().Add(2*24*time.Hour + time.Hour),
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "2",
+			},
+		},
+		{
+			name: "AppServiceScanner No bound certificates",
+			fields: fields{
+				rule: "app-017",
+				target: &armappservice.Site{
+					Name: to.Ptr("app"),
+				},
+				scanContext: &scanners.ScanContext{
+					AppServiceCertificates: map[string]time.Time{},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AppServiceScanner{}
+			rules := s.getAppRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AppServiceScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AppServiceScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppServiceScanner_FunctionRules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "AppServiceScanner DiagnosticSettings",
+			fields: fields{
+				rule: "func-001",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Private Endpoint",
+			fields: fields{
+				rule: "func-004",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					PrivateEndpoints: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner CAF",
+			fields: fields{
+				rule: "func-006",
+				target: &armappservice.Site{
+					Name: to.Ptr("func-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner HTTPS only",
+			fields: fields{
+				rule: "func-007",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						HTTPSOnly: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Integration",
+			fields: fields{
+				rule: "func-009",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VirtualNetworkSubnetID: to.Ptr("test"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Integration disabled",
+			fields: fields{
+				rule: "func-009",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VirtualNetworkSubnetID: nil,
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route all",
+			fields: fields{
+				rule: "func-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route disabled",
+			fields: fields{
+				rule: "func-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route nil",
+			fields: fields{
+				rule: "func-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: nil,
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner TLS 1.2",
+			fields: fields{
+				rule:   "func-011",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								MinTLSVersion: to.Ptr(armappservice.SupportedTLSVersionsOne2),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Remote Debugging",
+			fields: fields{
+				rule:   "func-012",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								RemoteDebuggingEnabled: to.Ptr(true),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Client Affinity Enabled",
+			fields: fields{
+				rule: "func-013",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						ClientAffinityEnabled: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Managed Identity None",
+			fields: fields{
+				rule:   "func-014",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								ManagedServiceIdentityID: nil,
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Managed Identity",
+			fields: fields{
+				rule:   "func-014",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								ManagedServiceIdentityID: to.Ptr(int32(1)),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AppServiceScanner{}
+			rules := s.getFunctionRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AppServiceScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AppServiceScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppServiceScanner_LogicRules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "AppServiceScanner DiagnosticSettings",
+			fields: fields{
+				rule: "logics-001",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Private Endpoint",
+			fields: fields{
+				rule: "logics-004",
+				target: &armappservice.Site{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					PrivateEndpoints: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner CAF",
+			fields: fields{
+				rule: "logics-006",
+				target: &armappservice.Site{
+					Name: to.Ptr("logics-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner HTTPS only",
+			fields: fields{
+				rule: "logics-007",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						HTTPSOnly: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Integration",
+			fields: fields{
+				rule: "logics-009",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VirtualNetworkSubnetID: to.Ptr("test"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Integration disabled",
+			fields: fields{
+				rule: "logics-009",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VirtualNetworkSubnetID: nil,
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route all",
+			fields: fields{
+				rule: "logics-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route disabled",
+			fields: fields{
+				rule: "logics-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner VNET Route nil",
+			fields: fields{
+				rule: "logics-010",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						VnetRouteAllEnabled: nil,
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner TLS 1.2",
+			fields: fields{
+				rule:   "logics-011",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								MinTLSVersion: to.Ptr(armappservice.SupportedTLSVersionsOne2),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Remote Debugging",
+			fields: fields{
+				rule:   "logics-012",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								RemoteDebuggingEnabled: to.Ptr(true),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Client Affinity Enabled",
+			fields: fields{
+				rule: "logics-013",
+				target: &armappservice.Site{
+					Properties: &armappservice.SiteProperties{
+						ClientAffinityEnabled: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Managed Identity None",
+			fields: fields{
+				rule:   "logics-014",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								ManagedServiceIdentityID: nil,
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AppServiceScanner Managed Identity",
+			fields: fields{
+				rule:   "logics-014",
+				target: &armappservice.Site{},
+				scanContext: &scanners.ScanContext{
+					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
+						SiteConfigResource: armappservice.SiteConfigResource{
+							Properties: &armappservice.SiteConfig{
+								ManagedServiceIdentityID: to.Ptr(int32(1)),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AppServiceScanner{}
+			rules := s.getLogicRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AppServiceScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AppServiceScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppServiceScanner_Certificates_NotAvailable(t *testing.T) {
+	s := &AppServiceScanner{}
+	rules := s.getAppRules()
+	_, _, err := rules["app-017"].Eval(context.Background(), &armappservice.Site{Name: to.Ptr("app")}, &scanners.ScanContext{})
+	if err == nil {
+		t.Error("AppServiceScanner Rule.Eval() error = nil, want an error when the certificates are not available")
+	}
+}
+
+func TestCertificateExpiryImpact(t *testing.T) {
+	tests := []struct {
+		result string
+		want   scanners.ImpactType
+	}{
+		{result: "0", want: scanners.ImpactHigh},
+		{result: "7", want: scanners.ImpactHigh},
+		{result: "8", want: scanners.ImpactMedium},
+		{result: "30", want: scanners.ImpactMedium},
+	}
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			if got := certificateExpiryImpact(tt.result); got != tt.want {
+				t.Errorf("certificateExpiryImpact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
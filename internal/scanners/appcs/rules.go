@@ -4,6 +4,7 @@
 package appcs
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,19 +19,22 @@ func (a *AppConfigurationScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "AppConfiguration should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armappconfiguration.ConfigurationStore)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-app-configuration/monitor-app-configuration?tabs=portal",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-app-configuration/monitor-app-configuration?tabs=portal",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"appcs-003": {
 			Id:             "appcs-003",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AppConfiguration should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				a := target.(*armappconfiguration.ConfigurationStore)
 				sku := strings.ToLower(*a.SKU.Name)
 				sla := "None"
@@ -38,7 +42,7 @@ func (a *AppConfigurationScanner) GetRules() map[string]scanners.AzureRule {
 					sla = "99.9%"
 				}
 
-				return sla == "None", sla
+				return sla == "None", sla, nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/app-configuration/",
 		},
@@ -47,10 +51,11 @@ func (a *AppConfigurationScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AppConfiguration should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				a := target.(*armappconfiguration.ConfigurationStore)
 				pe := len(a.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-app-configuration/concept-private-endpoint",
 		},
@@ -59,10 +64,10 @@ func (a *AppConfigurationScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "AppConfiguration SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				a := target.(*armappconfiguration.ConfigurationStore)
 				sku := string(*a.SKU.Name)
-				return false, sku
+				return false, sku, nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/app-configuration/",
 		},
@@ -71,10 +76,10 @@ func (a *AppConfigurationScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "AppConfiguration Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappconfiguration.ConfigurationStore)
 				caf := strings.HasPrefix(*c.Name, "appcs")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -83,33 +88,39 @@ func (a *AppConfigurationScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "AppConfiguration should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappconfiguration.ConfigurationStore)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"appcs-008": {
 			Id:             "appcs-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "AppConfiguration should have local authentication disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.AppConfiguration/configurationStores", "Microsoft.AppConfiguration/configurationStores/disableLocalAuth", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappconfiguration.ConfigurationStore)
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
-				return !localAuth, ""
+				return !localAuth, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-app-configuration/howto-disable-access-key-authentication?tabs=portal#disable-access-key-authentication",
+			Url:         "https://learn.microsoft.com/en-us/azure/azure-app-configuration/howto-disable-access-key-authentication?tabs=portal#disable-access-key-authentication",
+			Remediation: "az appconfig update --ids {resource_id} --disable-local-auth true",
 		},
 		"appcs-009": {
 			Id:             "appcs-009",
 			Category:       scanners.RulesCategoryDisasterRecovery,
 			Recommendation: "AppConfiguration should have purge protection enabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappconfiguration.ConfigurationStore)
 				purgeProtection := c.Properties.EnablePurgeProtection != nil && *c.Properties.EnablePurgeProtection
-				return !purgeProtection, ""
+				return !purgeProtection, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-app-configuration/concept-soft-delete#purge-protection",
 		},
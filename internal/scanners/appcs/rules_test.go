@@ -4,6 +4,7 @@
 package appcs
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -164,7 +165,10 @@ func TestAppConfigurationScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &AppConfigurationScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AppConfigurationScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -16,6 +16,9 @@ type AppInsightsScanner struct {
 
 // Init - Initializes the Application Insights Scanner
 func (a *AppInsightsScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.client, err = armapplicationinsights.NewComponentsClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
@@ -35,7 +38,7 @@ func (a *AppInsightsScanner) Scan(resourceGroupName string, scanContext *scanner
 	results := []scanners.AzureServiceResult{}
 
 	for _, g := range gateways {
-		rr := engine.EvaluateRules(rules, g, scanContext)
+		rr := engine.EvaluateRules(a.config.Ctx, rules, g, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
@@ -44,7 +47,9 @@ func (a *AppInsightsScanner) Scan(resourceGroupName string, scanContext *scanner
 			Location:         *g.Location,
 			Type:             *g.Type,
 			ServiceName:      *g.Name,
+			ID:               *g.ID,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(g),
 		})
 	}
 	return results, nil
@@ -4,6 +4,7 @@
 package appi
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,8 +19,8 @@ func (a *AppInsightsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Application Insights SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/application-insights/index.html",
 		},
@@ -28,10 +29,10 @@ func (a *AppInsightsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Application Insights Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapplicationinsights.Component)
 				caf := strings.HasPrefix(*c.Name, "appi")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -40,21 +41,24 @@ func (a *AppInsightsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Application Insights should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapplicationinsights.Component)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"appi-004": {
 			Id:             "appi-004",
 			Category:       scanners.RulesCategoryScalability,
 			Recommendation: "Azure Application Insights should store data in a Log Analytics Workspace",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armapplicationinsights.Component)
 
-				return c.Properties.WorkspaceResourceID == nil, ""
+				return c.Properties.WorkspaceResourceID == nil, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/app/create-workspace-resource",
 		},
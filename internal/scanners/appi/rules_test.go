@@ -4,6 +4,7 @@
 package appi
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -84,7 +85,10 @@ func TestAppInsightsScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &AppInsightsScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AppInsightsScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
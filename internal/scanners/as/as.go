@@ -16,6 +16,9 @@ type AnalysisServicesScanner struct {
 
 // Init - Initializes the AnalysisServicesScanner
 func (c *AnalysisServicesScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.client, err = armanalysisservices.NewServersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *AnalysisServicesScanner) Scan(resourceGroupName string, scanContext *sc
 	results := []scanners.AzureServiceResult{}
 
 	for _, ws := range workspaces {
-		rr := engine.EvaluateRules(rules, ws, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, ws, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *ws.Name,
+			ID:               *ws.ID,
 			Type:             *ws.Type,
 			Location:         *ws.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(ws),
 		})
 	}
 	return results, nil
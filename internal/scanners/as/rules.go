@@ -4,7 +4,10 @@
 package as
 
 import (
+	"context"
+
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/analysisservices/armanalysisservices"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,26 +21,29 @@ func (a *AnalysisServicesScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Azure Analysis Service should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armanalysisservices.Server)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/analysis-services/analysis-services-logging",
+			Url:             "https://learn.microsoft.com/en-us/azure/analysis-services/analysis-services-logging",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"as-002": {
 			Id:             "as-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Analysis Service should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armanalysisservices.Server)
 				sku := *i.SKU.Tier
 				sla := "None"
 				if sku != armanalysisservices.SKUTierDevelopment {
 					sla = "99.9%"
 				}
-				return sla == "None", sla
+				return sla == "None", sla, nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -46,9 +52,9 @@ func (a *AnalysisServicesScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Analysis Service SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armanalysisservices.Server)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/analysis-services/",
 		},
@@ -57,10 +63,10 @@ func (a *AnalysisServicesScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Analysis Service Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armanalysisservices.Server)
 				caf := strings.HasPrefix(*c.Name, "as")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -69,11 +75,66 @@ func (a *AnalysisServicesScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Analysis Service should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armanalysisservices.Server)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"as-006": {
+			Id:             "as-006",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "Azure Analysis Service should have backups configured",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armanalysisservices.Server)
+				backup := c.Properties != nil && c.Properties.BackupBlobContainerURI != nil && *c.Properties.BackupBlobContainerURI != ""
+				return !backup, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/analysis-services/analysis-services-backup",
+		},
+		"as-007": {
+			Id:             "as-007",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Analysis Service should have firewall rules configured",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armanalysisservices.Server)
+				firewall := c.Properties != nil && c.Properties.IPV4FirewallSettings != nil && len(c.Properties.IPV4FirewallSettings.FirewallRules) > 0
+				return !firewall, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/analysis-services/analysis-services-qs-firewall",
+		},
+		"as-008": {
+			Id:             "as-008",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Analysis Service should have less than 5 server administrators",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armanalysisservices.Server)
+				admins := 0
+				if c.Properties != nil && c.Properties.AsAdministrators != nil {
+					admins = len(c.Properties.AsAdministrators.Members)
+				}
+				return admins >= 5, strconv.Itoa(admins), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/analysis-services/analysis-services-server-admins",
+		},
+		"as-009": {
+			Id:             "as-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Analysis Service firewall should only allow access from Power BI when required",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armanalysisservices.Server)
-				return len(c.Tags) == 0, ""
+				powerBI := c.Properties != nil && c.Properties.IPV4FirewallSettings != nil &&
+					c.Properties.IPV4FirewallSettings.EnablePowerBIService != nil && *c.Properties.IPV4FirewallSettings.EnablePowerBIService
+				return powerBI, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url: "https://learn.microsoft.com/en-us/azure/analysis-services/analysis-services-qs-firewall",
 		},
 	}
 }
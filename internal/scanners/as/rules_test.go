@@ -4,6 +4,7 @@
 package as
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -108,12 +109,157 @@ func TestAnalysisServicesScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "AnalysisServicesScanner backup not configured",
+			fields: fields{
+				rule: "as-006",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AnalysisServicesScanner backup configured",
+			fields: fields{
+				rule: "as-006",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{
+						BackupBlobContainerURI: to.Ptr("https://st.blob.core.windows.net/backups"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AnalysisServicesScanner firewall without rules",
+			fields: fields{
+				rule: "as-007",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{
+						IPV4FirewallSettings: &armanalysisservices.IPv4FirewallSettings{},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AnalysisServicesScanner firewall with rules",
+			fields: fields{
+				rule: "as-007",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{
+						IPV4FirewallSettings: &armanalysisservices.IPv4FirewallSettings{
+							FirewallRules: []*armanalysisservices.IPv4FirewallRule{
+								{
+									FirewallRuleName: to.Ptr("office"),
+									RangeStart:       to.Ptr("10.0.0.1"),
+									RangeEnd:         to.Ptr("10.0.0.255"),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "AnalysisServicesScanner 5 administrators",
+			fields: fields{
+				rule: "as-008",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{
+						AsAdministrators: &armanalysisservices.ServerAdministrators{
+							Members: []*string{to.Ptr("a"), to.Ptr("b"), to.Ptr("c"), to.Ptr("d"), to.Ptr("e")},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "5",
+			},
+		},
+		{
+			name: "AnalysisServicesScanner 2 administrators",
+			fields: fields{
+				rule: "as-008",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{
+						AsAdministrators: &armanalysisservices.ServerAdministrators{
+							Members: []*string{to.Ptr("a"), to.Ptr("b")},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "2",
+			},
+		},
+		{
+			name: "AnalysisServicesScanner Power BI service enabled",
+			fields: fields{
+				rule: "as-009",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{
+						IPV4FirewallSettings: &armanalysisservices.IPv4FirewallSettings{
+							EnablePowerBIService: to.Ptr(true),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "AnalysisServicesScanner Power BI service disabled",
+			fields: fields{
+				rule: "as-009",
+				target: &armanalysisservices.Server{
+					Properties: &armanalysisservices.ServerProperties{
+						IPV4FirewallSettings: &armanalysisservices.IPv4FirewallSettings{
+							EnablePowerBIService: to.Ptr(false),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &AnalysisServicesScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AnalysisServicesScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
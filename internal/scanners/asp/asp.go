@@ -4,36 +4,29 @@
 package asp
 
 import (
-	"strings"
-
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
 )
 
-// AppServiceScanner - Scanner for App Service Plans
-type AppServiceScanner struct {
+// AppServicePlanScanner - Scanner for App Service Plans
+type AppServicePlanScanner struct {
 	config      *scanners.ScannerConfig
 	plansClient *armappservice.PlansClient
-	sitesClient *armappservice.WebAppsClient
 }
 
-// Init - Initializes the AppServiceScanner
-func (a *AppServiceScanner) Init(config *scanners.ScannerConfig) error {
+// Init - Initializes the AppServicePlanScanner
+func (a *AppServicePlanScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.plansClient, err = armappservice.NewPlansClient(config.SubscriptionID, config.Cred, config.ClientOptions)
-	if err != nil {
-		return err
-	}
-	a.sitesClient, err = armappservice.NewWebAppsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
-	if err != nil {
-		return err
-	}
-	return nil
+	return err
 }
 
 // Scan - Scans all App Service Plans in a Resource Group
-func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+func (a *AppServicePlanScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
 	scanners.LogResourceGroupScan(a.config.SubscriptionID, resourceGroupName, "App Service Plan")
 
 	plan, err := a.listPlans(resourceGroupName)
@@ -42,85 +35,27 @@ func (a *AppServiceScanner) Scan(resourceGroupName string, scanContext *scanners
 	}
 	engine := scanners.RuleEngine{}
 	rules := a.getPlanRules()
-	appRules := a.getAppRules()
-	functionRules := a.getFunctionRules()
-	logicRules := a.getLogicRules()
 	results := []scanners.AzureServiceResult{}
 
 	for _, p := range plan {
-		rr := engine.EvaluateRules(rules, p, scanContext)
+		rr := engine.EvaluateRules(a.config.Ctx, rules, p, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
 			SubscriptionName: a.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *p.Name,
+			ID:               *p.ID,
 			Type:             *p.Type,
 			Location:         *p.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(p),
 		})
-
-		sites, err := a.listSites(resourceGroupName, *p.Name)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, s := range sites {
-			config, err := a.sitesClient.GetConfiguration(a.config.Ctx, resourceGroupName, *s.Name, nil)
-			if err != nil {
-				return nil, err
-			}
-			scanContext.SiteConfig = &config
-
-			var result scanners.AzureServiceResult
-			// https://learn.microsoft.com/en-us/azure/azure-functions/functions-app-settings
-			kind := strings.ToLower(*s.Kind)
-			switch kind {
-			case "functionapp,linux", "functionapp":
-				rr := engine.EvaluateRules(functionRules, s, scanContext)
-
-				result = scanners.AzureServiceResult{
-					SubscriptionID:   a.config.SubscriptionID,
-					SubscriptionName: a.config.SubscriptionName,
-					ResourceGroup:    resourceGroupName,
-					ServiceName:      *s.Name,
-					Type:             *s.Type,
-					Location:         *p.Location,
-					Rules:            rr,
-				}
-			case "functionapp,workflowapp":
-				rr := engine.EvaluateRules(logicRules, s, scanContext)
-
-				result = scanners.AzureServiceResult{
-					SubscriptionID:   a.config.SubscriptionID,
-					SubscriptionName: a.config.SubscriptionName,
-					ResourceGroup:    resourceGroupName,
-					ServiceName:      *s.Name,
-					Type:             *s.Type,
-					Location:         *p.Location,
-					Rules:            rr,
-				}
-			default:
-				rr := engine.EvaluateRules(appRules, s, scanContext)
-				result = scanners.AzureServiceResult{
-					SubscriptionID:   a.config.SubscriptionID,
-					SubscriptionName: a.config.SubscriptionName,
-					ResourceGroup:    resourceGroupName,
-					ServiceName:      *s.Name,
-					Type:             *s.Type,
-					Location:         *p.Location,
-					Rules:            rr,
-				}
-			}
-
-			results = append(results, result)
-		}
-
 	}
 	return results, nil
 }
 
-func (a *AppServiceScanner) listPlans(resourceGroupName string) ([]*armappservice.Plan, error) {
+func (a *AppServicePlanScanner) listPlans(resourceGroupName string) ([]*armappservice.Plan, error) {
 	pager := a.plansClient.NewListByResourceGroupPager(resourceGroupName, nil)
 	results := []*armappservice.Plan{}
 	for pager.More() {
@@ -133,16 +68,3 @@ func (a *AppServiceScanner) listPlans(resourceGroupName string) ([]*armappservic
 
 	return results, nil
 }
-
-func (a *AppServiceScanner) listSites(resourceGroupName string, plan string) ([]*armappservice.Site, error) {
-	pager := a.plansClient.NewListWebAppsPager(resourceGroupName, plan, nil)
-	results := []*armappservice.Site{}
-	for pager.More() {
-		resp, err := pager.NextPage(a.config.Ctx)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, resp.Value...)
-	}
-	return results, nil
-}
@@ -4,6 +4,7 @@
 package asp
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -28,7 +29,7 @@ func TestAppServiceScanner_Rules(t *testing.T) {
 		want   want
 	}{
 		{
-			name: "AppServiceScanner DiagnosticSettings",
+			name: "AppServicePlanScanner DiagnosticSettings",
 			fields: fields{
 				rule: "asp-001",
 				target: &armappservice.Plan{
@@ -46,7 +47,7 @@ func TestAppServiceScanner_Rules(t *testing.T) {
 			},
 		},
 		{
-			name: "AppServiceScanner Availability Zones",
+			name: "AppServicePlanScanner Availability Zones",
 			fields: fields{
 				rule: "asp-002",
 				target: &armappservice.Plan{
@@ -62,7 +63,7 @@ func TestAppServiceScanner_Rules(t *testing.T) {
 			},
 		},
 		{
-			name: "AppServiceScanner SLA None",
+			name: "AppServicePlanScanner SLA None",
 			fields: fields{
 				rule: "asp-003",
 				target: &armappservice.Plan{
@@ -78,7 +79,7 @@ func TestAppServiceScanner_Rules(t *testing.T) {
 			},
 		},
 		{
-			name: "AppServiceScanner SLA 99.95%",
+			name: "AppServicePlanScanner SLA 99.95%",
 			fields: fields{
 				rule: "asp-003",
 				target: &armappservice.Plan{
@@ -94,7 +95,7 @@ func TestAppServiceScanner_Rules(t *testing.T) {
 			},
 		},
 		{
-			name: "AppServiceScanner SKU",
+			name: "AppServicePlanScanner SKU",
 			fields: fields{
 				rule: "asp-005",
 				target: &armappservice.Plan{
@@ -110,504 +111,58 @@ func TestAppServiceScanner_Rules(t *testing.T) {
 			},
 		},
 		{
-			name: "AppServiceScanner CAF",
+			name: "AppServicePlanScanner SKU Premium v3",
 			fields: fields{
-				rule: "asp-006",
+				rule: "asp-005",
 				target: &armappservice.Plan{
-					Name: to.Ptr("asp-test"),
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &AppServiceScanner{}
-			rules := s.getPlanRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
-			got := want{
-				broken: b,
-				result: w,
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("AppServiceScanner Rule.Eval() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestAppServiceScanner_AppRules(t *testing.T) {
-	type fields struct {
-		rule        string
-		target      interface{}
-		scanContext *scanners.ScanContext
-	}
-	type want struct {
-		broken bool
-		result string
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
-	}{
-		{
-			name: "AppServiceScanner DiagnosticSettings",
-			fields: fields{
-				rule: "app-001",
-				target: &armappservice.Site{
-					ID: to.Ptr("test"),
-				},
-				scanContext: &scanners.ScanContext{
-					DiagnosticsSettings: map[string]bool{
-						"test": true,
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Private Endpoint",
-			fields: fields{
-				rule: "app-004",
-				target: &armappservice.Site{
-					ID: to.Ptr("test"),
-				},
-				scanContext: &scanners.ScanContext{
-					PrivateEndpoints: map[string]bool{
-						"test": true,
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner CAF",
-			fields: fields{
-				rule: "app-006",
-				target: &armappservice.Site{
-					Name: to.Ptr("app-test"),
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner HTTPS only",
-			fields: fields{
-				rule: "app-007",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						HTTPSOnly: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Integration",
-			fields: fields{
-				rule: "app-009",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VirtualNetworkSubnetID: to.Ptr("test"),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Integration disabled",
-			fields: fields{
-				rule: "app-009",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VirtualNetworkSubnetID: nil,
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Route all",
-			fields: fields{
-				rule: "app-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Route disabled",
-			fields: fields{
-				rule: "app-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: to.Ptr(false),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Route nil",
-			fields: fields{
-				rule: "app-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: nil,
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner TLS 1.2",
-			fields: fields{
-				rule:   "app-011",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								MinTLSVersion: to.Ptr(armappservice.SupportedTLSVersionsOne2),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Remote Debugging",
-			fields: fields{
-				rule:   "app-012",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								RemoteDebuggingEnabled: to.Ptr(true),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Insecure FTP",
-			fields: fields{
-				rule:   "app-013",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								FtpsState: to.Ptr(armappservice.FtpsStateAllAllowed),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Always On",
-			fields: fields{
-				rule:   "app-014",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								AlwaysOn: to.Ptr(false),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Client Affinity Enabled",
-			fields: fields{
-				rule: "app-015",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						ClientAffinityEnabled: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Managed Identity None",
-			fields: fields{
-				rule: "app-016",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								ManagedServiceIdentityID: nil,
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Managed Identity",
-			fields: fields{
-				rule: "app-016",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								ManagedServiceIdentityID: to.Ptr(int32(1)),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &AppServiceScanner{}
-			rules := s.getAppRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
-			got := want{
-				broken: b,
-				result: w,
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("AppServiceScanner Rule.Eval() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
-func TestAppServiceScanner_FunctionRules(t *testing.T) {
-	type fields struct {
-		rule        string
-		target      interface{}
-		scanContext *scanners.ScanContext
-	}
-	type want struct {
-		broken bool
-		result string
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
-	}{
-		{
-			name: "AppServiceScanner DiagnosticSettings",
-			fields: fields{
-				rule: "func-001",
-				target: &armappservice.Site{
-					ID: to.Ptr("test"),
-				},
-				scanContext: &scanners.ScanContext{
-					DiagnosticsSettings: map[string]bool{
-						"test": true,
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Private Endpoint",
-			fields: fields{
-				rule: "func-004",
-				target: &armappservice.Site{
-					ID: to.Ptr("test"),
-				},
-				scanContext: &scanners.ScanContext{
-					PrivateEndpoints: map[string]bool{
-						"test": true,
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner CAF",
-			fields: fields{
-				rule: "func-006",
-				target: &armappservice.Site{
-					Name: to.Ptr("func-test"),
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner HTTPS only",
-			fields: fields{
-				rule: "func-007",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						HTTPSOnly: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Integration",
-			fields: fields{
-				rule: "func-009",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VirtualNetworkSubnetID: to.Ptr("test"),
+					SKU: &armappservice.SKUDescription{
+						Name: to.Ptr("P1v3"),
 					},
 				},
 				scanContext: &scanners.ScanContext{},
 			},
 			want: want{
 				broken: false,
-				result: "",
+				result: "P1v3",
 			},
 		},
 		{
-			name: "AppServiceScanner VNET Integration disabled",
+			name: "AppServicePlanScanner SKU Premium v2",
 			fields: fields{
-				rule: "func-009",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VirtualNetworkSubnetID: nil,
+				rule: "asp-005",
+				target: &armappservice.Plan{
+					SKU: &armappservice.SKUDescription{
+						Name: to.Ptr("P1v2"),
 					},
 				},
 				scanContext: &scanners.ScanContext{},
 			},
 			want: want{
 				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Route all",
-			fields: fields{
-				rule: "func-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
+				result: "P1v2",
 			},
 		},
 		{
-			name: "AppServiceScanner VNET Route disabled",
+			name: "AppServicePlanScanner SKU Standard",
 			fields: fields{
-				rule: "func-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: to.Ptr(false),
+				rule: "asp-005",
+				target: &armappservice.Plan{
+					SKU: &armappservice.SKUDescription{
+						Name: to.Ptr("S1"),
 					},
 				},
 				scanContext: &scanners.ScanContext{},
 			},
 			want: want{
 				broken: true,
-				result: "",
+				result: "S1",
 			},
 		},
 		{
-			name: "AppServiceScanner VNET Route nil",
+			name: "AppServicePlanScanner Availability Zones not set",
 			fields: fields{
-				rule: "func-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: nil,
-					},
-				},
+				rule:        "asp-002",
+				target:      &armappservice.Plan{},
 				scanContext: &scanners.ScanContext{},
 			},
 			want: want{
@@ -616,96 +171,14 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 			},
 		},
 		{
-			name: "AppServiceScanner TLS 1.2",
-			fields: fields{
-				rule:   "func-011",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								MinTLSVersion: to.Ptr(armappservice.SupportedTLSVersionsOne2),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Remote Debugging",
-			fields: fields{
-				rule:   "func-012",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								RemoteDebuggingEnabled: to.Ptr(true),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Client Affinity Enabled",
+			name: "AppServicePlanScanner CAF",
 			fields: fields{
-				rule: "func-013",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						ClientAffinityEnabled: to.Ptr(true),
-					},
+				rule: "asp-006",
+				target: &armappservice.Plan{
+					Name: to.Ptr("asp-test"),
 				},
 				scanContext: &scanners.ScanContext{},
 			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Managed Identity None",
-			fields: fields{
-				rule: "func-014",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								ManagedServiceIdentityID: nil,
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Managed Identity",
-			fields: fields{
-				rule: "func-014",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								ManagedServiceIdentityID: to.Ptr(int32(1)),
-							},
-						},
-					},
-				},
-			},
 			want: want{
 				broken: false,
 				result: "",
@@ -714,290 +187,36 @@ func TestAppServiceScanner_FunctionRules(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &AppServiceScanner{}
-			rules := s.getFunctionRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			s := &AppServicePlanScanner{}
+			rules := s.getPlanRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("AppServicePlanScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("AppServiceScanner Rule.Eval() = %v, want %v", got, tt.want)
+				t.Errorf("AppServicePlanScanner Rule.Eval() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestAppServiceScanner_LogicRules(t *testing.T) {
-	type fields struct {
-		rule        string
-		target      interface{}
-		scanContext *scanners.ScanContext
-	}
-	type want struct {
-		broken bool
-		result string
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
-	}{
-		{
-			name: "AppServiceScanner DiagnosticSettings",
-			fields: fields{
-				rule: "logics-001",
-				target: &armappservice.Site{
-					ID: to.Ptr("test"),
-				},
-				scanContext: &scanners.ScanContext{
-					DiagnosticsSettings: map[string]bool{
-						"test": true,
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Private Endpoint",
-			fields: fields{
-				rule: "logics-004",
-				target: &armappservice.Site{
-					ID: to.Ptr("test"),
-				},
-				scanContext: &scanners.ScanContext{
-					PrivateEndpoints: map[string]bool{
-						"test": true,
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner CAF",
-			fields: fields{
-				rule: "logics-006",
-				target: &armappservice.Site{
-					Name: to.Ptr("logics-test"),
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner HTTPS only",
-			fields: fields{
-				rule: "logics-007",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						HTTPSOnly: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Integration",
-			fields: fields{
-				rule: "logics-009",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VirtualNetworkSubnetID: to.Ptr("test"),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Integration disabled",
-			fields: fields{
-				rule: "logics-009",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VirtualNetworkSubnetID: nil,
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Route all",
-			fields: fields{
-				rule: "logics-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Route disabled",
-			fields: fields{
-				rule: "logics-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: to.Ptr(false),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner VNET Route nil",
-			fields: fields{
-				rule: "logics-010",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						VnetRouteAllEnabled: nil,
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner TLS 1.2",
-			fields: fields{
-				rule:   "logics-011",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								MinTLSVersion: to.Ptr(armappservice.SupportedTLSVersionsOne2),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Remote Debugging",
-			fields: fields{
-				rule:   "logics-012",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								RemoteDebuggingEnabled: to.Ptr(true),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Client Affinity Enabled",
-			fields: fields{
-				rule: "logics-013",
-				target: &armappservice.Site{
-					Properties: &armappservice.SiteProperties{
-						ClientAffinityEnabled: to.Ptr(true),
-					},
-				},
-				scanContext: &scanners.ScanContext{},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Managed Identity None",
-			fields: fields{
-				rule: "logics-014",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								ManagedServiceIdentityID: nil,
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: true,
-				result: "",
-			},
-		},
-		{
-			name: "AppServiceScanner Managed Identity",
-			fields: fields{
-				rule: "logics-014",
-				target: &armappservice.Site{},
-				scanContext: &scanners.ScanContext{
-					SiteConfig: &armappservice.WebAppsClientGetConfigurationResponse{
-						SiteConfigResource: armappservice.SiteConfigResource{
-							Properties: &armappservice.SiteConfig{
-								ManagedServiceIdentityID: to.Ptr(int32(1)),
-							},
-						},
-					},
-				},
-			},
-			want: want{
-				broken: false,
-				result: "",
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &AppServiceScanner{}
-			rules := s.getLogicRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
-			got := want{
-				broken: b,
-				result: w,
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("AppServiceScanner Rule.Eval() = %v, want %v", got, tt.want)
+func TestAppServiceScanner_SKU_NotAvailable(t *testing.T) {
+	s := &AppServicePlanScanner{}
+	rules := s.GetRules()
+	plans := []*armappservice.Plan{
+		{Name: to.Ptr("asp")},
+		{Name: to.Ptr("asp"), SKU: &armappservice.SKUDescription{}},
+	}
+	for _, rule := range []string{"asp-003", "asp-005"} {
+		for _, plan := range plans {
+			_, _, err := rules[rule].Eval(context.Background(), plan, &scanners.ScanContext{})
+			if err == nil {
+				t.Errorf("AppServicePlanScanner Rule.Eval() %s error = nil, want an error when the SKU is not available", rule)
 			}
-		})
+		}
 	}
 }
@@ -0,0 +1,70 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package bas
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+)
+
+// BastionScanner - Scanner for Azure Bastion
+type BastionScanner struct {
+	config *scanners.ScannerConfig
+	client *armnetwork.BastionHostsClient
+}
+
+// Init - Initializes the BastionScanner
+func (c *BastionScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	c.config = config
+	var err error
+	c.client, err = armnetwork.NewBastionHostsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all Azure Bastion hosts in a Resource Group
+func (c *BastionScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(c.config.SubscriptionID, resourceGroupName, "Azure Bastion")
+
+	bastions, err := c.listBastions(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := c.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, w := range bastions {
+		rr := engine.EvaluateRules(c.config.Ctx, rules, w, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   c.config.SubscriptionID,
+			SubscriptionName: c.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *w.Name,
+			ID:               *w.ID,
+			Type:             *w.Type,
+			Location:         *w.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(w),
+		})
+	}
+	return results, nil
+}
+
+func (c *BastionScanner) listBastions(resourceGroupName string) ([]*armnetwork.BastionHost, error) {
+	pager := c.client.NewListByResourceGroupPager(resourceGroupName, nil)
+
+	bastions := make([]*armnetwork.BastionHost, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		bastions = append(bastions, resp.Value...)
+	}
+	return bastions, nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package bas
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+)
+
+// GetRules - Returns the rules for the BastionScanner
+func (c *BastionScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"bas-001": {
+			Id:             "bas-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Azure Bastion should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armnetwork.BastionHost)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/bastion/monitor-bastion",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"bas-006": {
+			Id:             "bas-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Azure Bastion Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.BastionHost)
+				caf := strings.HasPrefix(*c.Name, "bas")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"bas-007": {
+			Id:             "bas-007",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Azure Bastion Standard should send BastionAuditLogs to a diagnostic setting",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.BastionHost)
+				if c.SKU == nil || c.SKU.Name == nil || *c.SKU.Name != armnetwork.BastionHostSKUNameStandard {
+					return false, "", nil
+				}
+				return !hasAuditLogs(scanContext.DiagnosticsSettingsCategories[strings.ToLower(*c.ID)]), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/bastion/diagnostic-logs",
+		},
+		"bas-008": {
+			Id:             "bas-008",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Bastion should have shareable links disabled",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.BastionHost)
+				enabled := c.Properties != nil && c.Properties.EnableShareableLink != nil && *c.Properties.EnableShareableLink
+				return enabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/bastion/shareable-link",
+		},
+	}
+}
+
+// hasAuditLogs - Returns true if one of the enabled log categories collects BastionAuditLogs,
+// also collected by the allLogs and audit category groups
+func hasAuditLogs(categories []string) bool {
+	for _, c := range categories {
+		if strings.EqualFold(c, "BastionAuditLogs") || strings.EqualFold(c, "allLogs") || strings.EqualFold(c, "audit") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package bas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+)
+
+func TestBastionScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "BastionScanner DiagnosticSettings",
+			fields: fields{
+				rule: "bas-001",
+				target: &armnetwork.BastionHost{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner CAF",
+			fields: fields{
+				rule: "bas-006",
+				target: &armnetwork.BastionHost{
+					Name: to.Ptr("bas-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner Standard without audit logs",
+			fields: fields{
+				rule: "bas-007",
+				target: &armnetwork.BastionHost{
+					ID: to.Ptr("test"),
+					SKU: &armnetwork.SKU{
+						Name: to.Ptr(armnetwork.BastionHostSKUNameStandard),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"VPNGatewayLogs"},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner Standard with audit logs",
+			fields: fields{
+				rule: "bas-007",
+				target: &armnetwork.BastionHost{
+					ID: to.Ptr("test"),
+					SKU: &armnetwork.SKU{
+						Name: to.Ptr(armnetwork.BastionHostSKUNameStandard),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"allLogs"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner Basic audit logs",
+			fields: fields{
+				rule: "bas-007",
+				target: &armnetwork.BastionHost{
+					ID: to.Ptr("test"),
+					SKU: &armnetwork.SKU{
+						Name: to.Ptr(armnetwork.BastionHostSKUNameBasic),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner shareable link enabled",
+			fields: fields{
+				rule: "bas-008",
+				target: &armnetwork.BastionHost{
+					Properties: &armnetwork.BastionHostPropertiesFormat{
+						EnableShareableLink: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "BastionScanner shareable link disabled",
+			fields: fields{
+				rule: "bas-008",
+				target: &armnetwork.BastionHost{
+					Properties: &armnetwork.BastionHostPropertiesFormat{
+						EnableShareableLink: to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &BastionScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("BastionScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BastionScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
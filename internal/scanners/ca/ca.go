@@ -16,6 +16,9 @@ type ContainerAppsScanner struct {
 
 // Init - Initializes the ContainerAppsScanner
 func (a *ContainerAppsScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.appsClient, err = armappcontainers.NewContainerAppsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (a *ContainerAppsScanner) Scan(resourceGroupName string, scanContext *scann
 	results := []scanners.AzureServiceResult{}
 
 	for _, app := range apps {
-		rr := engine.EvaluateRules(rules, app, scanContext)
+		rr := engine.EvaluateRules(a.config.Ctx, rules, app, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
 			SubscriptionName: a.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *app.Name,
+			ID:               *app.ID,
 			Type:             *app.Type,
 			Location:         *app.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(app),
 		})
 	}
 	return results, nil
@@ -4,6 +4,7 @@
 package ca
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,8 +19,8 @@ func (a *ContainerAppsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerApp should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.95%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.95%", nil
 			},
 			Url: "https://azure.microsoft.com/en-us/support/legal/sla/container-apps/v1_0/",
 		},
@@ -28,10 +29,10 @@ func (a *ContainerAppsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "ContainerApp Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ContainerApp)
 				caf := strings.HasPrefix(*c.Name, "ca")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -40,23 +41,26 @@ func (a *ContainerAppsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "ContainerApp should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ContainerApp)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"ca-008": {
 			Id:             "ca-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "ContainerApp should not allow insecure ingress traffic",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ContainerApp)
 				if c.Properties.Configuration != nil && c.Properties.Configuration.Ingress != nil && c.Properties.Configuration.Ingress.AllowInsecure != nil {
-					return *c.Properties.Configuration.Ingress.AllowInsecure, ""
+					return *c.Properties.Configuration.Ingress.AllowInsecure, "", nil
 				}
-				return false, ""
+				return false, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-apps/ingress-how-to?pivots=azure-cli",
 		},
@@ -65,9 +69,10 @@ func (a *ContainerAppsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "ContainerApp should use Managed Identities",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ContainerApp)
-				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armappcontainers.ManagedServiceIdentityTypeNone, ""
+				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armappcontainers.ManagedServiceIdentityTypeNone, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-apps/managed-identity?tabs=portal%2Cdotnet",
 		},
@@ -76,7 +81,7 @@ func (a *ContainerAppsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerApp should use Azure Files to persist container data",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ContainerApp)
 				ok := true
 				if c.Properties.Template != nil && c.Properties.Template.Volumes != nil {
@@ -87,7 +92,7 @@ func (a *ContainerAppsScanner) GetRules() map[string]scanners.AzureRule {
 					}
 				}
 
-				return !ok, ""
+				return !ok, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-apps/storage-mounts?pivots=azure-cli",
 		},
@@ -96,13 +101,13 @@ func (a *ContainerAppsScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerApp should avoid using session affinity",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ContainerApp)
 				return c.Properties.Configuration != nil &&
 					c.Properties.Configuration.Ingress != nil &&
 					c.Properties.Configuration.Ingress.StickySessions != nil &&
 					c.Properties.Configuration.Ingress.StickySessions.Affinity != nil &&
-					*c.Properties.Configuration.Ingress.StickySessions.Affinity == armappcontainers.AffinitySticky, ""
+					*c.Properties.Configuration.Ingress.StickySessions.Affinity == armappcontainers.AffinitySticky, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-apps/sticky-sessions?pivots=azure-portal",
 		},
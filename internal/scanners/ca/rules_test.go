@@ -4,6 +4,7 @@
 package ca
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -160,7 +161,10 @@ func TestContainerAppsScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ContainerAppsScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ContainerAppsScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -4,6 +4,7 @@
 package cae
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,22 +19,25 @@ func (a *ContainerAppsEnvironmentScanner) GetRules() map[string]scanners.AzureRu
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Container Apps Environment should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armappcontainers.ManagedEnvironment)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/container-apps/log-options#diagnostic-settings",
+			Url:             "https://learn.microsoft.com/en-us/azure/container-apps/log-options#diagnostic-settings",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"cae-002": {
 			Id:             "cae-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Container Apps Environment should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				app := target.(*armappcontainers.ManagedEnvironment)
 				zones := *app.Properties.ZoneRedundant
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-apps/disaster-recovery?tabs=bash#set-up-zone-redundancy-in-your-container-apps-environment",
 		},
@@ -42,8 +46,8 @@ func (a *ContainerAppsEnvironmentScanner) GetRules() map[string]scanners.AzureRu
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Container Apps Environment should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.95%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.95%", nil
 			},
 			Url: "https://azure.microsoft.com/en-us/support/legal/sla/container-apps/v1_0/",
 		},
@@ -52,10 +56,11 @@ func (a *ContainerAppsEnvironmentScanner) GetRules() map[string]scanners.AzureRu
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Container Apps Environment should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				app := target.(*armappcontainers.ManagedEnvironment)
 				pe := app.Properties.VnetConfiguration != nil && *app.Properties.VnetConfiguration.Internal
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-apps/vnet-custom-internal?tabs=bash&pivots=azure-portal",
 		},
@@ -64,10 +69,10 @@ func (a *ContainerAppsEnvironmentScanner) GetRules() map[string]scanners.AzureRu
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Container Apps Environment Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ManagedEnvironment)
 				caf := strings.HasPrefix(*c.Name, "cae")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -76,11 +81,14 @@ func (a *ContainerAppsEnvironmentScanner) GetRules() map[string]scanners.AzureRu
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Container Apps Environment should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armappcontainers.ManagedEnvironment)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 	}
 }
@@ -4,6 +4,7 @@
 package cae
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -126,7 +127,10 @@ func TestContainerAppsEnvironmentScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ContainerAppsEnvironmentScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ContainerAppsEnvironmentScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
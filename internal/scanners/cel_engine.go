@@ -0,0 +1,123 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+type (
+	// CustomRuleDefinition - Struct for a custom rule defined in the YAML config
+	CustomRuleDefinition struct {
+		Id             string   `yaml:"id"`
+		Category       string   `yaml:"category"`
+		Recommendation string   `yaml:"recommendation"`
+		Impact         string   `yaml:"impact"`
+		Url            string   `yaml:"url"`
+		ResourceTypes  []string `yaml:"resourceTypes,flow"`
+		Expr           string   `yaml:"expr"`
+	}
+
+	// CELRuleEngine - Evaluates custom rules written as CEL expressions
+	CELRuleEngine struct {
+		rules []celRule
+	}
+
+	celRule struct {
+		definition    CustomRuleDefinition
+		resourceTypes map[string]bool
+		program       cel.Program
+	}
+)
+
+// NewCELRuleEngine - Compiles the CEL expressions of the custom rules.
+// The ARM resource is available in the expressions as the "resource" variable.
+func NewCELRuleEngine(definitions []CustomRuleDefinition) (*CELRuleEngine, error) {
+	env, err := cel.NewEnv(cel.Variable("resource", cel.MapType(cel.StringType, cel.DynType)))
+	if err != nil {
+		return nil, err
+	}
+
+	engine := &CELRuleEngine{}
+	for _, d := range definitions {
+		if d.Id == "" {
+			return nil, fmt.Errorf("custom rule without id")
+		}
+		ast, issues := env.Compile(d.Expr)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("failed to compile custom rule %s: %w", d.Id, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return nil, fmt.Errorf("custom rule %s expression must return a bool, got %s", d.Id, ast.OutputType())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile custom rule %s: %w", d.Id, err)
+		}
+
+		resourceTypes := map[string]bool{}
+		for _, t := range d.ResourceTypes {
+			resourceTypes[strings.ToLower(t)] = true
+		}
+		engine.rules = append(engine.rules, celRule{
+			definition:    d,
+			resourceTypes: resourceTypes,
+			program:       program,
+		})
+	}
+	return engine, nil
+}
+
+// Rules - Returns the custom rules applicable to the resource type
+func (e *CELRuleEngine) Rules(resourceType string) map[string]AzureRule {
+	rules := map[string]AzureRule{}
+	if e == nil {
+		return rules
+	}
+	for _, r := range e.rules {
+		if len(r.resourceTypes) > 0 && !r.resourceTypes[strings.ToLower(resourceType)] {
+			continue
+		}
+		program := r.program
+		rules[r.definition.Id] = AzureRule{
+			Id:             r.definition.Id,
+			Category:       RulesCategory(r.definition.Category),
+			Recommendation: r.definition.Recommendation,
+			Impact:         ImpactType(r.definition.Impact),
+			Url:            r.definition.Url,
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				return evalCEL(program, target)
+			},
+		}
+	}
+	return rules
+}
+
+// evalCEL - Serializes the target to JSON and fires the rule when the expression evaluates to true
+func evalCEL(program cel.Program, target interface{}) (bool, string, error) {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return false, "", err
+	}
+	resource := map[string]interface{}{}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return false, "", err
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{"resource": resource})
+	if err != nil {
+		return false, "", err
+	}
+	broken, ok := out.(types.Bool)
+	if !ok {
+		return false, "", fmt.Errorf("expression returned %v instead of a bool", out.Type())
+	}
+	return bool(broken), "", nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+)
+
+func TestCELRuleEngine_Rules(t *testing.T) {
+	plan := &armappservice.Plan{
+		Name: to.Ptr("plan-prod"),
+		Type: to.Ptr("Microsoft.Web/serverfarms"),
+		SKU: &armappservice.SKUDescription{
+			Capacity: to.Ptr(int32(1)),
+		},
+		Properties: &armappservice.PlanProperties{
+			ZoneRedundant: to.Ptr(false),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "bool field",
+			expr: "!resource.properties.zoneRedundant",
+			want: true,
+		},
+		{
+			name: "string prefix",
+			expr: "!resource.name.startsWith('asp')",
+			want: true,
+		},
+		{
+			name: "integer comparison",
+			expr: "resource.sku.capacity < 2",
+			want: true,
+		},
+		{
+			name: "integer comparison not broken",
+			expr: "resource.sku.capacity > 1",
+			want: false,
+		},
+		{
+			name:    "missing field",
+			expr:    "resource.properties.perSiteScaling",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewCELRuleEngine([]CustomRuleDefinition{
+				{
+					Id:             "custom-001",
+					Category:       string(RulesCategoryGovernance),
+					Recommendation: "Custom recommendation",
+					Impact:         string(ImpactLow),
+					ResourceTypes:  []string{"microsoft.web/serverfarms"},
+					Expr:           tt.expr,
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewCELRuleEngine() error = %v", err)
+			}
+
+			rules := engine.Rules(*plan.Type)
+			rule, ok := rules["custom-001"]
+			if !ok {
+				t.Fatalf("CELRuleEngine.Rules() custom-001 not found")
+			}
+			broken, _, err := rule.Eval(context.Background(), plan, &ScanContext{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Eval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if broken != tt.want {
+				t.Errorf("Eval() = %v, want %v", broken, tt.want)
+			}
+
+			if len(engine.Rules("Microsoft.Storage/storageAccounts")) != 0 {
+				t.Errorf("CELRuleEngine.Rules() returned rules for another resource type")
+			}
+		})
+	}
+}
+
+func TestNewCELRuleEngine_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{
+			name: "invalid syntax",
+			expr: "resource.name ==",
+		},
+		{
+			name: "not a bool",
+			expr: "'azqr'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCELRuleEngine([]CustomRuleDefinition{{Id: "custom-001", Expr: tt.expr}})
+			if err == nil {
+				t.Errorf("NewCELRuleEngine() expected error")
+			}
+		})
+	}
+}
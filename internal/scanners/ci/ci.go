@@ -16,6 +16,9 @@ type ContainerInstanceScanner struct {
 
 // Init - Initializes the ContainerInstanceScanner
 func (c *ContainerInstanceScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.instancesClient, err = armcontainerinstance.NewContainerGroupsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *ContainerInstanceScanner) Scan(resourceGroupName string, scanContext *s
 	results := []scanners.AzureServiceResult{}
 
 	for _, instance := range instances {
-		rr := engine.EvaluateRules(rules, instance, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, instance, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *instance.Name,
+			ID:               *instance.ID,
 			Type:             *instance.Type,
 			Location:         *instance.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(instance),
 		})
 	}
 	return results, nil
@@ -4,6 +4,7 @@
 package ci
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,10 +19,10 @@ func (a *ContainerInstanceScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerInstance should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcontainerinstance.ContainerGroup)
 				zones := len(i.Zones) > 0
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-instances/availability-zones",
 		},
@@ -30,8 +31,8 @@ func (a *ContainerInstanceScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerInstance should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/container-instances/v1_0/index.html",
 		},
@@ -40,13 +41,13 @@ func (a *ContainerInstanceScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "ContainerInstance should use private IP addresses",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcontainerinstance.ContainerGroup)
 				pe := false
 				if i.Properties.IPAddress != nil && i.Properties.IPAddress.Type != nil {
 					pe = *i.Properties.IPAddress.Type == armcontainerinstance.ContainerGroupIPAddressTypePrivate
 				}
-				return !pe, ""
+				return !pe, "", nil
 			},
 		},
 		"ci-005": {
@@ -54,9 +55,9 @@ func (a *ContainerInstanceScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerInstance SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcontainerinstance.ContainerGroup)
-				return false, string(*i.Properties.SKU)
+				return false, string(*i.Properties.SKU), nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/container-instances/",
 		},
@@ -65,10 +66,10 @@ func (a *ContainerInstanceScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "ContainerInstance Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerinstance.ContainerGroup)
 				caf := strings.HasPrefix(*c.Name, "ci")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -77,11 +78,121 @@ func (a *ContainerInstanceScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "ContainerInstance should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerinstance.ContainerGroup)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
+		"ci-008": {
+			Id:             "ci-008",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "ContainerInstance should use Managed Identities",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerinstance.ContainerGroup)
+				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armcontainerinstance.ResourceIdentityTypeNone, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/container-instances/container-instances-managed-identity",
+		},
+		"ci-009": {
+			Id:             "ci-009",
+			Category:       scanners.RulesCategoryScalability,
+			Recommendation: "ContainerInstance containers should have CPU and memory limits",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerinstance.ContainerGroup)
+				names := []string{}
+				for _, container := range containers(c) {
+					if !hasLimits(container) {
+						names = append(names, *container.Name)
+					}
+				}
+				return len(names) > 0, strings.Join(names, ","), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/container-instances/container-instances-container-groups#resource-allocation",
+		},
+		"ci-010": {
+			Id:             "ci-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "ContainerInstance should only use images from trusted registries",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerinstance.ContainerGroup)
+				registries := []string{}
+				seen := map[string]bool{}
+				for _, container := range containers(c) {
+					if container.Properties == nil || container.Properties.Image == nil {
+						continue
+					}
+					registry := imageRegistry(*container.Properties.Image)
+					if isPublicRegistry(registry) && !seen[registry] {
+						seen[registry] = true
+						registries = append(registries, registry)
+					}
+				}
+				return len(registries) > 0, strings.Join(registries, ","), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/container-instances/container-instances-using-azure-container-registry",
+		},
+		"ci-011": {
+			Id:             "ci-011",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "ContainerInstance should send logs to Log Analytics",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerinstance.ContainerGroup)
+				logs := c.Properties != nil && c.Properties.Diagnostics != nil && c.Properties.Diagnostics.LogAnalytics != nil
+				return !logs, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/container-instances/container-instances-log-analytics",
+		},
+	}
+}
+
+// publicRegistries - Public container registries, images pulled from them are not trusted
+var publicRegistries = []string{"docker.io", "index.docker.io", "registry-1.docker.io", "ghcr.io", "quay.io", "gcr.io", "public.ecr.aws"}
+
+func containers(c *armcontainerinstance.ContainerGroup) []*armcontainerinstance.Container {
+	if c.Properties == nil {
+		return nil
+	}
+	return c.Properties.Containers
+}
+
+// hasLimits - Returns true when the container has non-zero CPU and memory limits
+func hasLimits(c *armcontainerinstance.Container) bool {
+	if c.Properties == nil || c.Properties.Resources == nil || c.Properties.Resources.Limits == nil {
+		return false
+	}
+	l := c.Properties.Resources.Limits
+	return l.CPU != nil && *l.CPU > 0 && l.MemoryInGB != nil && *l.MemoryInGB > 0
+}
+
+// imageRegistry - Returns the registry hostname of an image reference. Like docker, the first
+// path component is only a hostname when it has a dot or a port, or is localhost; otherwise the
+// image comes from Docker Hub.
+func imageRegistry(image string) string {
+	i := strings.Index(image, "/")
+	if i < 0 {
+		return "docker.io"
+	}
+	host := image[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return "docker.io"
+	}
+	return strings.ToLower(host)
+}
+
+func isPublicRegistry(registry string) bool {
+	for _, r := range publicRegistries {
+		if r == registry {
+			return true
+		}
 	}
+	return false
 }
@@ -4,6 +4,7 @@
 package ci
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -135,12 +136,179 @@ func TestContainerInstanceScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "ContainerInstanceScanner no managed identity",
+			fields: fields{
+				rule:        "ci-008",
+				target:      &armcontainerinstance.ContainerGroup{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerInstanceScanner managed identity",
+			fields: fields{
+				rule: "ci-008",
+				target: &armcontainerinstance.ContainerGroup{
+					Identity: &armcontainerinstance.ContainerGroupIdentity{
+						Type: to.Ptr(armcontainerinstance.ResourceIdentityTypeSystemAssigned),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerInstanceScanner containers without limits",
+			fields: fields{
+				rule: "ci-009",
+				target: &armcontainerinstance.ContainerGroup{
+					Properties: &armcontainerinstance.ContainerGroupProperties{
+						Containers: []*armcontainerinstance.Container{
+							{
+								Name: to.Ptr("app"),
+								Properties: &armcontainerinstance.ContainerProperties{
+									Resources: &armcontainerinstance.ResourceRequirements{
+										Limits: &armcontainerinstance.ResourceLimits{CPU: to.Ptr(1.0), MemoryInGB: to.Ptr(1.5)},
+									},
+								},
+							},
+							{
+								Name: to.Ptr("sidecar"),
+								Properties: &armcontainerinstance.ContainerProperties{
+									Resources: &armcontainerinstance.ResourceRequirements{},
+								},
+							},
+							{
+								Name: to.Ptr("job"),
+								Properties: &armcontainerinstance.ContainerProperties{
+									Resources: &armcontainerinstance.ResourceRequirements{
+										Limits: &armcontainerinstance.ResourceLimits{CPU: to.Ptr(1.0), MemoryInGB: to.Ptr(0.0)},
+									},
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "sidecar,job",
+			},
+		},
+		{
+			name: "ContainerInstanceScanner containers with limits",
+			fields: fields{
+				rule: "ci-009",
+				target: &armcontainerinstance.ContainerGroup{
+					Properties: &armcontainerinstance.ContainerGroupProperties{
+						Containers: []*armcontainerinstance.Container{
+							{
+								Name: to.Ptr("app"),
+								Properties: &armcontainerinstance.ContainerProperties{
+									Resources: &armcontainerinstance.ResourceRequirements{
+										Limits: &armcontainerinstance.ResourceLimits{CPU: to.Ptr(1.0), MemoryInGB: to.Ptr(1.5)},
+									},
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerInstanceScanner public registries",
+			fields: fields{
+				rule: "ci-010",
+				target: &armcontainerinstance.ContainerGroup{
+					Properties: &armcontainerinstance.ContainerGroupProperties{
+						Containers: []*armcontainerinstance.Container{
+							{Name: to.Ptr("web"), Properties: &armcontainerinstance.ContainerProperties{Image: to.Ptr("nginx:1.25")}},
+							{Name: to.Ptr("app"), Properties: &armcontainerinstance.ContainerProperties{Image: to.Ptr("crapp.azurecr.io/app:1.0")}},
+							{Name: to.Ptr("cache"), Properties: &armcontainerinstance.ContainerProperties{Image: to.Ptr("library/redis:7")}},
+							{Name: to.Ptr("tool"), Properties: &armcontainerinstance.ContainerProperties{Image: to.Ptr("ghcr.io/org/tool:latest")}},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "docker.io,ghcr.io",
+			},
+		},
+		{
+			name: "ContainerInstanceScanner trusted registries",
+			fields: fields{
+				rule: "ci-010",
+				target: &armcontainerinstance.ContainerGroup{
+					Properties: &armcontainerinstance.ContainerGroupProperties{
+						Containers: []*armcontainerinstance.Container{
+							{Name: to.Ptr("app"), Properties: &armcontainerinstance.ContainerProperties{Image: to.Ptr("crapp.azurecr.io/app:1.0")}},
+							{Name: to.Ptr("dev"), Properties: &armcontainerinstance.ContainerProperties{Image: to.Ptr("localhost:5000/dev")}},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerInstanceScanner no Log Analytics",
+			fields: fields{
+				rule: "ci-011",
+				target: &armcontainerinstance.ContainerGroup{
+					Properties: &armcontainerinstance.ContainerGroupProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerInstanceScanner Log Analytics",
+			fields: fields{
+				rule: "ci-011",
+				target: &armcontainerinstance.ContainerGroup{
+					Properties: &armcontainerinstance.ContainerGroupProperties{
+						Diagnostics: &armcontainerinstance.ContainerGroupDiagnostics{
+							LogAnalytics: &armcontainerinstance.LogAnalytics{WorkspaceID: to.Ptr("workspace")},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ContainerInstanceScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ContainerInstanceScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -4,21 +4,37 @@
 package cog
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices"
+	"github.com/rs/zerolog/log"
 )
 
 // CognitiveScanner - Scanner for Cognitive Services Accounts
 type CognitiveScanner struct {
-	config *scanners.ScannerConfig
-	client *armcognitiveservices.AccountsClient
+	config            *scanners.ScannerConfig
+	client            *armcognitiveservices.AccountsClient
+	deploymentsClient *armcognitiveservices.DeploymentsClient
+	restClient        *scanners.RESTClient
 }
 
 // Init - Initializes the CognitiveScanner
 func (a *CognitiveScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.client, err = armcognitiveservices.NewAccountsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.deploymentsClient, err = armcognitiveservices.NewDeploymentsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.restClient, err = scanners.NewRESTClient(config, raiPolicyVersion)
 	return err
 }
 
@@ -34,17 +50,35 @@ func (c *CognitiveScanner) Scan(resourceGroupName string, scanContext *scanners.
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.OpenAIContentFilters = map[string]map[string]bool{}
+
 	for _, eventHub := range eventHubs {
-		rr := engine.EvaluateRules(rules, eventHub, scanContext)
+		select {
+		case <-c.config.Ctx.Done():
+			return nil, c.config.Ctx.Err()
+		default:
+		}
+		if eventHub.Kind != nil && *eventHub.Kind == openAIAccountKind {
+			filters, err := c.getContentFilters(resourceGroupName, eventHub)
+			if err != nil {
+				log.Debug().Err(err).Msgf("Failed to get content filters of Azure OpenAI account %s", *eventHub.Name)
+			} else {
+				scanContext.OpenAIContentFilters[strings.ToLower(*eventHub.ID)] = filters
+			}
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, eventHub, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *eventHub.Name,
+			ID:               *eventHub.ID,
 			Type:             *eventHub.Type,
 			Location:         *eventHub.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(eventHub),
 		})
 	}
 	return results, nil
@@ -63,3 +97,32 @@ func (c *CognitiveScanner) listEventHubs(resourceGroupName string) ([]*armcognit
 	}
 	return namespaces, nil
 }
+
+// getContentFilters - Returns, for each model deployment of an Azure OpenAI account, whether a content filter is assigned
+func (c *CognitiveScanner) getContentFilters(resourceGroupName string, account *armcognitiveservices.Account) (map[string]bool, error) {
+	policies, err := c.listRaiPolicies(*account.ID)
+	if err != nil {
+		return nil, err
+	}
+	filtering := filteringPolicies(policies)
+
+	filters := map[string]bool{}
+	pager := c.deploymentsClient.NewListPager(resourceGroupName, *account.Name, nil)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range resp.Value {
+			if d.Name == nil {
+				continue
+			}
+			policy := ""
+			if d.Properties != nil && d.Properties.RaiPolicyName != nil {
+				policy = *d.Properties.RaiPolicyName
+			}
+			filters[*d.Name] = isContentFiltered(policy, filtering)
+		}
+	}
+	return filters, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package cog
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// The armcognitiveservices module does not include the content filters (RAI policies) of Azure OpenAI
+// accounts, so the scanner talks to the Microsoft.CognitiveServices/accounts/raiPolicies REST API through
+// scanners.RESTClient, using only the fields required by the rules.
+
+const (
+	raiPolicyVersion  = "2023-10-01-preview"
+	defaultRaiPolicy  = "microsoft.default"
+	openAIAccountKind = "OpenAI"
+)
+
+type (
+	// RaiPolicy - Content filter policy of an Azure OpenAI account
+	RaiPolicy struct {
+		Name       *string              `json:"name"`
+		Properties *RaiPolicyProperties `json:"properties"`
+	}
+
+	// RaiPolicyProperties - Content filter policy properties
+	RaiPolicyProperties struct {
+		ContentFilters []*RaiPolicyContentFilter `json:"contentFilters"`
+	}
+
+	// RaiPolicyContentFilter - Content filter of a content filter policy
+	RaiPolicyContentFilter struct {
+		Name     *string `json:"name"`
+		Enabled  *bool   `json:"enabled"`
+		Blocking *bool   `json:"blocking"`
+	}
+
+	raiPolicyList struct {
+		Value    []*RaiPolicy `json:"value"`
+		NextLink *string      `json:"nextLink"`
+	}
+)
+
+func (c *CognitiveScanner) listRaiPolicies(accountID string) ([]*RaiPolicy, error) {
+	policies := make([]*RaiPolicy, 0)
+	next := c.restClient.URL(accountID, "raiPolicies")
+	for next != "" {
+		page := raiPolicyList{}
+		if err := c.restClient.Get(c.config.Ctx, next, &page); err != nil {
+			return nil, err
+		}
+		policies = append(policies, page.Value...)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return policies, nil
+}
+
+// filteringPolicies - Returns the names (lower case) of the policies with at least one enabled and blocking content filter.
+// The built-in Microsoft.Default policies always filter content.
+func filteringPolicies(policies []*RaiPolicy) map[string]bool {
+	res := map[string]bool{}
+	for _, p := range policies {
+		if p.Name == nil || p.Properties == nil {
+			continue
+		}
+		for _, f := range p.Properties.ContentFilters {
+			if f.Enabled != nil && *f.Enabled && f.Blocking != nil && *f.Blocking {
+				res[strings.ToLower(*p.Name)] = true
+				break
+			}
+		}
+	}
+	return res
+}
+
+// isContentFiltered - Returns true if the content filter policy of a deployment filters content
+func isContentFiltered(raiPolicyName string, filtering map[string]bool) bool {
+	name := strings.ToLower(raiPolicyName)
+	if name == "" {
+		return false
+	}
+	return strings.HasPrefix(name, defaultRaiPolicy) || filtering[name]
+}
@@ -4,6 +4,9 @@
 package cog
 
 import (
+	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,20 +21,23 @@ func (a *CognitiveScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Cognitive Service Account should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armcognitiveservices.Account)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/monitor-event-hubs#collection-and-routing",
+			Url:             "https://learn.microsoft.com/en-us/azure/event-hubs/monitor-event-hubs#collection-and-routing",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"cog-003": {
 			Id:             "cog-003",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Cognitive Service Account should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
@@ -40,10 +46,11 @@ func (a *CognitiveScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Cognitive Service Account should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcognitiveservices.Account)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cognitive-services/cognitive-services-virtual-networks",
 		},
@@ -52,9 +59,9 @@ func (a *CognitiveScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Cognitive Service Account SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcognitiveservices.Account)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/templates/microsoft.cognitiveservices/accounts?pivots=deployment-language-bicep#sku",
 		},
@@ -63,37 +70,37 @@ func (a *CognitiveScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Cognitive Service Account Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcognitiveservices.Account)
 				switch strings.ToLower(*c.Kind) {
 				case "openai":
-					return !strings.HasPrefix(*c.Name, "oai"), ""
+					return !strings.HasPrefix(*c.Name, "oai"), "", nil
 				case "computervision":
-					return !strings.HasPrefix(*c.Name, "cv"), ""
+					return !strings.HasPrefix(*c.Name, "cv"), "", nil
 				case "contentmoderator":
-					return !strings.HasPrefix(*c.Name, "cm"), ""
+					return !strings.HasPrefix(*c.Name, "cm"), "", nil
 				case "contentsafety":
-					return !strings.HasPrefix(*c.Name, "cs"), ""
+					return !strings.HasPrefix(*c.Name, "cs"), "", nil
 				case "customvision.prediction":
-					return !strings.HasPrefix(*c.Name, "cstv"), ""
+					return !strings.HasPrefix(*c.Name, "cstv"), "", nil
 				case "customvision.training":
-					return !strings.HasPrefix(*c.Name, "cstvt"), ""
+					return !strings.HasPrefix(*c.Name, "cstvt"), "", nil
 				case "formrecognizer":
-					return !strings.HasPrefix(*c.Name, "di"), ""
+					return !strings.HasPrefix(*c.Name, "di"), "", nil
 				case "face":
-					return !strings.HasPrefix(*c.Name, "face"), ""
+					return !strings.HasPrefix(*c.Name, "face"), "", nil
 				case "healthinsights":
-					return !strings.HasPrefix(*c.Name, "hi"), ""
+					return !strings.HasPrefix(*c.Name, "hi"), "", nil
 				case "immersivereader":
-					return !strings.HasPrefix(*c.Name, "ir"), ""
+					return !strings.HasPrefix(*c.Name, "ir"), "", nil
 				case "textanalytics":
-					return !strings.HasPrefix(*c.Name, "lang"), ""
+					return !strings.HasPrefix(*c.Name, "lang"), "", nil
 				case "speechservices":
-					return !strings.HasPrefix(*c.Name, "spch"), ""
+					return !strings.HasPrefix(*c.Name, "spch"), "", nil
 				case "texttranslation":
-					return !strings.HasPrefix(*c.Name, "trsl"), ""
+					return !strings.HasPrefix(*c.Name, "trsl"), "", nil
 				default:
-					return !strings.HasPrefix(*c.Name, "cog"), ""
+					return !strings.HasPrefix(*c.Name, "cog"), "", nil
 				}
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
@@ -103,23 +110,81 @@ func (a *CognitiveScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Cognitive Service Account should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcognitiveservices.Account)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"cog-008": {
 			Id:             "cog-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Cognitive Service Account should have local authentication disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.CognitiveServices/accounts", "Microsoft.CognitiveServices/accounts/disableLocalAuth", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcognitiveservices.Account)
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
-				return !localAuth, ""
+				return !localAuth, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/ai-services/policy-reference#azure-ai-services",
+			Url:         "https://learn.microsoft.com/en-us/azure/ai-services/policy-reference#azure-ai-services",
+			Remediation: "az resource update --ids {resource_id} --set properties.disableLocalAuth=true",
+		},
+		"cog-009": {
+			Id:             "cog-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Cognitive Service Account should use a custom subdomain (regional endpoint)",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcognitiveservices.Account)
+				if c.Properties == nil || c.Properties.CustomSubDomainName == nil || *c.Properties.CustomSubDomainName == "" {
+					return true, "none", nil
+				}
+				return false, *c.Properties.CustomSubDomainName, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/ai-services/cognitive-services-custom-subdomains",
+		},
+		"cog-010": {
+			Id:             "cog-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Cognitive Service Account should restrict outbound network access",
+			Impact:         scanners.ImpactMedium,
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.CognitiveServices/accounts", "Microsoft.CognitiveServices/accounts/restrictOutboundNetworkAccess", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcognitiveservices.Account)
+				restricted := c.Properties != nil && c.Properties.RestrictOutboundNetworkAccess != nil && *c.Properties.RestrictOutboundNetworkAccess
+				return !restricted, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/ai-services/cognitive-services-data-loss-prevention",
+		},
+		"cog-011": {
+			Id:             "cog-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure OpenAI model deployments should have a content filter assigned",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcognitiveservices.Account)
+				if c.Kind == nil || *c.Kind != openAIAccountKind {
+					return false, "", nil
+				}
+				filters, ok := scanContext.OpenAIContentFilters[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("content filters not available for %s", *c.Name)
+				}
+				deployments := []string{}
+				for name, filtered := range filters {
+					if !filtered {
+						deployments = append(deployments, name)
+					}
+				}
+				sort.Strings(deployments)
+				return len(deployments) > 0, strings.Join(deployments, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/ai-services/openai/how-to/content-filters",
 		},
 	}
 }
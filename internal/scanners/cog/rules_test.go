@@ -4,6 +4,7 @@
 package cog
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -333,12 +334,152 @@ func TestCognitiveScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "CognitiveScanner CustomSubDomainName nil",
+			fields: fields{
+				rule: "cog-009",
+				target: &armcognitiveservices.Account{
+					Properties: &armcognitiveservices.AccountProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "none",
+			},
+		},
+		{
+			name: "CognitiveScanner CustomSubDomainName empty",
+			fields: fields{
+				rule: "cog-009",
+				target: &armcognitiveservices.Account{
+					Properties: &armcognitiveservices.AccountProperties{
+						CustomSubDomainName: to.Ptr(""),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "none",
+			},
+		},
+		{
+			name: "CognitiveScanner CustomSubDomainName set",
+			fields: fields{
+				rule: "cog-009",
+				target: &armcognitiveservices.Account{
+					Properties: &armcognitiveservices.AccountProperties{
+						CustomSubDomainName: to.Ptr("cog-app"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "cog-app",
+			},
+		},
+		{
+			name: "CognitiveScanner RestrictOutboundNetworkAccess nil",
+			fields: fields{
+				rule: "cog-010",
+				target: &armcognitiveservices.Account{
+					Properties: &armcognitiveservices.AccountProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "CognitiveScanner RestrictOutboundNetworkAccess true",
+			fields: fields{
+				rule: "cog-010",
+				target: &armcognitiveservices.Account{
+					Properties: &armcognitiveservices.AccountProperties{
+						RestrictOutboundNetworkAccess: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CognitiveScanner OpenAI deployments without content filter",
+			fields: fields{
+				rule: "cog-011",
+				target: &armcognitiveservices.Account{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("oai-test"),
+					Kind: to.Ptr("OpenAI"),
+				},
+				scanContext: &scanners.ScanContext{
+					OpenAIContentFilters: map[string]map[string]bool{
+						"test": {
+							"gpt-4o":       true,
+							"gpt-35-turbo": false,
+							"embeddings":   false,
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "embeddings, gpt-35-turbo",
+			},
+		},
+		{
+			name: "CognitiveScanner OpenAI deployments with content filter",
+			fields: fields{
+				rule: "cog-011",
+				target: &armcognitiveservices.Account{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("oai-test"),
+					Kind: to.Ptr("OpenAI"),
+				},
+				scanContext: &scanners.ScanContext{
+					OpenAIContentFilters: map[string]map[string]bool{
+						"test": {
+							"gpt-4o": true,
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CognitiveScanner content filter not OpenAI",
+			fields: fields{
+				rule: "cog-011",
+				target: &armcognitiveservices.Account{
+					ID:   to.Ptr("test"),
+					Kind: to.Ptr("TextAnalytics"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &CognitiveScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("CognitiveScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -349,3 +490,51 @@ func TestCognitiveScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestCognitiveScanner_ContentFilters_NotAvailable(t *testing.T) {
+	s := &CognitiveScanner{}
+	rules := s.GetRules()
+	target := &armcognitiveservices.Account{ID: to.Ptr("test"), Name: to.Ptr("oai-test"), Kind: to.Ptr("OpenAI")}
+	_, _, err := rules["cog-011"].Eval(context.Background(), target, &scanners.ScanContext{})
+	if err == nil {
+		t.Error("CognitiveScanner Rule.Eval() error = nil, want an error when the content filters are not available")
+	}
+}
+
+func TestIsContentFiltered(t *testing.T) {
+	filtering := filteringPolicies([]*RaiPolicy{
+		{
+			Name: to.Ptr("Strict"),
+			Properties: &RaiPolicyProperties{
+				ContentFilters: []*RaiPolicyContentFilter{
+					{Name: to.Ptr("hate"), Enabled: to.Ptr(true), Blocking: to.Ptr(true)},
+				},
+			},
+		},
+		{
+			Name: to.Ptr("AnnotateOnly"),
+			Properties: &RaiPolicyProperties{
+				ContentFilters: []*RaiPolicyContentFilter{
+					{Name: to.Ptr("hate"), Enabled: to.Ptr(true), Blocking: to.Ptr(false)},
+					{Name: to.Ptr("violence"), Enabled: to.Ptr(false), Blocking: to.Ptr(true)},
+				},
+			},
+		},
+	})
+	tests := []struct {
+		policy string
+		want   bool
+	}{
+		{policy: "Microsoft.Default", want: true},
+		{policy: "Microsoft.DefaultV2", want: true},
+		{policy: "strict", want: true},
+		{policy: "AnnotateOnly", want: false},
+		{policy: "Unknown", want: false},
+		{policy: "", want: false},
+	}
+	for _, tt := range tests {
+		if got := isContentFiltered(tt.policy, filtering); got != tt.want {
+			t.Errorf("isContentFiltered(%q) = %v, want %v", tt.policy, got, tt.want)
+		}
+	}
+}
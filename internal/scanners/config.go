@@ -0,0 +1,70 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// DefaultClientOptions - Returns the client options used by the scanners: retries with exponential backoff
+// to ride out Azure Resource Manager throttling
+func DefaultClientOptions() *arm.ClientOptions {
+	return &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Retry: policy.RetryOptions{
+				RetryDelay:    20 * time.Millisecond,
+				MaxRetries:    3,
+				MaxRetryDelay: 10 * time.Minute,
+			},
+		},
+	}
+}
+
+// WithDefaults - Returns a copy of the config with a background context and the default client options
+// when they are not set
+func (c *ScannerConfig) WithDefaults() *ScannerConfig {
+	config := *c
+	if config.Ctx == nil {
+		config.Ctx = context.Background()
+	}
+	if config.ClientOptions == nil {
+		config.ClientOptions = DefaultClientOptions()
+	}
+	return &config
+}
+
+// ValidateScannerConfig - Returns an error listing every missing or invalid field of the config,
+// so scanners fail in Init instead of creating clients that fail later in Scan
+func ValidateScannerConfig(config *ScannerConfig) error {
+	if config == nil {
+		return fmt.Errorf("invalid scanner config: config is nil")
+	}
+
+	problems := []string{}
+	if config.SubscriptionID == "" {
+		problems = append(problems, "SubscriptionID is empty")
+	}
+	if config.Cred == nil {
+		problems = append(problems, "Cred is nil")
+	}
+	if config.Ctx == nil {
+		problems = append(problems, "Ctx is nil")
+	} else if err := config.Ctx.Err(); err != nil {
+		problems = append(problems, fmt.Sprintf("Ctx is done (%s)", err))
+	}
+	if config.ClientOptions == nil {
+		problems = append(problems, "ClientOptions is nil")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid scanner config: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token"}, nil
+}
+
+func TestValidateScannerConfig(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		config *ScannerConfig
+		want   string
+	}{
+		{
+			name: "valid",
+			config: &ScannerConfig{
+				Ctx:            context.Background(),
+				Cred:           fakeCredential{},
+				ClientOptions:  DefaultClientOptions(),
+				SubscriptionID: "00000000-0000-0000-0000-000000000000",
+			},
+			want: "",
+		},
+		{
+			name:   "empty",
+			config: &ScannerConfig{},
+			want:   "invalid scanner config: SubscriptionID is empty, Cred is nil, Ctx is nil, ClientOptions is nil",
+		},
+		{
+			name: "cancelled context",
+			config: &ScannerConfig{
+				Ctx:            cancelled,
+				Cred:           fakeCredential{},
+				ClientOptions:  DefaultClientOptions(),
+				SubscriptionID: "00000000-0000-0000-0000-000000000000",
+			},
+			want: "invalid scanner config: Ctx is done (context canceled)",
+		},
+		{
+			name:   "nil",
+			config: nil,
+			want:   "invalid scanner config: config is nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScannerConfig(tt.config)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("ValidateScannerConfig() error = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScannerConfig_WithDefaults(t *testing.T) {
+	config := &ScannerConfig{
+		Cred:           fakeCredential{},
+		SubscriptionID: "00000000-0000-0000-0000-000000000000",
+	}
+	got := config.WithDefaults()
+	if err := ValidateScannerConfig(got); err != nil {
+		t.Errorf("ValidateScannerConfig(WithDefaults()) error = %v", err)
+	}
+	if config.Ctx != nil || config.ClientOptions != nil {
+		t.Error("WithDefaults() modified the original config")
+	}
+
+	options := DefaultClientOptions()
+	options.Retry.MaxRetries = 10
+	config.ClientOptions = options
+	if got := config.WithDefaults(); got.ClientOptions != options {
+		t.Error("WithDefaults() replaced the client options already set")
+	}
+}
@@ -16,6 +16,9 @@ type CosmosDBScanner struct {
 
 // Init - Initializes the CosmosDBScanner
 func (a *CosmosDBScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.databasesClient, err = armcosmos.NewDatabaseAccountsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *CosmosDBScanner) Scan(resourceGroupName string, scanContext *scanners.S
 	results := []scanners.AzureServiceResult{}
 
 	for _, database := range databases {
-		rr := engine.EvaluateRules(rules, database, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, database, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
-			SubscriptionID: c.config.SubscriptionID,
+			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
-			ResourceGroup:  resourceGroupName,
-			ServiceName:    *database.Name,
-			Type:           *database.Type,
-			Location:       *database.Location,
-			Rules:          rr,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *database.Name,
+			ID:               *database.ID,
+			Type:             *database.Type,
+			Location:         *database.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(database),
 		})
 	}
 	return results, nil
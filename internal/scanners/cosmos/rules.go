@@ -4,6 +4,7 @@
 package cosmos
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -14,24 +15,31 @@ import (
 func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 	return map[string]scanners.AzureRule{
 		"cosmos-001": {
-			Id:             "cosmos-001",
-			Category:       scanners.RulesCategoryMonitoringAndAlerting,
-			Recommendation: "CosmosDB should have diagnostic settings enabled",
-			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Id:                 "cosmos-001",
+			Category:           scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation:     "CosmosDB should have diagnostic settings enabled",
+			Impact:             scanners.ImpactLow,
+			Tags:               []string{"CIS", "logging"},
+			RequiresDeployment: true,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armcosmos.DatabaseAccountGetResults)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/monitor-resource-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/cosmos-db/monitor-resource-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"cosmos-002": {
 			Id:             "cosmos-002",
 			Category:       scanners.RulesCategoryHighAvailability,
-			Recommendation: "CosmosDB should have availability zones enabled",
+			Recommendation: "CosmosDB should have availability zones enabled (not supported by serverless accounts)",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcosmos.DatabaseAccountGetResults)
+				if isServerlessAccount(i) {
+					return false, "Serverless - N/A", nil
+				}
 				availabilityZones := false
 				availabilityZonesNotEnabledInALocation := false
 				numberOfLocations := 0
@@ -46,7 +54,7 @@ func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 
 				zones := availabilityZones && numberOfLocations >= 2 && !availabilityZonesNotEnabledInALocation
 
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/high-availability",
 		},
@@ -55,8 +63,17 @@ func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "CosmosDB should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcosmos.DatabaseAccountGetResults)
+				// Serverless accounts run in a single region
+				if isServerlessAccount(i) {
+					for _, location := range i.Properties.Locations {
+						if location.IsZoneRedundant != nil && *location.IsZoneRedundant {
+							return false, "99.99%", nil
+						}
+					}
+					return false, "99.9%", nil
+				}
 				sla := "99.99%"
 				availabilityZones := false
 				availabilityZonesNotEnabledInALocation := false
@@ -74,7 +91,7 @@ func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 				if availabilityZones && numberOfLocations >= 2 && !availabilityZonesNotEnabledInALocation {
 					sla = "99.999%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/high-availability#slas",
 		},
@@ -83,10 +100,12 @@ func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "CosmosDB should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Policy:         scanners.PolicyFieldCountEquals("Microsoft.DocumentDB/databaseAccounts", "Microsoft.DocumentDB/databaseAccounts/privateEndpointConnections[*]", 0),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcosmos.DatabaseAccountGetResults)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/how-to-configure-private-endpoints",
 		},
@@ -95,9 +114,9 @@ func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "CosmosDB SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcosmos.DatabaseAccountGetResults)
-				return false, string(*i.Properties.DatabaseAccountOfferType)
+				return false, string(*i.Properties.DatabaseAccountOfferType), nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/cosmos-db/autoscale-provisioned/",
 		},
@@ -106,10 +125,10 @@ func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "CosmosDB Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcosmos.DatabaseAccountGetResults)
 				caf := strings.HasPrefix(*c.Name, "cosmos")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -118,35 +137,139 @@ func (a *CosmosDBScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "CosmosDB should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcosmos.DatabaseAccountGetResults)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"cosmos-008": {
 			Id:             "cosmos-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "CosmosDB should have local authentication disabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.DocumentDB/databaseAccounts", "Microsoft.DocumentDB/databaseAccounts/disableLocalAuth", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcosmos.DatabaseAccountGetResults)
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
-				return !localAuth, ""
+				return !localAuth, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/how-to-setup-rbac#disable-local-auth",
+			Url:         "https://learn.microsoft.com/en-us/azure/cosmos-db/how-to-setup-rbac#disable-local-auth",
+			Remediation: "az resource update --ids {resource_id} --set properties.disableLocalAuth=true",
 		},
 		"cosmos-009": {
 			Id:             "cosmos-009",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "CosmosDB: disable write operations on metadata resources (databases, containers, throughput) via account keys",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			DependsOn:      []string{"cosmos-008"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.DocumentDB/databaseAccounts", "Microsoft.DocumentDB/databaseAccounts/disableKeyBasedMetadataWriteAccess", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcosmos.DatabaseAccountGetResults)
 				disabled := c.Properties.DisableKeyBasedMetadataWriteAccess != nil && *c.Properties.DisableKeyBasedMetadataWriteAccess
-				return !disabled, ""
+				return !disabled, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/role-based-access-control#set-via-arm-template",
 		},
+		"cosmos-014": {
+			Id:             "cosmos-014",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "CosmosDB CORS should not allow all origins",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcosmos.DatabaseAccountGetResults)
+				if c.Properties == nil {
+					return false, "", nil
+				}
+				wildcard := false
+				methods := []string{}
+				seen := map[string]bool{}
+				for _, cors := range c.Properties.Cors {
+					if cors.AllowedOrigins == nil || !containsValue(*cors.AllowedOrigins, "*") {
+						continue
+					}
+					wildcard = true
+					if cors.AllowedMethods == nil {
+						continue
+					}
+					for _, m := range strings.Split(*cors.AllowedMethods, ",") {
+						m = strings.ToUpper(strings.TrimSpace(m))
+						if broadCorsMethods[m] && !seen[m] {
+							seen[m] = true
+							methods = append(methods, m)
+						}
+					}
+				}
+				return wildcard, strings.Join(methods, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/how-to-configure-cross-origin-resource-sharing",
+		},
+		"cosmos-015": {
+			Id:                   "cosmos-015",
+			Category:             scanners.RulesCategorySecurity,
+			Recommendation:       "CosmosDB with public network access should restrict access with IP or virtual network rules",
+			Impact:               scanners.ImpactHigh,
+			DefenderAssessmentID: "276b1952-c364-852b-11e5-657f0fa34dc6",
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcosmos.DatabaseAccountGetResults)
+				if c.Properties == nil {
+					return false, "", nil
+				}
+				public := c.Properties.PublicNetworkAccess == nil || *c.Properties.PublicNetworkAccess == armcosmos.PublicNetworkAccessEnabled
+				restricted := len(c.Properties.IPRules) > 0 || len(c.Properties.VirtualNetworkRules) > 0
+				return public && !restricted, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/how-to-configure-firewall",
+		},
+		"cosmos-016": {
+			Id:                 "cosmos-016",
+			Category:           scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation:     "CosmosDB diagnostic settings should include the DataPlaneRequests log category",
+			Impact:             scanners.ImpactMedium,
+			Tags:               []string{"logging"},
+			RequiresDeployment: true,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armcosmos.DatabaseAccountGetResults)
+				return scanners.IsLogCategoryMissing(scanContext, *service.ID, "DataPlaneRequests"), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cosmos-db/monitor-resource-logs",
+		},
+	}
+}
+
+// broadCorsMethods - HTTP methods that allow changing data when combined with a wildcard origin
+var broadCorsMethods = map[string]bool{
+	"*":      true,
+	"DELETE": true,
+	"PATCH":  true,
+	"POST":   true,
+	"PUT":    true,
+}
+
+// containsValue - Returns true if the comma separated list contains the value
+func containsValue(list string, value string) bool {
+	for _, v := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isServerlessAccount - Returns true if the account has the EnableServerless capability. Serverless accounts
+// run in a single region and don't support availability zones, multi-region writes or continuous backup.
+func isServerlessAccount(account *armcosmos.DatabaseAccountGetResults) bool {
+	if account.Properties == nil {
+		return false
+	}
+	for _, c := range account.Properties.Capabilities {
+		if c != nil && c.Name != nil && strings.EqualFold(*c.Name, "EnableServerless") {
+			return true
+		}
 	}
+	return false
 }
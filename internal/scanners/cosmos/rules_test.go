@@ -4,12 +4,15 @@
 package cosmos
 
 import (
+	"context"
+	"encoding/json"
 	"reflect"
 	"testing"
 
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+	"github.com/open-policy-agent/opa/rego"
 )
 
 func TestCosmosDBScanner_Rules(t *testing.T) {
@@ -68,6 +71,81 @@ func TestCosmosDBScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "CosmosDBScanner Availability Zones serverless",
+			fields: fields{
+				rule: "cosmos-002",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						Capabilities: []*armcosmos.Capability{
+							{
+								Name: to.Ptr("EnableServerless"),
+							},
+						},
+						Locations: []*armcosmos.Location{
+							{
+								IsZoneRedundant: to.Ptr(false),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Serverless - N/A",
+			},
+		},
+		{
+			name: "CosmosDBScanner SLA serverless",
+			fields: fields{
+				rule: "cosmos-003",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						Capabilities: []*armcosmos.Capability{
+							{
+								Name: to.Ptr("EnableServerless"),
+							},
+						},
+						Locations: []*armcosmos.Location{
+							{
+								IsZoneRedundant: to.Ptr(false),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.9%",
+			},
+		},
+		{
+			name: "CosmosDBScanner SLA serverless zone redundant",
+			fields: fields{
+				rule: "cosmos-003",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						Capabilities: []*armcosmos.Capability{
+							{
+								Name: to.Ptr("EnableServerless"),
+							},
+						},
+						Locations: []*armcosmos.Location{
+							{
+								IsZoneRedundant: to.Ptr(true),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.99%",
+			},
+		},
 		{
 			name: "CosmosDBScanner SLA 99.99%",
 			fields: fields{
@@ -207,12 +285,224 @@ func TestCosmosDBScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "CosmosDBScanner CORS Wildcard Origin",
+			fields: fields{
+				rule: "cosmos-014",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						Cors: []*armcosmos.CorsPolicy{
+							{
+								AllowedOrigins: to.Ptr("*"),
+								AllowedMethods: to.Ptr("GET,HEAD"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner CORS Wildcard Origin with DELETE",
+			fields: fields{
+				rule: "cosmos-014",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						Cors: []*armcosmos.CorsPolicy{
+							{
+								AllowedOrigins: to.Ptr("https://contoso.com, *"),
+								AllowedMethods: to.Ptr("GET, delete, PUT"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "DELETE, PUT",
+			},
+		},
+		{
+			name: "CosmosDBScanner CORS Specific Origins",
+			fields: fields{
+				rule: "cosmos-014",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						Cors: []*armcosmos.CorsPolicy{
+							{
+								AllowedOrigins: to.Ptr("https://contoso.com,https://*.contoso.com"),
+								AllowedMethods: to.Ptr("*"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner CORS Not Configured",
+			fields: fields{
+				rule: "cosmos-014",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						Cors: []*armcosmos.CorsPolicy{},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner Public Network Access without Rules",
+			fields: fields{
+				rule: "cosmos-015",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						PublicNetworkAccess: to.Ptr(armcosmos.PublicNetworkAccessEnabled),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner Public Network Access with IP Rules",
+			fields: fields{
+				rule: "cosmos-015",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						PublicNetworkAccess: to.Ptr(armcosmos.PublicNetworkAccessEnabled),
+						IPRules: []*armcosmos.IPAddressOrRange{
+							{
+								IPAddressOrRange: to.Ptr("10.0.0.1"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner Public Network Access Disabled",
+			fields: fields{
+				rule: "cosmos-015",
+				target: &armcosmos.DatabaseAccountGetResults{
+					Properties: &armcosmos.DatabaseAccountGetProperties{
+						PublicNetworkAccess: to.Ptr(armcosmos.PublicNetworkAccessDisabled),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner DiagnosticSettings DataPlaneRequests",
+			fields: fields{
+				rule: "cosmos-016",
+				target: &armcosmos.DatabaseAccountGetResults{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"DataPlaneRequests"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner DiagnosticSettings allLogs",
+			fields: fields{
+				rule: "cosmos-016",
+				target: &armcosmos.DatabaseAccountGetResults{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"allLogs"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner DiagnosticSettings without DataPlaneRequests",
+			fields: fields{
+				rule: "cosmos-016",
+				target: &armcosmos.DatabaseAccountGetResults{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"QueryRuntimeStatistics"},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "CosmosDBScanner No DiagnosticSettings",
+			fields: fields{
+				rule: "cosmos-016",
+				target: &armcosmos.DatabaseAccountGetResults{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &CosmosDBScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("CosmosDBScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -223,3 +513,122 @@ func TestCosmosDBScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestCosmosDBScanner_Rego(t *testing.T) {
+	s := &CosmosDBScanner{}
+	rules := map[string]scanners.AzureRule{}
+	for k, r := range s.GetRules() {
+		if r.Policy != nil {
+			rules[k] = r
+		}
+	}
+	if _, ok := rules["cosmos-004"]; !ok {
+		t.Fatalf("cosmos-004 has no policy")
+	}
+	module, err := scanners.ToRegoModule(rules)
+	if err != nil {
+		t.Fatalf("ToRegoModule() error = %v", err)
+	}
+	query, err := rego.New(rego.Query("data.azqr.cosmos.deny"), rego.Module("cosmos.rego", module)).PrepareForEval(context.Background())
+	if err != nil {
+		t.Fatalf("failed to compile the Rego module: %v", err)
+	}
+
+	targets := map[string]*armcosmos.DatabaseAccountGetResults{
+		"not compliant": {
+			ID:         to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.DocumentDB/databaseAccounts/db"),
+			Name:       to.Ptr("db"),
+			Type:       to.Ptr("Microsoft.DocumentDB/databaseAccounts"),
+			Properties: &armcosmos.DatabaseAccountGetProperties{},
+		},
+		"compliant": {
+			ID:   to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.DocumentDB/databaseAccounts/cosmos-db"),
+			Name: to.Ptr("cosmos-db"),
+			Type: to.Ptr("Microsoft.DocumentDB/databaseAccounts"),
+			Tags: map[string]*string{"env": to.Ptr("prod")},
+			Properties: &armcosmos.DatabaseAccountGetProperties{
+				DisableLocalAuth:                   to.Ptr(true),
+				DisableKeyBasedMetadataWriteAccess: to.Ptr(true),
+				PrivateEndpointConnections: []*armcosmos.PrivateEndpointConnection{
+					{ID: to.Ptr("pe")},
+				},
+			},
+		},
+	}
+	for name, target := range targets {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+			input := map[string]interface{}{}
+			if err := json.Unmarshal(data, &input); err != nil {
+				t.Fatal(err)
+			}
+			rs, err := query.Eval(context.Background(), rego.EvalInput(input))
+			if err != nil {
+				t.Fatalf("Rego Eval() error = %v", err)
+			}
+			denied := map[string]bool{}
+			for _, d := range rs[0].Expressions[0].Value.([]interface{}) {
+				denied[d.(map[string]interface{})["rule"].(string)] = true
+			}
+
+			for k, r := range rules {
+				broken, _, err := r.Eval(context.Background(), target, &scanners.ScanContext{})
+				if err != nil {
+					t.Fatalf("%s Eval() error = %v", k, err)
+				}
+				if denied[k] != broken {
+					t.Errorf("%s Rego deny = %v, Eval() = %v", k, denied[k], broken)
+				}
+			}
+			if name == "not compliant" && !denied["cosmos-004"] {
+				t.Errorf("cosmos-004 Rego deny = false, want true")
+			}
+		})
+	}
+}
+
+func TestCosmosDBScanner_RuleURLOverrides(t *testing.T) {
+	s := &CosmosDBScanner{}
+	rules := map[string]scanners.AzureRule{"cosmos-001": s.GetRules()["cosmos-001"]}
+	target := &armcosmos.DatabaseAccountGetResults{
+		ID:   to.Ptr("test"),
+		Type: to.Ptr("Microsoft.DocumentDB/databaseAccounts"),
+	}
+
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		want      string
+	}{
+		{
+			name:      "override",
+			overrides: map[string]string{"cosmos-001": "https://wiki.contoso.com/azure/cosmos-diagnostics"},
+			want:      "https://wiki.contoso.com/azure/cosmos-diagnostics",
+		},
+		{
+			name:      "other rule overridden",
+			overrides: map[string]string{"cosmos-002": "https://wiki.contoso.com/azure/cosmos-zones"},
+			want:      rules["cosmos-001"].Url,
+		},
+		{
+			name: "no overrides",
+			want: rules["cosmos-001"].Url,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanContext := &scanners.ScanContext{
+				Exclusions:       &scanners.Exclude{},
+				RuleURLOverrides: tt.overrides,
+			}
+			engine := scanners.RuleEngine{}
+			results := engine.EvaluateRules(context.Background(), rules, target, scanContext)
+			if got := results["cosmos-001"].Learn; got != tt.want {
+				t.Errorf("cosmos-001 Learn = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
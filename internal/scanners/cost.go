@@ -29,6 +29,9 @@ type CostScanner struct {
 
 // Init - Initializes the Cost Scanner
 func (s *CostScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	s.config = config
 	var err error
 	s.client, err = armcostmanagement.NewQueryClient(config.Cred, config.ClientOptions)
@@ -4,6 +4,8 @@
 package cr
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
 )
@@ -12,13 +14,21 @@ import (
 type ContainerRegistryScanner struct {
 	config           *scanners.ScannerConfig
 	registriesClient *armcontainerregistry.RegistriesClient
+	scopeMapsClient  *armcontainerregistry.ScopeMapsClient
 }
 
 // Init - Initializes the ContainerRegistryScanner
 func (c *ContainerRegistryScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.registriesClient, err = armcontainerregistry.NewRegistriesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.scopeMapsClient, err = armcontainerregistry.NewScopeMapsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
 	return err
 }
 
@@ -34,17 +44,29 @@ func (c *ContainerRegistryScanner) Scan(resourceGroupName string, scanContext *s
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.ContainerRegistryScopeMaps = map[string]int{}
+
 	for _, registry := range regsitries {
-		rr := engine.EvaluateRules(rules, registry, scanContext)
+		if registry.SKU != nil && registry.SKU.Name != nil && *registry.SKU.Name == armcontainerregistry.SKUNamePremium {
+			count, err := c.countUserScopeMaps(resourceGroupName, *registry.Name)
+			if err != nil {
+				return nil, err
+			}
+			scanContext.ContainerRegistryScopeMaps[strings.ToLower(*registry.ID)] = count
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, registry, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
-			SubscriptionID: c.config.SubscriptionID,
+			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
-			ResourceGroup:  resourceGroupName,
-			ServiceName:    *registry.Name,
-			Type:           *registry.Type,
-			Location:       *registry.Location,
-			Rules:          rr,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *registry.Name,
+			ID:               *registry.ID,
+			Type:             *registry.Type,
+			Location:         *registry.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(registry),
 		})
 	}
 	return results, nil
@@ -63,3 +85,23 @@ func (c *ContainerRegistryScanner) listRegistries(resourceGroupName string) ([]*
 	}
 	return registries, nil
 }
+
+func (c *ContainerRegistryScanner) countUserScopeMaps(resourceGroupName, registryName string) (int, error) {
+	pager := c.scopeMapsClient.NewListPager(resourceGroupName, registryName, nil)
+
+	count := 0
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range resp.Value {
+			// Skip the built-in _repositories_admin, _repositories_pull and _repositories_push scope maps
+			if s.Properties != nil && s.Properties.Type != nil && strings.EqualFold(*s.Properties.Type, "BuildIn") {
+				continue
+			}
+			count++
+		}
+	}
+	return count, nil
+}
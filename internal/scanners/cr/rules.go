@@ -4,6 +4,8 @@
 package cr
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,22 +20,25 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "ContainerRegistry should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armcontainerregistry.Registry)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/container-registry/monitor-service",
+			Url:             "https://learn.microsoft.com/en-us/azure/container-registry/monitor-service",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"cr-002": {
 			Id:             "cr-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerRegistry should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcontainerregistry.Registry)
 				zones := *i.Properties.ZoneRedundancy == armcontainerregistry.ZoneRedundancyEnabled
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-registry/zone-redundancy",
 		},
@@ -42,8 +47,8 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerRegistry should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.95%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.95%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/container-registry/",
 		},
@@ -52,10 +57,11 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "ContainerRegistry should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcontainerregistry.Registry)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-registry/container-registry-private-link",
 		},
@@ -64,9 +70,9 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "ContainerRegistry SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armcontainerregistry.Registry)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-registry/container-registry-skus",
 		},
@@ -75,10 +81,10 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "ContainerRegistry Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerregistry.Registry)
 				caf := strings.HasPrefix(*c.Name, "cr")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -87,10 +93,10 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "ContainerRegistry should have anonymous pull access disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerregistry.Registry)
 				apull := c.Properties.AnonymousPullEnabled != nil && *c.Properties.AnonymousPullEnabled
-				return apull, ""
+				return apull, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/container-registry/anonymous-pull-access#configure-anonymous-pull-access",
 		},
@@ -99,10 +105,11 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "ContainerRegistry should have the Administrator account disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerregistry.Registry)
-				admin := c.Properties.AdminUserEnabled != nil && *c.Properties.AdminUserEnabled
-				return admin, ""
+				// Registries created before 2019 may have the admin user enabled when the property is not set.
+				admin := c.Properties.AdminUserEnabled == nil || *c.Properties.AdminUserEnabled
+				return admin, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/container-registry/container-registry-authentication-managed-identity",
 		},
@@ -111,25 +118,58 @@ func (a *ContainerRegistryScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "ContainerRegistry should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerregistry.Registry)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"cr-010": {
 			Id:             "cr-010",
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "ContainerRegistry should use retention policies",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcontainerregistry.Registry)
 				return c.Properties.Policies == nil ||
 					c.Properties.Policies.RetentionPolicy == nil ||
 					c.Properties.Policies.RetentionPolicy.Status == nil ||
-					*c.Properties.Policies.RetentionPolicy.Status == armcontainerregistry.PolicyStatusDisabled, ""
+					*c.Properties.Policies.RetentionPolicy.Status == armcontainerregistry.PolicyStatusDisabled, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/container-registry/container-registry-retention-policy",
 		},
+		"cr-011": {
+			Id:             "cr-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "ContainerRegistry Premium should use scope maps for token-based access",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerregistry.Registry)
+				if c.SKU == nil || c.SKU.Name == nil || *c.SKU.Name != armcontainerregistry.SKUNamePremium {
+					return false, "", nil
+				}
+				count := scanContext.ContainerRegistryScopeMaps[strings.ToLower(*c.ID)]
+				return count == 0, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/container-registry/container-registry-repository-scoped-permissions",
+		},
+		"cr-012": {
+			Id:             "cr-012",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "ContainerRegistry should have image vulnerability scanning enabled",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armcontainerregistry.Registry)
+				// Assessments are only fetched when Defender CSPM or Defender for Containers is enabled
+				if scanContext.ContainerRegistryVulnAssessments == nil {
+					return false, "", fmt.Errorf("vulnerability assessments not available for %s", *c.Name)
+				}
+				return !scanContext.ContainerRegistryVulnAssessments[strings.ToLower(*c.ID)], "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/defender-for-cloud/agentless-vulnerability-assessment-azure",
+		},
 	}
 }
@@ -4,6 +4,7 @@
 package cr
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -163,7 +164,7 @@ func TestContainerRegistryScanner_Rules(t *testing.T) {
 				scanContext: &scanners.ScanContext{},
 			},
 			want: want{
-				broken: false,
+				broken: true,
 				result: "",
 			},
 		},
@@ -217,12 +218,108 @@ func TestContainerRegistryScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "ContainerRegistryScanner Premium without scope maps",
+			fields: fields{
+				rule: "cr-011",
+				target: &armcontainerregistry.Registry{
+					ID: to.Ptr("test"),
+					SKU: &armcontainerregistry.SKU{
+						Name: to.Ptr(armcontainerregistry.SKUNamePremium),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ContainerRegistryScopeMaps: map[string]int{
+						"test": 0,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerRegistryScanner Premium with scope maps",
+			fields: fields{
+				rule: "cr-011",
+				target: &armcontainerregistry.Registry{
+					ID: to.Ptr("test"),
+					SKU: &armcontainerregistry.SKU{
+						Name: to.Ptr(armcontainerregistry.SKUNamePremium),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ContainerRegistryScopeMaps: map[string]int{
+						"test": 2,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerRegistryScanner Standard scope maps not applicable",
+			fields: fields{
+				rule: "cr-011",
+				target: &armcontainerregistry.Registry{
+					ID: to.Ptr("test"),
+					SKU: &armcontainerregistry.SKU{
+						Name: to.Ptr(armcontainerregistry.SKUNameStandard),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerRegistryScanner vulnerability assessment present",
+			fields: fields{
+				rule: "cr-012",
+				target: &armcontainerregistry.Registry{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/Test"),
+				},
+				scanContext: &scanners.ScanContext{
+					ContainerRegistryVulnAssessments: map[string]bool{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.containerregistry/registries/test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ContainerRegistryScanner vulnerability assessment missing",
+			fields: fields{
+				rule: "cr-012",
+				target: &armcontainerregistry.Registry{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/test"),
+				},
+				scanContext: &scanners.ScanContext{
+					ContainerRegistryVulnAssessments: map[string]bool{},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ContainerRegistryScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ContainerRegistryScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -233,3 +330,16 @@ func TestContainerRegistryScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerRegistryScanner_VulnAssessments_NotAvailable(t *testing.T) {
+	s := &ContainerRegistryScanner{}
+	rules := s.GetRules()
+	target := &armcontainerregistry.Registry{
+		ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/test"),
+		Name: to.Ptr("test"),
+	}
+	_, _, err := rules["cr-012"].Eval(context.Background(), target, &scanners.ScanContext{})
+	if err == nil {
+		t.Error("ContainerRegistryScanner Rule.Eval() error = nil, want an error when the vulnerability assessments are not available")
+	}
+}
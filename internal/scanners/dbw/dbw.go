@@ -4,21 +4,31 @@
 package dbw
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/databricks/armdatabricks"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/rs/zerolog/log"
 )
 
 // DatabricksScanner - Scanner for Azure Databricks
 type DatabricksScanner struct {
-	config *scanners.ScannerConfig
-	client *armdatabricks.WorkspacesClient
+	config         *scanners.ScannerConfig
+	client         *armdatabricks.WorkspacesClient
+	runtimeChecker *DatabricksWorkspaceRuntimeChecker
 }
 
 // Init - Initializes the DatabricksScanner
 func (c *DatabricksScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.client, err = armdatabricks.NewWorkspacesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	c.runtimeChecker = NewDatabricksWorkspaceRuntimeChecker(config.Cred)
 	return err
 }
 
@@ -34,17 +44,38 @@ func (c *DatabricksScanner) Scan(resourceGroupName string, scanContext *scanners
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	if scanContext.SubnetRouteInfo == nil {
+		scanContext.SubnetRouteInfo = map[string]scanners.SubnetRouteInfo{}
+	}
+
 	for _, ws := range workspaces {
-		rr := engine.EvaluateRules(rules, ws, scanContext)
+		for _, subnetID := range workspaceSubnets(ws) {
+			if _, ok := scanContext.SubnetRouteInfo[subnetID]; ok {
+				continue
+			}
+			// dbw-009 is skipped for the workspace when the subnet can't be read (e.g. in another subscription)
+			info, err := c.getSubnetRouteInfo(subnetID)
+			if err != nil {
+				log.Warn().Err(err).Msgf("Failed to get subnet %s of Databricks workspace %s", subnetID, *ws.Name)
+				continue
+			}
+			scanContext.SubnetRouteInfo[subnetID] = info
+		}
+
+		c.loadClusters(ws, scanContext)
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, ws, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *ws.Name,
+			ID:               *ws.ID,
 			Type:             *ws.Type,
 			Location:         *ws.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(ws),
 		})
 	}
 	return results, nil
@@ -63,3 +94,47 @@ func (c *DatabricksScanner) listWorkspaces(resourceGroupName string) ([]*armdata
 	}
 	return registries, nil
 }
+
+// loadClusters - Adds the clusters of the workspace to the scan context. Workspaces whose clusters
+// can't be listed (e.g. missing permissions or network restrictions) are logged and skipped:
+// dbw-010 and dbw-011 are not evaluated for them.
+func (c *DatabricksScanner) loadClusters(ws *armdatabricks.Workspace, scanContext *scanners.ScanContext) {
+	if ws.Properties == nil || ws.Properties.WorkspaceURL == nil {
+		return
+	}
+
+	clusters, err := c.runtimeChecker.ListClusters(c.config.Ctx, *ws.Properties.WorkspaceURL, *ws.ID)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to list clusters of Databricks workspace %s", *ws.Name)
+		return
+	}
+
+	scanContext.Lock()
+	defer scanContext.Unlock()
+	if scanContext.DatabricksClusters == nil {
+		scanContext.DatabricksClusters = map[string][]scanners.DatabricksCluster{}
+	}
+	scanContext.DatabricksClusters[strings.ToLower(*ws.ID)] = clusters
+}
+
+func (c *DatabricksScanner) getSubnetRouteInfo(subnetID string) (scanners.SubnetRouteInfo, error) {
+	info := scanners.SubnetRouteInfo{}
+	id, err := arm.ParseResourceID(subnetID)
+	if err != nil {
+		return info, err
+	}
+	// The injected VNET may live in a different subscription than the workspace
+	client, err := armnetwork.NewSubnetsClient(id.SubscriptionID, c.config.Cred, c.config.ClientOptions)
+	if err != nil {
+		return info, err
+	}
+	resp, err := client.Get(c.config.Ctx, id.ResourceGroupName, id.Parent.Name, id.Name, nil)
+	if err != nil {
+		return info, err
+	}
+	if resp.Properties != nil {
+		info.RouteTable = resp.Properties.RouteTable != nil
+		info.NatGateway = resp.Properties.NatGateway != nil
+	}
+	return info, nil
+}
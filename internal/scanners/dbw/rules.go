@@ -4,10 +4,11 @@
 package dbw
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
-	"github.com/Azure/azqr/internal/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/databricks/armdatabricks"
 )
 
@@ -19,20 +20,23 @@ func (a *DatabricksScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Azure Databricks should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armdatabricks.Workspace)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/databricks/administration-guide/account-settings/audit-log-delivery",
+			Url:             "https://learn.microsoft.com/en-us/azure/databricks/administration-guide/account-settings/audit-log-delivery",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"dbw-003": {
 			Id:             "dbw-003",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Databricks should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.95%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.95%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -41,10 +45,11 @@ func (a *DatabricksScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Databricks should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armdatabricks.Workspace)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/databricks/administration-guide/cloud-configurations/azure/private-link",
 		},
@@ -53,9 +58,9 @@ func (a *DatabricksScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Databricks SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armdatabricks.Workspace)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/databricks/",
 		},
@@ -64,10 +69,10 @@ func (a *DatabricksScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Databricks Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdatabricks.Workspace)
 				caf := strings.HasPrefix(*c.Name, "dbw")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -75,13 +80,108 @@ func (a *DatabricksScanner) GetRules() map[string]scanners.AzureRule {
 			Id:             "dbw-007",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Databricks should have the Public IP disabled",
-			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armdatabricks.Workspace)
-				broken := c.Properties.Parameters.EnableNoPublicIP != nil && c.Properties.Parameters.EnableNoPublicIP.Value == to.Ptr(true)
-				return broken, ""
+				return !noPublicIPEnabled(c), "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/databricks/security/network/secure-cluster-connectivity",
 		},
+		"dbw-008": {
+			Id:             "dbw-008",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Databricks with the Public IP disabled should be deployed in a customer managed VNET",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armdatabricks.Workspace)
+				if !noPublicIPEnabled(c) {
+					// Already reported by dbw-007
+					return false, "", nil
+				}
+				vnet := c.Properties.Parameters.CustomVirtualNetworkID
+				if vnet == nil || vnet.Value == nil || *vnet.Value == "" {
+					return true, "", nil
+				}
+				return false, *vnet.Value, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/databricks/security/network/classic/vnet-inject",
+		},
+		"dbw-009": {
+			Id:             "dbw-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Databricks VNET injected subnets should control egress with a NAT Gateway or a User-Defined Route",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armdatabricks.Workspace)
+				missing := []string{}
+				for _, subnetID := range workspaceSubnets(c) {
+					info, ok := scanContext.SubnetRouteInfo[subnetID]
+					if !ok {
+						return false, "", fmt.Errorf("subnet %s not available for %s", subnetID, *c.Name)
+					}
+					if !info.RouteTable && !info.NatGateway {
+						missing = append(missing, subnetID[strings.LastIndex(subnetID, "/")+1:])
+					}
+				}
+				return len(missing) > 0, strings.Join(missing, ","), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/databricks/security/network/classic/udr",
+		},
+		"dbw-010": {
+			Id:             "dbw-010",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Azure Databricks clusters should use a Long Term Support (LTS) Databricks Runtime",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armdatabricks.Workspace)
+				clusters, err := clustersWithRuntime(*c.ID, scanContext, runtimeNonLTS)
+				if err != nil {
+					return false, "", err
+				}
+				return len(clusters) > 0, strings.Join(clusters, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/databricks/release-notes/runtime/",
+		},
+		"dbw-011": {
+			Id:             "dbw-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Databricks clusters should not use an end of support Databricks Runtime",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armdatabricks.Workspace)
+				clusters, err := clustersWithRuntime(*c.ID, scanContext, runtimeEOL)
+				if err != nil {
+					return false, "", err
+				}
+				return len(clusters) > 0, strings.Join(clusters, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/databricks/release-notes/runtime/databricks-runtime-ver",
+		},
+	}
+}
+
+func noPublicIPEnabled(c *armdatabricks.Workspace) bool {
+	if c.Properties == nil || c.Properties.Parameters == nil {
+		return false
+	}
+	npip := c.Properties.Parameters.EnableNoPublicIP
+	return npip != nil && npip.Value != nil && *npip.Value
+}
+
+// workspaceSubnets - Returns the lowercased IDs of the subnets a VNET injected workspace is deployed to
+func workspaceSubnets(c *armdatabricks.Workspace) []string {
+	subnets := []string{}
+	if c.Properties == nil || c.Properties.Parameters == nil {
+		return subnets
+	}
+	p := c.Properties.Parameters
+	if p.CustomVirtualNetworkID == nil || p.CustomVirtualNetworkID.Value == nil || *p.CustomVirtualNetworkID.Value == "" {
+		return subnets
+	}
+	for _, name := range []*armdatabricks.WorkspaceCustomStringParameter{p.CustomPublicSubnetName, p.CustomPrivateSubnetName} {
+		if name != nil && name.Value != nil && *name.Value != "" {
+			subnets = append(subnets, strings.ToLower(*p.CustomVirtualNetworkID.Value+"/subnets/"+*name.Value))
+		}
 	}
+	return subnets
 }
@@ -4,8 +4,10 @@
 package dbw
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azqr/internal/to"
@@ -13,6 +15,19 @@ import (
 )
 
 func TestDatabricksScanner_Rules(t *testing.T) {
+	testNow := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	clustersContext := &scanners.ScanContext{
+		DatabricksClusters: map[string][]scanners.DatabricksCluster{
+			"test": {
+				{Name: "lts", SparkVersion: "15.4.x-scala2.12"},
+				{Name: "latest", SparkVersion: "17.1.x-scala2.13"},
+				{Name: "expired-lts", SparkVersion: "12.2.x-scala2.12"},
+				{Name: "old", SparkVersion: "13.0.x-scala2.12"},
+				{Name: "custom", SparkVersion: "custom:image"},
+			},
+		},
+	}
+
 	type fields struct {
 		rule        string
 		target      interface{}
@@ -127,12 +142,215 @@ func TestDatabricksScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "DatabricksScanner Public IP enabled",
+			fields: fields{
+				rule: "dbw-007",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{
+						Parameters: &armdatabricks.WorkspaceCustomParameters{
+							EnableNoPublicIP: &armdatabricks.WorkspaceCustomBooleanParameter{
+								Value: to.Ptr(false),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DatabricksScanner Public IP without parameters",
+			fields: fields{
+				rule: "dbw-007",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DatabricksScanner Public IP disabled without VNET injection",
+			fields: fields{
+				rule: "dbw-008",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{
+						Parameters: &armdatabricks.WorkspaceCustomParameters{
+							EnableNoPublicIP: &armdatabricks.WorkspaceCustomBooleanParameter{
+								Value: to.Ptr(true),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "DatabricksScanner Public IP disabled with VNET injection",
+			fields: fields{
+				rule: "dbw-008",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{
+						Parameters: &armdatabricks.WorkspaceCustomParameters{
+							EnableNoPublicIP: &armdatabricks.WorkspaceCustomBooleanParameter{
+								Value: to.Ptr(true),
+							},
+							CustomVirtualNetworkID: &armdatabricks.WorkspaceCustomStringParameter{
+								Value: to.Ptr("vnet"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "vnet",
+			},
+		},
+		{
+			name: "DatabricksScanner VNET injection without parameters",
+			fields: fields{
+				rule: "dbw-008",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DatabricksScanner egress not controlled",
+			fields: fields{
+				rule: "dbw-009",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{
+						Parameters: &armdatabricks.WorkspaceCustomParameters{
+							CustomVirtualNetworkID: &armdatabricks.WorkspaceCustomStringParameter{
+								Value: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"),
+							},
+							CustomPublicSubnetName: &armdatabricks.WorkspaceCustomStringParameter{
+								Value: to.Ptr("public"),
+							},
+							CustomPrivateSubnetName: &armdatabricks.WorkspaceCustomStringParameter{
+								Value: to.Ptr("private"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					SubnetRouteInfo: map[string]scanners.SubnetRouteInfo{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/virtualnetworks/vnet/subnets/public":  {NatGateway: true},
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/virtualnetworks/vnet/subnets/private": {},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "private",
+			},
+		},
+		{
+			name: "DatabricksScanner egress controlled",
+			fields: fields{
+				rule: "dbw-009",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{
+						Parameters: &armdatabricks.WorkspaceCustomParameters{
+							CustomVirtualNetworkID: &armdatabricks.WorkspaceCustomStringParameter{
+								Value: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"),
+							},
+							CustomPublicSubnetName: &armdatabricks.WorkspaceCustomStringParameter{
+								Value: to.Ptr("public"),
+							},
+							CustomPrivateSubnetName: &armdatabricks.WorkspaceCustomStringParameter{
+								Value: to.Ptr("private"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					SubnetRouteInfo: map[string]scanners.SubnetRouteInfo{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/virtualnetworks/vnet/subnets/public":  {NatGateway: true},
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/virtualnetworks/vnet/subnets/private": {RouteTable: true},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DatabricksScanner egress without VNET injection",
+			fields: fields{
+				rule: "dbw-009",
+				target: &armdatabricks.Workspace{
+					Properties: &armdatabricks.WorkspaceProperties{
+						Parameters: &armdatabricks.WorkspaceCustomParameters{},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DatabricksScanner non LTS runtime",
+			fields: fields{
+				rule: "dbw-010",
+				target: &armdatabricks.Workspace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: clustersContext,
+			},
+			want: want{
+				broken: true,
+				result: "latest (17.1.x-scala2.13)",
+			},
+		},
+		{
+			name: "DatabricksScanner end of support runtime",
+			fields: fields{
+				rule: "dbw-011",
+				target: &armdatabricks.Workspace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: clustersContext,
+			},
+			want: want{
+				broken: true,
+				result: "expired-lts (12.2.x-scala2.12), old (13.0.x-scala2.12)",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			now = func() time.Time { return testNow }
+			defer func() { now = time.Now }()
+
 			s := &DatabricksScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("DatabricksScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -143,3 +361,39 @@ func TestDatabricksScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabricksScanner_Subnet_NotAvailable(t *testing.T) {
+	target := &armdatabricks.Workspace{
+		Name: to.Ptr("dbw"),
+		Properties: &armdatabricks.WorkspaceProperties{
+			Parameters: &armdatabricks.WorkspaceCustomParameters{
+				CustomVirtualNetworkID: &armdatabricks.WorkspaceCustomStringParameter{
+					Value: to.Ptr("/subscriptions/y/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"),
+				},
+				CustomPublicSubnetName: &armdatabricks.WorkspaceCustomStringParameter{
+					Value: to.Ptr("public"),
+				},
+			},
+		},
+	}
+
+	s := &DatabricksScanner{}
+	rules := s.GetRules()
+	if _, _, err := rules["dbw-009"].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+		t.Error("DatabricksScanner Rule.Eval() dbw-009 error = nil, want an error when the subnet is not available")
+	}
+}
+
+func TestDatabricksScanner_Clusters_NotAvailable(t *testing.T) {
+	target := &armdatabricks.Workspace{
+		ID: to.Ptr("test"),
+	}
+
+	s := &DatabricksScanner{}
+	rules := s.GetRules()
+	for _, id := range []string{"dbw-010", "dbw-011"} {
+		if _, _, err := rules[id].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+			t.Errorf("DatabricksScanner Rule.Eval() %s error = nil, want an error when the clusters are not listed", id)
+		}
+	}
+}
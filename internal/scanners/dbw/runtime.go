@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package dbw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/embeded"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const (
+	// databricksScope - Scope of the Azure Databricks first party application
+	databricksScope = "2ff814a6-3304-4ab8-85cb-cd0e6f879c1d/.default"
+	managementScope = "https://management.azure.com/.default"
+)
+
+type (
+	// DatabricksWorkspaceRuntimeChecker - Lists the clusters of a workspace with the Databricks REST API
+	DatabricksWorkspaceRuntimeChecker struct {
+		cred       azcore.TokenCredential
+		httpClient *http.Client
+	}
+
+	// runtimeList - Databricks Runtime LTS versions and their end of support dates, embedded
+	// in databricks_runtimes.json and updated quarterly
+	runtimeList struct {
+		Updated string `json:"updated"`
+		LTS     []struct {
+			Version      string `json:"version"`
+			EndOfSupport string `json:"endOfSupport"`
+		} `json:"lts"`
+	}
+
+	runtimeStatus int
+)
+
+const (
+	runtimeUnknown runtimeStatus = iota
+	runtimeLTS
+	runtimeNonLTS
+	runtimeEOL
+)
+
+var sparkVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.`)
+
+// NewDatabricksWorkspaceRuntimeChecker - Creates a DatabricksWorkspaceRuntimeChecker
+func NewDatabricksWorkspaceRuntimeChecker(cred azcore.TokenCredential) *DatabricksWorkspaceRuntimeChecker {
+	return &DatabricksWorkspaceRuntimeChecker{
+		cred:       cred,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ListClusters - Lists the clusters of the workspace. The management token lets identities that are not
+// workspace users yet (e.g. service principals with Contributor on the workspace) call the API.
+func (c *DatabricksWorkspaceRuntimeChecker) ListClusters(ctx context.Context, workspaceURL, workspaceID string) ([]scanners.DatabricksCluster, error) {
+	token, err := c.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{databricksScope}})
+	if err != nil {
+		return nil, err
+	}
+	managementToken, err := c.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{managementScope}})
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(workspaceURL, "http://") && !strings.HasPrefix(workspaceURL, "https://") {
+		workspaceURL = "https://" + workspaceURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(workspaceURL, "/")+"/api/2.0/clusters/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	req.Header.Set("X-Databricks-Azure-SP-Management-Token", managementToken.Token)
+	req.Header.Set("X-Databricks-Azure-Workspace-Resource-Id", workspaceID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing clusters returned %s", resp.Status)
+	}
+
+	body := struct {
+		Clusters []struct {
+			ClusterName  string `json:"cluster_name"`
+			SparkVersion string `json:"spark_version"`
+		} `json:"clusters"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	clusters := []scanners.DatabricksCluster{}
+	for _, cl := range body.Clusters {
+		clusters = append(clusters, scanners.DatabricksCluster{Name: cl.ClusterName, SparkVersion: cl.SparkVersion})
+	}
+	return clusters, nil
+}
+
+// loadRuntimes - Parses the embedded Databricks Runtime list
+func loadRuntimes() runtimeList {
+	runtimes := runtimeList{}
+	_ = json.Unmarshal(embeded.GetTemplates("databricks_runtimes.json"), &runtimes)
+	return runtimes
+}
+
+var runtimes = loadRuntimes()
+
+// getRuntimeStatus - Returns whether the Spark version of a cluster (e.g. 13.3.x-scala2.12) is a supported LTS,
+// a non LTS or an end of life runtime. Non LTS versions older than the oldest supported LTS are end of life.
+func getRuntimeStatus(sparkVersion string, at time.Time) runtimeStatus {
+	major, minor, ok := parseSparkVersion(sparkVersion)
+	if !ok {
+		return runtimeUnknown
+	}
+
+	supported := false
+	for _, lts := range runtimes.LTS {
+		ltsMajor, ltsMinor, ok := parseSparkVersion(lts.Version + ".")
+		if !ok {
+			continue
+		}
+		end, err := time.Parse("2006-01-02", lts.EndOfSupport)
+		eol := err == nil && at.After(end)
+		if major == ltsMajor && minor == ltsMinor {
+			if eol {
+				return runtimeEOL
+			}
+			return runtimeLTS
+		}
+		if !eol && (major > ltsMajor || (major == ltsMajor && minor > ltsMinor)) {
+			supported = true
+		}
+	}
+	if !supported {
+		return runtimeEOL
+	}
+	return runtimeNonLTS
+}
+
+func parseSparkVersion(sparkVersion string) (int, int, bool) {
+	m := sparkVersionRegex.FindStringSubmatch(sparkVersion)
+	if m == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return major, minor, true
+}
+
+// clustersWithRuntime - Returns the clusters of the workspace running a runtime with the given status,
+// or an error when the clusters of the workspace were not listed
+func clustersWithRuntime(workspaceID string, scanContext *scanners.ScanContext, status runtimeStatus) ([]string, error) {
+	clusters, ok := scanContext.DatabricksClusters[strings.ToLower(workspaceID)]
+	if !ok {
+		return nil, fmt.Errorf("clusters not available for %s", workspaceID)
+	}
+	res := []string{}
+	for _, cl := range clusters {
+		if getRuntimeStatus(cl.SparkVersion, now()) == status {
+			res = append(res, fmt.Sprintf("%s (%s)", cl.Name, cl.SparkVersion))
+		}
+	}
+	return res, nil
+}
+
+// now - Returns the current time, replaced in tests
+var now = time.Now
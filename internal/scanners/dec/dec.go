@@ -4,21 +4,33 @@
 package dec
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/kusto/armkusto"
+	"github.com/rs/zerolog/log"
 )
 
 // DataExplorerScanner - Scanner for Data Explorer
 type DataExplorerScanner struct {
-	config *scanners.ScannerConfig
-	client *armkusto.ClustersClient
+	config          *scanners.ScannerConfig
+	client          *armkusto.ClustersClient
+	databasesClient *armkusto.DatabasesClient
 }
 
 // Init - Initializes the FrontDoor Scanner
 func (a *DataExplorerScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.client, err = armkusto.NewClustersClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.databasesClient, err = armkusto.NewDatabasesClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
 	return err
 }
 
@@ -34,8 +46,18 @@ func (a *DataExplorerScanner) Scan(resourceGroupName string, scanContext *scanne
 	rules := a.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.DataExplorerFollowerDatabases = map[string][]scanners.DataExplorerFollowerDatabase{}
+
 	for _, g := range kustoclusters {
-		rr := engine.EvaluateRules(rules, g, scanContext)
+		// dec-010 is skipped for the cluster when its followers can't be read (e.g. in another subscription)
+		followers, err := a.listFollowerDatabases(resourceGroupName, g)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to list the follower databases of Data Explorer %s", *g.Name)
+		} else {
+			scanContext.DataExplorerFollowerDatabases[strings.ToLower(*g.ID)] = followers
+		}
+
+		rr := engine.EvaluateRules(a.config.Ctx, rules, g, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
@@ -44,7 +66,9 @@ func (a *DataExplorerScanner) Scan(resourceGroupName string, scanContext *scanne
 			Location:         *g.Location,
 			Type:             *g.Type,
 			ServiceName:      *g.Name,
+			ID:               *g.ID,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(g),
 		})
 	}
 	return results, nil
@@ -63,3 +87,93 @@ func (a *DataExplorerScanner) listClusters(resourceGroupName string) ([]*armkust
 	}
 	return kustoclusters, nil
 }
+
+// listFollowerDatabases - Lists the databases following the cluster's databases, along with the leader's hot cache period
+func (a *DataExplorerScanner) listFollowerDatabases(resourceGroupName string, cluster *armkusto.Cluster) ([]scanners.DataExplorerFollowerDatabase, error) {
+	pager := a.client.NewListFollowerDatabasesPager(resourceGroupName, *cluster.Name, nil)
+
+	definitions := make([]*armkusto.FollowerDatabaseDefinition, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, resp.Value...)
+	}
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+
+	leaderDatabases, err := a.listDatabases(a.databasesClient, resourceGroupName, *cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	leaderHotCache := map[string]*string{}
+	for _, db := range leaderDatabases {
+		if rw, ok := db.(*armkusto.ReadWriteDatabase); ok && rw.Name != nil && rw.Properties != nil {
+			leaderHotCache[strings.ToLower(databaseName(*rw.Name))] = rw.Properties.HotCachePeriod
+		}
+	}
+
+	followers := []scanners.DataExplorerFollowerDatabase{}
+	for _, d := range definitions {
+		if d.ClusterResourceID == nil {
+			continue
+		}
+		id, err := arm.ParseResourceID(*d.ClusterResourceID)
+		if err != nil {
+			return nil, err
+		}
+		client := a.databasesClient
+		if !strings.EqualFold(id.SubscriptionID, a.config.SubscriptionID) {
+			client, err = armkusto.NewDatabasesClient(id.SubscriptionID, a.config.Cred, a.config.ClientOptions)
+			if err != nil {
+				return nil, err
+			}
+		}
+		databases, err := a.listDatabases(client, id.ResourceGroupName, id.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, db := range databases {
+			ro, ok := db.(*armkusto.ReadOnlyFollowingDatabase)
+			if !ok || ro.Name == nil || ro.Properties == nil || ro.Properties.LeaderClusterResourceID == nil || ro.Properties.OriginalDatabaseName == nil {
+				continue
+			}
+			if !strings.EqualFold(*ro.Properties.LeaderClusterResourceID, *cluster.ID) {
+				continue
+			}
+			if d.DatabaseName != nil && *d.DatabaseName != "*" && !strings.EqualFold(*d.DatabaseName, *ro.Properties.OriginalDatabaseName) {
+				continue
+			}
+			followers = append(followers, scanners.DataExplorerFollowerDatabase{
+				Name:                 *ro.Name,
+				HotCachePeriod:       ro.Properties.HotCachePeriod,
+				LeaderHotCachePeriod: leaderHotCache[strings.ToLower(*ro.Properties.OriginalDatabaseName)],
+			})
+		}
+	}
+	return followers, nil
+}
+
+func (a *DataExplorerScanner) listDatabases(client *armkusto.DatabasesClient, resourceGroupName, clusterName string) ([]armkusto.DatabaseClassification, error) {
+	pager := client.NewListByClusterPager(resourceGroupName, clusterName, nil)
+
+	databases := make([]armkusto.DatabaseClassification, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		databases = append(databases, resp.Value...)
+	}
+	return databases, nil
+}
+
+// databaseName - Returns the database name from a "cluster/database" resource name
+func databaseName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
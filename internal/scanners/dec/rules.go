@@ -4,6 +4,8 @@
 package dec
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,26 +20,29 @@ func (a *DataExplorerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Azure Data Explorer should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armkusto.Cluster)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/data-explorer/using-diagnostic-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/data-explorer/using-diagnostic-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"dec-002": {
 			Id:             "dec-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Data Explorer SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkusto.Cluster)
 				sla := "99.9%"
 				if c.SKU != nil && c.SKU.Name != nil && strings.HasPrefix(string(*c.SKU.Name), "Dev") {
 					sla = "None"
 				}
 
-				return sla == "None", sla
+				return sla == "None", sla, nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -46,14 +51,14 @@ func (a *DataExplorerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Data Explorer Production Cluster should not use Dev SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkusto.Cluster)
 				broken := false
 				if c.SKU != nil && c.SKU.Name != nil {
 					sku := string(*c.SKU.Name)
 					broken = strings.HasPrefix(sku, "Dev")
 				}
-				return broken, string(*c.SKU.Name)
+				return broken, string(*c.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/data-explorer/manage-cluster-choose-sku",
 		},
@@ -62,10 +67,11 @@ func (a *DataExplorerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Data Explorer should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armkusto.Cluster)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/data-explorer/security-network-private-endpoint",
 		},
@@ -74,10 +80,10 @@ func (a *DataExplorerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Data Explorer Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkusto.Cluster)
 				caf := strings.HasPrefix(*c.Name, "dec")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -86,20 +92,23 @@ func (a *DataExplorerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Data Explorer should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkusto.Cluster)
-				return c.Tags == nil || len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"dec-008": {
 			Id:             "dec-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Data Explorer should use Disk Encryption",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkusto.Cluster)
-				return c.Properties.EnableDiskEncryption == nil || !*c.Properties.EnableDiskEncryption, ""
+				return c.Properties.EnableDiskEncryption == nil || !*c.Properties.EnableDiskEncryption, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/data-explorer/cluster-encryption-overview",
 		},
@@ -108,11 +117,63 @@ func (a *DataExplorerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Data Explorer should use Managed Identities",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkusto.Cluster)
-				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armkusto.IdentityTypeNone, ""
+				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armkusto.IdentityTypeNone, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/data-explorer/configure-managed-identities-cluster?tabs=portal",
 		},
+		"dec-010": {
+			Id:             "dec-010",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Azure Data Explorer follower databases should not have a shorter hot cache period than the leader",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armkusto.Cluster)
+				followers, ok := scanContext.DataExplorerFollowerDatabases[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("follower databases not available for %s", *c.Name)
+				}
+				shorter := []string{}
+				for _, f := range followers {
+					if f.HotCachePeriod == nil || f.LeaderHotCachePeriod == nil {
+						continue
+					}
+					follower, err := scanners.ParseISODuration(*f.HotCachePeriod)
+					if err != nil {
+						continue
+					}
+					leader, err := scanners.ParseISODuration(*f.LeaderHotCachePeriod)
+					if err != nil {
+						continue
+					}
+					if follower < leader {
+						shorter = append(shorter, fmt.Sprintf("%s (%s)", f.Name, *f.HotCachePeriod))
+					}
+				}
+				return len(shorter) > 0, strings.Join(shorter, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/data-explorer/follower",
+		},
+		"dec-011": {
+			Id:             "dec-011",
+			Category:       scanners.RulesCategoryScalability,
+			Recommendation: "Azure Data Explorer should have optimized autoscale enabled",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armkusto.Cluster)
+				autoscale := c.Properties.OptimizedAutoscale
+				if autoscale == nil {
+					return true, "", nil
+				}
+				result := ""
+				if autoscale.Minimum != nil && autoscale.Maximum != nil {
+					result = fmt.Sprintf("min: %d, max: %d", *autoscale.Minimum, *autoscale.Maximum)
+				}
+				return autoscale.IsEnabled == nil || !*autoscale.IsEnabled, result, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/data-explorer/manage-cluster-horizontal-scaling",
+		},
 	}
 }
@@ -4,6 +4,7 @@
 package dec
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -175,12 +176,91 @@ func TestDataExplorerScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "DataExplorerScanner follower database with shorter hot cache",
+			fields: fields{
+				rule: "dec-010",
+				target: &armkusto.Cluster{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Kusto/clusters/Leader"),
+				},
+				scanContext: &scanners.ScanContext{
+					DataExplorerFollowerDatabases: map[string][]scanners.DataExplorerFollowerDatabase{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.kusto/clusters/leader": {
+							{Name: "follower/db1", HotCachePeriod: to.Ptr("P7D"), LeaderHotCachePeriod: to.Ptr("P31D")},
+							{Name: "follower/db2", HotCachePeriod: to.Ptr("P31D"), LeaderHotCachePeriod: to.Ptr("P31D")},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "follower/db1 (P7D)",
+			},
+		},
+		{
+			name: "DataExplorerScanner follower databases with matching hot cache",
+			fields: fields{
+				rule: "dec-010",
+				target: &armkusto.Cluster{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Kusto/clusters/leader"),
+				},
+				scanContext: &scanners.ScanContext{
+					DataExplorerFollowerDatabases: map[string][]scanners.DataExplorerFollowerDatabase{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.kusto/clusters/leader": {
+							{Name: "follower/db1", HotCachePeriod: to.Ptr("P60D"), LeaderHotCachePeriod: to.Ptr("P31D")},
+							{Name: "follower/db2", LeaderHotCachePeriod: to.Ptr("P31D")},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DataExplorerScanner optimized autoscale enabled",
+			fields: fields{
+				rule: "dec-011",
+				target: &armkusto.Cluster{
+					Properties: &armkusto.ClusterProperties{
+						OptimizedAutoscale: &armkusto.OptimizedAutoscale{
+							IsEnabled: to.Ptr(true),
+							Minimum:   to.Ptr(int32(2)),
+							Maximum:   to.Ptr(int32(10)),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "min: 2, max: 10",
+			},
+		},
+		{
+			name: "DataExplorerScanner optimized autoscale not configured",
+			fields: fields{
+				rule: "dec-011",
+				target: &armkusto.Cluster{
+					Properties: &armkusto.ClusterProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &DataExplorerScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("DataExplorerScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -191,3 +271,16 @@ func TestDataExplorerScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestDataExplorerScanner_FollowerDatabases_NotAvailable(t *testing.T) {
+	target := &armkusto.Cluster{
+		ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Kusto/clusters/leader"),
+		Name: to.Ptr("leader"),
+	}
+
+	s := &DataExplorerScanner{}
+	rules := s.GetRules()
+	if _, _, err := rules["dec-010"].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+		t.Error("DataExplorerScanner Rule.Eval() dec-010 error = nil, want an error when the follower databases are not available")
+	}
+}
@@ -20,19 +20,27 @@ type DefenderResult struct {
 
 // DefenderScanner - Defender scanner
 type DefenderScanner struct {
-	config       *ScannerConfig
-	client       *armsecurity.PricingsClient
-	defenderFunc func() ([]DefenderResult, error)
+	config            *ScannerConfig
+	client            *armsecurity.PricingsClient
+	assessmentsClient *armsecurity.AssessmentsClient
+	defenderFunc      func() ([]DefenderResult, error)
 }
 
 // Init - Initializes the Defender Scanner
 func (s *DefenderScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	s.config = config
 	var err error
 	s.client, err = armsecurity.NewPricingsClient(config.Cred, config.ClientOptions)
 	if err != nil {
 		return err
 	}
+	s.assessmentsClient, err = armsecurity.NewAssessmentsClient(config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -71,3 +79,111 @@ func (s *DefenderScanner) ListConfiguration() ([]DefenderResult, error) {
 
 	return s.defenderFunc()
 }
+
+// ListActiveRecommendations - Lists the unhealthy Defender for Cloud assessments in the subscription,
+// returning the assessment keys by resource ID.
+func (s *DefenderScanner) ListActiveRecommendations() (map[string][]string, error) {
+	LogSubscriptionScan(s.config.SubscriptionID, "Defender Recommendations")
+	res := map[string][]string{}
+	pager := s.assessmentsClient.NewListPager(fmt.Sprintf("subscriptions/%s", s.config.SubscriptionID), nil)
+	for pager.More() {
+		resp, err := pager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Value {
+			if a.ID == nil || a.Name == nil || a.Properties == nil || a.Properties.Status == nil || a.Properties.Status.Code == nil {
+				continue
+			}
+			if *a.Properties.Status.Code != armsecurity.AssessmentStatusCodeUnhealthy {
+				continue
+			}
+			resourceID := assessedResourceID(*a.ID)
+			res[resourceID] = append(res[resourceID], strings.ToLower(*a.Name))
+		}
+	}
+	return res, nil
+}
+
+// containerRegistryVulnAssessmentKeys - Defender for Cloud assessments reporting container registry image vulnerabilities
+var containerRegistryVulnAssessmentKeys = []string{
+	"c0b7cfc6-3172-465a-b378-53c7ff2cc0d5", // powered by Qualys
+	"090c7b07-b4ed-4e6e-8e0a-37aa3e44b9e1", // powered by Microsoft Defender Vulnerability Management
+}
+
+// ListContainerRegistryVulnAssessments - Lists the Container Registries with an image vulnerability assessment,
+// returning nil when neither Defender CSPM nor Defender for Containers is enabled in the subscription,
+// in which case cr-012 is skipped.
+func (s *DefenderScanner) ListContainerRegistryVulnAssessments() (map[string]bool, error) {
+	LogSubscriptionScan(s.config.SubscriptionID, "Defender Container Registry Assessments")
+	scope := fmt.Sprintf("subscriptions/%s", s.config.SubscriptionID)
+
+	licensed := false
+	for _, plan := range []string{"CloudPosture", "Containers"} {
+		resp, err := s.client.Get(s.config.Ctx, scope, plan, nil)
+		if err != nil {
+			if strings.Contains(err.Error(), "ERROR CODE: Subscription Not Registered") {
+				log.Info().Msg("Subscription Not Registered for Defender. Skipping Container Registry vulnerability assessments...")
+				return nil, nil
+			}
+			return nil, err
+		}
+		if resp.Properties != nil && resp.Properties.PricingTier != nil && *resp.Properties.PricingTier == armsecurity.PricingTierStandard {
+			licensed = true
+			break
+		}
+	}
+	if !licensed {
+		log.Info().Msg("Defender CSPM is not enabled. Skipping Container Registry vulnerability assessments...")
+		return nil, nil
+	}
+
+	res := map[string]bool{}
+	pager := s.assessmentsClient.NewListPager(scope, nil)
+	for pager.More() {
+		resp, err := pager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Value {
+			if a.ID == nil || a.Name == nil || a.Properties == nil || a.Properties.Status == nil || a.Properties.Status.Code == nil {
+				continue
+			}
+			resourceID := assessedResourceID(*a.ID)
+			if !strings.Contains(resourceID, "/providers/microsoft.containerregistry/registries/") {
+				continue
+			}
+			if *a.Properties.Status.Code == armsecurity.AssessmentStatusCodeNotApplicable {
+				continue
+			}
+			for _, key := range containerRegistryVulnAssessmentKeys {
+				if strings.EqualFold(*a.Name, key) {
+					res[resourceID] = true
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// assessedResourceID - Returns the lower case ID of the resource an assessment belongs to
+func assessedResourceID(assessmentID string) string {
+	id := strings.ToLower(assessmentID)
+	if i := strings.Index(id, "/providers/microsoft.security/assessments/"); i >= 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// isTrackedByDefender - Returns true if the rule's Defender assessment is unhealthy for the target
+func isTrackedByDefender(rule AzureRule, target interface{}, scanContext *ScanContext) bool {
+	if rule.DefenderAssessmentID == "" || scanContext == nil || scanContext.DefenderRecommendations == nil {
+		return false
+	}
+	for _, key := range scanContext.DefenderRecommendations[strings.ToLower(getResourceString(target, "ID"))] {
+		if strings.EqualFold(key, rule.DefenderAssessmentID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidateRuleDependencies - Returns an error if the DependsOn graph of the rules has a cycle
+func ValidateRuleDependencies(rules map[string]AzureRule) error {
+	_, err := ruleLevels(rules)
+	return err
+}
+
+// ruleLevels - Sorts the rules topologically by their DependsOn graph, grouping them in levels
+// where every rule only depends on rules of previous levels. Dependencies on rules not in the
+// map (e.g. excluded recommendations) are ignored.
+func ruleLevels(rules map[string]AzureRule) ([][]string, error) {
+	pending := map[string]int{}
+	dependents := map[string][]string{}
+	for k, rule := range rules {
+		pending[k] = 0
+		for _, dep := range rule.DependsOn {
+			if _, ok := rules[dep]; !ok {
+				continue
+			}
+			pending[k]++
+			dependents[dep] = append(dependents[dep], k)
+		}
+	}
+
+	levels := [][]string{}
+	level := []string{}
+	for k, n := range pending {
+		if n == 0 {
+			level = append(level, k)
+		}
+	}
+	sorted := 0
+	for len(level) > 0 {
+		sort.Strings(level)
+		levels = append(levels, level)
+		sorted += len(level)
+
+		next := []string{}
+		for _, k := range level {
+			for _, d := range dependents[k] {
+				pending[d]--
+				if pending[d] == 0 {
+					next = append(next, d)
+				}
+			}
+		}
+		level = next
+	}
+
+	if sorted < len(rules) {
+		cycle := []string{}
+		for k, n := range pending {
+			if n > 0 {
+				cycle = append(cycle, k)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("rule dependency cycle between %s", strings.Join(cycle, ", "))
+	}
+	return levels, nil
+}
+
+// dependencyBroken - Returns true if a prerequisite of the rule was evaluated as not compliant, failed
+// with an error or was skipped
+func dependencyBroken(rule AzureRule, rules map[string]AzureRule, results map[string]AzureRuleResult) bool {
+	for _, dep := range rule.DependsOn {
+		if _, ok := rules[dep]; !ok {
+			continue
+		}
+		r, ok := results[dep]
+		if !ok || r.NotCompliant || r.Error != nil {
+			return true
+		}
+	}
+	return false
+}
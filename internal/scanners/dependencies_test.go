@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func dependencyTestRule(id string, broken bool, dependsOn ...string) AzureRule {
+	return AzureRule{
+		Id:        id,
+		Impact:    ImpactLow,
+		DependsOn: dependsOn,
+		Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+			return broken, "", nil
+		},
+	}
+}
+
+func TestRuleEngine_EvaluateRules_DependsOn(t *testing.T) {
+	rules := map[string]AzureRule{
+		"test-001": dependencyTestRule("test-001", true),
+		"test-002": dependencyTestRule("test-002", true, "test-001"),
+		"test-003": dependencyTestRule("test-003", false, "test-002"),
+		"test-004": dependencyTestRule("test-004", false),
+		"test-005": dependencyTestRule("test-005", true, "test-004"),
+		"test-006": dependencyTestRule("test-006", false, "excluded-001"),
+	}
+	want := []string{"test-001", "test-004", "test-005", "test-006"}
+
+	for _, parallel := range []bool{false, true} {
+		engine := RuleEngine{}
+		scanContext := &ScanContext{Exclusions: &Exclude{}, ParallelRules: parallel}
+		results := engine.EvaluateRules(context.Background(), rules, nil, scanContext)
+		got := []string{}
+		for _, id := range []string{"test-001", "test-002", "test-003", "test-004", "test-005", "test-006"} {
+			if _, ok := results[id]; ok {
+				got = append(got, id)
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("RuleEngine.EvaluateRules() parallel=%v evaluated %v, want %v", parallel, got, want)
+		}
+	}
+}
+
+func TestRuleEngine_EvaluateRules_DependsOnError(t *testing.T) {
+	rules := map[string]AzureRule{
+		"test-001": {
+			Id: "test-001",
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				return false, "", errors.New("data not available")
+			},
+		},
+		"test-002": dependencyTestRule("test-002", true, "test-001"),
+	}
+
+	engine := RuleEngine{}
+	results := engine.EvaluateRules(context.Background(), rules, nil, &ScanContext{Exclusions: &Exclude{}})
+	if _, ok := results["test-002"]; ok {
+		t.Errorf("RuleEngine.EvaluateRules() evaluated test-002, want it skipped when test-001 fails with an error")
+	}
+}
+
+func TestValidateRuleDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]AzureRule
+		wantErr bool
+	}{
+		{
+			name: "no cycle",
+			rules: map[string]AzureRule{
+				"test-001": dependencyTestRule("test-001", false),
+				"test-002": dependencyTestRule("test-002", false, "test-001"),
+				"test-003": dependencyTestRule("test-003", false, "test-001", "test-002"),
+			},
+		},
+		{
+			name: "self dependency",
+			rules: map[string]AzureRule{
+				"test-001": dependencyTestRule("test-001", false, "test-001"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "cycle",
+			rules: map[string]AzureRule{
+				"test-001": dependencyTestRule("test-001", false, "test-003"),
+				"test-002": dependencyTestRule("test-002", false, "test-001"),
+				"test-003": dependencyTestRule("test-003", false, "test-002"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRuleDependencies(tt.rules); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRuleDependencies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -26,6 +26,9 @@ type DiagnosticSettingsScanner struct {
 
 // Init - Initializes the DiagnosticSettingsScanner
 func (d *DiagnosticSettingsScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	d.config = config
 	client, err := arm.NewClient(moduleName+".DiagnosticSettingsBatch", moduleVersion, d.config.Cred, d.config.ClientOptions)
 	if err != nil {
@@ -36,10 +39,12 @@ func (d *DiagnosticSettingsScanner) Init(config *ScannerConfig) error {
 	return nil
 }
 
-// ListResourcesWithDiagnosticSettings - Lists all resources with diagnostic settings
-func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[string]bool, error) {
+// ListResourcesWithDiagnosticSettings - Lists all resources with diagnostic settings, and the log
+// categories (or category groups, e.g. allLogs) enabled by their settings
+func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[string]bool, map[string][]string, error) {
 	resources := []string{}
 	res := map[string]bool{}
+	categories := map[string][]string{}
 
 	LogSubscriptionScan(d.config.SubscriptionID, "Resource Ids")
 
@@ -47,7 +52,7 @@ func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[s
 
 	if result == nil || result.Data == nil {
 		log.Info().Msg("Preflight: No resources found")
-		return res, nil
+		return res, categories, nil
 	}
 
 	for _, row := range result.Data {
@@ -58,7 +63,7 @@ func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[s
 	batches := int(math.Ceil(float64(len(resources)) / 20))
 
 	var wg sync.WaitGroup
-	ch := make(chan map[string]bool, 5)
+	ch := make(chan map[string][]string, 5)
 	wg.Add(batches)
 
 	go func() {
@@ -81,11 +86,11 @@ func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[s
 			if err != nil {
 				log.Fatal().Err(err).Msg("Failed to get diagnostic settings")
 			}
-			asyncRes := map[string]bool{}
+			asyncRes := map[string][]string{}
 			for _, response := range resp.Responses {
 				for _, diagnosticSetting := range response.Content.Value {
 					id := parseResourceId(diagnosticSetting.ID)
-					asyncRes[id] = true
+					asyncRes[id] = append(asyncRes[id], enabledLogCategories(diagnosticSetting)...)
 				}
 			}
 			ch <- asyncRes
@@ -94,11 +99,48 @@ func (d *DiagnosticSettingsScanner) ListResourcesWithDiagnosticSettings() (map[s
 
 	for i := 0; i < batches; i++ {
 		for k, v := range <-ch {
-			res[k] = v
+			res[k] = true
+			categories[k] = v
 		}
 	}
 
-	return res, nil
+	return res, categories, nil
+}
+
+// enabledLogCategories - Returns the log categories and category groups enabled by the diagnostic setting
+func enabledLogCategories(setting *armmonitor.DiagnosticSettingsResource) []string {
+	categories := []string{}
+	if setting.Properties == nil {
+		return categories
+	}
+	for _, l := range setting.Properties.Logs {
+		if l == nil || l.Enabled == nil || !*l.Enabled {
+			continue
+		}
+		if l.Category != nil {
+			categories = append(categories, *l.Category)
+		}
+		if l.CategoryGroup != nil {
+			categories = append(categories, *l.CategoryGroup)
+		}
+	}
+	return categories
+}
+
+// IsLogCategoryMissing - Returns true if the resource has diagnostic settings but none of them enables
+// the log category, directly or with the allLogs category group. Resources without diagnostic settings
+// are reported by the diagnostic settings rule of each service instead.
+func IsLogCategoryMissing(scanContext *ScanContext, resourceID, category string) bool {
+	id := strings.ToLower(resourceID)
+	if _, ok := scanContext.DiagnosticsSettings[id]; !ok {
+		return false
+	}
+	for _, c := range scanContext.DiagnosticsSettingsCategories[id] {
+		if strings.EqualFold(c, category) || strings.EqualFold(c, "allLogs") {
+			return false
+		}
+	}
+	return true
 }
 
 const (
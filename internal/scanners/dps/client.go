@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package dps
+
+import (
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// The armdeviceprovisioningservices module is not a dependency of azqr, so the scanner talks to the
+// Microsoft.Devices/provisioningServices REST API through scanners.RESTClient, using only the fields required by the rules.
+
+const apiVersion = "2022-02-05"
+
+type (
+	// ProvisioningService - Device Provisioning Service
+	ProvisioningService struct {
+		ID         *string                        `json:"id"`
+		Name       *string                        `json:"name"`
+		Type       *string                        `json:"type"`
+		Location   *string                        `json:"location"`
+		Tags       map[string]*string             `json:"tags"`
+		Properties *ProvisioningServiceProperties `json:"properties"`
+		SystemData *SystemData                    `json:"systemData"`
+	}
+
+	// SystemData - Metadata about the creation of the resource
+	SystemData struct {
+		CreatedAt *time.Time `json:"createdAt"`
+	}
+
+	// ProvisioningServiceProperties - Device Provisioning Service properties
+	ProvisioningServiceProperties struct {
+		PublicNetworkAccess        *string       `json:"publicNetworkAccess"`
+		PrivateEndpointConnections []interface{} `json:"privateEndpointConnections"`
+	}
+
+	// SharedAccessSignatureAuthorizationRule - Shared access policy of a Device Provisioning Service.
+	// Only the name and rights are read, the primary and secondary keys are discarded.
+	SharedAccessSignatureAuthorizationRule struct {
+		KeyName *string `json:"keyName"`
+		Rights  *string `json:"rights"`
+	}
+
+	provisioningServiceList struct {
+		Value    []*ProvisioningService `json:"value"`
+		NextLink *string                `json:"nextLink"`
+	}
+
+	sharedAccessSignatureAuthorizationRuleList struct {
+		Value    []*SharedAccessSignatureAuthorizationRule `json:"value"`
+		NextLink *string                                   `json:"nextLink"`
+	}
+)
+
+func (c *DPSScanner) listServices(resourceGroupName string) ([]*ProvisioningService, error) {
+	services := make([]*ProvisioningService, 0)
+	next := c.client.URL("subscriptions", c.config.SubscriptionID, "resourceGroups", resourceGroupName, "providers/Microsoft.Devices/provisioningServices")
+	for next != "" {
+		page := provisioningServiceList{}
+		if err := c.client.Get(c.config.Ctx, next, &page); err != nil {
+			return nil, err
+		}
+		services = append(services, page.Value...)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return services, nil
+}
+
+func (c *DPSScanner) listAccessPolicies(serviceID string) ([]*SharedAccessSignatureAuthorizationRule, error) {
+	policies := make([]*SharedAccessSignatureAuthorizationRule, 0)
+	next := c.client.URL(serviceID, "listkeys")
+	for next != "" {
+		page := sharedAccessSignatureAuthorizationRuleList{}
+		if err := c.client.Post(c.config.Ctx, next, &page); err != nil {
+			return nil, err
+		}
+		policies = append(policies, page.Value...)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return policies, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package dps
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// DPSScanner - Scanner for Azure IoT Hub Device Provisioning Service
+type DPSScanner struct {
+	config *scanners.ScannerConfig
+	client *scanners.RESTClient
+}
+
+// Init - Initializes the DPSScanner
+func (c *DPSScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	c.config = config
+	var err error
+	c.client, err = scanners.NewRESTClient(config, apiVersion)
+	return err
+}
+
+// Scan - Scans all Device Provisioning Services in a Resource Group
+func (c *DPSScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(c.config.SubscriptionID, resourceGroupName, "Device Provisioning Service")
+
+	services, err := c.listServices(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := c.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	scanContext.DPSAccessPolicyRights = map[string]map[string]string{}
+
+	for _, service := range services {
+		// listkeys is a POST, not allowed to Reader role scans: dps-003 is skipped when it fails
+		policies, err := c.listAccessPolicies(*service.ID)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to list shared access policies of Device Provisioning Service %s", *service.Name)
+		} else {
+			rights := map[string]string{}
+			for _, p := range policies {
+				if p.KeyName != nil && p.Rights != nil {
+					rights[*p.KeyName] = *p.Rights
+				}
+			}
+			scanContext.DPSAccessPolicyRights[strings.ToLower(*service.ID)] = rights
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, service, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   c.config.SubscriptionID,
+			SubscriptionName: c.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *service.Name,
+			ID:               *service.ID,
+			Type:             *service.Type,
+			Location:         *service.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(service),
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package dps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// adminPolicyName - Name of the built-in shared access policy granted all permissions
+const adminPolicyName = "provisioningserviceowner"
+
+// GetRules - Returns the rules for the DPSScanner
+func (c *DPSScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"dps-001": {
+			Id:             "dps-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Device Provisioning Service should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*ProvisioningService)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/iot-dps/monitor-iot-dps",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"dps-002": {
+			Id:             "dps-002",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Device Provisioning Service should have private endpoints enabled",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*ProvisioningService)
+				pe := i.Properties != nil && len(i.Properties.PrivateEndpointConnections) > 0
+				return !pe, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/iot-dps/virtual-network-support",
+		},
+		"dps-003": {
+			Id:             "dps-003",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Device Provisioning Service shared access policies should not grant EnrollmentWrite outside the owner policy",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*ProvisioningService)
+				policyRights, ok := scanContext.DPSAccessPolicyRights[strings.ToLower(*i.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("shared access policies not available for %s", *i.Name)
+				}
+				policies := []string{}
+				for name, rights := range policyRights {
+					if strings.EqualFold(name, adminPolicyName) {
+						continue
+					}
+					if hasRight(rights, "EnrollmentWrite") {
+						policies = append(policies, name)
+					}
+				}
+				sort.Strings(policies)
+				return len(policies) > 0, strings.Join(policies, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/iot-dps/how-to-control-access",
+		},
+		"dps-004": {
+			Id:             "dps-004",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Device Provisioning Service Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*ProvisioningService)
+				caf := strings.HasPrefix(*c.Name, "provs")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"dps-005": {
+			Id:             "dps-005",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Device Provisioning Service should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*ProvisioningService)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+	}
+}
+
+// hasRight - Returns true if the comma separated rights of a shared access policy contain the right
+func hasRight(rights string, right string) bool {
+	for _, r := range strings.Split(rights, ",") {
+		if strings.EqualFold(strings.TrimSpace(r), right) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package dps
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+)
+
+func TestDPSScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "DPSScanner DiagnosticSettings",
+			fields: fields{
+				rule: "dps-001",
+				target: &ProvisioningService{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DPSScanner Private Endpoint",
+			fields: fields{
+				rule: "dps-002",
+				target: &ProvisioningService{
+					Properties: &ProvisioningServiceProperties{
+						PrivateEndpointConnections: []interface{}{
+							"test",
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DPSScanner Shared Access Policies",
+			fields: fields{
+				rule: "dps-003",
+				target: &ProvisioningService{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DPSAccessPolicyRights: map[string]map[string]string{
+						"test": {
+							"provisioningserviceowner": "ServiceConfig, DeviceConnect, EnrollmentWrite",
+							"enrollmentread":           "EnrollmentRead",
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DPSScanner Shared Access Policies with EnrollmentWrite",
+			fields: fields{
+				rule: "dps-003",
+				target: &ProvisioningService{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DPSAccessPolicyRights: map[string]map[string]string{
+						"test": {
+							"provisioningserviceowner": "ServiceConfig, DeviceConnect, EnrollmentWrite",
+							"devices":                  "EnrollmentRead, EnrollmentWrite",
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "devices",
+			},
+		},
+		{
+			name: "DPSScanner CAF",
+			fields: fields{
+				rule: "dps-004",
+				target: &ProvisioningService{
+					Name: to.Ptr("provs-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "DPSScanner Tags",
+			fields: fields{
+				rule: "dps-005",
+				target: &ProvisioningService{
+					Tags: map[string]*string{
+						"test": to.Ptr("test"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &DPSScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("DPSScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DPSScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDPSScanner_CreatedAt(t *testing.T) {
+	service := &ProvisioningService{}
+	err := json.Unmarshal([]byte(`{"name":"provs","systemData":{"createdAt":"2024-01-02T03:04:05.1234567Z"}}`), service)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 123456700, time.UTC)
+	got := scanners.GetCreatedAt(service)
+	if got == nil || !got.Equal(want) {
+		t.Errorf("GetCreatedAt() = %v, want %v", got, want)
+	}
+}
+
+func TestDPSScanner_AccessPolicies_NotAvailable(t *testing.T) {
+	target := &ProvisioningService{
+		ID:   to.Ptr("test"),
+		Name: to.Ptr("provs-test"),
+	}
+
+	s := &DPSScanner{}
+	rules := s.GetRules()
+	if _, _, err := rules["dps-003"].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+		t.Error("DPSScanner Rule.Eval() dps-003 error = nil, want an error when the shared access policies are not available")
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseISODuration - Parses an ISO 8601 duration (e.g. PT1M30S or P1Y). Years and months are
+// counted as 365 and 30 days.
+func ParseISODuration(s string) (time.Duration, error) {
+	m := isoDurationRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid ISO 8601 duration: %s", s)
+	}
+	var d time.Duration
+	day := 24 * time.Hour
+	units := []time.Duration{365 * day, 30 * day, 7 * day, day, time.Hour, time.Minute, time.Second}
+	for i, u := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(m[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(v * float64(u))
+	}
+	return d, nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT1M", want: time.Minute},
+		{value: "PT1M30S", want: 90 * time.Second},
+		{value: "PT0.5S", want: 500 * time.Millisecond},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "P90D", want: 90 * 24 * time.Hour},
+		{value: "P1Y", want: 365 * 24 * time.Hour},
+		{value: "P1Y6M", want: 545 * 24 * time.Hour},
+		{value: "P2W", want: 14 * 24 * time.Hour},
+		{value: "1 minute", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseISODuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseISODuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseISODuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
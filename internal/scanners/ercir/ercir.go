@@ -0,0 +1,88 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package ercir
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+)
+
+// ExpressRouteCircuitScanner - Scanner for ExpressRoute Circuits
+type ExpressRouteCircuitScanner struct {
+	config *scanners.ScannerConfig
+	client *armnetwork.ExpressRouteCircuitsClient
+}
+
+// Init - Initializes the ExpressRouteCircuitScanner
+func (c *ExpressRouteCircuitScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	c.config = config
+	var err error
+	c.client, err = armnetwork.NewExpressRouteCircuitsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all ExpressRoute Circuits in a Resource Group
+func (c *ExpressRouteCircuitScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(c.config.SubscriptionID, resourceGroupName, "ExpressRoute Circuit")
+
+	circuits, err := c.listCircuits(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	scanContext.ExpressRouteCircuitLocations = map[string]int{}
+	for _, circuit := range circuits {
+		if location := peeringLocation(circuit); location != "" {
+			scanContext.ExpressRouteCircuitLocations[location]++
+		}
+	}
+
+	engine := scanners.RuleEngine{}
+	rules := c.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, w := range circuits {
+		rr := engine.EvaluateRules(c.config.Ctx, rules, w, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   c.config.SubscriptionID,
+			SubscriptionName: c.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *w.Name,
+			ID:               *w.ID,
+			Type:             *w.Type,
+			Location:         *w.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(w),
+		})
+	}
+	return results, nil
+}
+
+func (c *ExpressRouteCircuitScanner) listCircuits(resourceGroupName string) ([]*armnetwork.ExpressRouteCircuit, error) {
+	pager := c.client.NewListPager(resourceGroupName, nil)
+
+	circuits := make([]*armnetwork.ExpressRouteCircuit, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		circuits = append(circuits, resp.Value...)
+	}
+	return circuits, nil
+}
+
+func peeringLocation(circuit *armnetwork.ExpressRouteCircuit) string {
+	if circuit.Properties == nil || circuit.Properties.ServiceProviderProperties == nil ||
+		circuit.Properties.ServiceProviderProperties.PeeringLocation == nil {
+		return ""
+	}
+	return strings.ToLower(*circuit.Properties.ServiceProviderProperties.PeeringLocation)
+}
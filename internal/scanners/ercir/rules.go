@@ -0,0 +1,104 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package ercir
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+)
+
+// GetRules - Returns the rules for the ExpressRouteCircuitScanner
+func (c *ExpressRouteCircuitScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"ercir-001": {
+			Id:             "ercir-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "ExpressRoute Circuit should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armnetwork.ExpressRouteCircuit)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/expressroute/monitor-expressroute",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"ercir-006": {
+			Id:             "ercir-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "ExpressRoute Circuit Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.ExpressRouteCircuit)
+				caf := strings.HasPrefix(*g.Name, "erc")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"ercir-007": {
+			Id:             "ercir-007",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "ExpressRoute Circuit should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.ExpressRouteCircuit)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"ercir-008": {
+			Id:             "ercir-008",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "ExpressRoute Circuit should have a redundant circuit in the same peering location",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.ExpressRouteCircuit)
+				if c.Properties != nil && c.Properties.GlobalReachEnabled != nil && *c.Properties.GlobalReachEnabled {
+					return false, "", nil
+				}
+				location := peeringLocation(c)
+				if location == "" {
+					return false, "", nil
+				}
+				count := scanContext.ExpressRouteCircuitLocations[location]
+				return count < 2, strconv.Itoa(count), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/expressroute/designing-for-high-availability-with-expressroute",
+		},
+		"ercir-009": {
+			Id:             "ercir-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "ExpressRoute Circuit private peering should have a route filter configured",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.ExpressRouteCircuit)
+				if c.Properties == nil {
+					return false, "", nil
+				}
+				for _, p := range c.Properties.Peerings {
+					if p.Properties == nil || p.Properties.PeeringType == nil ||
+						*p.Properties.PeeringType != armnetwork.ExpressRoutePeeringTypeAzurePrivatePeering {
+						continue
+					}
+					asn := ""
+					if p.Properties.PeerASN != nil {
+						asn = strconv.FormatInt(*p.Properties.PeerASN, 10)
+					}
+					return p.Properties.RouteFilter == nil, asn, nil
+				}
+				return false, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/expressroute/how-to-routefilter-portal",
+		},
+	}
+}
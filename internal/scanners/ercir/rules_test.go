@@ -0,0 +1,223 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package ercir
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+)
+
+func TestExpressRouteCircuitScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "ExpressRouteCircuitScanner DiagnosticSettings",
+			fields: fields{
+				rule: "ercir-001",
+				target: &armnetwork.ExpressRouteCircuit{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner CAF",
+			fields: fields{
+				rule: "ercir-006",
+				target: &armnetwork.ExpressRouteCircuit{
+					Name: to.Ptr("erc-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner Tags",
+			fields: fields{
+				rule:        "ercir-007",
+				target:      &armnetwork.ExpressRouteCircuit{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner single circuit in peering location",
+			fields: fields{
+				rule: "ercir-008",
+				target: &armnetwork.ExpressRouteCircuit{
+					Properties: &armnetwork.ExpressRouteCircuitPropertiesFormat{
+						ServiceProviderProperties: &armnetwork.ExpressRouteCircuitServiceProviderProperties{
+							PeeringLocation: to.Ptr("Amsterdam"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ExpressRouteCircuitLocations: map[string]int{
+						"amsterdam": 1,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "1",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner redundant circuit in peering location",
+			fields: fields{
+				rule: "ercir-008",
+				target: &armnetwork.ExpressRouteCircuit{
+					Properties: &armnetwork.ExpressRouteCircuitPropertiesFormat{
+						ServiceProviderProperties: &armnetwork.ExpressRouteCircuitServiceProviderProperties{
+							PeeringLocation: to.Ptr("Amsterdam"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ExpressRouteCircuitLocations: map[string]int{
+						"amsterdam": 2,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "2",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner Global Reach enabled",
+			fields: fields{
+				rule: "ercir-008",
+				target: &armnetwork.ExpressRouteCircuit{
+					Properties: &armnetwork.ExpressRouteCircuitPropertiesFormat{
+						GlobalReachEnabled: to.Ptr(true),
+						ServiceProviderProperties: &armnetwork.ExpressRouteCircuitServiceProviderProperties{
+							PeeringLocation: to.Ptr("Amsterdam"),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ExpressRouteCircuitLocations: map[string]int{
+						"amsterdam": 1,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner private peering without route filter",
+			fields: fields{
+				rule: "ercir-009",
+				target: &armnetwork.ExpressRouteCircuit{
+					Properties: &armnetwork.ExpressRouteCircuitPropertiesFormat{
+						Peerings: []*armnetwork.ExpressRouteCircuitPeering{
+							{
+								Properties: &armnetwork.ExpressRouteCircuitPeeringPropertiesFormat{
+									PeeringType: to.Ptr(armnetwork.ExpressRoutePeeringTypeAzurePrivatePeering),
+									PeerASN:     to.Ptr(int64(65001)),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "65001",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner private peering with route filter",
+			fields: fields{
+				rule: "ercir-009",
+				target: &armnetwork.ExpressRouteCircuit{
+					Properties: &armnetwork.ExpressRouteCircuitPropertiesFormat{
+						Peerings: []*armnetwork.ExpressRouteCircuitPeering{
+							{
+								Properties: &armnetwork.ExpressRouteCircuitPeeringPropertiesFormat{
+									PeeringType: to.Ptr(armnetwork.ExpressRoutePeeringTypeAzurePrivatePeering),
+									PeerASN:     to.Ptr(int64(65001)),
+									RouteFilter: &armnetwork.SubResource{
+										ID: to.Ptr("test"),
+									},
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "65001",
+			},
+		},
+		{
+			name: "ExpressRouteCircuitScanner no private peering",
+			fields: fields{
+				rule: "ercir-009",
+				target: &armnetwork.ExpressRouteCircuit{
+					Properties: &armnetwork.ExpressRouteCircuitPropertiesFormat{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ExpressRouteCircuitScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ExpressRouteCircuitScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpressRouteCircuitScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
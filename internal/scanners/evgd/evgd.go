@@ -16,6 +16,9 @@ type EventGridScanner struct {
 
 // Init - Initializes the EventGridScanner
 func (a *EventGridScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.domainsClient, err = armeventgrid.NewDomainsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (a *EventGridScanner) Scan(resourceGroupName string, scanContext *scanners.
 	results := []scanners.AzureServiceResult{}
 
 	for _, d := range domains {
-		rr := engine.EvaluateRules(rules, d, scanContext)
+		rr := engine.EvaluateRules(a.config.Ctx, rules, d, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
 			SubscriptionName: a.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *d.Name,
+			ID:               *d.ID,
 			Type:             *d.Type,
 			Location:         *d.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(d),
 		})
 	}
 	return results, nil
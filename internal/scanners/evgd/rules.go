@@ -4,6 +4,7 @@
 package evgd
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,20 +19,23 @@ func (a *EventGridScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Event Grid Domain should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armeventgrid.Domain)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/event-grid/diagnostic-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/event-grid/diagnostic-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"evgd-003": {
 			Id:             "evgd-003",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Event Grid Domain should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.99%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.99%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/event-grid/",
 		},
@@ -40,10 +44,11 @@ func (a *EventGridScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Event Grid Domain should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armeventgrid.Domain)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/event-grid/configure-private-endpoints",
 		},
@@ -52,8 +57,8 @@ func (a *EventGridScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Event Grid Domain SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "None"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "None", nil
 			},
 			Url: "https://azure.microsoft.com/en-gb/pricing/details/event-grid/",
 		},
@@ -62,10 +67,10 @@ func (a *EventGridScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Event Grid Domain Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armeventgrid.Domain)
 				caf := strings.HasPrefix(*c.Name, "evgd")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -74,23 +79,44 @@ func (a *EventGridScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Event Grid Domain should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armeventgrid.Domain)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"evgd-008": {
 			Id:             "evgd-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Event Grid Domain should have local authentication disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.EventGrid/domains", "Microsoft.EventGrid/domains/disableLocalAuth", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armeventgrid.Domain)
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
-				return !localAuth, ""
+				return !localAuth, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/event-grid/authenticate-with-access-keys-shared-access-signatures",
+			Url:         "https://learn.microsoft.com/en-us/azure/event-grid/authenticate-with-access-keys-shared-access-signatures",
+			Remediation: "az eventgrid domain update --ids {resource_id} --disable-local-auth true",
+		},
+		"evgd-009": {
+			Id:             "evgd-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Event Grid Domain should not combine public network access, local authentication and no private endpoints",
+			Impact:         scanners.ImpactCritical,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventgrid.Domain)
+				public := c.Properties.PublicNetworkAccess == nil || *c.Properties.PublicNetworkAccess == armeventgrid.PublicNetworkAccessEnabled
+				localAuth := c.Properties.DisableLocalAuth == nil || !*c.Properties.DisableLocalAuth
+				pe := len(c.Properties.PrivateEndpointConnections) > 0
+				return public && localAuth && !pe, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-grid/network-security",
 		},
 	}
 }
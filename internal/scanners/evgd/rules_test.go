@@ -4,6 +4,7 @@
 package evgd
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -119,12 +120,66 @@ func TestEventGridScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "EventGridScanner public with local auth and no private endpoints",
+			fields: fields{
+				rule: "evgd-009",
+				target: &armeventgrid.Domain{
+					Properties: &armeventgrid.DomainProperties{
+						PublicNetworkAccess: to.Ptr(armeventgrid.PublicNetworkAccessEnabled),
+						DisableLocalAuth:    to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridScanner public without local auth",
+			fields: fields{
+				rule: "evgd-009",
+				target: &armeventgrid.Domain{
+					Properties: &armeventgrid.DomainProperties{
+						PublicNetworkAccess: to.Ptr(armeventgrid.PublicNetworkAccessEnabled),
+						DisableLocalAuth:    to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridScanner public access disabled",
+			fields: fields{
+				rule: "evgd-009",
+				target: &armeventgrid.Domain{
+					Properties: &armeventgrid.DomainProperties{
+						PublicNetworkAccess: to.Ptr(armeventgrid.PublicNetworkAccessDisabled),
+						DisableLocalAuth:    to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &EventGridScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("EventGridScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
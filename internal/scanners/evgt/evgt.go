@@ -0,0 +1,70 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package evgt
+
+import (
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventgrid/armeventgrid"
+)
+
+// EventGridTopicScanner - Scanner for EventGrid Topics
+type EventGridTopicScanner struct {
+	config       *scanners.ScannerConfig
+	topicsClient *armeventgrid.TopicsClient
+}
+
+// Init - Initializes the EventGridTopicScanner
+func (a *EventGridTopicScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	a.config = config
+	var err error
+	a.topicsClient, err = armeventgrid.NewTopicsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	return err
+}
+
+// Scan - Scans all EventGrid Topics in a Resource Group
+func (a *EventGridTopicScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(a.config.SubscriptionID, resourceGroupName, "EventGrid Topic")
+
+	topics, err := a.listTopics(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := a.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, t := range topics {
+		rr := engine.EvaluateRules(a.config.Ctx, rules, t, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   a.config.SubscriptionID,
+			SubscriptionName: a.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *t.Name,
+			ID:               *t.ID,
+			Type:             *t.Type,
+			Location:         *t.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(t),
+		})
+	}
+	return results, nil
+}
+
+func (a *EventGridTopicScanner) listTopics(resourceGroupName string) ([]*armeventgrid.Topic, error) {
+	pager := a.topicsClient.NewListByResourceGroupPager(resourceGroupName, nil)
+
+	topics := make([]*armeventgrid.Topic, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(a.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, resp.Value...)
+	}
+	return topics, nil
+}
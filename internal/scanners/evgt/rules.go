@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package evgt
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventgrid/armeventgrid"
+)
+
+// GetRules - Returns the rules for the EventGridTopicScanner
+func (a *EventGridTopicScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"evgt-001": {
+			Id:             "evgt-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Event Grid Topic should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armeventgrid.Topic)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/event-grid/diagnostic-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"evgt-002": {
+			Id:             "evgt-002",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Event Grid Topic should have a SLA",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.99%", nil
+			},
+			Url: "https://www.azure.cn/en-us/support/sla/event-grid/",
+		},
+		"evgt-003": {
+			Id:             "evgt-003",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Event Grid Topic should have private endpoints enabled",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventgrid.Topic)
+				return !hasPrivateEndpoints(c), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-grid/configure-private-endpoints",
+		},
+		"evgt-004": {
+			Id:             "evgt-004",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Event Grid Topic Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventgrid.Topic)
+				caf := strings.HasPrefix(*c.Name, "evgt")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"evgt-005": {
+			Id:             "evgt-005",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Event Grid Topic should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventgrid.Topic)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"evgt-006": {
+			Id:             "evgt-006",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Event Grid Topic should have local authentication disabled",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "identity"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.EventGrid/topics", "Microsoft.EventGrid/topics/disableLocalAuth", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventgrid.Topic)
+				return localAuthEnabled(c), "", nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/event-grid/authenticate-with-access-keys-shared-access-signatures",
+			Remediation: "az eventgrid topic update --ids {resource_id} --disable-local-auth true",
+		},
+		"evgt-007": {
+			Id:             "evgt-007",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Event Grid Topic should not combine public network access, local authentication and no private endpoints",
+			Impact:         scanners.ImpactCritical,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventgrid.Topic)
+				return publicNetworkAccess(c) && localAuthEnabled(c) && !hasPrivateEndpoints(c), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-grid/network-security",
+		},
+	}
+}
+
+func publicNetworkAccess(c *armeventgrid.Topic) bool {
+	return c.Properties == nil || c.Properties.PublicNetworkAccess == nil || *c.Properties.PublicNetworkAccess == armeventgrid.PublicNetworkAccessEnabled
+}
+
+func localAuthEnabled(c *armeventgrid.Topic) bool {
+	return c.Properties == nil || c.Properties.DisableLocalAuth == nil || !*c.Properties.DisableLocalAuth
+}
+
+func hasPrivateEndpoints(c *armeventgrid.Topic) bool {
+	return c.Properties != nil && len(c.Properties.PrivateEndpointConnections) > 0
+}
@@ -0,0 +1,212 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package evgt
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventgrid/armeventgrid"
+)
+
+func TestEventGridTopicScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "EventGridTopicScanner DiagnosticSettings",
+			fields: fields{
+				rule: "evgt-001",
+				target: &armeventgrid.Topic{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner SLA",
+			fields: fields{
+				rule:        "evgt-002",
+				target:      &armeventgrid.Topic{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "99.99%",
+			},
+		},
+		{
+			name: "EventGridTopicScanner Private Endpoint",
+			fields: fields{
+				rule: "evgt-003",
+				target: &armeventgrid.Topic{
+					Properties: &armeventgrid.TopicProperties{
+						PrivateEndpointConnections: []*armeventgrid.PrivateEndpointConnection{
+							{
+								ID: to.Ptr("test"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner CAF",
+			fields: fields{
+				rule: "evgt-004",
+				target: &armeventgrid.Topic{
+					Name: to.Ptr("evgt-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner Tags",
+			fields: fields{
+				rule: "evgt-005",
+				target: &armeventgrid.Topic{
+					Tags: map[string]*string{"env": to.Ptr("prod")},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner local auth enabled",
+			fields: fields{
+				rule: "evgt-006",
+				target: &armeventgrid.Topic{
+					Properties: &armeventgrid.TopicProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner local auth disabled",
+			fields: fields{
+				rule: "evgt-006",
+				target: &armeventgrid.Topic{
+					Properties: &armeventgrid.TopicProperties{
+						DisableLocalAuth: to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner public access, local auth and no private endpoints",
+			fields: fields{
+				rule: "evgt-007",
+				target: &armeventgrid.Topic{
+					Properties: &armeventgrid.TopicProperties{
+						PublicNetworkAccess: to.Ptr(armeventgrid.PublicNetworkAccessEnabled),
+						DisableLocalAuth:    to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner public access with private endpoints",
+			fields: fields{
+				rule: "evgt-007",
+				target: &armeventgrid.Topic{
+					Properties: &armeventgrid.TopicProperties{
+						PublicNetworkAccess: to.Ptr(armeventgrid.PublicNetworkAccessEnabled),
+						PrivateEndpointConnections: []*armeventgrid.PrivateEndpointConnection{
+							{
+								ID: to.Ptr("test"),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventGridTopicScanner public access disabled",
+			fields: fields{
+				rule: "evgt-007",
+				target: &armeventgrid.Topic{
+					Properties: &armeventgrid.TopicProperties{
+						PublicNetworkAccess: to.Ptr(armeventgrid.PublicNetworkAccessDisabled),
+						DisableLocalAuth:    to.Ptr(false),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &EventGridTopicScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("EventGridTopicScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EventGridTopicScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
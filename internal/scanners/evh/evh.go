@@ -4,21 +4,55 @@
 package evh
 
 import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventhub/armeventhub"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/rs/zerolog/log"
 )
 
+// storageAPIVersion - API version used to check that the capture storage accounts exist
+const storageAPIVersion = "2023-01-01"
+
 // EventHubScanner - Scanner for Event Hubs
 type EventHubScanner struct {
-	config *scanners.ScannerConfig
-	client *armeventhub.NamespacesClient
+	config               *scanners.ScannerConfig
+	client               *armeventhub.NamespacesClient
+	eventHubsClient      *armeventhub.EventHubsClient
+	consumerGroupsClient *armeventhub.ConsumerGroupsClient
+	schemaRegistryClient *armeventhub.SchemaRegistryClient
+	resourcesClient      *armresources.Client
 }
 
 // Init - Initializes the EventHubScanner
 func (a *EventHubScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.client, err = armeventhub.NewNamespacesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.eventHubsClient, err = armeventhub.NewEventHubsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.consumerGroupsClient, err = armeventhub.NewConsumerGroupsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.schemaRegistryClient, err = armeventhub.NewSchemaRegistryClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.resourcesClient, err = armresources.NewClient(config.SubscriptionID, config.Cred, config.ClientOptions)
 	return err
 }
 
@@ -34,17 +68,45 @@ func (c *EventHubScanner) Scan(resourceGroupName string, scanContext *scanners.S
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	if scanContext.EventHubProperties == nil {
+		scanContext.EventHubProperties = map[string][]scanners.EventHubEntity{}
+	}
+	if scanContext.EventHubCaptureStorageAccounts == nil {
+		scanContext.EventHubCaptureStorageAccounts = map[string]bool{}
+	}
+	if scanContext.EventHubSchemaGroups == nil {
+		scanContext.EventHubSchemaGroups = map[string]int{}
+	}
+
 	for _, eventHub := range eventHubs {
-		rr := engine.EvaluateRules(rules, eventHub, scanContext)
+		hubs, err := c.listHubs(resourceGroupName, *eventHub.Name)
+		if err != nil {
+			return nil, err
+		}
+		scanContext.EventHubProperties[strings.ToLower(*eventHub.ID)] = hubs
+		c.checkCaptureStorageAccounts(hubs, scanContext)
+
+		if eventHub.SKU != nil && eventHub.SKU.Name != nil && *eventHub.SKU.Name == armeventhub.SKUNamePremium {
+			count, err := c.countSchemaGroups(resourceGroupName, *eventHub.Name)
+			if err != nil {
+				log.Debug().Err(err).Msgf("Failed to list schema groups of Event Hub Namespace %s", *eventHub.Name)
+			} else {
+				scanContext.EventHubSchemaGroups[strings.ToLower(*eventHub.ID)] = count
+			}
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, eventHub, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *eventHub.Name,
+			ID:               *eventHub.ID,
 			Type:             *eventHub.Type,
 			Location:         *eventHub.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(eventHub),
 		})
 	}
 	return results, nil
@@ -63,3 +125,104 @@ func (c *EventHubScanner) listEventHubs(resourceGroupName string) ([]*armeventhu
 	}
 	return namespaces, nil
 }
+
+// listHubs - Lists the Event Hubs of the namespace with their number of consumer groups
+func (c *EventHubScanner) listHubs(resourceGroupName, namespaceName string) ([]scanners.EventHubEntity, error) {
+	pager := c.eventHubsClient.NewListByNamespacePager(resourceGroupName, namespaceName, nil)
+
+	hubs := []scanners.EventHubEntity{}
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range resp.Value {
+			if h.Name == nil {
+				continue
+			}
+			hub := scanners.EventHubEntity{Name: *h.Name}
+			if h.Properties != nil {
+				hub.MessageRetentionInDays = h.Properties.MessageRetentionInDays
+				hub.PartitionCount = h.Properties.PartitionCount
+				setCapture(&hub, h.Properties.CaptureDescription)
+			}
+
+			cgPager := c.consumerGroupsClient.NewListByEventHubPager(resourceGroupName, namespaceName, *h.Name, nil)
+			for cgPager.More() {
+				cgResp, err := cgPager.NextPage(c.config.Ctx)
+				if err != nil {
+					return nil, err
+				}
+				hub.ConsumerGroupCount += len(cgResp.Value)
+			}
+			hubs = append(hubs, hub)
+		}
+	}
+	return hubs, nil
+}
+
+// setCapture - Sets the capture settings of the hub
+func setCapture(hub *scanners.EventHubEntity, capture *armeventhub.CaptureDescription) {
+	if capture == nil || capture.Enabled == nil || !*capture.Enabled {
+		return
+	}
+	hub.CaptureEnabled = true
+	if capture.Destination == nil || capture.Destination.Properties == nil {
+		return
+	}
+	p := capture.Destination.Properties
+	if p.StorageAccountResourceID != nil && *p.StorageAccountResourceID != "" {
+		hub.CaptureStorageAccountID = *p.StorageAccountResourceID
+		container := ""
+		if p.BlobContainer != nil {
+			container = *p.BlobContainer
+		}
+		hub.CaptureDestination = path.Base(hub.CaptureStorageAccountID) + "/" + container
+	} else if p.DataLakeAccountName != nil {
+		folder := ""
+		if p.DataLakeFolderPath != nil {
+			folder = *p.DataLakeFolderPath
+		}
+		hub.CaptureDestination = *p.DataLakeAccountName + "/" + strings.TrimPrefix(folder, "/")
+	}
+}
+
+// checkCaptureStorageAccounts - Checks that the capture storage accounts of the hubs exist. Accounts that
+// could not be checked are left out of the scan context.
+func (c *EventHubScanner) checkCaptureStorageAccounts(hubs []scanners.EventHubEntity, scanContext *scanners.ScanContext) {
+	for _, h := range hubs {
+		id := strings.ToLower(h.CaptureStorageAccountID)
+		if id == "" {
+			continue
+		}
+		if _, ok := scanContext.EventHubCaptureStorageAccounts[id]; ok {
+			continue
+		}
+		_, err := c.resourcesClient.GetByID(c.config.Ctx, h.CaptureStorageAccountID, storageAPIVersion, nil)
+		if err != nil {
+			var respErr *azcore.ResponseError
+			if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+				scanContext.EventHubCaptureStorageAccounts[id] = false
+				continue
+			}
+			log.Debug().Err(err).Msgf("Failed to get capture storage account %s", h.CaptureStorageAccountID)
+			continue
+		}
+		scanContext.EventHubCaptureStorageAccounts[id] = true
+	}
+}
+
+// countSchemaGroups - Returns the number of schema groups of the namespace schema registry
+func (c *EventHubScanner) countSchemaGroups(resourceGroupName, namespaceName string) (int, error) {
+	pager := c.schemaRegistryClient.NewListByNamespacePager(resourceGroupName, namespaceName, nil)
+
+	count := 0
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return 0, err
+		}
+		count += len(resp.Value)
+	}
+	return count, nil
+}
@@ -4,6 +4,9 @@
 package evh
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,22 +21,25 @@ func (a *EventHubScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Event Hub Namespace should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armeventhub.EHNamespace)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/monitor-event-hubs#collection-and-routing",
+			Url:             "https://learn.microsoft.com/en-us/azure/event-hubs/monitor-event-hubs#collection-and-routing",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"evh-002": {
 			Id:             "evh-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Event Hub Namespace should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armeventhub.EHNamespace)
 				zones := *i.Properties.ZoneRedundant
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/event-hubs-premium-overview#high-availability-with-availability-zones",
 		},
@@ -42,14 +48,14 @@ func (a *EventHubScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Event Hub Namespace should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armeventhub.EHNamespace)
 				sku := string(*i.SKU.Name)
 				sla := "99.95%"
 				if !strings.Contains(sku, "Basic") && !strings.Contains(sku, "Standard") {
 					sla = "99.99%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/event-hubs/",
 		},
@@ -58,10 +64,11 @@ func (a *EventHubScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Event Hub Namespace should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armeventhub.EHNamespace)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/network-security",
 		},
@@ -70,9 +77,9 @@ func (a *EventHubScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Event Hub Namespace SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armeventhub.EHNamespace)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/compare-tiers",
 		},
@@ -81,10 +88,10 @@ func (a *EventHubScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Event Hub Namespace Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armeventhub.EHNamespace)
 				caf := strings.HasPrefix(*c.Name, "evh")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -93,23 +100,169 @@ func (a *EventHubScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Event Hub should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armeventhub.EHNamespace)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"evh-008": {
 			Id:             "evh-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Event Hub should have local authentication disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.EventHub/namespaces", "Microsoft.EventHub/namespaces/disableLocalAuth", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armeventhub.EHNamespace)
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
-				return !localAuth, ""
+				return !localAuth, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/authorize-access-event-hubs#shared-access-signatures",
+			Url:         "https://learn.microsoft.com/en-us/azure/event-hubs/authorize-access-event-hubs#shared-access-signatures",
+			Remediation: "az eventhubs namespace update --ids {resource_id} --disable-local-auth true",
 		},
+		"evh-009": {
+			Id:             "evh-009",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "Event Hub should retain events for at least 3 days",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventhub.EHNamespace)
+				hubs := withRetention(scanContext.EventHubProperties[strings.ToLower(*c.ID)], 0, 3)
+				return len(hubs) > 0, strings.Join(hubs, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/event-hubs-features#event-retention",
+		},
+		"evh-010": {
+			Id:             "evh-010",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "Event Hub should retain events for at least 7 days",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventhub.EHNamespace)
+				hubs := withRetention(scanContext.EventHubProperties[strings.ToLower(*c.ID)], 3, 7)
+				return len(hubs) > 0, strings.Join(hubs, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/event-hubs-features#event-retention",
+		},
+		"evh-011": {
+			Id:             "evh-011",
+			Category:       scanners.RulesCategoryScalability,
+			Recommendation: "Event Hub should have at least 4 partitions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventhub.EHNamespace)
+				hubs := []string{}
+				for _, h := range scanContext.EventHubProperties[strings.ToLower(*c.ID)] {
+					if h.PartitionCount != nil && *h.PartitionCount < 4 {
+						hubs = append(hubs, fmt.Sprintf("%s (%d)", h.Name, *h.PartitionCount))
+					}
+				}
+				return len(hubs) > 0, strings.Join(hubs, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/event-hubs-scalability#partitions",
+		},
+		"evh-012": {
+			Id:             "evh-012",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Event Hub should have a dedicated consumer group for each consumer",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventhub.EHNamespace)
+				hubs := []string{}
+				for _, h := range scanContext.EventHubProperties[strings.ToLower(*c.ID)] {
+					if h.ConsumerGroupCount == 1 {
+						hubs = append(hubs, h.Name)
+					}
+				}
+				return len(hubs) > 0, strings.Join(hubs, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/event-hubs-features#consumer-groups",
+		},
+		"evh-013": {
+			Id:             "evh-013",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Event Hub named for capture should have capture enabled",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventhub.EHNamespace)
+				missing := []string{}
+				destinations := []string{}
+				for _, h := range scanContext.EventHubProperties[strings.ToLower(*c.ID)] {
+					if h.CaptureEnabled {
+						if h.CaptureDestination != "" {
+							destinations = append(destinations, h.CaptureDestination)
+						}
+					} else if strings.Contains(strings.ToLower(h.Name), "capture") {
+						missing = append(missing, h.Name)
+					}
+				}
+				if len(missing) > 0 {
+					return true, strings.Join(missing, ", "), nil
+				}
+				return false, strings.Join(destinations, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/event-hubs-capture-overview",
+		},
+		"evh-014": {
+			Id:             "evh-014",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Event Hub capture storage account should exist",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventhub.EHNamespace)
+				hubs := []string{}
+				for _, h := range scanContext.EventHubProperties[strings.ToLower(*c.ID)] {
+					if !h.CaptureEnabled || h.CaptureStorageAccountID == "" {
+						continue
+					}
+					exists, ok := scanContext.EventHubCaptureStorageAccounts[strings.ToLower(h.CaptureStorageAccountID)]
+					if !ok {
+						return false, "", fmt.Errorf("capture storage account of %s not available", h.Name)
+					}
+					if !exists {
+						hubs = append(hubs, h.Name)
+					}
+				}
+				return len(hubs) > 0, strings.Join(hubs, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/event-hubs-capture-enable-through-portal",
+		},
+		"evh-015": {
+			Id:             "evh-015",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Event Hub Namespace Premium should have a schema registry configured",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armeventhub.EHNamespace)
+				if c.SKU == nil || c.SKU.Name == nil || *c.SKU.Name != armeventhub.SKUNamePremium {
+					return false, "", nil
+				}
+				count, ok := scanContext.EventHubSchemaGroups[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("schema groups not available for %s", *c.Name)
+				}
+				return count == 0, strconv.Itoa(count), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/event-hubs/schema-registry-overview",
+		},
+	}
+}
+
+// withRetention - Returns the hubs retaining events for at least min and less than max days, with their retention
+func withRetention(hubs []scanners.EventHubEntity, min, max int64) []string {
+	res := []string{}
+	for _, h := range hubs {
+		if h.MessageRetentionInDays == nil {
+			continue
+		}
+		days := *h.MessageRetentionInDays
+		if days >= min && days < max {
+			res = append(res, fmt.Sprintf("%s (%d)", h.Name, days))
+		}
 	}
+	return res
 }
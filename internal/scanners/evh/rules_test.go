@@ -4,6 +4,7 @@
 package evh
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -13,6 +14,36 @@ import (
 )
 
 func TestEventHubScanner_Rules(t *testing.T) {
+	hubsContext := &scanners.ScanContext{
+		EventHubProperties: map[string][]scanners.EventHubEntity{
+			"test": {
+				{Name: "telemetry", MessageRetentionInDays: to.Ptr(int64(1)), PartitionCount: to.Ptr(int64(2)), ConsumerGroupCount: 1},
+				{Name: "orders", MessageRetentionInDays: to.Ptr(int64(5)), PartitionCount: to.Ptr(int64(4)), ConsumerGroupCount: 3},
+				{Name: "audit", MessageRetentionInDays: to.Ptr(int64(7)), PartitionCount: to.Ptr(int64(32)), ConsumerGroupCount: 2},
+			},
+		},
+	}
+	captureContext := &scanners.ScanContext{
+		EventHubProperties: map[string][]scanners.EventHubEntity{
+			"test": {
+				{Name: "orders-capture", CaptureEnabled: true, CaptureStorageAccountID: "/subscriptions/xxx/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/stcapture", CaptureDestination: "stcapture/orders"},
+				{Name: "audit-capture"},
+			},
+			"ok": {
+				{Name: "orders-capture", CaptureEnabled: true, CaptureStorageAccountID: "/subscriptions/xxx/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/starchive", CaptureDestination: "starchive/orders"},
+				{Name: "telemetry"},
+			},
+		},
+		EventHubCaptureStorageAccounts: map[string]bool{
+			"/subscriptions/xxx/resourcegroups/rg/providers/microsoft.storage/storageaccounts/stcapture": false,
+			"/subscriptions/xxx/resourcegroups/rg/providers/microsoft.storage/storageaccounts/starchive": true,
+		},
+		EventHubSchemaGroups: map[string]int{
+			"test": 0,
+			"ok":   2,
+		},
+	}
+
 	type fields struct {
 		rule        string
 		target      interface{}
@@ -159,12 +190,194 @@ func TestEventHubScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "EventHubScanner retention below 3 days",
+			fields: fields{
+				rule: "evh-009",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: hubsContext,
+			},
+			want: want{
+				broken: true,
+				result: "telemetry (1)",
+			},
+		},
+		{
+			name: "EventHubScanner retention below 7 days",
+			fields: fields{
+				rule: "evh-010",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: hubsContext,
+			},
+			want: want{
+				broken: true,
+				result: "orders (5)",
+			},
+		},
+		{
+			name: "EventHubScanner partitions",
+			fields: fields{
+				rule: "evh-011",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: hubsContext,
+			},
+			want: want{
+				broken: true,
+				result: "telemetry (2)",
+			},
+		},
+		{
+			name: "EventHubScanner consumer groups",
+			fields: fields{
+				rule: "evh-012",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: hubsContext,
+			},
+			want: want{
+				broken: true,
+				result: "telemetry",
+			},
+		},
+		{
+			name: "EventHubScanner without hubs",
+			fields: fields{
+				rule: "evh-009",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventHubScanner capture disabled",
+			fields: fields{
+				rule: "evh-013",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: captureContext,
+			},
+			want: want{
+				broken: true,
+				result: "audit-capture",
+			},
+		},
+		{
+			name: "EventHubScanner capture enabled",
+			fields: fields{
+				rule: "evh-013",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("ok"),
+				},
+				scanContext: captureContext,
+			},
+			want: want{
+				broken: false,
+				result: "starchive/orders",
+			},
+		},
+		{
+			name: "EventHubScanner capture storage account missing",
+			fields: fields{
+				rule: "evh-014",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: captureContext,
+			},
+			want: want{
+				broken: true,
+				result: "orders-capture",
+			},
+		},
+		{
+			name: "EventHubScanner capture storage account exists",
+			fields: fields{
+				rule: "evh-014",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("ok"),
+				},
+				scanContext: captureContext,
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "EventHubScanner Premium without schema registry",
+			fields: fields{
+				rule: "evh-015",
+				target: &armeventhub.EHNamespace{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("evh-test"),
+					SKU: &armeventhub.SKU{
+						Name: to.Ptr(armeventhub.SKUNamePremium),
+					},
+				},
+				scanContext: captureContext,
+			},
+			want: want{
+				broken: true,
+				result: "0",
+			},
+		},
+		{
+			name: "EventHubScanner Premium with schema registry",
+			fields: fields{
+				rule: "evh-015",
+				target: &armeventhub.EHNamespace{
+					ID:   to.Ptr("ok"),
+					Name: to.Ptr("evh-ok"),
+					SKU: &armeventhub.SKU{
+						Name: to.Ptr(armeventhub.SKUNamePremium),
+					},
+				},
+				scanContext: captureContext,
+			},
+			want: want{
+				broken: false,
+				result: "2",
+			},
+		},
+		{
+			name: "EventHubScanner Standard schema registry",
+			fields: fields{
+				rule: "evh-015",
+				target: &armeventhub.EHNamespace{
+					ID: to.Ptr("test"),
+					SKU: &armeventhub.SKU{
+						Name: to.Ptr(armeventhub.SKUNameStandard),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &EventHubScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("EventHubScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -175,3 +388,41 @@ func TestEventHubScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestEventHubScanner_CaptureStorageAccount_NotAvailable(t *testing.T) {
+	s := &EventHubScanner{}
+	rules := s.GetRules()
+	scanContext := &scanners.ScanContext{
+		EventHubProperties: map[string][]scanners.EventHubEntity{
+			"test": {
+				{Name: "orders", CaptureEnabled: true, CaptureStorageAccountID: "/subscriptions/xxx/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"},
+			},
+		},
+	}
+	_, _, err := rules["evh-014"].Eval(context.Background(), &armeventhub.EHNamespace{ID: to.Ptr("test")}, scanContext)
+	if err == nil {
+		t.Error("EventHubScanner Rule.Eval() error = nil, want an error when the capture storage account could not be checked")
+	}
+}
+
+func TestSetCapture(t *testing.T) {
+	hub := scanners.EventHubEntity{Name: "orders"}
+	setCapture(&hub, &armeventhub.CaptureDescription{
+		Enabled: to.Ptr(true),
+		Destination: &armeventhub.Destination{
+			Properties: &armeventhub.DestinationProperties{
+				StorageAccountResourceID: to.Ptr("/subscriptions/xxx/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/stcapture"),
+				BlobContainer:            to.Ptr("orders"),
+			},
+		},
+	})
+	if !hub.CaptureEnabled || hub.CaptureDestination != "stcapture/orders" {
+		t.Errorf("setCapture() = %+v, want capture to stcapture/orders", hub)
+	}
+
+	hub = scanners.EventHubEntity{Name: "audit"}
+	setCapture(&hub, &armeventhub.CaptureDescription{Enabled: to.Ptr(false)})
+	if hub.CaptureEnabled {
+		t.Errorf("setCapture() = %+v, want capture disabled", hub)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+)
+
+// FirewallPolicyScanner - Scanner for Firewall Policies
+type FirewallPolicyScanner struct {
+	config *ScannerConfig
+	client *armnetwork.FirewallPoliciesClient
+}
+
+// Init - Initializes the FirewallPolicyScanner
+func (s *FirewallPolicyScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	s.config = config
+	var err error
+	s.client, err = armnetwork.NewFirewallPoliciesClient(s.config.SubscriptionID, s.config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListFirewallPolicyIDPS - Lists the IDPS mode of all Firewall Policies
+func (s *FirewallPolicyScanner) ListFirewallPolicyIDPS() (map[string]string, error) {
+	LogSubscriptionScan(s.config.SubscriptionID, "Firewall Policies")
+
+	res := map[string]string{}
+	opt := armnetwork.FirewallPoliciesClientListAllOptions{}
+
+	pager := s.client.NewListAllPager(&opt)
+
+	for pager.More() {
+		resp, err := pager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range resp.Value {
+			mode := ""
+			if v.Properties != nil && v.Properties.IntrusionDetection != nil && v.Properties.IntrusionDetection.Mode != nil {
+				mode = string(*v.Properties.IntrusionDetection.Mode)
+			}
+			res[strings.ToLower(*v.ID)] = mode
+		}
+	}
+
+	return res, nil
+}
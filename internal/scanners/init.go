@@ -0,0 +1,29 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"sync"
+)
+
+// ParallelInitScanners - Initializes the scanners concurrently. The returned slice has an entry for
+// each scanner, nil when the scanner was initialized. Scanners not started before ctx is done get ctx.Err().
+func ParallelInitScanners(ctx context.Context, scanners []IAzureScanner, config *ScannerConfig) []error {
+	errs := make([]error, len(scanners))
+	var wg sync.WaitGroup
+	for i, s := range scanners {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		wg.Add(1)
+		go func(i int, s IAzureScanner) {
+			defer wg.Done()
+			errs[i] = s.Init(config)
+		}(i, s)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,102 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type initTestScanner struct {
+	delay time.Duration
+	err   error
+	calls *int32
+}
+
+func (s *initTestScanner) Init(config *ScannerConfig) error {
+	time.Sleep(s.delay)
+	if s.calls != nil {
+		atomic.AddInt32(s.calls, 1)
+	}
+	return s.err
+}
+
+func (s *initTestScanner) GetRules() map[string]AzureRule {
+	return map[string]AzureRule{}
+}
+
+func (s *initTestScanner) Scan(resourceGroupName string, scanContext *ScanContext) ([]AzureServiceResult, error) {
+	return nil, nil
+}
+
+func initTestScanners(count int, delay time.Duration, calls *int32) []IAzureScanner {
+	scanners := []IAzureScanner{}
+	for i := 0; i < count; i++ {
+		scanners = append(scanners, &initTestScanner{delay: delay, calls: calls})
+	}
+	return scanners
+}
+
+func TestParallelInitScanners(t *testing.T) {
+	var calls int32
+	scanners := initTestScanners(5, time.Millisecond, &calls)
+	failure := errors.New("init failed")
+	scanners[2].(*initTestScanner).err = failure
+
+	errs := ParallelInitScanners(context.Background(), scanners, &ScannerConfig{})
+	if len(errs) != len(scanners) {
+		t.Fatalf("ParallelInitScanners() returned %d errors, want %d", len(errs), len(scanners))
+	}
+	for i, err := range errs {
+		want := error(nil)
+		if i == 2 {
+			want = failure
+		}
+		if err != want {
+			t.Errorf("ParallelInitScanners() error[%d] = %v, want %v", i, err, want)
+		}
+	}
+	if calls != 5 {
+		t.Errorf("ParallelInitScanners() initialized %d scanners, want 5", calls)
+	}
+}
+
+func TestParallelInitScanners_Cancelled(t *testing.T) {
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := ParallelInitScanners(ctx, initTestScanners(3, 0, &calls), &ScannerConfig{})
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ParallelInitScanners() error[%d] = %v, want %v", i, err, context.Canceled)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("ParallelInitScanners() initialized %d scanners, want 0", calls)
+	}
+}
+
+// BenchmarkInitScanners compares sequential and parallel initialization of 25 scanners
+// whose Init takes 5ms, simulating client creation in a high-latency environment.
+func BenchmarkInitScanners(b *testing.B) {
+	scanners := initTestScanners(25, 5*time.Millisecond, nil)
+	config := &ScannerConfig{}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, s := range scanners {
+				_ = s.Init(config)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ParallelInitScanners(context.Background(), scanners, config)
+		}
+	})
+}
@@ -4,21 +4,33 @@
 package kv
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azcertificates"
+	"github.com/rs/zerolog/log"
 )
 
 // KeyVaultScanner - Scanner for Key Vaults
 type KeyVaultScanner struct {
 	config       *scanners.ScannerConfig
 	vaultsClient *armkeyvault.VaultsClient
+	keysClient   *armkeyvault.KeysClient
 }
 
 // Init - Initializes the KeyVaultScanner
 func (c *KeyVaultScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.vaultsClient, err = armkeyvault.NewVaultsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.keysClient, err = armkeyvault.NewKeysClient(config.SubscriptionID, config.Cred, config.ClientOptions)
 	return err
 }
 
@@ -35,16 +47,26 @@ func (c *KeyVaultScanner) Scan(resourceGroupName string, scanContext *scanners.S
 	results := []scanners.AzureServiceResult{}
 
 	for _, vault := range vaults {
-		rr := engine.EvaluateRules(rules, vault, scanContext)
+		select {
+		case <-c.config.Ctx.Done():
+			return nil, c.config.Ctx.Err()
+		default:
+		}
+		c.loadCertificateExpiries(vault, scanContext)
+		c.loadKeyRotationPolicies(resourceGroupName, vault, scanContext)
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, vault, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *vault.Name,
+			ID:               *vault.ID,
 			Type:             *vault.Type,
 			Location:         *vault.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(vault),
 		})
 	}
 	return results, nil
@@ -63,3 +85,113 @@ func (c *KeyVaultScanner) listVaults(resourceGroupName string) ([]*armkeyvault.V
 	}
 	return vaults, nil
 }
+
+// loadCertificateExpiries - Adds the expiration dates of the enabled certificates of the vault to
+// the scan context. Vaults whose certificates can't be listed (e.g. missing data plane permissions
+// or firewall) are logged and skipped.
+func (c *KeyVaultScanner) loadCertificateExpiries(vault *armkeyvault.Vault, scanContext *scanners.ScanContext) {
+	if vault.Properties == nil || vault.Properties.VaultURI == nil {
+		return
+	}
+
+	expiries, err := c.listCertificateExpiries(*vault.Properties.VaultURI)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to list certificates of Key Vault %s", *vault.Name)
+		return
+	}
+
+	scanContext.Lock()
+	defer scanContext.Unlock()
+	if scanContext.KeyVaultCertificateExpiries == nil {
+		scanContext.KeyVaultCertificateExpiries = map[string][]scanners.CertExpiry{}
+	}
+	scanContext.KeyVaultCertificateExpiries[strings.ToLower(*vault.ID)] = expiries
+}
+
+func (c *KeyVaultScanner) listCertificateExpiries(vaultURI string) ([]scanners.CertExpiry, error) {
+	options := &azcertificates.ClientOptions{}
+	if c.config.ClientOptions != nil {
+		options.ClientOptions = c.config.ClientOptions.ClientOptions
+	}
+	client, err := azcertificates.NewClient(vaultURI, c.config.Cred, options)
+	if err != nil {
+		return nil, err
+	}
+
+	pager := client.NewListCertificatePropertiesPager(nil)
+	expiries := []scanners.CertExpiry{}
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range resp.Value {
+			if cert.ID == nil || cert.Attributes == nil || cert.Attributes.Expires == nil {
+				continue
+			}
+			if cert.Attributes.Enabled != nil && !*cert.Attributes.Enabled {
+				continue
+			}
+			expiries = append(expiries, scanners.CertExpiry{
+				Name:      cert.ID.Name(),
+				ExpiresAt: *cert.Attributes.Expires,
+			})
+		}
+	}
+	return expiries, nil
+}
+
+// loadKeyRotationPolicies - Adds the rotation policies of the enabled keys of the vault to the scan
+// context, by key ID. Keys without a rotation policy are added with a nil policy. Soft-deleted keys
+// are not listed. Vaults whose keys can't be listed are logged and skipped.
+func (c *KeyVaultScanner) loadKeyRotationPolicies(resourceGroupName string, vault *armkeyvault.Vault, scanContext *scanners.ScanContext) {
+	policies, err := c.listKeyRotationPolicies(resourceGroupName, *vault.Name)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to list keys of Key Vault %s", *vault.Name)
+		return
+	}
+
+	scanContext.Lock()
+	defer scanContext.Unlock()
+	if scanContext.KeyVaultKeyRotationPolicies == nil {
+		scanContext.KeyVaultKeyRotationPolicies = map[string]*armkeyvault.RotationPolicy{}
+	}
+	for id, p := range policies {
+		scanContext.KeyVaultKeyRotationPolicies[id] = p
+	}
+}
+
+func (c *KeyVaultScanner) listKeyRotationPolicies(resourceGroupName, vaultName string) (map[string]*armkeyvault.RotationPolicy, error) {
+	pager := c.keysClient.NewListPager(resourceGroupName, vaultName, nil)
+	policies := map[string]*armkeyvault.RotationPolicy{}
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range resp.Value {
+			if k.ID == nil || k.Name == nil || k.Properties == nil {
+				continue
+			}
+			if k.Properties.Attributes != nil && k.Properties.Attributes.Enabled != nil && !*k.Properties.Attributes.Enabled {
+				continue
+			}
+			select {
+			case <-c.config.Ctx.Done():
+				return nil, c.config.Ctx.Err()
+			default:
+			}
+			// The rotation policy is only returned when getting the key
+			key, err := c.keysClient.Get(c.config.Ctx, resourceGroupName, vaultName, *k.Name, nil)
+			if err != nil {
+				return nil, err
+			}
+			var policy *armkeyvault.RotationPolicy
+			if key.Properties != nil {
+				policy = key.Properties.RotationPolicy
+			}
+			policies[strings.ToLower(*k.ID)] = policy
+		}
+	}
+	return policies, nil
+}
@@ -4,7 +4,12 @@
 package kv
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azqr/internal/to"
@@ -19,20 +24,23 @@ func (a *KeyVaultScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Key Vault should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armkeyvault.Vault)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/key-vault/general/monitor-key-vault",
+			Url:             "https://learn.microsoft.com/en-us/azure/key-vault/general/monitor-key-vault",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"kv-003": {
 			Id:             "kv-003",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Key Vault should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.99%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.99%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/key-vault/",
 		},
@@ -41,10 +49,11 @@ func (a *KeyVaultScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Key Vault should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armkeyvault.Vault)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/key-vault/general/private-link-service",
 		},
@@ -53,9 +62,9 @@ func (a *KeyVaultScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Key Vault SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armkeyvault.Vault)
-				return false, string(*i.Properties.SKU.Name)
+				return false, string(*i.Properties.SKU.Name), nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/key-vault/",
 		},
@@ -64,10 +73,10 @@ func (a *KeyVaultScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Key Vault Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkeyvault.Vault)
 				caf := strings.HasPrefix(*c.Name, "kv")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -76,33 +85,151 @@ func (a *KeyVaultScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Key Vault should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkeyvault.Vault)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"kv-008": {
 			Id:             "kv-008",
 			Category:       scanners.RulesCategoryDisasterRecovery,
 			Recommendation: "Key Vault should have soft delete enabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkeyvault.Vault)
-				return c.Properties.EnableSoftDelete == nil || c.Properties.EnableSoftDelete == to.Ptr(false), ""
+				return c.Properties.EnableSoftDelete == nil || c.Properties.EnableSoftDelete == to.Ptr(false), "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/key-vault/general/soft-delete-overview",
 		},
 		"kv-009": {
-			Id:             "kv-009",
-			Category:       scanners.RulesCategoryDisasterRecovery,
-			Recommendation: "Key Vault should have purge protection enabled",
-			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Id:                  "kv-009",
+			Category:            scanners.RulesCategoryDisasterRecovery,
+			Recommendation:      "Key Vault should have purge protection enabled",
+			Impact:              scanners.ImpactMedium,
+			PolicyDefinitionIDs: []string{"/providers/Microsoft.Authorization/policyDefinitions/0b60c0b2-2dc2-4e1c-b5c9-abbed971de53"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armkeyvault.Vault)
-				return c.Properties.EnablePurgeProtection == nil || c.Properties.EnablePurgeProtection == to.Ptr(false), ""
+				return c.Properties.EnablePurgeProtection == nil || c.Properties.EnablePurgeProtection == to.Ptr(false), "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/key-vault/general/soft-delete-overview#purge-protection",
 		},
+		"kv-011a": {
+			Id:             "kv-011a",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Key Vault certificates should not expire within 7 days",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return evalCertificateExpiry(target, scanContext, math.MinInt, 7)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/key-vault/certificates/overview-renew-certificate",
+		},
+		"kv-011b": {
+			Id:             "kv-011b",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Key Vault certificates should not expire within 30 days",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return evalCertificateExpiry(target, scanContext, 7, 30)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/key-vault/certificates/overview-renew-certificate",
+		},
+		"kv-011c": {
+			Id:             "kv-011c",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Key Vault certificates should not expire within 90 days",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return evalCertificateExpiry(target, scanContext, 30, 90)
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/key-vault/certificates/overview-renew-certificate",
+		},
+		"kv-012": {
+			Id:             "kv-012",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Key Vault keys should have a rotation policy rotating them at least every 365 days",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armkeyvault.Vault)
+				prefix := strings.ToLower(*c.ID) + "/keys/"
+				keys := []string{}
+				for id := range scanContext.KeyVaultKeyRotationPolicies {
+					if strings.HasPrefix(id, prefix) {
+						keys = append(keys, id)
+					}
+				}
+				sort.Strings(keys)
+
+				broken := []string{}
+				for _, id := range keys {
+					name := id[len(prefix):]
+					period, d := rotationPeriod(scanContext.KeyVaultKeyRotationPolicies[id])
+					if period == "" {
+						broken = append(broken, fmt.Sprintf("%s (none configured)", name))
+					} else if d > 365*24*time.Hour {
+						broken = append(broken, fmt.Sprintf("%s (%s)", name, period))
+					}
+				}
+				return len(broken) > 0, strings.Join(broken, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/key-vault/keys/how-to-configure-key-rotation",
+		},
+	}
+}
+
+// rotationPeriod - Returns the rotation trigger of the policy and the time after which keys are rotated,
+// or an empty string when the policy doesn't rotate keys
+func rotationPeriod(policy *armkeyvault.RotationPolicy) (string, time.Duration) {
+	if policy == nil {
+		return "", 0
+	}
+	for _, a := range policy.LifetimeActions {
+		if a == nil || a.Action == nil || a.Action.Type == nil || a.Trigger == nil ||
+			!strings.EqualFold(string(*a.Action.Type), string(armkeyvault.KeyRotationPolicyActionTypeRotate)) {
+			continue
+		}
+		if a.Trigger.TimeAfterCreate != nil {
+			d, err := scanners.ParseISODuration(*a.Trigger.TimeAfterCreate)
+			if err == nil {
+				return *a.Trigger.TimeAfterCreate, d
+			}
+		}
+		if a.Trigger.TimeBeforeExpiry != nil && policy.Attributes != nil && policy.Attributes.ExpiryTime != nil {
+			before, err := scanners.ParseISODuration(*a.Trigger.TimeBeforeExpiry)
+			if err != nil {
+				continue
+			}
+			expiry, err := scanners.ParseISODuration(*policy.Attributes.ExpiryTime)
+			if err != nil {
+				continue
+			}
+			return fmt.Sprintf("%s before %s expiry", *a.Trigger.TimeBeforeExpiry, *policy.Attributes.ExpiryTime), expiry - before
+		}
+	}
+	return "", 0
+}
+
+// now - Returns the current time, replaced in tests
+var now = time.Now
+
+// evalCertificateExpiry - Returns the certificates of the vault expiring in more than minDays and up
+// to maxDays, so a certificate is only reported by the rule with the highest impact.
+func evalCertificateExpiry(target interface{}, scanContext *scanners.ScanContext, minDays, maxDays int) (bool, string, error) {
+	c := target.(*armkeyvault.Vault)
+	expiring := []string{}
+	for _, cert := range scanContext.KeyVaultCertificateExpiries[strings.ToLower(*c.ID)] {
+		days := int(math.Floor(cert.ExpiresAt.Sub(now()).Hours() / 24))
+		if days <= minDays || days > maxDays {
+			continue
+		}
+		if days < 0 {
+			expiring = append(expiring, fmt.Sprintf("%s (expired)", cert.Name))
+		} else {
+			expiring = append(expiring, fmt.Sprintf("%s (%d days)", cert.Name, days))
+		}
 	}
+	return len(expiring) > 0, strings.Join(expiring, ", "), nil
 }
@@ -4,8 +4,10 @@
 package kv
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azqr/internal/to"
@@ -13,6 +15,22 @@ import (
 )
 
 func TestKeyVaultScanner_Rules(t *testing.T) {
+	testNow := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	certificatesContext := &scanners.ScanContext{
+		KeyVaultCertificateExpiries: map[string][]scanners.CertExpiry{
+			"test": {
+				{Name: "expired-cert", ExpiresAt: testNow.AddDate(0, 0, -2)},
+				{Name: "web-cert", ExpiresAt: testNow.AddDate(0, 0, 3)},
+				{Name: "api-cert", ExpiresAt: testNow.AddDate(0, 0, 20)},
+				{Name: "mtls-cert", ExpiresAt: testNow.AddDate(0, 0, 60)},
+				{Name: "root-cert", ExpiresAt: testNow.AddDate(0, 0, 120)},
+			},
+			"other": {
+				{Name: "root-cert", ExpiresAt: testNow.AddDate(0, 0, 120)},
+			},
+		},
+	}
+
 	type fields struct {
 		rule        string
 		target      interface{}
@@ -141,12 +159,143 @@ func TestKeyVaultScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "KeyVaultScanner certificate expiring within 7 days",
+			fields: fields{
+				rule: "kv-011a",
+				target: &armkeyvault.Vault{
+					ID: to.Ptr("test"),
+				},
+				scanContext: certificatesContext,
+			},
+			want: want{
+				broken: true,
+				result: "expired-cert (expired), web-cert (3 days)",
+			},
+		},
+		{
+			name: "KeyVaultScanner certificate expiring within 30 days",
+			fields: fields{
+				rule: "kv-011b",
+				target: &armkeyvault.Vault{
+					ID: to.Ptr("test"),
+				},
+				scanContext: certificatesContext,
+			},
+			want: want{
+				broken: true,
+				result: "api-cert (20 days)",
+			},
+		},
+		{
+			name: "KeyVaultScanner certificate expiring within 90 days",
+			fields: fields{
+				rule: "kv-011c",
+				target: &armkeyvault.Vault{
+					ID: to.Ptr("test"),
+				},
+				scanContext: certificatesContext,
+			},
+			want: want{
+				broken: true,
+				result: "mtls-cert (60 days)",
+			},
+		},
+		{
+			name: "KeyVaultScanner no certificate expiring",
+			fields: fields{
+				rule: "kv-011c",
+				target: &armkeyvault.Vault{
+					ID: to.Ptr("other"),
+				},
+				scanContext: certificatesContext,
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "KeyVaultScanner keys without rotation or with a long rotation period",
+			fields: fields{
+				rule: "kv-012",
+				target: &armkeyvault.Vault{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/KV"),
+				},
+				scanContext: &scanners.ScanContext{
+					KeyVaultKeyRotationPolicies: map[string]*armkeyvault.RotationPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.keyvault/vaults/kv/keys/key1": nil,
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.keyvault/vaults/kv/keys/key2": {
+							LifetimeActions: []*armkeyvault.LifetimeAction{
+								{
+									Action:  &armkeyvault.Action{Type: to.Ptr(armkeyvault.KeyRotationPolicyActionTypeRotate)},
+									Trigger: &armkeyvault.Trigger{TimeAfterCreate: to.Ptr("P2Y")},
+								},
+							},
+						},
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.keyvault/vaults/kv/keys/key3": {
+							LifetimeActions: []*armkeyvault.LifetimeAction{
+								{
+									Action:  &armkeyvault.Action{Type: to.Ptr(armkeyvault.KeyRotationPolicyActionTypeNotify)},
+									Trigger: &armkeyvault.Trigger{TimeBeforeExpiry: to.Ptr("P30D")},
+								},
+							},
+						},
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.keyvault/vaults/other/keys/key4": nil,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "key1 (none configured), key2 (P2Y), key3 (none configured)",
+			},
+		},
+		{
+			name: "KeyVaultScanner keys rotated within 365 days",
+			fields: fields{
+				rule: "kv-012",
+				target: &armkeyvault.Vault{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/kv"),
+				},
+				scanContext: &scanners.ScanContext{
+					KeyVaultKeyRotationPolicies: map[string]*armkeyvault.RotationPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.keyvault/vaults/kv/keys/key1": {
+							LifetimeActions: []*armkeyvault.LifetimeAction{
+								{
+									Action:  &armkeyvault.Action{Type: to.Ptr(armkeyvault.KeyRotationPolicyActionTypeRotate)},
+									Trigger: &armkeyvault.Trigger{TimeAfterCreate: to.Ptr("P90D")},
+								},
+							},
+						},
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.keyvault/vaults/kv/keys/key2": {
+							Attributes: &armkeyvault.KeyRotationPolicyAttributes{ExpiryTime: to.Ptr("P1Y")},
+							LifetimeActions: []*armkeyvault.LifetimeAction{
+								{
+									Action:  &armkeyvault.Action{Type: to.Ptr(armkeyvault.KeyRotationPolicyActionTypeRotate)},
+									Trigger: &armkeyvault.Trigger{TimeBeforeExpiry: to.Ptr("P30D")},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			now = func() time.Time { return testNow }
+			defer func() { now = time.Now }()
+
 			s := &KeyVaultScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("KeyVaultScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
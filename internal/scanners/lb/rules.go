@@ -4,6 +4,8 @@
 package lb
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,19 +20,22 @@ func (a *LoadBalancerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Load Balancer should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armnetwork.LoadBalancer)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/load-balancer/monitor-load-balancer#creating-a-diagnostic-setting",
+			Url:             "https://learn.microsoft.com/en-us/azure/load-balancer/monitor-load-balancer#creating-a-diagnostic-setting",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"lb-002": {
 			Id:             "lb-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Load Balancer should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armnetwork.LoadBalancer)
 				broken := false
 				for _, ipc := range i.Properties.FrontendIPConfigurations {
@@ -46,7 +51,7 @@ func (a *LoadBalancerScanner) GetRules() map[string]scanners.AzureRule {
 					}
 				}
 
-				return broken, ""
+				return broken, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/load-balancer/load-balancer-standard-availability-zones#zone-redundant",
 		},
@@ -55,14 +60,14 @@ func (a *LoadBalancerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Load Balancer should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armnetwork.LoadBalancer)
 				sla := "99.99%"
 				sku := *i.SKU.Name
 				if sku == armnetwork.LoadBalancerSKUNameBasic {
 					sla = "None"
 				}
-				return sla == "None", sla
+				return sla == "None", sla, nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/load-balancer/skus",
 		},
@@ -71,10 +76,10 @@ func (a *LoadBalancerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Load Balancer SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armnetwork.LoadBalancer)
 				sku := *i.SKU.Name
-				return sku != armnetwork.LoadBalancerSKUNameStandard, string(*i.SKU.Name)
+				return sku != armnetwork.LoadBalancerSKUNameStandard, string(*i.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/load-balancer/skus",
 		},
@@ -83,7 +88,7 @@ func (a *LoadBalancerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Load Balancer Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.LoadBalancer)
 				hasPrivateIP := false
 				for _, ipc := range c.Properties.FrontendIPConfigurations {
@@ -102,7 +107,7 @@ func (a *LoadBalancerScanner) GetRules() map[string]scanners.AzureRule {
 				}
 
 				caf := (strings.HasPrefix(*c.Name, "lbi") && hasPrivateIP) || (strings.HasPrefix(*c.Name, "lbe") && hasPublicIP)
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -111,11 +116,85 @@ func (a *LoadBalancerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Load Balancer should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.LoadBalancer)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
+		"lb-008": {
+			Id:             "lb-008",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Load Balancer health probes should detect unhealthy backends within 30 seconds",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.LoadBalancer)
+				if c.Properties == nil {
+					return false, "", nil
+				}
+				detection := 0
+				for _, probe := range c.Properties.Probes {
+					if d := probeDetectionTime(probe); d > detection {
+						detection = d
+					}
+				}
+				if detection == 0 {
+					return false, "", nil
+				}
+				return detection > 30, fmt.Sprintf("%ds", detection), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/load-balancer/load-balancer-custom-probe-overview#probe-interval--timeout",
+		},
+		"lb-009": {
+			Id:             "lb-009",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Load Balancer rules should not enable floating IP unless required (e.g. SQL Server Always On listeners)",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armnetwork.LoadBalancer)
+				if c.Properties == nil {
+					return false, "", nil
+				}
+				rules := []string{}
+				for _, r := range c.Properties.LoadBalancingRules {
+					if r.Properties == nil || r.Properties.EnableFloatingIP == nil || !*r.Properties.EnableFloatingIP || isSQLRule(r) {
+						continue
+					}
+					if r.Name != nil {
+						rules = append(rules, *r.Name)
+					}
+				}
+				return len(rules) > 0, strings.Join(rules, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/load-balancer/load-balancer-floating-ip",
+		},
+	}
+}
+
+// probeDetectionTime - Returns the seconds a probe takes to mark a backend unhealthy, using the
+// ARM defaults (15 seconds interval, 2 probes) for the properties not set
+func probeDetectionTime(probe *armnetwork.Probe) int {
+	if probe == nil || probe.Properties == nil {
+		return 0
+	}
+	interval, count := 15, 2
+	if probe.Properties.IntervalInSeconds != nil {
+		interval = int(*probe.Properties.IntervalInSeconds)
+	}
+	if probe.Properties.NumberOfProbes != nil {
+		count = int(*probe.Properties.NumberOfProbes)
+	}
+	return interval * count
+}
+
+// isSQLRule - Load balancing rules have no description, so SQL Server Always On listener rules, which
+// require floating IP, are recognized by their name or the default SQL Server port
+func isSQLRule(r *armnetwork.LoadBalancingRule) bool {
+	if r.Name != nil && strings.Contains(strings.ToLower(*r.Name), "sql") {
+		return true
 	}
+	return r.Properties.FrontendPort != nil && *r.Properties.FrontendPort == 1433
 }
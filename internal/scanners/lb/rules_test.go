@@ -4,6 +4,7 @@
 package lb
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -153,12 +154,179 @@ func TestLoadBalancerScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "LoadBalancerScanner Probe detection time",
+			fields: fields{
+				rule: "lb-008",
+				target: &armnetwork.LoadBalancer{
+					Properties: &armnetwork.LoadBalancerPropertiesFormat{
+						Probes: []*armnetwork.Probe{
+							{
+								Properties: &armnetwork.ProbePropertiesFormat{
+									IntervalInSeconds: to.Ptr[int32](5),
+									NumberOfProbes:    to.Ptr[int32](2),
+								},
+							},
+							{
+								Properties: &armnetwork.ProbePropertiesFormat{
+									IntervalInSeconds: to.Ptr[int32](10),
+									NumberOfProbes:    to.Ptr[int32](3),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "30s",
+			},
+		},
+		{
+			name: "LoadBalancerScanner Probe detection time too long",
+			fields: fields{
+				rule: "lb-008",
+				target: &armnetwork.LoadBalancer{
+					Properties: &armnetwork.LoadBalancerPropertiesFormat{
+						Probes: []*armnetwork.Probe{
+							{
+								Properties: &armnetwork.ProbePropertiesFormat{
+									IntervalInSeconds: to.Ptr[int32](5),
+									NumberOfProbes:    to.Ptr[int32](2),
+								},
+							},
+							{
+								Properties: &armnetwork.ProbePropertiesFormat{
+									IntervalInSeconds: to.Ptr[int32](15),
+									NumberOfProbes:    to.Ptr[int32](4),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "60s",
+			},
+		},
+		{
+			name: "LoadBalancerScanner Probe detection time defaults",
+			fields: fields{
+				rule: "lb-008",
+				target: &armnetwork.LoadBalancer{
+					Properties: &armnetwork.LoadBalancerPropertiesFormat{
+						Probes: []*armnetwork.Probe{
+							{
+								Properties: &armnetwork.ProbePropertiesFormat{},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "30s",
+			},
+		},
+		{
+			name: "LoadBalancerScanner Floating IP disabled",
+			fields: fields{
+				rule: "lb-009",
+				target: &armnetwork.LoadBalancer{
+					Properties: &armnetwork.LoadBalancerPropertiesFormat{
+						LoadBalancingRules: []*armnetwork.LoadBalancingRule{
+							{
+								Name: to.Ptr("http"),
+								Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+									FrontendPort:     to.Ptr[int32](80),
+									EnableFloatingIP: to.Ptr(false),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LoadBalancerScanner Floating IP SQL Always On",
+			fields: fields{
+				rule: "lb-009",
+				target: &armnetwork.LoadBalancer{
+					Properties: &armnetwork.LoadBalancerPropertiesFormat{
+						LoadBalancingRules: []*armnetwork.LoadBalancingRule{
+							{
+								Name: to.Ptr("ag-listener"),
+								Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+									FrontendPort:     to.Ptr[int32](1433),
+									EnableFloatingIP: to.Ptr(true),
+								},
+							},
+							{
+								Name: to.Ptr("sqlmirroring"),
+								Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+									FrontendPort:     to.Ptr[int32](5022),
+									EnableFloatingIP: to.Ptr(true),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LoadBalancerScanner Floating IP enabled",
+			fields: fields{
+				rule: "lb-009",
+				target: &armnetwork.LoadBalancer{
+					Properties: &armnetwork.LoadBalancerPropertiesFormat{
+						LoadBalancingRules: []*armnetwork.LoadBalancingRule{
+							{
+								Name: to.Ptr("http"),
+								Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+									FrontendPort:     to.Ptr[int32](80),
+									EnableFloatingIP: to.Ptr(true),
+								},
+							},
+							{
+								Name: to.Ptr("https"),
+								Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+									FrontendPort:     to.Ptr[int32](443),
+									EnableFloatingIP: to.Ptr(false),
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "http",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &LoadBalancerScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("LoadBalancerScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -0,0 +1,144 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/graph"
+	"github.com/rs/zerolog/log"
+)
+
+// LockScanner - Scanner for Resource Locks
+type LockScanner struct {
+	config     *ScannerConfig
+	graphQuery *graph.GraphQuery
+}
+
+// Init - Initializes the LockScanner
+func (s *LockScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	s.config = config
+	s.graphQuery = graph.NewGraphQuery(s.config.Cred)
+	return nil
+}
+
+// ListResourceLocks - Lists the scopes (subscriptions, resource groups or resources) with at least one lock
+func (s *LockScanner) ListResourceLocks() (map[string]bool, error) {
+	LogSubscriptionScan(s.config.SubscriptionID, "Resource Locks")
+
+	res := map[string]bool{}
+
+	result, err := s.graphQuery.Run(s.config.Ctx, "resources | where type =~ 'microsoft.authorization/locks' | project id", []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		log.Info().Msg("Preflight: No resource locks found")
+		return res, nil
+	}
+
+	for _, row := range result.Data {
+		m := row.(map[string]interface{})
+		id := strings.ToLower(m["id"].(string))
+		i := strings.Index(id, "/providers/microsoft.authorization/locks/")
+		if i < 0 {
+			continue
+		}
+		res[id[:i]] = true
+	}
+
+	return res, nil
+}
+
+// HasResourceLock - Returns true if the resource, or any of its parent scopes, has a lock
+func HasResourceLock(locks map[string]bool, resourceID string) bool {
+	id := strings.ToLower(resourceID)
+	if locks[id] {
+		return true
+	}
+	for i := len(id) - 1; i > 0; i-- {
+		if id[i] == '/' && locks[id[:i]] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLockRequired - Returns true if the resource type is listed in the locks configuration
+// or the resource has one of the configured tags (criticality=high by default)
+func (l *Locks) IsLockRequired(resourceType string, tags map[string]*string) bool {
+	if l != nil {
+		if l.resourceTypes == nil {
+			l.resourceTypes = make(map[string]bool)
+			for _, t := range l.ResourceTypes {
+				l.resourceTypes[strings.ToLower(t)] = true
+			}
+		}
+		if l.resourceTypes[strings.ToLower(resourceType)] {
+			return true
+		}
+	}
+
+	required := map[string]string{"criticality": "high"}
+	if l != nil && len(l.Tags) > 0 {
+		required = l.Tags
+	}
+
+	for k, v := range tags {
+		if v == nil {
+			continue
+		}
+		for rk, rv := range required {
+			if strings.EqualFold(k, rk) && strings.EqualFold(*v, rv) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetSharedRules - Returns the rules evaluated for every resource, regardless of the scanner
+func GetSharedRules() map[string]AzureRule {
+	return map[string]AzureRule{
+		"lock-001": {
+			Id:             "lock-001",
+			Category:       RulesCategoryGovernance,
+			Recommendation: "Critical resources should have a delete or read-only lock",
+			Impact:         ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				id := getResourceString(target, "ID")
+				if scanContext.ResourceLocks == nil {
+					return false, "", fmt.Errorf("resource locks not available for %s", id)
+				}
+				return !HasResourceLock(scanContext.ResourceLocks, id), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/lock-resources",
+		},
+	}
+}
+
+// IsSharedRuleSelected - Returns true if the shared rule is neither excluded nor filtered out by the tags
+func IsSharedRuleSelected(id string, exclusions *Exclude, tagFilter []string) bool {
+	rule, ok := GetSharedRules()[id]
+	return ok && !exclusions.IsRecommendationExcluded(rule.Id) && rule.HasAnyTag(tagFilter)
+}
+
+// isSharedRuleApplicable - Returns true if the shared rule should be evaluated for the target
+func isSharedRuleApplicable(id string, target interface{}, scanContext *ScanContext) bool {
+	switch id {
+	case "lock-001":
+		// Resource Groups are checked by rg-003
+		if strings.EqualFold(getResourceString(target, "Type"), "Microsoft.Resources/resourceGroups") {
+			return false
+		}
+		tags, _ := getResourceField(target, "Tags").(map[string]*string)
+		return scanContext.Locks.IsLockRequired(getResourceString(target, "Type"), tags)
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+)
+
+func TestSharedRules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "SharedRules Resource Lock",
+			fields: fields{
+				rule: "lock-001",
+				target: &armappservice.Plan{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan"),
+				},
+				scanContext: &ScanContext{
+					ResourceLocks: map[string]bool{
+						"/subscriptions/sub/resourcegroups/rg/providers/microsoft.web/serverfarms/plan": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SharedRules Resource Group Lock",
+			fields: fields{
+				rule: "lock-001",
+				target: &armappservice.Plan{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan"),
+				},
+				scanContext: &ScanContext{
+					ResourceLocks: map[string]bool{
+						"/subscriptions/sub/resourcegroups/rg": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SharedRules No Lock",
+			fields: fields{
+				rule: "lock-001",
+				target: &armappservice.Plan{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan"),
+				},
+				scanContext: &ScanContext{
+					ResourceLocks: map[string]bool{
+						"/subscriptions/sub/resourcegroups/rg2": true,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := GetSharedRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SharedRules Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SharedRules Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharedRules_LocksNotAvailable(t *testing.T) {
+	rules := GetSharedRules()
+	target := &armappservice.Plan{
+		ID: to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan"),
+	}
+	_, _, err := rules["lock-001"].Eval(context.Background(), target, &ScanContext{})
+	if err == nil {
+		t.Error("SharedRules Rule.Eval() error = nil, want an error when the resource locks are not available")
+	}
+}
+
+func TestIsSharedRuleSelected(t *testing.T) {
+	tests := []struct {
+		name       string
+		exclusions *Exclude
+		tagFilter  []string
+		want       bool
+	}{
+		{
+			name:       "selected by default",
+			exclusions: &Exclude{},
+			want:       true,
+		},
+		{
+			name:       "excluded",
+			exclusions: &Exclude{Recommendations: []string{"LOCK-001"}},
+			want:       false,
+		},
+		{
+			name:       "filtered out by tags",
+			exclusions: &Exclude{},
+			tagFilter:  []string{"no-such-tag"},
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSharedRuleSelected("lock-001", tt.exclusions, tt.tagFilter); got != tt.want {
+				t.Errorf("IsSharedRuleSelected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocks_IsLockRequired(t *testing.T) {
+	tests := []struct {
+		name         string
+		locks        *Locks
+		resourceType string
+		tags         map[string]*string
+		want         bool
+	}{
+		{
+			name:         "default criticality tag",
+			locks:        nil,
+			resourceType: "Microsoft.Web/serverfarms",
+			tags:         map[string]*string{"Criticality": to.Ptr("High")},
+			want:         true,
+		},
+		{
+			name:         "default without tags",
+			locks:        nil,
+			resourceType: "Microsoft.Web/serverfarms",
+			tags:         nil,
+			want:         false,
+		},
+		{
+			name:         "configured resource type",
+			locks:        &Locks{ResourceTypes: []string{"microsoft.web/serverfarms"}},
+			resourceType: "Microsoft.Web/serverfarms",
+			tags:         nil,
+			want:         true,
+		},
+		{
+			name:         "configured tags",
+			locks:        &Locks{Tags: map[string]string{"env": "prod"}},
+			resourceType: "Microsoft.Web/serverfarms",
+			tags:         map[string]*string{"criticality": to.Ptr("high")},
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.locks.IsLockRequired(tt.resourceType, tt.tags); got != tt.want {
+				t.Errorf("Locks.IsLockRequired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
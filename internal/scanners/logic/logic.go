@@ -4,22 +4,40 @@
 package logic
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/graph"
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/logic/armlogic"
+	"github.com/rs/zerolog/log"
 )
 
+// managedIdentityConnectors - API connectors known to support managed identity authentication
+var managedIdentityConnectors = []string{"office365", "azureblob", "azurequeues"}
+
 // LogicAppScanner - Scanner for LogicApp
 type LogicAppScanner struct {
-	config *scanners.ScannerConfig
-	client *armlogic.WorkflowsClient
+	config            *scanners.ScannerConfig
+	client            *armlogic.WorkflowsClient
+	graphQuery        *graph.GraphQuery
+	connectionsListed bool
 }
 
 // Init - Initializes the LogicAppScanner
 func (c *LogicAppScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.client, err = armlogic.NewWorkflowsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
-	return err
+	if err != nil {
+		return err
+	}
+	c.graphQuery = graph.NewGraphQuery(config.Cred)
+	c.connectionsListed = false
+	return nil
 }
 
 // Scan - Scans all LogicApps in a Resource Group
@@ -33,18 +51,34 @@ func (c *LogicAppScanner) Scan(resourceGroupName string, scanContext *scanners.S
 	engine := scanners.RuleEngine{}
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
+	if len(vnets) == 0 {
+		return results, nil
+	}
+
+	// API connections are listed once per subscription: Resource Graph throttles per tenant
+	if !c.connectionsListed {
+		c.connectionsListed = true
+		connections, err := c.listKeyBasedConnections()
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to list Logic App API connections, skipping logic-009")
+		} else {
+			scanContext.LogicAppConnections = connections
+		}
+	}
 
 	for _, w := range vnets {
-		rr := engine.EvaluateRules(rules, w, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, w, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *w.Name,
+			ID:               *w.ID,
 			Type:             *w.Type,
 			Location:         *w.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(w),
 		})
 	}
 	return results, nil
@@ -63,3 +97,31 @@ func (c *LogicAppScanner) list(resourceGroupName string) ([]*armlogic.Workflow,
 	}
 	return logicApps, nil
 }
+
+// listKeyBasedConnections - Lists the API connections of the subscription using key based authentication for connectors that support managed identity
+func (c *LogicAppScanner) listKeyBasedConnections() (map[string]string, error) {
+	res := map[string]string{}
+
+	query := "resources | where type =~ 'microsoft.web/connections' | project id, apiId = tostring(properties.api.id), parameterValueType = tostring(properties.parameterValueType)"
+	result, err := c.graphQuery.Run(c.config.Ctx, query, []*string{&c.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range result.Data {
+		m := row.(map[string]interface{})
+		// Connections authenticated with a managed identity use the Alternative parameter value type
+		if strings.EqualFold(fmt.Sprint(m["parameterValueType"]), "Alternative") {
+			continue
+		}
+		apiID := strings.ToLower(fmt.Sprint(m["apiId"]))
+		for _, connector := range managedIdentityConnectors {
+			if strings.HasSuffix(apiID, "/managedapis/"+connector) {
+				res[strings.ToLower(fmt.Sprint(m["id"]))] = connector
+				break
+			}
+		}
+	}
+
+	return res, nil
+}
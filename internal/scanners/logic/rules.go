@@ -4,6 +4,9 @@
 package logic
 
 import (
+	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,20 +21,23 @@ func (a *LogicAppScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Logic App should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armlogic.Workflow)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/logic-apps/monitor-workflows-collect-diagnostic-data",
+			Url:             "https://learn.microsoft.com/en-us/azure/logic-apps/monitor-workflows-collect-diagnostic-data",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"logic-003": {
 			Id:             "logic-003",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Logic App should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
@@ -40,28 +46,20 @@ func (a *LogicAppScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Logic App should limit access to Http Triggers",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armlogic.Workflow)
-				http := false
-				if service.Properties.Definition != nil {
-					triggers, ok := service.Properties.Definition.(map[string]interface{})["triggers"]
-					if ok {
-						for _, t := range triggers.(map[string]interface{}) {
-							trigger := t.(map[string]interface{})
-							if trigger["type"] == "Request" && trigger["kind"] == "Http" {
-								http = true
-								break
-							}
-						}
-					}
+				if service.Properties == nil {
+					return false, "", nil
 				}
-
-				broken := http
-
-				if http && service.Properties.AccessControl != nil && service.Properties.AccessControl.Triggers != nil {
-					broken = len(service.Properties.AccessControl.Triggers.AllowedCallerIPAddresses) == 0
+				triggers := httpTriggers(service)
+				if len(triggers) == 0 {
+					return false, "", nil
+				}
+				if service.Properties.AccessControl != nil && service.Properties.AccessControl.Triggers != nil &&
+					len(service.Properties.AccessControl.Triggers.AllowedCallerIPAddresses) > 0 {
+					return false, "", nil
 				}
-				return broken, ""
+				return true, strings.Join(triggers, ","), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/logic-apps/logic-apps-securing-a-logic-app?tabs=azure-portal#restrict-access-by-ip-address-range",
 		},
@@ -70,11 +68,11 @@ func (a *LogicAppScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Logic App Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armlogic.Workflow)
 
 				caf := strings.HasPrefix(*c.Name, "logic")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -83,11 +81,97 @@ func (a *LogicAppScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Logic App should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armlogic.Workflow)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"logic-008": {
+			Id:             "logic-008",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App should use Managed Identities",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armlogic.Workflow)
+				return c.Identity == nil || c.Identity.Type == nil || *c.Identity.Type == armlogic.ManagedServiceIdentityTypeNone, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/logic-apps/create-managed-service-identity",
+		},
+		"logic-009": {
+			Id:             "logic-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Logic App API connections should use Managed Identity authentication when the connector supports it",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"CIS", "identity"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armlogic.Workflow)
+				if scanContext.LogicAppConnections == nil {
+					return false, "", fmt.Errorf("API connections not available for %s", *c.Name)
+				}
+				connectors := []string{}
+				for _, id := range connectionIDs(c) {
+					if connector, ok := scanContext.LogicAppConnections[strings.ToLower(id)]; ok {
+						connectors = append(connectors, connector)
+					}
+				}
+				return len(connectors) > 0, strings.Join(connectors, ","), nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url: "https://learn.microsoft.com/en-us/azure/logic-apps/authenticate-with-managed-identity",
 		},
 	}
 }
+
+// connectionIDs - Returns the API connection IDs referenced by the workflow $connections parameter
+func connectionIDs(w *armlogic.Workflow) []string {
+	ids := []string{}
+	if w.Properties == nil || w.Properties.Parameters == nil {
+		return ids
+	}
+	p, ok := w.Properties.Parameters["$connections"]
+	if !ok || p == nil {
+		return ids
+	}
+	connections, ok := p.Value.(map[string]interface{})
+	if !ok {
+		return ids
+	}
+	for _, v := range connections {
+		connection, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := connection["connectionId"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// httpTriggers - Returns the names of the HTTP request triggers declared in the workflow definition, sorted
+func httpTriggers(w *armlogic.Workflow) []string {
+	names := []string{}
+	definition, ok := w.Properties.Definition.(map[string]interface{})
+	if !ok {
+		return names
+	}
+	triggers, ok := definition["triggers"].(map[string]interface{})
+	if !ok {
+		return names
+	}
+	for name, t := range triggers {
+		trigger, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if trigger["type"] == "Request" && trigger["kind"] == "Http" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
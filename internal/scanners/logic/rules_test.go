@@ -4,6 +4,7 @@
 package logic
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -88,7 +89,38 @@ func TestLogicAppScanner_Rules(t *testing.T) {
 			},
 			want: want{
 				broken: true,
-				result: "",
+				result: "trigger1",
+			},
+		},
+		{
+			name: "LogicAppScanner Http Triggers without access control",
+			fields: fields{
+				rule: "logic-004",
+				target: &armlogic.Workflow{
+					ID: to.Ptr("test"),
+					Properties: &armlogic.WorkflowProperties{
+						Definition: map[string]interface{}{
+							"triggers": map[string]interface{}{
+								"manual": map[string]interface{}{
+									"type": "Request",
+									"kind": "Http",
+								},
+								"hook": map[string]interface{}{
+									"type": "Request",
+									"kind": "Http",
+								},
+								"schedule": map[string]interface{}{
+									"type": "Recurrence",
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "hook,manual",
 			},
 		},
 		{
@@ -138,12 +170,101 @@ func TestLogicAppScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "LogicAppScanner ManagedIdentity None",
+			fields: fields{
+				rule: "logic-008",
+				target: &armlogic.Workflow{
+					Identity: &armlogic.ManagedServiceIdentity{
+						Type: to.Ptr(armlogic.ManagedServiceIdentityTypeNone),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "LogicAppScanner ManagedIdentity SystemAssigned",
+			fields: fields{
+				rule: "logic-008",
+				target: &armlogic.Workflow{
+					Identity: &armlogic.ManagedServiceIdentity{
+						Type: to.Ptr(armlogic.ManagedServiceIdentityTypeSystemAssigned),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "LogicAppScanner key based connection",
+			fields: fields{
+				rule: "logic-009",
+				target: &armlogic.Workflow{
+					Properties: &armlogic.WorkflowProperties{
+						Parameters: map[string]*armlogic.WorkflowParameter{
+							"$connections": {
+								Value: map[string]interface{}{
+									"office365": map[string]interface{}{
+										"connectionId": "/subscriptions/x/resourceGroups/rg/providers/Microsoft.Web/connections/office365",
+									},
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					LogicAppConnections: map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.web/connections/office365": "office365",
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "office365",
+			},
+		},
+		{
+			name: "LogicAppScanner managed identity connection",
+			fields: fields{
+				rule: "logic-009",
+				target: &armlogic.Workflow{
+					Properties: &armlogic.WorkflowProperties{
+						Parameters: map[string]*armlogic.WorkflowParameter{
+							"$connections": {
+								Value: map[string]interface{}{
+									"office365": map[string]interface{}{
+										"connectionId": "/subscriptions/x/resourceGroups/rg/providers/Microsoft.Web/connections/office365",
+									},
+								},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					LogicAppConnections: map[string]string{},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &LogicAppScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("LogicAppScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -17,6 +17,9 @@ type MariaScanner struct {
 
 // Init - Initializes the MariaScanner
 func (c *MariaScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.serverClient, err = armmariadb.NewServersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -44,16 +47,18 @@ func (c *MariaScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 	results := []scanners.AzureServiceResult{}
 
 	for _, server := range servers {
-		rr := engine.EvaluateRules(rules, server, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, server, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *server.Name,
+			ID:               *server.ID,
 			Type:             *server.Type,
 			Location:         *server.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(server),
 		})
 
 		databases, err := c.listDatabases(resourceGroupName, *server.Name)
@@ -61,14 +66,16 @@ func (c *MariaScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 			return nil, err
 		}
 		for _, database := range databases {
-			rr := engine.EvaluateRules(databaseRules, database, scanContext)
+			rr := engine.EvaluateRules(c.config.Ctx, databaseRules, database, scanContext)
 
 			results = append(results, scanners.AzureServiceResult{
 				SubscriptionID: c.config.SubscriptionID,
 				ResourceGroup:  resourceGroupName,
 				ServiceName:    *database.Name,
+				ID:             *database.ID,
 				Type:           *database.Type,
 				Rules:          rr,
+				CreatedAt:      scanners.GetCreatedAt(database),
 			})
 		}
 	}
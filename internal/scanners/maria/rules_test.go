@@ -4,6 +4,7 @@
 package maria
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -111,7 +112,10 @@ func TestMariaScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &MariaScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("MariaScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -157,7 +161,10 @@ func TestMariaScanner_DatabaseRules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &MariaScanner{}
 			rules := s.GetDatabaseRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("MariaScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
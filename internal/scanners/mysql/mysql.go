@@ -16,6 +16,9 @@ type MySQLScanner struct {
 
 // Init - Initializes the MySQLScanner
 func (c *MySQLScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.postgreClient, err = armmysql.NewServersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *MySQLScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 	results := []scanners.AzureServiceResult{}
 
 	for _, postgre := range postgre {
-		rr := engine.EvaluateRules(rules, postgre, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, postgre, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *postgre.Name,
+			ID:               *postgre.ID,
 			Type:             *postgre.Type,
 			Location:         *postgre.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(postgre),
 		})
 	}
 
@@ -16,6 +16,9 @@ type MySQLFlexibleScanner struct {
 
 // Init - Initializes the MySQLFlexibleScanner
 func (c *MySQLFlexibleScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.flexibleClient, err = armmysqlflexibleservers.NewServersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *MySQLFlexibleScanner) Scan(resourceGroupName string, scanContext *scann
 	results := []scanners.AzureServiceResult{}
 
 	for _, postgre := range flexibles {
-		rr := engine.EvaluateRules(rules, postgre, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, postgre, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			ResourceGroup:    resourceGroupName,
 			SubscriptionName: c.config.SubscriptionName,
 			ServiceName:      *postgre.Name,
+			ID:               *postgre.ID,
 			Type:             *postgre.Type,
 			Location:         *postgre.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(postgre),
 		})
 	}
 
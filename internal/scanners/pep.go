@@ -16,6 +16,9 @@ type PrivateEndpointScanner struct {
 
 // Init - Initializes the PrivateEndpointScanner
 func (s *PrivateEndpointScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	s.config = config
 	var err error
 	s.client, err = armnetwork.NewPrivateEndpointsClient(s.config.SubscriptionID, s.config.Cred, config.ClientOptions)
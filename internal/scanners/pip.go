@@ -15,6 +15,9 @@ type PublicIPScanner struct {
 
 // Init - Initializes the PublicIPScanner
 func (s *PublicIPScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	s.config = config
 	var err error
 	s.client, err = armnetwork.NewPublicIPAddressesClient(s.config.SubscriptionID, s.config.Cred, config.ClientOptions)
@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// PolicyCondition - Azure Policy condition (the "if" block of a policy rule)
+	PolicyCondition map[string]interface{}
+
+	// PolicyExportable - Implemented by rules that can be exported as Azure Policy custom definitions
+	PolicyExportable interface {
+		ToPolicyDefinition() ([]byte, error)
+	}
+
+	policyDefinition struct {
+		Name       string           `json:"name"`
+		Properties policyProperties `json:"properties"`
+	}
+
+	policyProperties struct {
+		DisplayName string                 `json:"displayName"`
+		PolicyType  string                 `json:"policyType"`
+		Mode        string                 `json:"mode"`
+		Description string                 `json:"description"`
+		Metadata    map[string]string      `json:"metadata"`
+		PolicyRule  map[string]interface{} `json:"policyRule"`
+	}
+)
+
+// PolicyFieldNotEquals - Returns a condition matching resources of the given type whose field (policy alias) is not equal to value
+func PolicyFieldNotEquals(resourceType, field string, value interface{}) PolicyCondition {
+	return PolicyCondition{
+		"allOf": []PolicyCondition{
+			{
+				"field":  "type",
+				"equals": resourceType,
+			},
+			{
+				"field":     field,
+				"notEquals": value,
+			},
+		},
+	}
+}
+
+// PolicyFieldEquals - Returns a condition matching resources of the given type whose field (policy alias) is equal to value
+func PolicyFieldEquals(resourceType, field string, value interface{}) PolicyCondition {
+	return PolicyCondition{
+		"allOf": []PolicyCondition{
+			{
+				"field":  "type",
+				"equals": resourceType,
+			},
+			{
+				"field":  field,
+				"equals": value,
+			},
+		},
+	}
+}
+
+// PolicyFieldCountEquals - Returns a condition matching resources of the given type whose array field
+// (policy alias ending with [*]) has the given number of elements
+func PolicyFieldCountEquals(resourceType, field string, count int) PolicyCondition {
+	return PolicyCondition{
+		"allOf": []PolicyCondition{
+			{
+				"field":  "type",
+				"equals": resourceType,
+			},
+			{
+				"count": PolicyCondition{
+					"field": field,
+				},
+				"equals": count,
+			},
+		},
+	}
+}
+
+// ToPolicyDefinition - Returns the Azure Policy custom definition (audit effect) of the rule
+func (r *AzureRule) ToPolicyDefinition() ([]byte, error) {
+	if r.Policy == nil {
+		return nil, fmt.Errorf("rule %s cannot be exported as an Azure Policy definition", r.Id)
+	}
+
+	definition := policyDefinition{
+		Name: "azqr-" + r.Id,
+		Properties: policyProperties{
+			DisplayName: r.Recommendation,
+			PolicyType:  "Custom",
+			Mode:        "Indexed",
+			Description: fmt.Sprintf("%s (azqr rule %s). %s", r.Recommendation, r.Id, r.Url),
+			Metadata: map[string]string{
+				"category":   string(r.Category),
+				"version":    "1.0.0",
+				"azqrRuleId": r.Id,
+			},
+			PolicyRule: map[string]interface{}{
+				"if": r.Policy,
+				"then": map[string]string{
+					"effect": "audit",
+				},
+			},
+		},
+	}
+
+	return json.MarshalIndent(definition, "", "  ")
+}
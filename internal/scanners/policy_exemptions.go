@@ -0,0 +1,216 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azqr/internal/graph"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// PolicyExemptionScanner - Scanner for Azure Policy Exemptions
+	PolicyExemptionScanner struct {
+		config     *ScannerConfig
+		graphQuery *graph.GraphQuery
+	}
+
+	// policyExemption - Exemption of a policy assignment
+	policyExemption struct {
+		// scope - Lower case ID of the exempted subscription, resource group or resource
+		scope string
+		// policyDefinitionID - Lower case ID of the policy definition, or initiative, of the assignment
+		policyDefinitionID string
+		// referenceIDs - Lower case reference IDs of the exempted definitions when the assignment is an
+		// initiative. All its definitions are exempted when empty.
+		referenceIDs []string
+		expiresOn    *time.Time
+	}
+)
+
+// Init - Initializes the PolicyExemptionScanner
+func (s *PolicyExemptionScanner) Init(config *ScannerConfig) error {
+	if err := ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	s.config = config
+	s.graphQuery = graph.NewGraphQuery(s.config.Cred)
+	return nil
+}
+
+// ListPolicyExemptions - Lists the scopes (subscriptions, resource groups or resources) with policy exemptions,
+// returning the exempted policy definition (or initiative) IDs by scope. Expired exemptions are ignored.
+func (s *PolicyExemptionScanner) ListPolicyExemptions() (map[string][]string, error) {
+	LogSubscriptionScan(s.config.SubscriptionID, "Policy Exemptions")
+
+	query := `policyresources
+| where type =~ 'microsoft.authorization/policyexemptions'
+| extend assignmentId = tolower(tostring(properties.policyAssignmentId))
+| join kind=inner (
+    policyresources
+    | where type =~ 'microsoft.authorization/policyassignments'
+    | project assignmentId = tolower(id), policyDefinitionId = tolower(tostring(properties.policyDefinitionId))
+) on assignmentId
+| project id, policyDefinitionId, policyDefinitionReferenceIds = properties.policyDefinitionReferenceIds, expiresOn = tostring(properties.expiresOn)`
+	result, err := s.graphQuery.Run(s.config.Ctx, query, []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		log.Info().Msg("Preflight: No policy exemptions found")
+		return map[string][]string{}, nil
+	}
+
+	exemptions := []policyExemption{}
+	initiatives := map[string]bool{}
+	for _, row := range result.Data {
+		e, ok := parsePolicyExemption(row.(map[string]interface{}))
+		if !ok {
+			continue
+		}
+		exemptions = append(exemptions, e)
+		if isPolicyInitiative(e.policyDefinitionID) {
+			initiatives[e.policyDefinitionID] = true
+		}
+	}
+
+	members, err := s.listInitiativeMembers(initiatives)
+	if err != nil {
+		return nil, err
+	}
+
+	return exemptedDefinitions(exemptions, members, time.Now()), nil
+}
+
+// listInitiativeMembers - Returns the policy definition IDs of each initiative, by lower case reference ID
+func (s *PolicyExemptionScanner) listInitiativeMembers(initiatives map[string]bool) (map[string]map[string]string, error) {
+	res := map[string]map[string]string{}
+	if len(initiatives) == 0 {
+		return res, nil
+	}
+
+	ids := []string{}
+	for id := range initiatives {
+		ids = append(ids, fmt.Sprintf("'%s'", id))
+	}
+	query := fmt.Sprintf(`policyresources
+| where type =~ 'microsoft.authorization/policysetdefinitions' and tolower(id) in (%s)
+| mv-expand definition = properties.policyDefinitions
+| project id = tolower(id), referenceId = tolower(tostring(definition.policyDefinitionReferenceId)), policyDefinitionId = tolower(tostring(definition.policyDefinitionId))`, strings.Join(ids, ", "))
+	result, err := s.graphQuery.Run(s.config.Ctx, query, []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range result.Data {
+		m := row.(map[string]interface{})
+		id, _ := m["id"].(string)
+		referenceID, _ := m["referenceId"].(string)
+		definitionID, _ := m["policyDefinitionId"].(string)
+		if id == "" || referenceID == "" || definitionID == "" {
+			continue
+		}
+		if res[id] == nil {
+			res[id] = map[string]string{}
+		}
+		res[id][referenceID] = definitionID
+	}
+	return res, nil
+}
+
+// parsePolicyExemption - Parses a row of the policy exemptions query
+func parsePolicyExemption(m map[string]interface{}) (policyExemption, bool) {
+	id, ok := m["id"].(string)
+	if !ok {
+		return policyExemption{}, false
+	}
+	definitionID, ok := m["policyDefinitionId"].(string)
+	if !ok {
+		return policyExemption{}, false
+	}
+	id = strings.ToLower(id)
+	i := strings.Index(id, "/providers/microsoft.authorization/policyexemptions/")
+	if i < 0 {
+		return policyExemption{}, false
+	}
+
+	e := policyExemption{
+		scope:              id[:i],
+		policyDefinitionID: strings.ToLower(definitionID),
+	}
+	if refs, ok := m["policyDefinitionReferenceIds"].([]interface{}); ok {
+		for _, r := range refs {
+			if ref, ok := r.(string); ok && ref != "" {
+				e.referenceIDs = append(e.referenceIDs, strings.ToLower(ref))
+			}
+		}
+	}
+	if expiresOn, ok := m["expiresOn"].(string); ok && expiresOn != "" {
+		t, err := time.Parse(time.RFC3339, expiresOn)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to parse the expiration date of policy exemption %s", id)
+		} else {
+			e.expiresOn = &t
+		}
+	}
+	return e, true
+}
+
+// exemptedDefinitions - Returns the exempted policy definition IDs by scope. Expired exemptions are
+// ignored. An initiative exemption exempts the initiative and, when its members are known, the
+// definitions of the exempted reference IDs (all of them when the exemption has no reference IDs).
+func exemptedDefinitions(exemptions []policyExemption, members map[string]map[string]string, now time.Time) map[string][]string {
+	res := map[string][]string{}
+	for _, e := range exemptions {
+		if e.expiresOn != nil && !e.expiresOn.After(now) {
+			continue
+		}
+		if !isPolicyInitiative(e.policyDefinitionID) {
+			res[e.scope] = append(res[e.scope], e.policyDefinitionID)
+			continue
+		}
+		if len(e.referenceIDs) == 0 {
+			res[e.scope] = append(res[e.scope], e.policyDefinitionID)
+			for _, definitionID := range members[e.policyDefinitionID] {
+				res[e.scope] = append(res[e.scope], definitionID)
+			}
+			continue
+		}
+		for _, ref := range e.referenceIDs {
+			if definitionID, ok := members[e.policyDefinitionID][ref]; ok {
+				res[e.scope] = append(res[e.scope], definitionID)
+			}
+		}
+	}
+	return res
+}
+
+// isPolicyInitiative - Returns true if the policy definition ID is the ID of an initiative (policy set definition)
+func isPolicyInitiative(definitionID string) bool {
+	return strings.Contains(strings.ToLower(definitionID), "/providers/microsoft.authorization/policysetdefinitions/")
+}
+
+// isPolicyExempted - Returns true if the target, or any of its parent scopes, is exempted from one of the rule's policies
+func isPolicyExempted(rule AzureRule, target interface{}, scanContext *ScanContext) bool {
+	if len(rule.PolicyDefinitionIDs) == 0 || scanContext == nil || len(scanContext.PolicyExemptions) == 0 {
+		return false
+	}
+	id := strings.ToLower(getResourceString(target, "ID"))
+	for i := len(id); i > 0; i-- {
+		if i < len(id) && id[i] != '/' {
+			continue
+		}
+		for _, exempted := range scanContext.PolicyExemptions[id[:i]] {
+			for _, definitionID := range rule.PolicyDefinitionIDs {
+				if strings.EqualFold(exempted, definitionID) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
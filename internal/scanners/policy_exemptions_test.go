@@ -0,0 +1,96 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestExemptedDefinitions(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	definition := "/providers/microsoft.authorization/policydefinitions/def1"
+	initiative := "/providers/microsoft.authorization/policysetdefinitions/set1"
+	members := map[string]map[string]string{
+		initiative: {
+			"ref1": "/providers/microsoft.authorization/policydefinitions/def1",
+			"ref2": "/providers/microsoft.authorization/policydefinitions/def2",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		exemptions []policyExemption
+		want       map[string][]string
+	}{
+		{
+			name:       "definition",
+			exemptions: []policyExemption{{scope: "/subscriptions/sub", policyDefinitionID: definition}},
+			want:       map[string][]string{"/subscriptions/sub": {definition}},
+		},
+		{
+			name:       "expired exemption",
+			exemptions: []policyExemption{{scope: "/subscriptions/sub", policyDefinitionID: definition, expiresOn: &past}},
+			want:       map[string][]string{},
+		},
+		{
+			name:       "exemption not expired yet",
+			exemptions: []policyExemption{{scope: "/subscriptions/sub", policyDefinitionID: definition, expiresOn: &future}},
+			want:       map[string][]string{"/subscriptions/sub": {definition}},
+		},
+		{
+			name:       "whole initiative",
+			exemptions: []policyExemption{{scope: "/subscriptions/sub", policyDefinitionID: initiative}},
+			want: map[string][]string{"/subscriptions/sub": {
+				"/providers/microsoft.authorization/policydefinitions/def1",
+				"/providers/microsoft.authorization/policydefinitions/def2",
+				initiative,
+			}},
+		},
+		{
+			name:       "one definition of an initiative",
+			exemptions: []policyExemption{{scope: "/subscriptions/sub", policyDefinitionID: initiative, referenceIDs: []string{"ref2"}}},
+			want:       map[string][]string{"/subscriptions/sub": {"/providers/microsoft.authorization/policydefinitions/def2"}},
+		},
+		{
+			name:       "unknown initiative members",
+			exemptions: []policyExemption{{scope: "/subscriptions/sub", policyDefinitionID: "/providers/microsoft.authorization/policysetdefinitions/other", referenceIDs: []string{"ref1"}}},
+			want:       map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exemptedDefinitions(tt.exemptions, members, now)
+			for _, ids := range got {
+				sort.Strings(ids)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exemptedDefinitions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePolicyExemption(t *testing.T) {
+	got, ok := parsePolicyExemption(map[string]interface{}{
+		"id":                           "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Authorization/policyExemptions/ex",
+		"policyDefinitionId":           "/providers/microsoft.authorization/policysetdefinitions/set1",
+		"policyDefinitionReferenceIds": []interface{}{"Ref1"},
+		"expiresOn":                    "2024-06-01T00:00:00Z",
+	})
+	expiresOn := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	want := policyExemption{
+		scope:              "/subscriptions/sub/resourcegroups/rg",
+		policyDefinitionID: "/providers/microsoft.authorization/policysetdefinitions/set1",
+		referenceIDs:       []string{"ref1"},
+		expiresOn:          &expiresOn,
+	}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePolicyExemption() = %+v, %v, want %+v", got, ok, want)
+	}
+}
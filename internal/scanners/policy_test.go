@@ -0,0 +1,117 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// policyOperators - Condition operators allowed by the Azure Policy definition schema
+var policyOperators = []string{
+	"equals", "notEquals", "like", "notLike", "match", "matchInsensitively", "notMatch", "notMatchInsensitively",
+	"contains", "notContains", "in", "notIn", "containsKey", "notContainsKey", "less", "lessOrEquals",
+	"greater", "greaterOrEquals", "exists",
+}
+
+func validatePolicyCondition(t *testing.T, condition map[string]interface{}) {
+	t.Helper()
+	for _, logical := range []string{"allOf", "anyOf"} {
+		if c, ok := condition[logical]; ok {
+			conditions, ok := c.([]interface{})
+			if !ok || len(conditions) == 0 {
+				t.Fatalf("%s should be a non empty array", logical)
+			}
+			for _, c := range conditions {
+				validatePolicyCondition(t, c.(map[string]interface{}))
+			}
+			return
+		}
+	}
+	if c, ok := condition["not"]; ok {
+		validatePolicyCondition(t, c.(map[string]interface{}))
+		return
+	}
+
+	if c, ok := condition["count"]; ok {
+		if _, ok := c.(map[string]interface{})["field"].(string); !ok {
+			t.Fatalf("count %v should have a field", c)
+		}
+	} else if _, ok := condition["field"].(string); !ok {
+		t.Fatalf("condition %v should have a field", condition)
+	}
+	operators := 0
+	for _, op := range policyOperators {
+		if _, ok := condition[op]; ok {
+			operators++
+		}
+	}
+	if operators != 1 || len(condition) != 2 {
+		t.Fatalf("condition %v should have exactly one operator", condition)
+	}
+}
+
+func TestAzureRule_ToPolicyDefinition(t *testing.T) {
+	rule := AzureRule{
+		Id:             "test-001",
+		Category:       RulesCategorySecurity,
+		Recommendation: "Test should have local authentication disabled",
+		Url:            "https://learn.microsoft.com",
+		Policy:         PolicyFieldNotEquals("Microsoft.Test/accounts", "Microsoft.Test/accounts/disableLocalAuth", true),
+	}
+
+	var exportable PolicyExportable = &rule
+	b, err := exportable.ToPolicyDefinition()
+	if err != nil {
+		t.Fatalf("AzureRule.ToPolicyDefinition() error = %v", err)
+	}
+
+	definition := map[string]interface{}{}
+	if err := json.Unmarshal(b, &definition); err != nil {
+		t.Fatalf("AzureRule.ToPolicyDefinition() returned invalid JSON: %v", err)
+	}
+
+	properties := definition["properties"].(map[string]interface{})
+	if properties["displayName"] != rule.Recommendation {
+		t.Errorf("displayName = %v, want %v", properties["displayName"], rule.Recommendation)
+	}
+	if properties["policyType"] != "Custom" {
+		t.Errorf("policyType = %v, want Custom", properties["policyType"])
+	}
+	if properties["mode"] != "Indexed" {
+		t.Errorf("mode = %v, want Indexed", properties["mode"])
+	}
+
+	policyRule := properties["policyRule"].(map[string]interface{})
+	then := policyRule["then"].(map[string]interface{})
+	if then["effect"] != "audit" {
+		t.Errorf("effect = %v, want audit", then["effect"])
+	}
+	validatePolicyCondition(t, policyRule["if"].(map[string]interface{}))
+}
+
+func TestAzureRule_ToPolicyDefinition_NotExportable(t *testing.T) {
+	rule := AzureRule{Id: "test-002"}
+	if _, err := rule.ToPolicyDefinition(); err == nil {
+		t.Errorf("AzureRule.ToPolicyDefinition() expected an error for a rule without policy")
+	}
+}
+
+func TestPolicyFieldCountEquals(t *testing.T) {
+	rule := AzureRule{
+		Id:     "test-003",
+		Policy: PolicyFieldCountEquals("Microsoft.Test/accounts", "Microsoft.Test/accounts/privateEndpointConnections[*]", 0),
+	}
+	b, err := rule.ToPolicyDefinition()
+	if err != nil {
+		t.Fatalf("AzureRule.ToPolicyDefinition() error = %v", err)
+	}
+
+	definition := map[string]interface{}{}
+	if err := json.Unmarshal(b, &definition); err != nil {
+		t.Fatalf("AzureRule.ToPolicyDefinition() returned invalid JSON: %v", err)
+	}
+	policyRule := definition["properties"].(map[string]interface{})["policyRule"].(map[string]interface{})
+	validatePolicyCondition(t, policyRule["if"].(map[string]interface{}))
+}
@@ -16,6 +16,9 @@ type PostgreScanner struct {
 
 // Init - Initializes the PostgreScanner
 func (c *PostgreScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.postgreClient, err = armpostgresql.NewServersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *PostgreScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 	results := []scanners.AzureServiceResult{}
 
 	for _, postgre := range postgre {
-		rr := engine.EvaluateRules(rules, postgre, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, postgre, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *postgre.Name,
+			ID:               *postgre.ID,
 			Type:             *postgre.Type,
 			Location:         *postgre.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(postgre),
 		})
 	}
 
@@ -4,21 +4,41 @@
 package psql
 
 import (
+	"context"
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/postgresql/armpostgresqlflexibleservers"
+	"github.com/rs/zerolog/log"
 )
 
+// flexibleConfigurations - Server parameters read for the PostgreSQL Flexible rules
+var flexibleConfigurations = []string{"ssl", "require_secure_transport"}
+
+// configurationsGetter - Gets server parameters, implemented by armpostgresqlflexibleservers.ConfigurationsClient
+type configurationsGetter interface {
+	Get(ctx context.Context, resourceGroupName string, serverName string, configurationName string, options *armpostgresqlflexibleservers.ConfigurationsClientGetOptions) (armpostgresqlflexibleservers.ConfigurationsClientGetResponse, error)
+}
+
 // PostgreFlexibleScanner - Scanner for PostgreSQL
 type PostgreFlexibleScanner struct {
-	config         *scanners.ScannerConfig
-	flexibleClient *armpostgresqlflexibleservers.ServersClient
+	config               *scanners.ScannerConfig
+	flexibleClient       *armpostgresqlflexibleservers.ServersClient
+	configurationsClient configurationsGetter
 }
 
 // Init - Initializes the PostgreFlexibleScanner
 func (c *PostgreFlexibleScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.flexibleClient, err = armpostgresqlflexibleservers.NewServersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.configurationsClient, err = armpostgresqlflexibleservers.NewConfigurationsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
 	return err
 }
 
@@ -34,22 +54,46 @@ func (c *PostgreFlexibleScanner) Scan(resourceGroupName string, scanContext *sca
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.PostgreSQLConfigurations = map[string]map[string]string{}
+
 	for _, postgre := range flexibles {
-		rr := engine.EvaluateRules(rules, postgre, scanContext)
+		scanContext.PostgreSQLConfigurations[strings.ToLower(*postgre.ID)] = c.getConfigurations(resourceGroupName, *postgre.Name)
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, postgre, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *postgre.Name,
+			ID:               *postgre.ID,
 			Type:             *postgre.Type,
 			Location:         *postgre.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(postgre),
 		})
 	}
 
 	return results, nil
 }
+
+// getConfigurations - Returns the values of the server parameters used by the rules, by parameter name.
+// Parameters that could not be read are left out.
+func (c *PostgreFlexibleScanner) getConfigurations(resourceGroupName, serverName string) map[string]string {
+	res := map[string]string{}
+	for _, name := range flexibleConfigurations {
+		resp, err := c.configurationsClient.Get(c.config.Ctx, resourceGroupName, serverName, name, nil)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to get parameter %s of PostgreSQL server %s", name, serverName)
+			continue
+		}
+		if resp.Properties != nil && resp.Properties.Value != nil {
+			res[name] = *resp.Properties.Value
+		}
+	}
+	return res
+}
+
 func (c *PostgreFlexibleScanner) listFlexiblePostgre(resourceGroupName string) ([]*armpostgresqlflexibleservers.Server, error) {
 	pager := c.flexibleClient.NewListByResourceGroupPager(resourceGroupName, nil)
 
@@ -4,7 +4,10 @@
 package psql
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -145,7 +148,10 @@ func TestPostgreScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &PostgreScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("PostgreScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -310,12 +316,91 @@ func TestPostgreFlexibleScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "PostgreFlexibleScanner ssl off",
+			fields: fields{
+				rule: "psqlf-011",
+				target: &armpostgresqlflexibleservers.Server{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.DBforPostgreSQL/flexibleServers/psql"),
+					Name: to.Ptr("psql"),
+				},
+				scanContext: &scanners.ScanContext{
+					PostgreSQLConfigurations: map[string]map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.dbforpostgresql/flexibleservers/psql": {"ssl": "off"},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "PostgreFlexibleScanner ssl on",
+			fields: fields{
+				rule: "psqlf-011",
+				target: &armpostgresqlflexibleservers.Server{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.DBforPostgreSQL/flexibleServers/psql"),
+					Name: to.Ptr("psql"),
+				},
+				scanContext: &scanners.ScanContext{
+					PostgreSQLConfigurations: map[string]map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.dbforpostgresql/flexibleservers/psql": {"ssl": "on"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "PostgreFlexibleScanner require_secure_transport off",
+			fields: fields{
+				rule: "psqlf-012",
+				target: &armpostgresqlflexibleservers.Server{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.DBforPostgreSQL/flexibleServers/psql"),
+					Name: to.Ptr("psql"),
+				},
+				scanContext: &scanners.ScanContext{
+					PostgreSQLConfigurations: map[string]map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.dbforpostgresql/flexibleservers/psql": {"require_secure_transport": "OFF"},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "PostgreFlexibleScanner require_secure_transport on",
+			fields: fields{
+				rule: "psqlf-012",
+				target: &armpostgresqlflexibleservers.Server{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.DBforPostgreSQL/flexibleServers/psql"),
+					Name: to.Ptr("psql"),
+				},
+				scanContext: &scanners.ScanContext{
+					PostgreSQLConfigurations: map[string]map[string]string{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.dbforpostgresql/flexibleservers/psql": {"require_secure_transport": "on"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &PostgreFlexibleScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("PostgreFlexibleScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -326,3 +411,47 @@ func TestPostgreFlexibleScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+type fakeConfigurationsClient struct {
+	values map[string]string
+}
+
+func (f *fakeConfigurationsClient) Get(ctx context.Context, resourceGroupName string, serverName string, configurationName string, options *armpostgresqlflexibleservers.ConfigurationsClientGetOptions) (armpostgresqlflexibleservers.ConfigurationsClientGetResponse, error) {
+	value, ok := f.values[configurationName]
+	if !ok {
+		return armpostgresqlflexibleservers.ConfigurationsClientGetResponse{}, errors.New("parameter not found")
+	}
+	return armpostgresqlflexibleservers.ConfigurationsClientGetResponse{
+		Configuration: armpostgresqlflexibleservers.Configuration{
+			Properties: &armpostgresqlflexibleservers.ConfigurationProperties{Value: to.Ptr(value)},
+		},
+	}, nil
+}
+
+func TestPostgreFlexibleScanner_getConfigurations(t *testing.T) {
+	s := &PostgreFlexibleScanner{
+		config:               &scanners.ScannerConfig{Ctx: context.Background()},
+		configurationsClient: &fakeConfigurationsClient{values: map[string]string{"require_secure_transport": "off"}},
+	}
+	got := s.getConfigurations("rg", "psql")
+	want := map[string]string{"require_secure_transport": "off"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getConfigurations() = %v, want %v", got, want)
+	}
+
+	// ssl could not be read, so psqlf-011 is skipped while psqlf-012 fails
+	server := &armpostgresqlflexibleservers.Server{
+		ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.DBforPostgreSQL/flexibleServers/psql"),
+		Name: to.Ptr("psql"),
+	}
+	scanContext := &scanners.ScanContext{
+		PostgreSQLConfigurations: map[string]map[string]string{strings.ToLower(*server.ID): got},
+	}
+	rules := s.GetRules()
+	if _, _, err := rules["psqlf-011"].Eval(context.Background(), server, scanContext); err == nil {
+		t.Error("psqlf-011 Eval() error = nil, want an error when ssl was not read")
+	}
+	if broken, _, err := rules["psqlf-012"].Eval(context.Background(), server, scanContext); err != nil || !broken {
+		t.Errorf("psqlf-012 Eval() = %v, %v, want broken", broken, err)
+	}
+}
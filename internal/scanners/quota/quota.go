@@ -0,0 +1,197 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package quota
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/graph"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/subscription/armsubscription"
+)
+
+// Usage - Current usage and limit of a quota in a location
+type Usage struct {
+	Provider     string
+	Name         string
+	Location     string
+	CurrentValue int64
+	Limit        int64
+}
+
+// Percentage - Returns the usage as a percentage of the limit
+func (u *Usage) Percentage() float64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return float64(u.CurrentValue) * 100 / float64(u.Limit)
+}
+
+// QuotaScanner - Scanner for Subscription Quotas
+type QuotaScanner struct {
+	config              *scanners.ScannerConfig
+	computeUsageClient  *armcompute.UsageClient
+	networkUsagesClient *armnetwork.UsagesClient
+	subscriptionsClient *armsubscription.SubscriptionsClient
+	graphQuery          *graph.GraphQuery
+}
+
+// Init - Initializes the QuotaScanner
+func (s *QuotaScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	s.config = config
+	var err error
+	s.computeUsageClient, err = armcompute.NewUsageClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	s.networkUsagesClient, err = armnetwork.NewUsagesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	s.subscriptionsClient, err = armsubscription.NewSubscriptionsClient(config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	s.graphQuery = graph.NewGraphQuery(config.Cred)
+	return nil
+}
+
+// ScanSubscriptionScope - Scans the quotas of each location with resources deployed in the Subscription
+func (s *QuotaScanner) ScanSubscriptionScope(scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogSubscriptionScan(s.config.SubscriptionID, "Quotas")
+
+	locations, err := s.listLocations()
+	if err != nil {
+		return nil, err
+	}
+	scanContext.Lock()
+	scanContext.SubscriptionLocations = locations
+	scanContext.Unlock()
+
+	engine := scanners.RuleEngine{}
+	rules := s.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, location := range locations {
+		usages, err := s.listUsages(location)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range usages {
+			rr := engine.EvaluateRules(s.config.Ctx, rules, u, scanContext)
+
+			results = append(results, scanners.AzureServiceResult{
+				SubscriptionID:   s.config.SubscriptionID,
+				SubscriptionName: s.config.SubscriptionName,
+				ServiceName:      u.Name,
+				Type:             u.Provider + "/quotas",
+				Location:         u.Location,
+				Rules:            rr,
+			})
+		}
+	}
+	return results, nil
+}
+
+// listLocations - Returns the locations of the Subscription with at least one resource deployed
+func (s *QuotaScanner) listLocations() ([]string, error) {
+	available := map[string]bool{}
+	pager := s.subscriptionsClient.NewListLocationsPager(s.config.SubscriptionID, nil)
+	for pager.More() {
+		resp, err := pager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range resp.Value {
+			if l.Name != nil {
+				available[strings.ToLower(*l.Name)] = true
+			}
+		}
+	}
+
+	locations := []string{}
+	result, err := s.graphQuery.Run(s.config.Ctx, "resources | distinct location", []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range result.Data {
+		m := row.(map[string]interface{})
+		location, ok := m["location"].(string)
+		if !ok {
+			continue
+		}
+		location = strings.ToLower(location)
+		if available[location] {
+			locations = append(locations, location)
+		}
+	}
+	sort.Strings(locations)
+
+	return locations, nil
+}
+
+// listUsages - Returns the compute and network quotas in use in the location
+func (s *QuotaScanner) listUsages(location string) ([]*Usage, error) {
+	usages := []*Usage{}
+
+	computePager := s.computeUsageClient.NewListPager(location, nil)
+	for computePager.More() {
+		resp, err := computePager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range resp.Value {
+			if u.CurrentValue == nil || u.Limit == nil || *u.CurrentValue == 0 {
+				continue
+			}
+			usages = append(usages, &Usage{
+				Provider:     "Microsoft.Compute",
+				Name:         usageName(u.Name.LocalizedValue, u.Name.Value),
+				Location:     location,
+				CurrentValue: int64(*u.CurrentValue),
+				Limit:        *u.Limit,
+			})
+		}
+	}
+
+	networkPager := s.networkUsagesClient.NewListPager(location, nil)
+	for networkPager.More() {
+		resp, err := networkPager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range resp.Value {
+			if u.CurrentValue == nil || u.Limit == nil || *u.CurrentValue == 0 {
+				continue
+			}
+			usages = append(usages, &Usage{
+				Provider:     "Microsoft.Network",
+				Name:         usageName(u.Name.LocalizedValue, u.Name.Value),
+				Location:     location,
+				CurrentValue: *u.CurrentValue,
+				Limit:        *u.Limit,
+			})
+		}
+	}
+
+	return usages, nil
+}
+
+func usageName(localized, value *string) string {
+	if localized != nil && *localized != "" {
+		return *localized
+	}
+	if value != nil {
+		return *value
+	}
+	return ""
+}
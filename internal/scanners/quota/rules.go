@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+const (
+	defaultThresholdHigh   = 90
+	defaultThresholdMedium = 80
+)
+
+// GetRules - Returns the rules for the QuotaScanner
+func (s *QuotaScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"quota-001": {
+			Id:             "quota-001",
+			Category:       scanners.RulesCategoryScalability,
+			Recommendation: "Quota usage should be below the high threshold (90% by default)",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				u := target.(*Usage)
+				high, _ := thresholds(scanContext)
+				if u.Percentage() > high {
+					return true, usageResult(u), nil
+				}
+				return false, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/quotas/view-quotas",
+		},
+		"quota-002": {
+			Id:             "quota-002",
+			Category:       scanners.RulesCategoryScalability,
+			Recommendation: "Quota usage should be below the medium threshold (80% by default)",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				u := target.(*Usage)
+				high, medium := thresholds(scanContext)
+				p := u.Percentage()
+				if p > medium && p <= high {
+					return true, usageResult(u), nil
+				}
+				return false, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/quotas/view-quotas",
+		},
+	}
+}
+
+func thresholds(scanContext *scanners.ScanContext) (float64, float64) {
+	high, medium := float64(defaultThresholdHigh), float64(defaultThresholdMedium)
+	if scanContext.QuotaThresholdHigh > 0 {
+		high = scanContext.QuotaThresholdHigh
+	}
+	if scanContext.QuotaThresholdMedium > 0 {
+		medium = scanContext.QuotaThresholdMedium
+	}
+	return high, medium
+}
+
+func usageResult(u *Usage) string {
+	return fmt.Sprintf("%.0f%% (%d of %d)", u.Percentage(), u.CurrentValue, u.Limit)
+}
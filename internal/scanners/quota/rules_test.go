@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package quota
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+func TestQuotaScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "QuotaScanner usage above high threshold",
+			fields: fields{
+				rule:        "quota-001",
+				target:      &Usage{Name: "Total Regional vCPUs", CurrentValue: 95, Limit: 100},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "95% (95 of 100)",
+			},
+		},
+		{
+			name: "QuotaScanner usage below high threshold",
+			fields: fields{
+				rule:        "quota-001",
+				target:      &Usage{Name: "Total Regional vCPUs", CurrentValue: 85, Limit: 100},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "QuotaScanner usage above medium threshold",
+			fields: fields{
+				rule:        "quota-002",
+				target:      &Usage{Name: "Public IP Addresses", CurrentValue: 85, Limit: 100},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "85% (85 of 100)",
+			},
+		},
+		{
+			name: "QuotaScanner usage above high threshold is not reported as medium",
+			fields: fields{
+				rule:        "quota-002",
+				target:      &Usage{Name: "Public IP Addresses", CurrentValue: 95, Limit: 100},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "QuotaScanner custom thresholds",
+			fields: fields{
+				rule:        "quota-001",
+				target:      &Usage{Name: "Total Regional vCPUs", CurrentValue: 75, Limit: 100},
+				scanContext: &scanners.ScanContext{QuotaThresholdHigh: 70, QuotaThresholdMedium: 50},
+			},
+			want: want{
+				broken: true,
+				result: "75% (75 of 100)",
+			},
+		},
+		{
+			name: "QuotaScanner zero limit",
+			fields: fields{
+				rule:        "quota-001",
+				target:      &Usage{Name: "Dedicated Hosts", CurrentValue: 1, Limit: 0},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &QuotaScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("QuotaScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QuotaScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
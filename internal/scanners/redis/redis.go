@@ -16,6 +16,9 @@ type RedisScanner struct {
 
 // Init - Initializes the RedisScanner
 func (c *RedisScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.redisClient, err = armredis.NewClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *RedisScanner) Scan(resourceGroupName string, scanContext *scanners.Scan
 	results := []scanners.AzureServiceResult{}
 
 	for _, redis := range redis {
-		rr := engine.EvaluateRules(rules, redis, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, redis, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *redis.Name,
+			ID:               *redis.ID,
 			Type:             *redis.Type,
 			Location:         *redis.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(redis),
 		})
 	}
 	return results, nil
@@ -4,6 +4,7 @@
 package redis
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -14,26 +15,30 @@ import (
 func (a *RedisScanner) GetRules() map[string]scanners.AzureRule {
 	return map[string]scanners.AzureRule{
 		"redis-001": {
-			Id:             "redis-001",
-			Category:       scanners.RulesCategoryMonitoringAndAlerting,
-			Recommendation: "Redis should have diagnostic settings enabled",
-			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Id:                 "redis-001",
+			Category:           scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation:     "Redis should have diagnostic settings enabled",
+			Impact:             scanners.ImpactLow,
+			Tags:               []string{"CIS", "logging"},
+			RequiresDeployment: true,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armredis.ResourceInfo)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-monitor-diagnostic-settings",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-monitor-diagnostic-settings",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"redis-002": {
 			Id:             "redis-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Redis should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armredis.ResourceInfo)
 				zones := len(i.Zones) > 0
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-high-availability",
 		},
@@ -42,8 +47,8 @@ func (a *RedisScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Redis should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
@@ -52,10 +57,12 @@ func (a *RedisScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Redis should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Policy:         scanners.PolicyFieldCountEquals("Microsoft.Cache/Redis", "Microsoft.Cache/Redis/privateEndpointConnections[*]", 0),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armredis.ResourceInfo)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-private-link",
 		},
@@ -64,9 +71,9 @@ func (a *RedisScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Redis SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armredis.ResourceInfo)
-				return false, string(*i.Properties.SKU.Name)
+				return false, string(*i.Properties.SKU.Name), nil
 			},
 			Url: "https://azure.microsoft.com/en-gb/pricing/details/cache/",
 		},
@@ -75,10 +82,10 @@ func (a *RedisScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Redis Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armredis.ResourceInfo)
 				caf := strings.HasPrefix(*c.Name, "redis")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -87,33 +94,56 @@ func (a *RedisScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Redis should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armredis.ResourceInfo)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"redis-008": {
-			Id:             "redis-008",
-			Category:       scanners.RulesCategorySecurity,
-			Recommendation: "Redis should not enable non SSL ports",
-			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Id:                   "redis-008",
+			Category:             scanners.RulesCategorySecurity,
+			Recommendation:       "Redis should not enable non SSL ports",
+			Impact:               scanners.ImpactHigh,
+			PolicyDefinitionIDs:  []string{"/providers/Microsoft.Authorization/policyDefinitions/22bee202-a82f-4305-9a2a-6d7f44d4dedb"},
+			DefenderAssessmentID: "35b25be2-d08a-e340-45ed-f08a95d804fc",
+			Policy:               scanners.PolicyFieldEquals("Microsoft.Cache/Redis", "Microsoft.Cache/Redis/enableNonSslPort", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armredis.ResourceInfo)
-				return c.Properties.EnableNonSSLPort != nil && *c.Properties.EnableNonSSLPort, ""
+				return c.Properties.EnableNonSSLPort != nil && *c.Properties.EnableNonSSLPort, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-configure#access-ports",
+			Url:         "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-configure#access-ports",
+			Remediation: "az redis update --ids {resource_id} --set enableNonSslPort=false",
 		},
 		"redis-009": {
 			Id:             "redis-009",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Redis should enforce TLS >= 1.2",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "data-protection"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.Cache/Redis", "Microsoft.Cache/Redis/minimumTlsVersion", "1.2"),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armredis.ResourceInfo)
-				return c.Properties.MinimumTLSVersion == nil || *c.Properties.MinimumTLSVersion != armredis.TLSVersionOne2, ""
+				return c.Properties.MinimumTLSVersion == nil || *c.Properties.MinimumTLSVersion != armredis.TLSVersionOne2, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-remove-tls-10-11",
+			Url:         "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-remove-tls-10-11",
+			Remediation: "az redis update --ids {resource_id} --set minimumTlsVersion=1.2",
+		},
+		"redis-010": {
+			Id:                 "redis-010",
+			Category:           scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation:     "Redis diagnostic settings should include the ConnectedClientList log category",
+			Impact:             scanners.ImpactMedium,
+			Tags:               []string{"logging"},
+			RequiresDeployment: true,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armredis.ResourceInfo)
+				return scanners.IsLogCategoryMissing(scanContext, *service.ID, "ConnectedClientList"), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-monitor-diagnostic-settings",
 		},
 	}
 }
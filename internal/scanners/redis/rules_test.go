@@ -4,6 +4,7 @@
 package redis
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -155,12 +156,92 @@ func TestRedisScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "RedisScanner DiagnosticSettings ConnectedClientList",
+			fields: fields{
+				rule: "redis-010",
+				target: &armredis.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"ConnectedClientList"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "RedisScanner DiagnosticSettings allLogs",
+			fields: fields{
+				rule: "redis-010",
+				target: &armredis.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"allLogs"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "RedisScanner DiagnosticSettings without ConnectedClientList",
+			fields: fields{
+				rule: "redis-010",
+				target: &armredis.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"audit"},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "RedisScanner No DiagnosticSettings",
+			fields: fields{
+				rule: "redis-010",
+				target: &armredis.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &RedisScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("RedisScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
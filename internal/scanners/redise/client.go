@@ -0,0 +1,108 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package redise
+
+import (
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// The armredisenterprise module is not a dependency of azqr, so the scanner talks to the
+// Microsoft.Cache/redisEnterprise REST API through scanners.RESTClient, using only the fields required by the rules.
+
+const apiVersion = "2023-11-01"
+
+type (
+	// Cluster - Redis Enterprise cluster
+	Cluster struct {
+		ID         *string            `json:"id"`
+		Name       *string            `json:"name"`
+		Type       *string            `json:"type"`
+		Location   *string            `json:"location"`
+		Tags       map[string]*string `json:"tags"`
+		Zones      []*string          `json:"zones"`
+		SKU        *SKU               `json:"sku"`
+		Properties *ClusterProperties `json:"properties"`
+		SystemData *SystemData        `json:"systemData"`
+	}
+
+	// SystemData - Metadata about the creation of the resource
+	SystemData struct {
+		CreatedAt *time.Time `json:"createdAt"`
+	}
+
+	// SKU - Redis Enterprise cluster SKU
+	SKU struct {
+		Name     *string `json:"name"`
+		Capacity *int32  `json:"capacity"`
+	}
+
+	// ClusterProperties - Redis Enterprise cluster properties
+	ClusterProperties struct {
+		MinimumTLSVersion          *string       `json:"minimumTlsVersion"`
+		PrivateEndpointConnections []interface{} `json:"privateEndpointConnections"`
+	}
+
+	// Database - Redis Enterprise database
+	Database struct {
+		ID         *string             `json:"id"`
+		Properties *DatabaseProperties `json:"properties"`
+	}
+
+	// DatabaseProperties - Redis Enterprise database properties
+	DatabaseProperties struct {
+		GeoReplication *GeoReplication `json:"geoReplication"`
+	}
+
+	// GeoReplication - Active geo-replication settings of a database
+	GeoReplication struct {
+		GroupNickname   *string           `json:"groupNickname"`
+		LinkedDatabases []*LinkedDatabase `json:"linkedDatabases"`
+	}
+
+	// LinkedDatabase - Database linked through active geo-replication
+	LinkedDatabase struct {
+		ID    *string `json:"id"`
+		State *string `json:"state"`
+	}
+
+	clusterList struct {
+		Value    []*Cluster `json:"value"`
+		NextLink *string    `json:"nextLink"`
+	}
+
+	databaseList struct {
+		Value    []*Database `json:"value"`
+		NextLink *string     `json:"nextLink"`
+	}
+)
+
+func (c *RedisEnterpriseScanner) listClusters(resourceGroupName string) ([]*Cluster, error) {
+	clusters := make([]*Cluster, 0)
+	next := c.client.URL("subscriptions", c.config.SubscriptionID, "resourceGroups", resourceGroupName, "providers/Microsoft.Cache/redisEnterprise")
+	for next != "" {
+		page := clusterList{}
+		if err := c.client.Get(c.config.Ctx, next, &page); err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, page.Value...)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return clusters, nil
+}
+
+func (c *RedisEnterpriseScanner) listDatabases(clusterID string) ([]*Database, error) {
+	databases := make([]*Database, 0)
+	next := c.client.URL(clusterID, "databases")
+	for next != "" {
+		page := databaseList{}
+		if err := c.client.Get(c.config.Ctx, next, &page); err != nil {
+			return nil, err
+		}
+		databases = append(databases, page.Value...)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return databases, nil
+}
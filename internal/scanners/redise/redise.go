@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package redise
+
+import (
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// RedisEnterpriseScanner - Scanner for Azure Cache for Redis Enterprise
+type RedisEnterpriseScanner struct {
+	config *scanners.ScannerConfig
+	client *scanners.RESTClient
+}
+
+// Init - Initializes the RedisEnterpriseScanner
+func (c *RedisEnterpriseScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	c.config = config
+	var err error
+	c.client, err = scanners.NewRESTClient(config, apiVersion)
+	return err
+}
+
+// Scan - Scans all Redis Enterprise clusters in a Resource Group
+func (c *RedisEnterpriseScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(c.config.SubscriptionID, resourceGroupName, "Redis Enterprise")
+
+	clusters, err := c.listClusters(resourceGroupName)
+	if err != nil {
+		return nil, err
+	}
+	engine := scanners.RuleEngine{}
+	rules := c.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	scanContext.RedisEnterpriseLinkedDatabases = map[string]int{}
+
+	for _, cluster := range clusters {
+		databases, err := c.listDatabases(*cluster.ID)
+		if err != nil {
+			return nil, err
+		}
+		scanContext.RedisEnterpriseLinkedDatabases[strings.ToLower(*cluster.ID)] = countLinkedDatabases(databases)
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, cluster, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   c.config.SubscriptionID,
+			SubscriptionName: c.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *cluster.Name,
+			ID:               *cluster.ID,
+			Type:             *cluster.Type,
+			Location:         *cluster.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(cluster),
+		})
+	}
+	return results, nil
+}
+
+// countLinkedDatabases - Returns the number of databases linked to the cluster databases, excluding themselves
+func countLinkedDatabases(databases []*Database) int {
+	count := 0
+	for _, d := range databases {
+		if d.Properties == nil || d.Properties.GeoReplication == nil {
+			continue
+		}
+		for _, l := range d.Properties.GeoReplication.LinkedDatabases {
+			if l.ID != nil && d.ID != nil && strings.EqualFold(*l.ID, *d.ID) {
+				continue
+			}
+			count++
+		}
+	}
+	return count
+}
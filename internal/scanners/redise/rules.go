@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package redise
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// GetRules - Returns the rules for the RedisEnterpriseScanner
+func (c *RedisEnterpriseScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"redise-001": {
+			Id:             "redise-001",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Redis Enterprise should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*Cluster)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-monitor-diagnostic-settings",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"redise-002": {
+			Id:             "redise-002",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "Redis Enterprise should have active geo-replication configured",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*Cluster)
+				linked := scanContext.RedisEnterpriseLinkedDatabases[strings.ToLower(*i.ID)]
+				return linked == 0, strconv.Itoa(linked), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-how-to-active-geo-replication",
+		},
+		"redise-003": {
+			Id:             "redise-003",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Redis Enterprise should have private endpoints enabled",
+			Impact:         scanners.ImpactHigh,
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*Cluster)
+				pe := i.Properties != nil && len(i.Properties.PrivateEndpointConnections) > 0
+				return !pe, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-private-link",
+		},
+		"redise-004": {
+			Id:             "redise-004",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Redis Enterprise should enforce TLS >= 1.2",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "data-protection"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*Cluster)
+				// Clusters default to TLS 1.2 when the minimum version is not set
+				if i.Properties == nil || i.Properties.MinimumTLSVersion == nil {
+					return false, "", nil
+				}
+				v := *i.Properties.MinimumTLSVersion
+				return v == "1.0" || v == "1.1", v, nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/azure-cache-for-redis/cache-remove-tls-10-11",
+			Remediation: "az redisenterprise update --ids {resource_id} --minimum-tls-version 1.2",
+		},
+		"redise-005": {
+			Id:             "redise-005",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Redis Enterprise SKU",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				i := target.(*Cluster)
+				if i.SKU == nil || i.SKU.Name == nil {
+					return false, "", nil
+				}
+				return false, *i.SKU.Name, nil
+			},
+			Url: "https://azure.microsoft.com/en-us/pricing/details/cache/",
+		},
+		"redise-006": {
+			Id:             "redise-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Redis Enterprise Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*Cluster)
+				caf := strings.HasPrefix(*c.Name, "redis")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"redise-007": {
+			Id:             "redise-007",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Redis Enterprise should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*Cluster)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+	}
+}
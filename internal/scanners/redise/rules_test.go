@@ -0,0 +1,209 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package redise
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+)
+
+func TestRedisEnterpriseScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "RedisEnterpriseScanner DiagnosticSettings",
+			fields: fields{
+				rule: "redise-001",
+				target: &Cluster{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner no geo-replication",
+			fields: fields{
+				rule: "redise-002",
+				target: &Cluster{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					RedisEnterpriseLinkedDatabases: map[string]int{
+						"test": 0,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "0",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner geo-replication",
+			fields: fields{
+				rule: "redise-002",
+				target: &Cluster{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					RedisEnterpriseLinkedDatabases: map[string]int{
+						"test": 1,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "1",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner no Private Endpoint",
+			fields: fields{
+				rule:        "redise-003",
+				target:      &Cluster{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner Private Endpoint",
+			fields: fields{
+				rule: "redise-003",
+				target: &Cluster{
+					Properties: &ClusterProperties{
+						PrivateEndpointConnections: []interface{}{
+							map[string]interface{}{},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner TLS 1.0",
+			fields: fields{
+				rule: "redise-004",
+				target: &Cluster{
+					Properties: &ClusterProperties{
+						MinimumTLSVersion: to.Ptr("1.0"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "1.0",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner TLS 1.2",
+			fields: fields{
+				rule: "redise-004",
+				target: &Cluster{
+					Properties: &ClusterProperties{
+						MinimumTLSVersion: to.Ptr("1.2"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "1.2",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner SKU",
+			fields: fields{
+				rule: "redise-005",
+				target: &Cluster{
+					SKU: &SKU{
+						Name: to.Ptr("Enterprise_E10"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "Enterprise_E10",
+			},
+		},
+		{
+			name: "RedisEnterpriseScanner CAF",
+			fields: fields{
+				rule: "redise-006",
+				target: &Cluster{
+					Name: to.Ptr("redis-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &RedisEnterpriseScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("RedisEnterpriseScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RedisEnterpriseScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedisEnterpriseScanner_CreatedAt(t *testing.T) {
+	cluster := &Cluster{}
+	err := json.Unmarshal([]byte(`{"name":"redis","systemData":{"createdAt":"2024-01-02T03:04:05.1234567Z"}}`), cluster)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 123456700, time.UTC)
+	got := scanners.GetCreatedAt(cluster)
+	if got == nil || !got.Equal(want) {
+		t.Errorf("GetCreatedAt() = %v, want %v", got, want)
+	}
+}
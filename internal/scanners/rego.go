@@ -0,0 +1,201 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// regoHelpers - Functions used by the generated deny rules. Azure Policy compares strings case insensitively.
+const regoHelpers = `str(x) := lower(x) if {
+	is_string(x)
+}
+
+str(x) := "" if {
+	not is_string(x)
+}
+`
+
+// regoKeywords - Reserved words that can't be used as package names
+var regoKeywords = map[string]bool{
+	"as": true, "contains": true, "default": true, "else": true, "every": true, "false": true, "if": true,
+	"import": true, "in": true, "not": true, "null": true, "package": true, "some": true, "true": true, "with": true,
+}
+
+// RegoPackage - Returns the Rego package name of the rule, azqr.<scanner>
+func (r *AzureRule) RegoPackage() string {
+	scanner := strings.Split(r.Id, "-")[0]
+	if regoKeywords[scanner] {
+		return fmt.Sprintf("azqr[%q]", scanner)
+	}
+	return "azqr." + scanner
+}
+
+// ToRegoRule - Returns the rule as a Rego deny rule generated from its Azure Policy condition, or a
+// commented stub when the rule has no Policy or the condition uses operators not supported in Rego yet
+func (r *AzureRule) ToRegoRule() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s: %s\n", r.Id, r.Recommendation)
+
+	exprs, ok := regoExpressions(r.Policy)
+	if !ok {
+		sb.WriteString("# TODO: implement\n")
+		sb.WriteString("# deny contains result if {\n")
+		sb.WriteString("# \t...\n")
+		sb.WriteString("# }\n")
+		return sb.String()
+	}
+
+	message, _ := json.Marshal(r.Recommendation)
+	fmt.Fprintf(&sb, "deny contains result if {\n")
+	for _, expr := range exprs {
+		fmt.Fprintf(&sb, "\t%s\n", expr)
+	}
+	fmt.Fprintf(&sb, "\tresult := {\n")
+	fmt.Fprintf(&sb, "\t\t\"rule\": %q,\n", r.Id)
+	fmt.Fprintf(&sb, "\t\t\"impact\": %q,\n", r.Impact)
+	fmt.Fprintf(&sb, "\t\t\"id\": object.get(input, \"id\", \"\"),\n")
+	fmt.Fprintf(&sb, "\t\t\"name\": object.get(input, \"name\", \"\"),\n")
+	fmt.Fprintf(&sb, "\t\t\"msg\": %s,\n", message)
+	fmt.Fprintf(&sb, "\t}\n")
+	fmt.Fprintf(&sb, "}\n")
+	return sb.String()
+}
+
+// regoExpressions - Returns the Rego expressions (all must match) of an Azure Policy condition.
+// Supports allOf, field conditions with the equals, notEquals and exists operators and count conditions
+// with the equals operator.
+func regoExpressions(c PolicyCondition) ([]string, bool) {
+	if len(c) == 0 {
+		return nil, false
+	}
+	if allOf, ok := c["allOf"]; ok {
+		if len(c) != 1 {
+			return nil, false
+		}
+		conditions, ok := allOf.([]PolicyCondition)
+		if !ok || len(conditions) == 0 {
+			return nil, false
+		}
+		exprs := []string{}
+		for _, condition := range conditions {
+			e, ok := regoExpressions(condition)
+			if !ok {
+				return nil, false
+			}
+			exprs = append(exprs, e...)
+		}
+		return exprs, true
+	}
+	if count, ok := c["count"]; ok {
+		return regoCountExpression(count, c)
+	}
+	return regoFieldExpression(c)
+}
+
+func regoFieldExpression(c PolicyCondition) ([]string, bool) {
+	field, ok := c["field"].(string)
+	if !ok || len(c) != 2 {
+		return nil, false
+	}
+	path := regoPath(field)
+	for operator, v := range c {
+		if operator == "field" {
+			continue
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		s, isString := v.(string)
+		switch operator {
+		case "equals", "notEquals":
+			comparison := "=="
+			if operator == "notEquals" {
+				comparison = "!="
+			}
+			if isString {
+				return []string{fmt.Sprintf("str(object.get(input, %s, null)) %s %q", path, comparison, strings.ToLower(s))}, true
+			}
+			return []string{fmt.Sprintf("object.get(input, %s, null) %s %s", path, comparison, value)}, true
+		case "exists":
+			exists, ok := v.(bool)
+			if !ok {
+				return nil, false
+			}
+			if exists {
+				return []string{fmt.Sprintf("object.get(input, %s, null) != null", path)}, true
+			}
+			return []string{fmt.Sprintf("object.get(input, %s, null) == null", path)}, true
+		}
+	}
+	return nil, false
+}
+
+func regoCountExpression(count interface{}, c PolicyCondition) ([]string, bool) {
+	m, ok := count.(PolicyCondition)
+	if !ok || len(m) != 1 || len(c) != 2 {
+		return nil, false
+	}
+	field, ok := m["field"].(string)
+	if !ok || !strings.HasSuffix(field, "[*]") {
+		return nil, false
+	}
+	equals, ok := c["equals"].(int)
+	if !ok {
+		return nil, false
+	}
+	path := regoPath(strings.TrimSuffix(field, "[*]"))
+	return []string{fmt.Sprintf("count(object.get(input, %s, [])) == %d", path, equals)}, true
+}
+
+// regoPath - Returns the path in the ARM resource JSON of an Azure Policy field: the top level fields
+// (name, type, location, tags...) or the property path of an alias, e.g. Microsoft.Storage/storageAccounts/minimumTlsVersion
+func regoPath(field string) string {
+	segments := []string{}
+	if i := strings.LastIndex(field, "/"); i >= 0 {
+		segments = append(segments, "properties")
+		field = field[i+1:]
+	}
+	segments = append(segments, strings.Split(field, ".")...)
+	quoted := []string{}
+	for _, s := range segments {
+		quoted = append(quoted, fmt.Sprintf("%q", s))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// ToRegoModule - Returns a Rego module with the deny rules of the given rules, sorted by id.
+// All rules must belong to the same package.
+func ToRegoModule(rules map[string]AzureRule) (string, error) {
+	ids := make([]string, 0, len(rules))
+	for k := range rules {
+		ids = append(ids, k)
+	}
+	sort.Strings(ids)
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no rules to export")
+	}
+
+	first := rules[ids[0]]
+	pkg := first.RegoPackage()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "package %s\n\n", pkg)
+	sb.WriteString("import future.keywords.contains\n")
+	sb.WriteString("import future.keywords.if\n\n")
+	sb.WriteString(regoHelpers)
+	for _, id := range ids {
+		rule := rules[id]
+		if rule.RegoPackage() != pkg {
+			return "", fmt.Errorf("rule %s does not belong to package %s", rule.Id, pkg)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(rule.ToRegoRule())
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+)
+
+func TestAzureRule_ToRegoRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PolicyCondition
+		want   []string
+	}{
+		{
+			name:   "not equals",
+			policy: PolicyFieldNotEquals("Microsoft.Test/accounts", "Microsoft.Test/accounts/disableLocalAuth", true),
+			want: []string{
+				`str(object.get(input, ["type"], null)) == "microsoft.test/accounts"`,
+				`object.get(input, ["properties", "disableLocalAuth"], null) != true`,
+			},
+		},
+		{
+			name:   "not equals string",
+			policy: PolicyFieldNotEquals("Microsoft.Test/accounts", "Microsoft.Test/accounts/minimumTlsVersion", "TLS1_2"),
+			want:   []string{`str(object.get(input, ["properties", "minimumTlsVersion"], null)) != "tls1_2"`},
+		},
+		{
+			name:   "equals",
+			policy: PolicyFieldEquals("Microsoft.Test/accounts", "Microsoft.Test/accounts/enableNonSslPort", true),
+			want:   []string{`object.get(input, ["properties", "enableNonSslPort"], null) == true`},
+		},
+		{
+			name:   "count",
+			policy: PolicyFieldCountEquals("Microsoft.Test/accounts", "Microsoft.Test/accounts/privateEndpointConnections[*]", 0),
+			want:   []string{`count(object.get(input, ["properties", "privateEndpointConnections"], [])) == 0`},
+		},
+		{
+			name:   "exists",
+			policy: PolicyCondition{"field": "Microsoft.Test/accounts/encryption.keySource", "exists": false},
+			want:   []string{`object.get(input, ["properties", "encryption", "keySource"], null) == null`},
+		},
+		{
+			name:   "unsupported operator",
+			policy: PolicyCondition{"field": "name", "notLike": "st*"},
+			want:   []string{"# TODO: implement"},
+		},
+		{
+			name: "stub",
+			want: []string{"# TODO: implement"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := AzureRule{Id: "st-001", Recommendation: "Storage recommendation", Impact: ImpactHigh, Policy: tt.policy}
+			got := rule.ToRegoRule()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("AzureRule.ToRegoRule() = %s, want %s", got, want)
+				}
+			}
+
+			module, err := ToRegoModule(map[string]AzureRule{rule.Id: rule})
+			if err != nil {
+				t.Fatalf("ToRegoModule() error = %v", err)
+			}
+			if _, err := ast.ParseModule("st.rego", module); err != nil {
+				t.Errorf("ToRegoModule() invalid Rego: %v\n%s", err, module)
+			}
+		})
+	}
+}
+
+func TestToRegoModule_Keywords(t *testing.T) {
+	rule := AzureRule{Id: "as-001", Policy: PolicyFieldNotEquals("Microsoft.AnalysisServices/servers", "Microsoft.AnalysisServices/servers/ipV4FirewallSettings.enablePowerBIService", false)}
+	module, err := ToRegoModule(map[string]AzureRule{rule.Id: rule})
+	if err != nil {
+		t.Fatalf("ToRegoModule() error = %v", err)
+	}
+	if _, err := ast.ParseModule("as.rego", module); err != nil {
+		t.Errorf("ToRegoModule() invalid Rego: %v\n%s", err, module)
+	}
+
+	_, err = ToRegoModule(map[string]AzureRule{"as-001": rule, "st-001": {Id: "st-001"}})
+	if err == nil {
+		t.Errorf("ToRegoModule() expected error for rules of different packages")
+	}
+}
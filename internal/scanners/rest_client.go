@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const (
+	restClientModuleName    = "azqr"
+	restClientModuleVersion = "dev"
+)
+
+// RESTClient - Client for the Azure Resource Manager REST APIs of resource providers whose SDK module is
+// not a dependency of azqr. Requests go through the ARM pipeline (authentication, retries and throttling)
+// and identify themselves as azqr in the User-Agent.
+type RESTClient struct {
+	client     *arm.Client
+	apiVersion string
+}
+
+// NewRESTClient - Creates a RESTClient sending requests with the given API version
+func NewRESTClient(config *ScannerConfig, apiVersion string) (*RESTClient, error) {
+	client, err := arm.NewClient(restClientModuleName+".RESTClient", restClientModuleVersion, config.Cred, config.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &RESTClient{
+		client:     client,
+		apiVersion: apiVersion,
+	}, nil
+}
+
+// URL - Returns the URL of the ARM endpoint joined with the given path segments
+func (c *RESTClient) URL(paths ...string) string {
+	return runtime.JoinPaths(c.client.Endpoint(), paths...)
+}
+
+// Get - Sends a GET request to the URL and unmarshals the JSON response into result
+func (c *RESTClient) Get(ctx context.Context, url string, result interface{}) error {
+	return c.do(ctx, http.MethodGet, url, result)
+}
+
+// Post - Sends a POST request without body to the URL and unmarshals the JSON response into result
+func (c *RESTClient) Post(ctx context.Context, url string, result interface{}) error {
+	return c.do(ctx, http.MethodPost, url, result)
+}
+
+func (c *RESTClient) do(ctx context.Context, method string, url string, result interface{}) error {
+	req, err := runtime.NewRequest(ctx, method, url)
+	if err != nil {
+		return err
+	}
+	// Next links already carry the api-version
+	if req.Raw().URL.Query().Get("api-version") == "" {
+		reqQP := req.Raw().URL.Query()
+		reqQP.Set("api-version", c.apiVersion)
+		req.Raw().URL.RawQuery = reqQP.Encode()
+	}
+	req.Raw().Header["Accept"] = []string{"application/json"}
+
+	resp, err := c.client.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return runtime.NewResponseError(resp)
+	}
+	return runtime.UnmarshalAsJSON(resp, result)
+}
+
+// NextLink - Returns the next link of a list response page, or an empty string on the last page
+func NextLink(link *string) string {
+	if link == nil {
+		return ""
+	}
+	return *link
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package rg
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/azqr/internal/graph"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/rs/zerolog/log"
+)
+
+// ResourceGroupScanner - Scanner for Resource Groups
+type ResourceGroupScanner struct {
+	config               *scanners.ScannerConfig
+	resourceGroupsClient *armresources.ResourceGroupsClient
+	graphQuery           *graph.GraphQuery
+}
+
+// Init - Initializes the ResourceGroupScanner
+func (s *ResourceGroupScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	s.config = config
+	var err error
+	s.resourceGroupsClient, err = armresources.NewResourceGroupsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	s.graphQuery = graph.NewGraphQuery(config.Cred)
+	return nil
+}
+
+// ScanSubscriptionScope - Scans all Resource Groups in the Subscription
+func (s *ResourceGroupScanner) ScanSubscriptionScope(scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogSubscriptionScan(s.config.SubscriptionID, "Resource Groups")
+
+	resourceGroups, err := s.listResourceGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	// rg-004 is skipped when the environment tags can't be listed
+	environments, err := s.listEnvironmentTags()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to list the environment tags of the resources, skipping rg-004")
+		environments = nil
+	}
+	scanContext.Lock()
+	scanContext.ResourceGroupEnvironmentTags = environments
+	scanContext.Unlock()
+
+	engine := scanners.RuleEngine{}
+	rules := s.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, g := range resourceGroups {
+		if scanContext.Exclusions.IsResourceGroupExcluded(*g.ID) {
+			continue
+		}
+
+		rr := engine.EvaluateRules(s.config.Ctx, rules, g, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   s.config.SubscriptionID,
+			SubscriptionName: s.config.SubscriptionName,
+			ResourceGroup:    *g.Name,
+			ServiceName:      *g.Name,
+			ID:               *g.ID,
+			Type:             *g.Type,
+			Location:         *g.Location,
+			Rules:            rr,
+		})
+	}
+	return results, nil
+}
+
+func (s *ResourceGroupScanner) listResourceGroups() ([]*armresources.ResourceGroup, error) {
+	pager := s.resourceGroupsClient.NewListPager(nil)
+	results := []*armresources.ResourceGroup{}
+	for pager.More() {
+		resp, err := pager.NextPage(s.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, resp.Value...)
+	}
+
+	return results, nil
+}
+
+// listEnvironmentTags - Returns the distinct environment tag values of the resources in each Resource Group
+func (s *ResourceGroupScanner) listEnvironmentTags() (map[string][]string, error) {
+	res := map[string][]string{}
+
+	query := "resources | mv-expand bagexpansion=array tags | where tolower(tostring(tags[0])) in ('environment', 'env') | project id, environment = tolower(tostring(tags[1]))"
+	result, err := s.graphQuery.Run(s.config.Ctx, query, []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, row := range result.Data {
+		m := row.(map[string]interface{})
+		id, ok := m["id"].(string)
+		if !ok {
+			continue
+		}
+		env, ok := m["environment"].(string)
+		if !ok || env == "" {
+			continue
+		}
+		id = strings.ToLower(id)
+		i := strings.Index(id, "/providers/")
+		if i < 0 {
+			continue
+		}
+		rg := id[:i]
+		if seen[rg+"|"+env] {
+			continue
+		}
+		seen[rg+"|"+env] = true
+		res[rg] = append(res[rg], env)
+	}
+
+	for _, envs := range res {
+		sort.Strings(envs)
+	}
+
+	return res, nil
+}
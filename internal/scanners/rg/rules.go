@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package rg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// GetRules - Returns the rules for the ResourceGroupScanner
+func (s *ResourceGroupScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"rg-001": {
+			Id:             "rg-001",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Resource Group Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armresources.ResourceGroup)
+				caf := strings.HasPrefix(*g.Name, "rg")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"rg-002": {
+			Id:             "rg-002",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Resource Group should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armresources.ResourceGroup)
+				broken, result := scanners.EvaluateTagPolicy(g.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation: "az group update --name {resource_group} --tags <key>=<value>",
+		},
+		"rg-003": {
+			Id:             "rg-003",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Resource Group should have a delete or read-only lock",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armresources.ResourceGroup)
+				return !scanners.HasResourceLock(scanContext.ResourceLocks, *g.ID), "", nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/lock-resources",
+			Remediation: "az lock create --name azqr --lock-type CanNotDelete --resource-group {resource_group}",
+		},
+		"rg-004": {
+			Id:             "rg-004",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Resource Group should not mix production and development resources",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armresources.ResourceGroup)
+				if scanContext.ResourceGroupEnvironmentTags == nil {
+					return false, "", fmt.Errorf("environment tags not available for %s", *g.Name)
+				}
+				envs := scanContext.ResourceGroupEnvironmentTags[strings.ToLower(*g.ID)]
+				prod, dev := false, false
+				for _, e := range envs {
+					if strings.Contains(e, "prod") {
+						prod = true
+					} else if strings.Contains(e, "dev") {
+						dev = true
+					}
+				}
+				if prod && dev {
+					return true, strings.Join(envs, ", "), nil
+				}
+				return false, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/landing-zone/design-area/resource-org-subscriptions",
+		},
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package rg
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+func TestResourceGroupScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "ResourceGroupScanner CAF",
+			fields: fields{
+				rule: "rg-001",
+				target: &armresources.ResourceGroup{
+					Name: to.Ptr("rg-test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner CAF not compliant",
+			fields: fields{
+				rule: "rg-001",
+				target: &armresources.ResourceGroup{
+					Name: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner tags",
+			fields: fields{
+				rule: "rg-002",
+				target: &armresources.ResourceGroup{
+					Tags: map[string]*string{"env": to.Ptr("prod")},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner no tags",
+			fields: fields{
+				rule:        "rg-002",
+				target:      &armresources.ResourceGroup{},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner lock",
+			fields: fields{
+				rule: "rg-003",
+				target: &armresources.ResourceGroup{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					ResourceLocks: map[string]bool{
+						"/subscriptions/sub/resourcegroups/rg-test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner subscription lock",
+			fields: fields{
+				rule: "rg-003",
+				target: &armresources.ResourceGroup{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					ResourceLocks: map[string]bool{
+						"/subscriptions/sub": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner no lock",
+			fields: fields{
+				rule: "rg-003",
+				target: &armresources.ResourceGroup{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					ResourceLocks: map[string]bool{
+						"/subscriptions/sub/resourcegroups/rg-other": true,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner mixed environments",
+			fields: fields{
+				rule: "rg-004",
+				target: &armresources.ResourceGroup{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					ResourceGroupEnvironmentTags: map[string][]string{
+						"/subscriptions/sub/resourcegroups/rg-test": {"dev", "prod"},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "dev, prod",
+			},
+		},
+		{
+			name: "ResourceGroupScanner single environment",
+			fields: fields{
+				rule: "rg-004",
+				target: &armresources.ResourceGroup{
+					ID: to.Ptr("/subscriptions/sub/resourceGroups/rg-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					ResourceGroupEnvironmentTags: map[string][]string{
+						"/subscriptions/sub/resourcegroups/rg-test": {"production"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ResourceGroupScanner required tags missing",
+			fields: fields{
+				rule: "rg-002",
+				target: &armresources.ResourceGroup{
+					Tags: map[string]*string{"env": to.Ptr("prod")},
+				},
+				scanContext: &scanners.ScanContext{
+					RequiredTags: []scanners.RequiredTag{{Key: "owner"}, {Key: "env", AllowedValues: []string{"prod"}}},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "missing: owner",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ResourceGroupScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ResourceGroupScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceGroupScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceGroupScanner_EnvironmentTags_NotAvailable(t *testing.T) {
+	s := &ResourceGroupScanner{}
+	rules := s.GetRules()
+	target := &armresources.ResourceGroup{
+		ID:   to.Ptr("/subscriptions/sub/resourceGroups/rg-test"),
+		Name: to.Ptr("rg-test"),
+	}
+	if _, _, err := rules["rg-004"].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+		t.Error("ResourceGroupScanner Rule.Eval() rg-004 error = nil, want an error when the environment tags are not available")
+	}
+}
@@ -4,7 +4,10 @@
 package sb
 
 import (
+	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/servicebus/armservicebus"
@@ -18,23 +21,26 @@ func (a *ServiceBusScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Service Bus should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armservicebus.SBNamespace)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/monitor-service-bus#collection-and-routing",
+			Url:             "https://learn.microsoft.com/en-us/azure/service-bus-messaging/monitor-service-bus#collection-and-routing",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"sb-002": {
 			Id:             "sb-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Service Bus should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armservicebus.SBNamespace)
 				sku := string(*i.SKU.Name)
 				zones := strings.Contains(sku, "Premium") && i.Properties.ZoneRedundant != nil && *i.Properties.ZoneRedundant
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-outages-disasters#availability-zones",
 		},
@@ -43,14 +49,14 @@ func (a *ServiceBusScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Service Bus should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armservicebus.SBNamespace)
 				sku := string(*i.SKU.Name)
 				sla := "99.9%"
 				if strings.Contains(sku, "Premium") {
 					sla = "99.95%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/service-bus/",
 		},
@@ -59,10 +65,11 @@ func (a *ServiceBusScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Service Bus should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armservicebus.SBNamespace)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/network-security",
 		},
@@ -71,9 +78,9 @@ func (a *ServiceBusScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Service Bus SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armservicebus.SBNamespace)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/service-bus/",
 		},
@@ -82,10 +89,10 @@ func (a *ServiceBusScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Service Bus Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armservicebus.SBNamespace)
 				caf := strings.HasPrefix(*c.Name, "sb")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -94,23 +101,175 @@ func (a *ServiceBusScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Service Bus should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armservicebus.SBNamespace)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"sb-008": {
 			Id:             "sb-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Service Bus should have local authentication disabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "identity"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.ServiceBus/namespaces", "Microsoft.ServiceBus/namespaces/disableLocalAuth", true),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armservicebus.SBNamespace)
 				localAuth := c.Properties.DisableLocalAuth != nil && *c.Properties.DisableLocalAuth
-				return !localAuth, ""
+				return !localAuth, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-sas",
+			Url:         "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-sas",
+			Remediation: "az servicebus namespace update --ids {resource_id} --disable-local-auth true",
 		},
+		"sb-010": {
+			Id:             "sb-010",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "Service Bus Premium should have Geo-disaster recovery configured with a partner namespace",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				if c.SKU == nil || c.SKU.Name == nil || *c.SKU.Name != armservicebus.SKUNamePremium {
+					return false, "", nil
+				}
+				_, ok := scanContext.ServiceBusGDRHealth[strings.ToLower(*c.ID)]
+				return !ok, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-geo-dr",
+		},
+		"sb-011": {
+			Id:             "sb-011",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "Service Bus Geo-disaster recovery pairing should be healthy",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				health, ok := scanContext.ServiceBusGDRHealth[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", nil
+				}
+				return health != string(armservicebus.ProvisioningStateDRSucceeded), health, nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-geo-dr",
+		},
+		"sb-012": {
+			Id:             "sb-012",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Service Bus queues should have a max delivery count of 10 or less",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				entities := excessiveDeliveryCount(scanContext.ServiceBusQueueProperties[strings.ToLower(*c.ID)])
+				return len(entities) > 0, strings.Join(entities, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-dead-letter-queues#maximum-delivery-count",
+		},
+		"sb-013": {
+			Id:             "sb-013",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Service Bus queues should dead-letter expired messages",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				entities := withoutDeadLetteringOnExpiration(scanContext.ServiceBusQueueProperties[strings.ToLower(*c.ID)])
+				return len(entities) > 0, strings.Join(entities, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-dead-letter-queues#time-to-live",
+		},
+		"sb-014": {
+			Id:             "sb-014",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Service Bus queues should not use the maximum lock duration of 5 minutes",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				entities := withLongLockDuration(scanContext.ServiceBusQueueProperties[strings.ToLower(*c.ID)])
+				return len(entities) > 0, strings.Join(entities, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/message-transfers-locks-settlement#peeklock",
+		},
+		"sb-015": {
+			Id:             "sb-015",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Service Bus topic subscriptions should have a max delivery count of 10 or less",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				entities := excessiveDeliveryCount(scanContext.ServiceBusTopicProperties[strings.ToLower(*c.ID)])
+				return len(entities) > 0, strings.Join(entities, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-dead-letter-queues#maximum-delivery-count",
+		},
+		"sb-016": {
+			Id:             "sb-016",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Service Bus topic subscriptions should dead-letter expired messages",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				entities := withoutDeadLetteringOnExpiration(scanContext.ServiceBusTopicProperties[strings.ToLower(*c.ID)])
+				return len(entities) > 0, strings.Join(entities, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-dead-letter-queues#time-to-live",
+		},
+		"sb-017": {
+			Id:             "sb-017",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Service Bus topic subscriptions should not use the maximum lock duration of 5 minutes",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armservicebus.SBNamespace)
+				entities := withLongLockDuration(scanContext.ServiceBusTopicProperties[strings.ToLower(*c.ID)])
+				return len(entities) > 0, strings.Join(entities, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/service-bus-messaging/message-transfers-locks-settlement#peeklock",
+		},
+	}
+}
+
+// maxLockDuration - Maximum lock duration allowed by Service Bus
+const maxLockDuration = 5 * time.Minute
+
+// excessiveDeliveryCount - Returns the entities without a max delivery count or with more than 10 deliveries,
+// with their max delivery count
+func excessiveDeliveryCount(entities []scanners.ServiceBusEntity) []string {
+	res := []string{}
+	for _, e := range entities {
+		if e.MaxDeliveryCount == nil {
+			res = append(res, fmt.Sprintf("%s (not set)", e.Name))
+		} else if *e.MaxDeliveryCount > 10 {
+			res = append(res, fmt.Sprintf("%s (%d)", e.Name, *e.MaxDeliveryCount))
+		}
+	}
+	return res
+}
+
+// withoutDeadLetteringOnExpiration - Returns the entities dropping expired messages
+func withoutDeadLetteringOnExpiration(entities []scanners.ServiceBusEntity) []string {
+	res := []string{}
+	for _, e := range entities {
+		if e.DeadLetteringOnMessageExpiration == nil || !*e.DeadLetteringOnMessageExpiration {
+			res = append(res, e.Name)
+		}
+	}
+	return res
+}
+
+// withLongLockDuration - Returns the entities with a lock duration of 5 minutes or more
+func withLongLockDuration(entities []scanners.ServiceBusEntity) []string {
+	res := []string{}
+	for _, e := range entities {
+		if e.LockDuration == nil {
+			continue
+		}
+		d, err := scanners.ParseISODuration(*e.LockDuration)
+		if err != nil || d < maxLockDuration {
+			continue
+		}
+		res = append(res, fmt.Sprintf("%s (%s)", e.Name, *e.LockDuration))
 	}
+	return res
 }
@@ -4,6 +4,7 @@
 package sb
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -13,6 +14,16 @@ import (
 )
 
 func TestServiceBusScanner_Rules(t *testing.T) {
+	entities := []scanners.ServiceBusEntity{
+		{Name: "orders", MaxDeliveryCount: to.Ptr(int32(10)), DeadLetteringOnMessageExpiration: to.Ptr(true), LockDuration: to.Ptr("PT1M")},
+		{Name: "payments", MaxDeliveryCount: to.Ptr(int32(100)), DeadLetteringOnMessageExpiration: to.Ptr(false), LockDuration: to.Ptr("PT5M")},
+		{Name: "events"},
+	}
+	entitiesContext := &scanners.ScanContext{
+		ServiceBusQueueProperties: map[string][]scanners.ServiceBusEntity{"test": entities},
+		ServiceBusTopicProperties: map[string][]scanners.ServiceBusEntity{"test": {{Name: "topic/sub", MaxDeliveryCount: to.Ptr(int32(20)), DeadLetteringOnMessageExpiration: to.Ptr(true), LockDuration: to.Ptr("PT4M59S")}}},
+	}
+
 	type fields struct {
 		rule        string
 		target      interface{}
@@ -162,12 +173,196 @@ func TestServiceBusScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "ServiceBusScanner Premium without GDR",
+			fields: fields{
+				rule: "sb-010",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+					SKU: &armservicebus.SBSKU{
+						Name: to.Ptr(armservicebus.SKUNamePremium),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "ServiceBusScanner Premium with GDR",
+			fields: fields{
+				rule: "sb-010",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+					SKU: &armservicebus.SBSKU{
+						Name: to.Ptr(armservicebus.SKUNamePremium),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ServiceBusGDRHealth: map[string]string{
+						"test": "Succeeded",
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ServiceBusScanner Standard GDR",
+			fields: fields{
+				rule: "sb-010",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+					SKU: &armservicebus.SBSKU{
+						Name: to.Ptr(armservicebus.SKUNameStandard),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ServiceBusScanner GDR pairing failed",
+			fields: fields{
+				rule: "sb-011",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+					SKU: &armservicebus.SBSKU{
+						Name: to.Ptr(armservicebus.SKUNamePremium),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ServiceBusGDRHealth: map[string]string{
+						"test": "Failed",
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "Failed",
+			},
+		},
+		{
+			name: "ServiceBusScanner GDR pairing succeeded",
+			fields: fields{
+				rule: "sb-011",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+					SKU: &armservicebus.SBSKU{
+						Name: to.Ptr(armservicebus.SKUNamePremium),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					ServiceBusGDRHealth: map[string]string{
+						"test": "Succeeded",
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "Succeeded",
+			},
+		},
+		{
+			name: "ServiceBusScanner queues max delivery count",
+			fields: fields{
+				rule: "sb-012",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: entitiesContext,
+			},
+			want: want{
+				broken: true,
+				result: "payments (100), events (not set)",
+			},
+		},
+		{
+			name: "ServiceBusScanner queues dead-lettering on expiration",
+			fields: fields{
+				rule: "sb-013",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: entitiesContext,
+			},
+			want: want{
+				broken: true,
+				result: "payments, events",
+			},
+		},
+		{
+			name: "ServiceBusScanner queues lock duration",
+			fields: fields{
+				rule: "sb-014",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: entitiesContext,
+			},
+			want: want{
+				broken: true,
+				result: "payments (PT5M)",
+			},
+		},
+		{
+			name: "ServiceBusScanner topic subscriptions max delivery count",
+			fields: fields{
+				rule: "sb-015",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: entitiesContext,
+			},
+			want: want{
+				broken: true,
+				result: "topic/sub (20)",
+			},
+		},
+		{
+			name: "ServiceBusScanner topic subscriptions dead-lettering on expiration",
+			fields: fields{
+				rule: "sb-016",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: entitiesContext,
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "ServiceBusScanner topic subscriptions lock duration",
+			fields: fields{
+				rule: "sb-017",
+				target: &armservicebus.SBNamespace{
+					ID: to.Ptr("test"),
+				},
+				scanContext: entitiesContext,
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &ServiceBusScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("ServiceBusScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -4,6 +4,8 @@
 package sb
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/servicebus/armservicebus"
 )
@@ -12,13 +14,36 @@ import (
 type ServiceBusScanner struct {
 	config           *scanners.ScannerConfig
 	servicebusClient *armservicebus.NamespacesClient
+	drClient         *armservicebus.DisasterRecoveryConfigsClient
+	queuesClient     *armservicebus.QueuesClient
+	topicsClient     *armservicebus.TopicsClient
+	subsClient       *armservicebus.SubscriptionsClient
 }
 
 // Init - Initializes the ServiceBusScanner
 func (a *ServiceBusScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.servicebusClient, err = armservicebus.NewNamespacesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.drClient, err = armservicebus.NewDisasterRecoveryConfigsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.queuesClient, err = armservicebus.NewQueuesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.topicsClient, err = armservicebus.NewTopicsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.subsClient, err = armservicebus.NewSubscriptionsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
 	return err
 }
 
@@ -34,17 +59,52 @@ func (c *ServiceBusScanner) Scan(resourceGroupName string, scanContext *scanners
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.ServiceBusGDRHealth = map[string]string{}
+	if scanContext.ServiceBusQueueProperties == nil {
+		scanContext.ServiceBusQueueProperties = map[string][]scanners.ServiceBusEntity{}
+	}
+	if scanContext.ServiceBusTopicProperties == nil {
+		scanContext.ServiceBusTopicProperties = map[string][]scanners.ServiceBusEntity{}
+	}
+
 	for _, servicebus := range servicebus {
-		rr := engine.EvaluateRules(rules, servicebus, scanContext)
+		if servicebus.SKU != nil && servicebus.SKU.Name != nil && *servicebus.SKU.Name == armservicebus.SKUNamePremium {
+			health, err := c.getGDRHealth(resourceGroupName, *servicebus.Name)
+			if err != nil {
+				return nil, err
+			}
+			if health != "" {
+				scanContext.ServiceBusGDRHealth[strings.ToLower(*servicebus.ID)] = health
+			}
+		}
+
+		queues, err := c.listQueues(resourceGroupName, *servicebus.Name)
+		if err != nil {
+			return nil, err
+		}
+		scanContext.ServiceBusQueueProperties[strings.ToLower(*servicebus.ID)] = queues
+
+		// Topics are not available in the Basic tier
+		if servicebus.SKU == nil || servicebus.SKU.Name == nil || *servicebus.SKU.Name != armservicebus.SKUNameBasic {
+			topicSubscriptions, err := c.listTopicSubscriptions(resourceGroupName, *servicebus.Name)
+			if err != nil {
+				return nil, err
+			}
+			scanContext.ServiceBusTopicProperties[strings.ToLower(*servicebus.ID)] = topicSubscriptions
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, servicebus, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *servicebus.Name,
+			ID:               *servicebus.ID,
 			Type:             *servicebus.Type,
 			Location:         *servicebus.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(servicebus),
 		})
 	}
 	return results, nil
@@ -63,3 +123,86 @@ func (c *ServiceBusScanner) listServiceBus(resourceGroupName string) ([]*armserv
 	}
 	return namespaces, nil
 }
+
+func (c *ServiceBusScanner) listQueues(resourceGroupName, namespaceName string) ([]scanners.ServiceBusEntity, error) {
+	pager := c.queuesClient.NewListByNamespacePager(resourceGroupName, namespaceName, nil)
+
+	queues := []scanners.ServiceBusEntity{}
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range resp.Value {
+			if q.Name == nil || q.Properties == nil {
+				continue
+			}
+			queues = append(queues, scanners.ServiceBusEntity{
+				Name:                             *q.Name,
+				MaxDeliveryCount:                 q.Properties.MaxDeliveryCount,
+				DeadLetteringOnMessageExpiration: q.Properties.DeadLetteringOnMessageExpiration,
+				LockDuration:                     q.Properties.LockDuration,
+			})
+		}
+	}
+	return queues, nil
+}
+
+// listTopicSubscriptions - Lists the subscriptions of every topic of the namespace, named <topic>/<subscription>,
+// since the delivery settings of topics are set on their subscriptions
+func (c *ServiceBusScanner) listTopicSubscriptions(resourceGroupName, namespaceName string) ([]scanners.ServiceBusEntity, error) {
+	pager := c.topicsClient.NewListByNamespacePager(resourceGroupName, namespaceName, nil)
+
+	subscriptions := []scanners.ServiceBusEntity{}
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range resp.Value {
+			if t.Name == nil {
+				continue
+			}
+			subsPager := c.subsClient.NewListByTopicPager(resourceGroupName, namespaceName, *t.Name, nil)
+			for subsPager.More() {
+				subsResp, err := subsPager.NextPage(c.config.Ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, s := range subsResp.Value {
+					if s.Name == nil || s.Properties == nil {
+						continue
+					}
+					subscriptions = append(subscriptions, scanners.ServiceBusEntity{
+						Name:                             *t.Name + "/" + *s.Name,
+						MaxDeliveryCount:                 s.Properties.MaxDeliveryCount,
+						DeadLetteringOnMessageExpiration: s.Properties.DeadLetteringOnMessageExpiration,
+						LockDuration:                     s.Properties.LockDuration,
+					})
+				}
+			}
+		}
+	}
+	return subscriptions, nil
+}
+
+func (c *ServiceBusScanner) getGDRHealth(resourceGroupName, namespaceName string) (string, error) {
+	pager := c.drClient.NewListPager(resourceGroupName, namespaceName, nil)
+
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, alias := range resp.Value {
+			if alias.Properties == nil || alias.Properties.PartnerNamespace == nil || *alias.Properties.PartnerNamespace == "" {
+				continue
+			}
+			if alias.Properties.ProvisioningState == nil {
+				return "Unknown", nil
+			}
+			return string(*alias.Properties.ProvisioningState), nil
+		}
+	}
+	return "", nil
+}
@@ -6,13 +6,20 @@ package scanners
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/apimanagement/armapimanagement"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/sql/armsql"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/synapse/armsynapse"
 	"github.com/rs/zerolog/log"
 )
 
@@ -22,7 +29,20 @@ type (
 	}
 
 	AzqrFilter struct {
-		Exclude *Exclude `yaml:"exclude"`
+		Exclude     *Exclude               `yaml:"exclude"`
+		Locks       *Locks                 `yaml:"locks"`
+		Remediation *Remediation           `yaml:"remediation"`
+		CustomRules []CustomRuleDefinition `yaml:"customRules"`
+		// RuleURLOverrides - Replaces the Learn URL of the rules by id, e.g. with internal documentation
+		RuleURLOverrides map[string]string `yaml:"ruleUrlOverrides"`
+		// RequiredTags - Tags the "should have tags" rules require, instead of at least one tag
+		RequiredTags []RequiredTag `yaml:"requiredTags"`
+	}
+
+	// Remediation - Struct for the settings of the remediate command
+	Remediation struct {
+		Tags        map[string]string `yaml:"tags"`
+		WorkspaceID string            `yaml:"workspaceId"`
 	}
 
 	// Exclude - Struct for Exclude
@@ -37,6 +57,13 @@ type (
 		recommendations map[string]bool
 	}
 
+	// Locks - Struct for the resources required to have a lock
+	Locks struct {
+		ResourceTypes []string          `yaml:"resourceTypes,flow"`
+		Tags          map[string]string `yaml:"tags"`
+		resourceTypes map[string]bool
+	}
+
 	// ScannerConfig - Struct for Scanner Config
 	ScannerConfig struct {
 		Ctx              context.Context
@@ -46,14 +73,137 @@ type (
 		SubscriptionName string
 	}
 
-	// ScanContext - Struct for Scanner Context
+	// ScanContext - Struct for Scanner Context. Rules only read the context. Fields set before the
+	// scan (e.g. PrivateEndpoints, DiagnosticsSettings) are read-only. The other fields are owned by
+	// the scanner filling them, which only writes them from its Scan, between its own evaluations.
+	// Resource groups are scanned one at a time and a scanner never scans two concurrently, so its
+	// rules never read its fields while they are written, even with ParallelRules. Scanners writing
+	// fields other scanners read (e.g. rg, kv) must hold the write lock, and readers the read lock.
 	ScanContext struct {
-		Exclusions            *Exclude
-		PrivateEndpoints      map[string]bool
-		DiagnosticsSettings   map[string]bool
-		PublicIPs             map[string]*armnetwork.PublicIPAddress
-		SiteConfig            *armappservice.WebAppsClientGetConfigurationResponse
-		BlobServiceProperties *armstorage.BlobServicesClientGetServicePropertiesResponse
+		sync.RWMutex
+		ParallelRules                           bool
+		TagFilter                               []string
+		Exclusions                              *Exclude
+		PrivateEndpoints                        map[string]bool
+		DiagnosticsSettings                     map[string]bool
+		PublicIPs                               map[string]*armnetwork.PublicIPAddress
+		SiteConfig                              *armappservice.WebAppsClientGetConfigurationResponse
+		AppServicePlans                         map[string]*armappservice.Plan
+		AppServiceSlots                         map[string]int
+		AppServiceCertificates                  map[string]time.Time
+		BlobServiceProperties                   *armstorage.BlobServicesClientGetServicePropertiesResponse
+		ContainerRegistryScopeMaps              map[string]int
+		FirewallPolicyIDPS                      map[string]string
+		ExpressRouteCircuitLocations            map[string]int
+		DataFactoryLinkedServiceCounts          map[string]int
+		DataFactoryManagedPrivateEndpointCounts map[string]int
+		ServiceBusGDRHealth                     map[string]string
+		LogicAppConnections                     map[string]string
+		RedisEnterpriseLinkedDatabases          map[string]int
+		SubnetRouteInfo                         map[string]SubnetRouteInfo
+		ResourceLocks                           map[string]bool
+		Locks                                   *Locks
+		DPSAccessPolicyRights                   map[string]map[string]string
+		SQLGeoReplicationLinks                  map[string]int
+		SQLLongTermRetentionPolicies            map[string]*armsql.LongTermRetentionPolicy
+		SQLVulnerabilityAssessments             map[string]*armsql.ServerVulnerabilityAssessment
+		CustomRules                             *CELRuleEngine
+		DefenderRecommendations                 map[string][]string
+		RuleURLOverrides                        map[string]string
+		KeyVaultCertificateExpiries             map[string][]CertExpiry
+		AKSStableVersions                       map[string]string
+		DiagnosticsSettingsCategories           map[string][]string
+		ResourceGroupEnvironmentTags            map[string][]string
+		SubscriptionLocations                   []string
+		QuotaThresholdHigh                      float64
+		QuotaThresholdMedium                    float64
+		PolicyExemptions                        map[string][]string
+		DatabricksClusters                      map[string][]DatabricksCluster
+		TrafficManagerNestingDepth              map[string]int
+		ServiceBusQueueProperties               map[string][]ServiceBusEntity
+		ServiceBusTopicProperties               map[string][]ServiceBusEntity
+		EventHubProperties                      map[string][]EventHubEntity
+		RequiredTags                            []RequiredTag
+		ContainerRegistryVulnAssessments        map[string]bool
+		DataExplorerFollowerDatabases           map[string][]DataExplorerFollowerDatabase
+		FirewallPolicyRuleGroups                map[string][]FirewallRuleCollectionGroup
+		ApplicationGatewayWAFPolicies           map[string]WAFConfiguration
+		VPNGatewayConnections                   map[string][]VPNGatewayConnection
+		KeyVaultKeyRotationPolicies             map[string]*armkeyvault.RotationPolicy
+		SynapseSQLPoolEncryptions               map[string]*armsynapse.TransparentDataEncryption
+		SynapseSQLPoolAuditingPolicies          map[string]*armsynapse.SQLPoolBlobAuditingPolicy
+		StorageLifecyclePolicies                map[string]*armstorage.ManagementPolicy
+		PostgreSQLConfigurations                map[string]map[string]string
+		SentinelWorkspaces                      map[string]bool
+		AutomationRunAsConnections              map[string]int
+		EventHubCaptureStorageAccounts          map[string]bool
+		EventHubSchemaGroups                    map[string]int
+		APIMAPIs                                map[string][]*armapimanagement.APIContract
+		OpenAIContentFilters                    map[string]map[string]bool
+	}
+
+	// SubnetRouteInfo - Egress configuration of a subnet
+	SubnetRouteInfo struct {
+		RouteTable bool
+		NatGateway bool
+	}
+
+	// CertExpiry - Expiration date of a Key Vault certificate
+	CertExpiry struct {
+		Name      string
+		ExpiresAt time.Time
+	}
+
+	// DatabricksCluster - Databricks Runtime of a cluster of an Azure Databricks workspace
+	DatabricksCluster struct {
+		Name         string
+		SparkVersion string
+	}
+
+	// ServiceBusEntity - Delivery settings of a Service Bus queue or topic subscription
+	ServiceBusEntity struct {
+		Name                             string
+		MaxDeliveryCount                 *int32
+		DeadLetteringOnMessageExpiration *bool
+		LockDuration                     *string
+	}
+
+	// EventHubEntity - Retention, partitions, consumer groups and capture settings of an Event Hub
+	EventHubEntity struct {
+		Name                    string
+		MessageRetentionInDays  *int64
+		PartitionCount          *int64
+		ConsumerGroupCount      int
+		CaptureEnabled          bool
+		CaptureStorageAccountID string
+		CaptureDestination      string
+	}
+
+	// FirewallRuleCollectionGroup - Priority and DNAT rule collections of a Firewall Policy rule collection group
+	FirewallRuleCollectionGroup struct {
+		Name     string
+		Priority *int32
+		HasDNAT  bool
+	}
+
+	// VPNGatewayConnection - Site-to-site connection of a VPN Gateway
+	VPNGatewayConnection struct {
+		Name          string
+		IPsecPolicies int
+	}
+
+	// WAFConfiguration - Mode and managed rule set of a Web Application Firewall
+	WAFConfiguration struct {
+		Mode           string
+		RuleSetType    string
+		RuleSetVersion string
+	}
+
+	// DataExplorerFollowerDatabase - Hot cache periods of a follower database and of the leader database it follows
+	DataExplorerFollowerDatabase struct {
+		Name                 string
+		HotCachePeriod       *string
+		LeaderHotCachePeriod *string
 	}
 
 	// IAzureScanner - Interface for all Azure Scanners
@@ -63,6 +213,14 @@ type (
 		Scan(resourceGroupName string, scanContext *ScanContext) ([]AzureServiceResult, error)
 	}
 
+	// ISubscriptionScanner - Interface for scanners of resources at subscription scope (e.g. Resource Groups),
+	// called once per subscription instead of once per resource group
+	ISubscriptionScanner interface {
+		Init(config *ScannerConfig) error
+		GetRules() map[string]AzureRule
+		ScanSubscriptionScope(scanContext *ScanContext) ([]AzureServiceResult, error)
+	}
+
 	// AzureServiceResult - Struct for all Azure Service Results
 	AzureServiceResult struct {
 		SubscriptionID   string
@@ -71,7 +229,10 @@ type (
 		Location         string
 		Type             string
 		ServiceName      string
-		Rules            map[string]AzureRuleResult
+		// ID - Resource ID of the service, ResourceID rebuilds it from the other fields when not set
+		ID        string
+		Rules     map[string]AzureRuleResult
+		CreatedAt *time.Time `json:"createdAt,omitempty"`
 	}
 
 	AzureRule struct {
@@ -80,9 +241,34 @@ type (
 		Recommendation string
 		Impact         ImpactType
 		Url            string
-		Eval           func(target interface{}, scanContext *ScanContext) (bool, string)
+		Tags           []string
+		Policy         PolicyCondition
+		// DefenderAssessmentID - Key of the Defender for Cloud assessment reporting the same finding
+		DefenderAssessmentID string
+		// PolicyDefinitionIDs - Built-in Azure Policy definitions checking the same configuration,
+		// findings are reported as exempted when the resource has an exemption for one of them
+		PolicyDefinitionIDs []string
+		// Remediation - Azure CLI command fixing the finding, with {resource_id} and {resource_group} placeholders
+		Remediation string
+		// AutoRemediation - Fix the remediate command applies to the findings of the rule, if any
+		AutoRemediation AutoRemediationType
+		// DependsOn - IDs of the rules that must be compliant for this rule to be evaluated
+		DependsOn []string
+		// RequiresDeployment - True for rules checking data only known once the resource is deployed, like
+		// the diagnostic settings looked up by resource ID. Not evaluated against infrastructure as code.
+		RequiresDeployment bool
+		// ResultImpact - Optional, returns the impact of a non compliant result when it depends on the
+		// result (e.g. days left before a certificate expires). Impact is used when not set.
+		ResultImpact func(result string) ImpactType
+		// Eval - Evaluates the rule. Evals doing long-running work (e.g. calling Azure APIs or
+		// looping over large results) must stop and return when ctx is done.
+		Eval func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error)
 	}
 
+	// EvalFunc - Deprecated: rule evaluation signature used before Eval received a context and could
+	// return an error. Use EvalFunc.Eval to adapt existing rules.
+	EvalFunc func(target interface{}, scanContext *ScanContext) (bool, string)
+
 	AzureRuleResult struct {
 		Id             string
 		Category       RulesCategory
@@ -91,15 +277,52 @@ type (
 		Learn          string
 		Result         string
 		NotCompliant   bool
+		// Error - Set when the rule could not be evaluated (e.g. missing data). The rule is then neither
+		// compliant nor not compliant, and reports leave it out of the counts and the compliance score.
+		Error error
+		// AlreadyTrackedByDefender - True when Defender for Cloud already reports the finding as unhealthy
+		AlreadyTrackedByDefender bool
+		// NotApplicablePreDeploy - True when the rule needs data only available after deployment (e.g. diagnostic settings)
+		NotApplicablePreDeploy bool
+		Remediation            string
+		// PolicyExempted - True when the rule fails but the resource is exempted from the matching Azure Policy
+		PolicyExempted bool
+		// TimedOut - True when the evaluation did not complete within the rule timeout. The rule is reported as not compliant.
+		TimedOut bool
 	}
 
-	RuleEngine struct{}
+	// RuleEngine - Evaluates rules. Evaluations taking longer than RuleTimeout (DefaultRuleTimeout when
+	// not set) have their context cancelled and are reported as timed out.
+	RuleEngine struct {
+		RuleTimeout time.Duration
+	}
 )
 
+// DefaultRuleTimeout - Maximum duration of a rule evaluation when RuleEngine.RuleTimeout is not set
+const DefaultRuleTimeout = 30 * time.Second
+
+// RuleTimedOutResult - Result of the rules whose evaluation timed out
+const RuleTimedOutResult = "Rule evaluation timed out"
+
+// ResourceID - Returns the lower case resource ID of the service
 func (r *AzureServiceResult) ResourceID() string {
+	if r.ID != "" {
+		return strings.ToLower(r.ID)
+	}
 	return strings.ToLower(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s/%s", r.SubscriptionID, r.ResourceGroup, r.Type, r.ServiceName))
 }
 
+// RemediationCommand - Returns the remediation of the rule result with the placeholders replaced by the resource values
+func (r *AzureServiceResult) RemediationCommand(rr AzureRuleResult) string {
+	if rr.Remediation == "" {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{resource_id}", r.ResourceID(),
+		"{resource_group}", r.ResourceGroup,
+	).Replace(rr.Remediation)
+}
+
 func (e *Exclude) IsSubscriptionExcluded(subscriptionID string) bool {
 	if e.subscriptions == nil {
 		e.subscriptions = make(map[string]bool)
@@ -152,33 +375,253 @@ func (e *Exclude) IsRecommendationExcluded(recommendationID string) bool {
 	return ok
 }
 
-func (e *RuleEngine) EvaluateRule(rule AzureRule, target interface{}, scanContext *ScanContext) AzureRuleResult {
-	broken, result := rule.Eval(target, scanContext)
+// HasAnyTag - Returns true if the rule has at least one of the tags (case insensitive), or if no tags are given
+func (r *AzureRule) HasAnyTag(tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, t := range tags {
+		for _, rt := range r.Tags {
+			if strings.EqualFold(t, rt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Eval - Adapts a rule written with the deprecated signature to AzureRule.Eval
+func (f EvalFunc) Eval(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+	broken, result := f(target, scanContext)
+	return broken, result, nil
+}
+
+func (e *RuleEngine) EvaluateRule(ctx context.Context, rule AzureRule, target interface{}, scanContext *ScanContext) AzureRuleResult {
+	broken, result, timedOut, err := e.eval(ctx, rule, target, scanContext)
+	if timedOut {
+		log.Warn().Msgf("Rule %s evaluation timed out", rule.Id)
+		return AzureRuleResult{
+			Id:             rule.Id,
+			Category:       rule.Category,
+			Recommendation: rule.Recommendation,
+			Impact:         rule.Impact,
+			Learn:          rule.Url,
+			Result:         RuleTimedOutResult,
+			NotCompliant:   true,
+			Remediation:    rule.Remediation,
+			TimedOut:       true,
+		}
+	}
+
+	// A rule failing with an error was not evaluated: it is neither compliant nor broken
+	if err != nil {
+		broken = false
+	}
+
+	exempted := broken && isPolicyExempted(rule, target, scanContext)
+	if exempted {
+		broken = false
+	}
+
+	impact := rule.Impact
+	if broken && rule.ResultImpact != nil {
+		impact = rule.ResultImpact(result)
+	}
 
 	return AzureRuleResult{
-		Id:             rule.Id,
-		Category:       rule.Category,
-		Recommendation: rule.Recommendation,
-		Impact:         rule.Impact,
-		Learn:          rule.Url,
-		Result:         result,
-		NotCompliant:   broken,
+		Id:                       rule.Id,
+		Category:                 rule.Category,
+		Recommendation:           rule.Recommendation,
+		Impact:                   impact,
+		Learn:                    rule.Url,
+		Result:                   result,
+		NotCompliant:             broken,
+		Error:                    err,
+		AlreadyTrackedByDefender: broken && isTrackedByDefender(rule, target, scanContext),
+		Remediation:              rule.Remediation,
+		PolicyExempted:           exempted,
 	}
 }
 
-func (e *RuleEngine) EvaluateRules(rules map[string]AzureRule, target interface{}, scanContext *ScanContext) map[string]AzureRuleResult {
-	results := map[string]AzureRuleResult{}
+// eval - Runs the rule Eval with the rule timeout. When the timeout expires or ctx is cancelled, eval
+// returns right away and cancels the Eval context. An Eval still running exits when it honours its
+// context, and its results are discarded.
+func (e *RuleEngine) eval(ctx context.Context, rule AzureRule, target interface{}, scanContext *ScanContext) (bool, string, bool, error) {
+	timeout := e.RuleTimeout
+	if timeout <= 0 {
+		timeout = DefaultRuleTimeout
+	}
+	evalCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
+	type evalResult struct {
+		broken bool
+		result string
+		err    error
+	}
+	done := make(chan evalResult, 1)
+	go func() {
+		broken, result, err := rule.Eval(evalCtx, target, scanContext)
+		done <- evalResult{broken, result, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.broken, r.result, false, r.err
+	case <-evalCtx.Done():
+		if ctx.Err() != nil {
+			return false, "", false, ctx.Err()
+		}
+		return false, "", true, nil
+	}
+}
+
+// EvaluateRules - Evaluates the rules against the target. Rules failing with an error are
+// kept in the results with the Error field set, and evaluation stops if ctx is cancelled.
+// When scanContext.ParallelRules is set, the rules are evaluated concurrently. Rules listed in
+// DependsOn are evaluated first and the dependent rule is skipped if one of them fails. The Learn URL
+// of the results is replaced by scanContext.RuleURLOverrides when set for the rule.
+func (e *RuleEngine) EvaluateRules(ctx context.Context, rules map[string]AzureRule, target interface{}, scanContext *ScanContext) map[string]AzureRuleResult {
+	selected := map[string]AzureRule{}
 	for k, rule := range rules {
-		if scanContext.Exclusions.IsRecommendationExcluded(rule.Id) {
+		if scanContext.Exclusions.IsRecommendationExcluded(rule.Id) || !rule.HasAnyTag(scanContext.TagFilter) {
 			continue
 		}
-		results[k] = e.EvaluateRule(rule, target, scanContext)
+		selected[k] = rule
 	}
+	for k, rule := range GetSharedRules() {
+		if scanContext.Exclusions.IsRecommendationExcluded(rule.Id) || !rule.HasAnyTag(scanContext.TagFilter) || !isSharedRuleApplicable(rule.Id, target, scanContext) {
+			continue
+		}
+		selected[k] = rule
+	}
+	for k, rule := range scanContext.CustomRules.Rules(getResourceString(target, "Type")) {
+		if scanContext.Exclusions.IsRecommendationExcluded(rule.Id) || !rule.HasAnyTag(scanContext.TagFilter) {
+			continue
+		}
+		selected[k] = rule
+	}
+
+	// Rules are evaluated level by level, skipping the rules whose prerequisites are not compliant
+	levels, err := ruleLevels(selected)
+	ignoreDependencies := err != nil
+	if ignoreDependencies {
+		log.Warn().Err(err).Msg("Ignoring rule dependencies")
+		level := []string{}
+		for k := range selected {
+			level = append(level, k)
+		}
+		levels = [][]string{level}
+	}
+
+	results := map[string]AzureRuleResult{}
+	for _, level := range levels {
+		if ctx.Err() != nil {
+			break
+		}
+		batch := map[string]AzureRule{}
+		for _, k := range level {
+			if !ignoreDependencies && dependencyBroken(selected[k], selected, results) {
+				continue
+			}
+			batch[k] = selected[k]
+		}
+
+		if scanContext.ParallelRules {
+			for k, r := range e.evaluateParallel(ctx, batch, target, scanContext) {
+				results[k] = r
+			}
+			continue
+		}
+		for k, rule := range batch {
+			if ctx.Err() != nil {
+				break
+			}
+			results[k] = e.EvaluateRule(ctx, rule, target, scanContext)
+		}
+	}
+
+	for k, r := range results {
+		if r.Error != nil {
+			log.Warn().Err(r.Error).Msgf("Failed to evaluate rule %s", r.Id)
+		}
+		if url, ok := scanContext.RuleURLOverrides[r.Id]; ok {
+			r.Learn = url
+			results[k] = r
+		}
+	}
+
+	return results
+}
+
+func (e *RuleEngine) evaluateParallel(ctx context.Context, rules map[string]AzureRule, target interface{}, scanContext *ScanContext) map[string]AzureRuleResult {
+	results := map[string]AzureRuleResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	scanContext.RLock()
+	defer scanContext.RUnlock()
+
+	for k, rule := range rules {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(k string, rule AzureRule) {
+			defer wg.Done()
+			r := e.EvaluateRule(ctx, rule, target, scanContext)
+			mu.Lock()
+			results[k] = r
+			mu.Unlock()
+		}(k, rule)
+	}
+	wg.Wait()
 
 	return results
 }
 
+// GetCreatedAt - Returns the creation time found in the SystemData of an ARM resource, or nil if not available
+func GetCreatedAt(resource interface{}) *time.Time {
+	systemData := getResourceField(resource, "SystemData")
+	if systemData == nil {
+		return nil
+	}
+	t, _ := getResourceField(systemData, "CreatedAt").(*time.Time)
+	return t
+}
+
+// getResourceField - Returns the value of a field of an ARM resource struct, or nil if the field does not exist.
+// Each SDK package declares its own resource types, so fields shared by all of them are read through reflection.
+func getResourceField(resource interface{}, name string) interface{} {
+	v := reflect.ValueOf(resource)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	f := v.FieldByName(name)
+	if !f.IsValid() || !f.CanInterface() {
+		return nil
+	}
+	if (f.Kind() == reflect.Ptr || f.Kind() == reflect.Map) && f.IsNil() {
+		return nil
+	}
+	return f.Interface()
+}
+
+// getResourceString - Returns the value of a *string field of an ARM resource struct, or an empty string
+func getResourceString(resource interface{}, name string) string {
+	if s, ok := getResourceField(resource, name).(*string); ok {
+		return *s
+	}
+	return ""
+}
+
 func ParseLocation(location string) string {
 	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
 }
@@ -202,11 +645,13 @@ func LogSubscriptionScan(subscriptionID string, serviceName string) {
 
 type ImpactType string
 type RulesCategory string
+type AutoRemediationType string
 
 const (
-	ImpactHigh   ImpactType = "High"
-	ImpactMedium ImpactType = "Medium"
-	ImpactLow    ImpactType = "Low"
+	ImpactCritical ImpactType = "Critical"
+	ImpactHigh     ImpactType = "High"
+	ImpactMedium   ImpactType = "Medium"
+	ImpactLow      ImpactType = "Low"
 
 	RulesCategoryHighAvailability      RulesCategory = "High Availability"
 	RulesCategoryMonitoringAndAlerting RulesCategory = "Monitoring and Alerting"
@@ -215,4 +660,33 @@ const (
 	RulesCategorySecurity              RulesCategory = "Security"
 	RulesCategoryGovernance            RulesCategory = "Governance"
 	RulesCategoryOtherBestPractices    RulesCategory = "Other Best Practices"
+
+	AutoRemediationTags               AutoRemediationType = "Tags"
+	AutoRemediationDiagnosticSettings AutoRemediationType = "Diagnostic Settings"
 )
+
+// Impacts - Impact levels, from the highest to the lowest
+var Impacts = []ImpactType{ImpactCritical, ImpactHigh, ImpactMedium, ImpactLow}
+
+// ParseImpact - Returns the impact level with the given name, case insensitive
+func ParseImpact(name string) (ImpactType, error) {
+	for _, i := range Impacts {
+		if strings.EqualFold(string(i), name) {
+			return i, nil
+		}
+	}
+	return "", fmt.Errorf("invalid impact %s, must be one of Critical, High, Medium or Low", name)
+}
+
+// AtLeast - Returns true if the impact is the threshold or a higher level
+func (i ImpactType) AtLeast(threshold ImpactType) bool {
+	for _, impact := range Impacts {
+		if impact == i {
+			return true
+		}
+		if impact == threshold {
+			return false
+		}
+	}
+	return false
+}
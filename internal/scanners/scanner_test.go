@@ -0,0 +1,456 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azqr/internal/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+)
+
+func testRules(count int) map[string]AzureRule {
+	rules := map[string]AzureRule{}
+	for i := 0; i < count; i++ {
+		id := fmt.Sprintf("test-%03d", i)
+		rules[id] = AzureRule{
+			Id:     id,
+			Impact: ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				service := target.(*armappservice.Plan)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
+				return !ok, *service.Name, nil
+			},
+		}
+	}
+	return rules
+}
+
+func testPlans(count int) []*armappservice.Plan {
+	plans := []*armappservice.Plan{}
+	for i := 0; i < count; i++ {
+		plans = append(plans, &armappservice.Plan{
+			ID:   to.Ptr(fmt.Sprintf("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan%d", i)),
+			Name: to.Ptr(fmt.Sprintf("plan%d", i)),
+		})
+	}
+	return plans
+}
+
+func TestRuleEngine_EvaluateRules(t *testing.T) {
+	rules := testRules(10)
+	plans := testPlans(20)
+	diagnostics := map[string]bool{}
+	for i, p := range plans {
+		if i%2 == 0 {
+			diagnostics[strings.ToLower(*p.ID)] = true
+		}
+	}
+
+	engine := RuleEngine{}
+	sequential := &ScanContext{Exclusions: &Exclude{Recommendations: []string{"test-001"}}, DiagnosticsSettings: diagnostics}
+	parallel := &ScanContext{Exclusions: &Exclude{Recommendations: []string{"test-001"}}, DiagnosticsSettings: diagnostics, ParallelRules: true}
+
+	for _, p := range plans {
+		want := engine.EvaluateRules(context.Background(), rules, p, sequential)
+		got := engine.EvaluateRules(context.Background(), rules, p, parallel)
+		if len(got) != 9 {
+			t.Errorf("RuleEngine.EvaluateRules() returned %d results, want 9", len(got))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("RuleEngine.EvaluateRules() parallel = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRuleEngine_EvaluateRule_Timeout(t *testing.T) {
+	block := make(chan struct{})
+
+	rules := map[string]AzureRule{
+		"test-001": {
+			Id:     "test-001",
+			Impact: ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				// Ignores ctx and never returns
+				<-block
+				return false, "", nil
+			},
+		},
+		"test-002": {
+			Id:     "test-002",
+			Impact: ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				return false, "ok", nil
+			},
+		},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		engine := RuleEngine{RuleTimeout: 10 * time.Millisecond}
+		got := engine.EvaluateRules(context.Background(), rules, testPlans(1)[0], &ScanContext{Exclusions: &Exclude{}, ParallelRules: parallel})
+
+		blocked := got["test-001"]
+		if !blocked.TimedOut || !blocked.NotCompliant || blocked.Result != RuleTimedOutResult {
+			t.Errorf("RuleEngine.EvaluateRules() parallel=%v blocked rule = %+v, want a timed out result", parallel, blocked)
+		}
+		if r := got["test-002"]; r.TimedOut || r.NotCompliant || r.Result != "ok" {
+			t.Errorf("RuleEngine.EvaluateRules() parallel=%v = %+v, want a compliant result", parallel, r)
+		}
+	}
+}
+
+func TestRuleEngine_EvaluateRule_ResultImpact(t *testing.T) {
+	rule := AzureRule{
+		Id:     "test-001",
+		Impact: ImpactHigh,
+		ResultImpact: func(result string) ImpactType {
+			if result == "minor" {
+				return ImpactMedium
+			}
+			return ImpactHigh
+		},
+	}
+	tests := []struct {
+		broken bool
+		result string
+		want   ImpactType
+	}{
+		{broken: true, result: "minor", want: ImpactMedium},
+		{broken: true, result: "major", want: ImpactHigh},
+		{broken: false, result: "minor", want: ImpactHigh},
+	}
+	for _, tt := range tests {
+		rule.Eval = func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+			return tt.broken, tt.result, nil
+		}
+		engine := RuleEngine{}
+		got := engine.EvaluateRule(context.Background(), rule, testPlans(1)[0], &ScanContext{Exclusions: &Exclude{}})
+		if got.Impact != tt.want {
+			t.Errorf("RuleEngine.EvaluateRule() broken=%v result=%s impact = %v, want %v", tt.broken, tt.result, got.Impact, tt.want)
+		}
+	}
+}
+
+func TestRuleEngine_EvaluateRule_Error(t *testing.T) {
+	rule := AzureRule{
+		Id: "test-001",
+		Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+			return true, "", fmt.Errorf("data not available")
+		},
+	}
+
+	engine := RuleEngine{}
+	got := engine.EvaluateRule(context.Background(), rule, testPlans(1)[0], &ScanContext{Exclusions: &Exclude{}})
+	if got.Error == nil || got.NotCompliant {
+		t.Errorf("RuleEngine.EvaluateRule() = %+v, want the error and a rule neither compliant nor broken", got)
+	}
+}
+
+func TestRuleEngine_EvaluateRule_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rule := AzureRule{
+		Id: "test-001",
+		Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+			cancel()
+			<-ctx.Done()
+			return false, "", nil
+		},
+	}
+
+	engine := RuleEngine{}
+	got := engine.EvaluateRule(ctx, rule, testPlans(1)[0], &ScanContext{})
+	if got.TimedOut || got.Error == nil {
+		t.Errorf("RuleEngine.EvaluateRule() = %+v, want the context error and no timeout", got)
+	}
+}
+
+func BenchmarkRuleEngine_EvaluateRules(b *testing.B) {
+	rules := testRules(10)
+	plans := testPlans(100)
+	engine := RuleEngine{}
+
+	for _, parallel := range []bool{false, true} {
+		scanContext := &ScanContext{Exclusions: &Exclude{}, DiagnosticsSettings: map[string]bool{}, ParallelRules: parallel}
+		b.Run(fmt.Sprintf("parallel=%t", parallel), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, p := range plans {
+					engine.EvaluateRules(context.Background(), rules, p, scanContext)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleEngine_EvaluateRules_Tags(t *testing.T) {
+	rules := map[string]AzureRule{
+		"test-001": {
+			Id:   "test-001",
+			Tags: []string{"CIS"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				return false, "", nil
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		tags []string
+		want bool
+	}{
+		{
+			name: "no tag filter",
+			tags: nil,
+			want: true,
+		},
+		{
+			name: "matching tag",
+			tags: []string{"cis"},
+			want: true,
+		},
+		{
+			name: "matching one of the tags",
+			tags: []string{"NIST", "CIS"},
+			want: true,
+		},
+		{
+			name: "no matching tag",
+			tags: []string{"NIST"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := RuleEngine{}
+			scanContext := &ScanContext{Exclusions: &Exclude{}, TagFilter: tt.tags}
+			results := engine.EvaluateRules(context.Background(), rules, &armappservice.Plan{}, scanContext)
+			if _, got := results["test-001"]; got != tt.want {
+				t.Errorf("RuleEngine.EvaluateRules() evaluated test-001 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleEngine_EvaluateRules_Defender(t *testing.T) {
+	plan := &armappservice.Plan{
+		ID: to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan"),
+	}
+	assessmentID := "35b25be2-d08a-e340-45ed-f08a95d804fc"
+	tests := []struct {
+		name            string
+		broken          bool
+		assessmentID    string
+		recommendations map[string][]string
+		want            bool
+	}{
+		{
+			name:            "unhealthy assessment",
+			broken:          true,
+			assessmentID:    assessmentID,
+			recommendations: map[string][]string{assessedResourceID(*plan.ID + "/providers/Microsoft.Security/assessments/" + assessmentID): {assessmentID}},
+			want:            true,
+		},
+		{
+			name:            "compliant rule",
+			broken:          false,
+			assessmentID:    assessmentID,
+			recommendations: map[string][]string{strings.ToLower(*plan.ID): {assessmentID}},
+			want:            false,
+		},
+		{
+			name:            "other assessment",
+			broken:          true,
+			assessmentID:    assessmentID,
+			recommendations: map[string][]string{strings.ToLower(*plan.ID): {"1c5de8e1-f68d-6a17-e0d2-ec259c42768c"}},
+			want:            false,
+		},
+		{
+			name:            "rule without assessment",
+			broken:          true,
+			recommendations: map[string][]string{strings.ToLower(*plan.ID): {assessmentID}},
+			want:            false,
+		},
+		{
+			name:         "integration disabled",
+			broken:       true,
+			assessmentID: assessmentID,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broken := tt.broken
+			rules := map[string]AzureRule{
+				"test-001": {
+					Id:                   "test-001",
+					DefenderAssessmentID: tt.assessmentID,
+					Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+						return broken, "", nil
+					},
+				},
+			}
+			engine := RuleEngine{}
+			scanContext := &ScanContext{Exclusions: &Exclude{}, DefenderRecommendations: tt.recommendations}
+			results := engine.EvaluateRules(context.Background(), rules, plan, scanContext)
+			if got := results["test-001"].AlreadyTrackedByDefender; got != tt.want {
+				t.Errorf("RuleEngine.EvaluateRules() AlreadyTrackedByDefender = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAzureServiceResult_RemediationCommand(t *testing.T) {
+	rules := map[string]AzureRule{
+		"test-001": {
+			Id:          "test-001",
+			Impact:      ImpactLow,
+			Remediation: "az tag update --resource-id {resource_id} --operation Merge --tags env=prod # {resource_group}",
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				return true, "", nil
+			},
+		},
+		"test-002": {
+			Id:     "test-002",
+			Impact: ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+				return true, "", nil
+			},
+		},
+	}
+
+	engine := RuleEngine{}
+	results := engine.EvaluateRules(context.Background(), rules, testPlans(1)[0], &ScanContext{Exclusions: &Exclude{}})
+	service := AzureServiceResult{
+		SubscriptionID: "sub",
+		ResourceGroup:  "rg",
+		Type:           "Microsoft.Web/serverfarms",
+		ServiceName:    "plan0",
+		Rules:          results,
+	}
+
+	want := "az tag update --resource-id /subscriptions/sub/resourcegroups/rg/providers/microsoft.web/serverfarms/plan0 --operation Merge --tags env=prod # rg"
+	if got := service.RemediationCommand(results["test-001"]); got != want {
+		t.Errorf("AzureServiceResult.RemediationCommand() = %v, want %v", got, want)
+	}
+	if got := service.RemediationCommand(results["test-002"]); got != "" {
+		t.Errorf("AzureServiceResult.RemediationCommand() = %v, want empty", got)
+	}
+}
+
+func TestRuleEngine_EvaluateRules_PolicyExemptions(t *testing.T) {
+	plan := &armappservice.Plan{
+		ID: to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/serverfarms/plan"),
+	}
+	definitionID := "/providers/Microsoft.Authorization/policyDefinitions/404c3081-a854-4457-ae30-26a93ef643f9"
+	tests := []struct {
+		name          string
+		broken        bool
+		definitionIDs []string
+		exemptions    map[string][]string
+		wantExempted  bool
+		wantBroken    bool
+	}{
+		{
+			name:          "resource exemption",
+			broken:        true,
+			definitionIDs: []string{definitionID},
+			exemptions:    map[string][]string{strings.ToLower(*plan.ID): {strings.ToLower(definitionID)}},
+			wantExempted:  true,
+			wantBroken:    false,
+		},
+		{
+			name:          "resource group exemption",
+			broken:        true,
+			definitionIDs: []string{definitionID},
+			exemptions:    map[string][]string{"/subscriptions/sub/resourcegroups/rg": {strings.ToLower(definitionID)}},
+			wantExempted:  true,
+			wantBroken:    false,
+		},
+		{
+			name:          "exemption of another resource group",
+			broken:        true,
+			definitionIDs: []string{definitionID},
+			exemptions:    map[string][]string{"/subscriptions/sub/resourcegroups/rg2": {strings.ToLower(definitionID)}},
+			wantExempted:  false,
+			wantBroken:    true,
+		},
+		{
+			name:          "exemption of another policy",
+			broken:        true,
+			definitionIDs: []string{definitionID},
+			exemptions:    map[string][]string{strings.ToLower(*plan.ID): {"/providers/microsoft.authorization/policydefinitions/other"}},
+			wantExempted:  false,
+			wantBroken:    true,
+		},
+		{
+			name:         "rule without policy",
+			broken:       true,
+			exemptions:   map[string][]string{strings.ToLower(*plan.ID): {strings.ToLower(definitionID)}},
+			wantExempted: false,
+			wantBroken:   true,
+		},
+		{
+			name:          "compliant rule",
+			broken:        false,
+			definitionIDs: []string{definitionID},
+			exemptions:    map[string][]string{strings.ToLower(*plan.ID): {strings.ToLower(definitionID)}},
+			wantExempted:  false,
+			wantBroken:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broken := tt.broken
+			rules := map[string]AzureRule{
+				"test-001": {
+					Id:                  "test-001",
+					PolicyDefinitionIDs: tt.definitionIDs,
+					Eval: func(ctx context.Context, target interface{}, scanContext *ScanContext) (bool, string, error) {
+						return broken, "", nil
+					},
+				},
+			}
+			engine := RuleEngine{}
+			scanContext := &ScanContext{Exclusions: &Exclude{}, PolicyExemptions: tt.exemptions}
+			results := engine.EvaluateRules(context.Background(), rules, plan, scanContext)
+			if got := results["test-001"].PolicyExempted; got != tt.wantExempted {
+				t.Errorf("RuleEngine.EvaluateRules() PolicyExempted = %v, want %v", got, tt.wantExempted)
+			}
+			if got := results["test-001"].NotCompliant; got != tt.wantBroken {
+				t.Errorf("RuleEngine.EvaluateRules() NotCompliant = %v, want %v", got, tt.wantBroken)
+			}
+		})
+	}
+}
+
+func TestImpactType_AtLeast(t *testing.T) {
+	tests := []struct {
+		impact    ImpactType
+		threshold ImpactType
+		want      bool
+	}{
+		{ImpactCritical, ImpactMedium, true},
+		{ImpactCritical, ImpactCritical, true},
+		{ImpactHigh, ImpactCritical, false},
+		{ImpactMedium, ImpactMedium, true},
+		{ImpactLow, ImpactMedium, false},
+	}
+	for _, tt := range tests {
+		if got := tt.impact.AtLeast(tt.threshold); got != tt.want {
+			t.Errorf("ImpactType(%s).AtLeast(%s) = %v, want %v", tt.impact, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestParseImpact(t *testing.T) {
+	if got, err := ParseImpact("medium"); err != nil || got != ImpactMedium {
+		t.Errorf("ParseImpact(medium) = %s, %v", got, err)
+	}
+	if _, err := ParseImpact("severe"); err == nil {
+		t.Error("ParseImpact(severe) expected an error")
+	}
+}
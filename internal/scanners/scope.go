@@ -0,0 +1,31 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+)
+
+// ResolveScopeToScanTargets - Splits the scope of a scan, a subscription, resource group or resource id,
+// in the subscription, resource group and resource to scan. Only the parts given in the scope are set.
+func ResolveScopeToScanTargets(scope string) (subscriptionID, resourceGroup, resourceID string, err error) {
+	scope = strings.TrimSuffix(strings.TrimSpace(scope), "/")
+	id, err := arm.ParseResourceID(scope)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid scope %s: %w", scope, err)
+	}
+
+	switch {
+	case strings.EqualFold(id.ResourceType.String(), arm.SubscriptionResourceType.String()):
+		return id.SubscriptionID, "", "", nil
+	case strings.EqualFold(id.ResourceType.String(), arm.ResourceGroupResourceType.String()):
+		return id.SubscriptionID, id.ResourceGroupName, "", nil
+	case id.SubscriptionID != "" && id.ResourceGroupName != "":
+		return id.SubscriptionID, id.ResourceGroupName, scope, nil
+	}
+	return "", "", "", fmt.Errorf("invalid scope %s: expected a subscription, resource group or resource id", scope)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import "testing"
+
+func TestResolveScopeToScanTargets(t *testing.T) {
+	tests := []struct {
+		name               string
+		scope              string
+		wantSubscriptionID string
+		wantResourceGroup  string
+		wantResourceID     string
+		wantErr            bool
+	}{
+		{
+			name:               "subscription",
+			scope:              "/subscriptions/00000000-0000-0000-0000-000000000001",
+			wantSubscriptionID: "00000000-0000-0000-0000-000000000001",
+		},
+		{
+			name:               "resource group",
+			scope:              "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg-app/",
+			wantSubscriptionID: "00000000-0000-0000-0000-000000000001",
+			wantResourceGroup:  "rg-app",
+		},
+		{
+			name:               "resource",
+			scope:              "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg-app/providers/Microsoft.Cache/Redis/redis-app",
+			wantSubscriptionID: "00000000-0000-0000-0000-000000000001",
+			wantResourceGroup:  "rg-app",
+			wantResourceID:     "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/rg-app/providers/Microsoft.Cache/Redis/redis-app",
+		},
+		{
+			name:    "not an id",
+			scope:   "rg-app",
+			wantErr: true,
+		},
+		{
+			name:    "tenant resource",
+			scope:   "/providers/Microsoft.Management/managementGroups/mg",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscriptionID, resourceGroup, resourceID, err := ResolveScopeToScanTargets(tt.scope)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveScopeToScanTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if subscriptionID != tt.wantSubscriptionID || resourceGroup != tt.wantResourceGroup || resourceID != tt.wantResourceID {
+				t.Errorf("ResolveScopeToScanTargets() = (%s, %s, %s), want (%s, %s, %s)", subscriptionID, resourceGroup, resourceID, tt.wantSubscriptionID, tt.wantResourceGroup, tt.wantResourceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azqr/internal/scanners"
+)
+
+// minRetentionInDays - Minimum retention for Microsoft Sentinel investigations and hunting
+const minRetentionInDays = 90
+
+// GetRules - Returns the rules for the SentinelScanner
+func (s *SentinelScanner) GetRules() map[string]scanners.AzureRule {
+	return map[string]scanners.AzureRule{
+		"sentinel-001": {
+			Id:             "sentinel-001",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Microsoft Sentinel workspace should retain data for at least 90 days",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				w := target.(*Workspace)
+				return w.RetentionInDays < minRetentionInDays, strconv.Itoa(w.RetentionInDays), nil
+			},
+			Url:         "https://learn.microsoft.com/en-us/azure/sentinel/billing#data-retention-and-archived-logs-costs",
+			Remediation: "az monitor log-analytics workspace update --ids {resource_id} --retention-time 90",
+		},
+		"sentinel-002": {
+			Id:             "sentinel-002",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Microsoft Sentinel workspace should have at least one data connector",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				w := target.(*Workspace)
+				if w.DataConnectors == nil {
+					return false, "", fmt.Errorf("data connectors not available for %s", w.Name)
+				}
+				return *w.DataConnectors < 1, strconv.Itoa(*w.DataConnectors), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/sentinel/connect-data-sources",
+		},
+		"sentinel-003": {
+			Id:             "sentinel-003",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "Microsoft Sentinel workspace should have diagnostic settings enabled",
+			Impact:         scanners.ImpactLow,
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				w := target.(*Workspace)
+				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(w.ID)]
+				return !ok, "", nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/sentinel/enable-monitoring",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
+		},
+		"sentinel-004": {
+			Id:             "sentinel-004",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Microsoft Sentinel workspace on a commitment tier should be linked to a dedicated cluster",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				w := target.(*Workspace)
+				// Ingestion volume is not exposed by ARM: a commitment tier (100 GB/day or more) identifies high-volume workspaces
+				if w.CapacityReservationLevel == 0 {
+					return false, "", nil
+				}
+				return w.ClusterResourceID == "", strconv.Itoa(w.CapacityReservationLevel) + " GB/day", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-monitor/logs/logs-dedicated-clusters",
+		},
+		"sentinel-005": {
+			Id:             "sentinel-005",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Microsoft Sentinel workspace Name should comply with naming conventions",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				w := target.(*Workspace)
+				caf := strings.HasPrefix(w.Name, "law-")
+				return !caf, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
+		},
+		"sentinel-006": {
+			Id:             "sentinel-006",
+			Category:       scanners.RulesCategoryGovernance,
+			Recommendation: "Microsoft Sentinel workspace should have tags",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				w := target.(*Workspace)
+				broken, result := scanners.EvaluateTagPolicy(w.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+	}
+}
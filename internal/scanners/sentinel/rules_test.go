@@ -0,0 +1,184 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package sentinel
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azqr/internal/to"
+)
+
+func TestSentinelScanner_Rules(t *testing.T) {
+	type fields struct {
+		rule        string
+		target      interface{}
+		scanContext *scanners.ScanContext
+	}
+	type want struct {
+		broken bool
+		result string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "SentinelScanner retention 90 days",
+			fields: fields{
+				rule:        "sentinel-001",
+				target:      &Workspace{RetentionInDays: 90},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "90",
+			},
+		},
+		{
+			name: "SentinelScanner retention 30 days",
+			fields: fields{
+				rule:        "sentinel-001",
+				target:      &Workspace{RetentionInDays: 30},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "30",
+			},
+		},
+		{
+			name: "SentinelScanner data connectors",
+			fields: fields{
+				rule:        "sentinel-002",
+				target:      &Workspace{DataConnectors: to.Ptr(2)},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "2",
+			},
+		},
+		{
+			name: "SentinelScanner without data connectors",
+			fields: fields{
+				rule:        "sentinel-002",
+				target:      &Workspace{DataConnectors: to.Ptr(0)},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "0",
+			},
+		},
+		{
+			name: "SentinelScanner DiagnosticSettings",
+			fields: fields{
+				rule:   "sentinel-003",
+				target: &Workspace{ID: "test"},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SentinelScanner pay-as-you-go without dedicated cluster",
+			fields: fields{
+				rule:        "sentinel-004",
+				target:      &Workspace{SKU: "PerGB2018"},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SentinelScanner commitment tier without dedicated cluster",
+			fields: fields{
+				rule:        "sentinel-004",
+				target:      &Workspace{SKU: "CapacityReservation", CapacityReservationLevel: 100},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "100 GB/day",
+			},
+		},
+		{
+			name: "SentinelScanner commitment tier with dedicated cluster",
+			fields: fields{
+				rule: "sentinel-004",
+				target: &Workspace{
+					SKU:                      "LACluster",
+					CapacityReservationLevel: 500,
+					ClusterResourceID:        "/subscriptions/xxx/resourcegroups/rg/providers/microsoft.operationalinsights/clusters/cluster",
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "500 GB/day",
+			},
+		},
+		{
+			name: "SentinelScanner CAF",
+			fields: fields{
+				rule:        "sentinel-005",
+				target:      &Workspace{Name: "law-sentinel"},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SentinelScanner tags",
+			fields: fields{
+				rule:        "sentinel-006",
+				target:      &Workspace{Tags: map[string]*string{"env": to.Ptr("prod")}},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SentinelScanner{}
+			rules := s.GetRules()
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SentinelScanner Rule.Eval() error = %v", err)
+			}
+			got := want{
+				broken: b,
+				result: w,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SentinelScanner Rule.Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentinelScanner_DataConnectors_NotAvailable(t *testing.T) {
+	s := &SentinelScanner{}
+	rules := s.GetRules()
+	if _, _, err := rules["sentinel-002"].Eval(context.Background(), &Workspace{Name: "sentinel"}, &scanners.ScanContext{}); err == nil {
+		t.Error("SentinelScanner Rule.Eval() sentinel-002 error = nil, want an error when the data connectors are not available")
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package sentinel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azqr/internal/graph"
+	"github.com/Azure/azqr/internal/scanners"
+	"github.com/rs/zerolog/log"
+)
+
+// dataConnectorsAPIVersion - API version of Microsoft.SecurityInsights/dataConnectors. The armsecurityinsights
+// module is not a dependency of azqr, so data connectors are listed through scanners.RESTClient.
+const dataConnectorsAPIVersion = "2023-02-01"
+
+// dataConnectorList - Page of Microsoft Sentinel data connectors, only counted by the rules
+type dataConnectorList struct {
+	Value    []interface{} `json:"value"`
+	NextLink *string       `json:"nextLink"`
+}
+
+// Workspace - Log Analytics workspace with the Microsoft Sentinel solution installed
+type Workspace struct {
+	ID                       string
+	Name                     string
+	Type                     string
+	Location                 string
+	Tags                     map[string]*string
+	RetentionInDays          int
+	SKU                      string
+	CapacityReservationLevel int
+	ClusterResourceID        string
+	// DataConnectors - Number of Microsoft Sentinel data connectors, nil when they could not be listed
+	DataConnectors *int
+}
+
+// SentinelScanner - Scanner for Microsoft Sentinel workspaces
+type SentinelScanner struct {
+	config     *scanners.ScannerConfig
+	graphQuery *graph.GraphQuery
+	restClient *scanners.RESTClient
+}
+
+// Init - Initializes the SentinelScanner
+func (s *SentinelScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
+	s.config = config
+	s.graphQuery = graph.NewGraphQuery(config.Cred)
+	var err error
+	s.restClient, err = scanners.NewRESTClient(config, dataConnectorsAPIVersion)
+	return err
+}
+
+// ListSentinelWorkspaces - Lists the ids of the Log Analytics workspaces with the SecurityInsights (Microsoft Sentinel) solution installed
+func (s *SentinelScanner) ListSentinelWorkspaces() (map[string]bool, error) {
+	scanners.LogSubscriptionScan(s.config.SubscriptionID, "Microsoft Sentinel Solutions")
+
+	res := map[string]bool{}
+
+	query := "resources | where type =~ 'microsoft.operationsmanagement/solutions' and name startswith 'SecurityInsights(' | project workspaceResourceId = tostring(properties.workspaceResourceId)"
+	result, err := s.graphQuery.Run(s.config.Ctx, query, []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		log.Info().Msg("Preflight: No Microsoft Sentinel workspaces found")
+		return res, nil
+	}
+
+	for _, row := range result.Data {
+		m := row.(map[string]interface{})
+		if id, ok := m["workspaceResourceId"].(string); ok && id != "" {
+			res[strings.ToLower(id)] = true
+		}
+	}
+
+	return res, nil
+}
+
+// Scan - Scans all Microsoft Sentinel workspaces in a Resource Group
+func (s *SentinelScanner) Scan(resourceGroupName string, scanContext *scanners.ScanContext) ([]scanners.AzureServiceResult, error) {
+	scanners.LogResourceGroupScan(s.config.SubscriptionID, resourceGroupName, "Microsoft Sentinel")
+
+	workspaces, err := s.listWorkspaces(resourceGroupName, scanContext.SentinelWorkspaces)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range workspaces {
+		count, err := s.countDataConnectors(w.ID)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to list data connectors of Microsoft Sentinel workspace %s, skipping sentinel-002", w.Name)
+			continue
+		}
+		w.DataConnectors = &count
+	}
+
+	engine := scanners.RuleEngine{}
+	rules := s.GetRules()
+	results := []scanners.AzureServiceResult{}
+
+	for _, w := range workspaces {
+		rr := engine.EvaluateRules(s.config.Ctx, rules, w, scanContext)
+
+		results = append(results, scanners.AzureServiceResult{
+			SubscriptionID:   s.config.SubscriptionID,
+			SubscriptionName: s.config.SubscriptionName,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      w.Name,
+			ID:               w.ID,
+			Type:             w.Type,
+			Location:         w.Location,
+			Rules:            rr,
+		})
+	}
+	return results, nil
+}
+
+// listWorkspaces - Returns the Log Analytics workspaces of the Resource Group with Microsoft Sentinel installed
+func (s *SentinelScanner) listWorkspaces(resourceGroupName string, sentinelWorkspaces map[string]bool) ([]*Workspace, error) {
+	workspaces := []*Workspace{}
+	if len(sentinelWorkspaces) == 0 {
+		return workspaces, nil
+	}
+
+	query := fmt.Sprintf("resources | where type =~ 'microsoft.operationalinsights/workspaces' and resourceGroup =~ '%s' | project id, name, type, location, tags, retentionInDays = toint(properties.retentionInDays), sku = tostring(properties.sku.name), capacityReservationLevel = toint(properties.sku.capacityReservationLevel), clusterResourceId = tostring(properties.features.clusterResourceId)", resourceGroupName)
+	result, err := s.graphQuery.Run(s.config.Ctx, query, []*string{&s.config.SubscriptionID})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range result.Data {
+		m := row.(map[string]interface{})
+		id, _ := m["id"].(string)
+		if !sentinelWorkspaces[strings.ToLower(id)] {
+			continue
+		}
+		w := &Workspace{
+			ID:                       id,
+			Tags:                     map[string]*string{},
+			RetentionInDays:          intValue(m["retentionInDays"]),
+			CapacityReservationLevel: intValue(m["capacityReservationLevel"]),
+		}
+		w.Name, _ = m["name"].(string)
+		w.Type, _ = m["type"].(string)
+		w.Location, _ = m["location"].(string)
+		w.SKU, _ = m["sku"].(string)
+		w.ClusterResourceID, _ = m["clusterResourceId"].(string)
+		if tags, ok := m["tags"].(map[string]interface{}); ok {
+			for k, v := range tags {
+				if value, ok := v.(string); ok {
+					w.Tags[k] = &value
+				}
+			}
+		}
+		workspaces = append(workspaces, w)
+	}
+	return workspaces, nil
+}
+
+// countDataConnectors - Returns the number of Microsoft Sentinel data connectors of the workspace
+func (s *SentinelScanner) countDataConnectors(workspaceID string) (int, error) {
+	count := 0
+	next := s.restClient.URL(workspaceID, "providers/Microsoft.SecurityInsights/dataConnectors")
+	for next != "" {
+		page := dataConnectorList{}
+		if err := s.restClient.Get(s.config.Ctx, next, &page); err != nil {
+			return 0, err
+		}
+		count += len(page.Value)
+		next = scanners.NextLink(page.NextLink)
+	}
+	return count, nil
+}
+
+func intValue(v interface{}) int {
+	if f, ok := v.(float64); ok {
+		return int(f)
+	}
+	return 0
+}
@@ -4,6 +4,7 @@
 package sigr
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,26 +19,29 @@ func (a *SignalRScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "SignalR should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armsignalr.ResourceInfo)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-signalr/signalr-howto-diagnostic-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-signalr/signalr-howto-diagnostic-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"sigr-002": {
 			Id:             "sigr-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "SignalR should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsignalr.ResourceInfo)
 				sku := string(*i.SKU.Name)
 				zones := false
 				if strings.Contains(sku, "Premium") {
 					zones = true
 				}
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-signalr/availability-zones",
 		},
@@ -46,8 +50,8 @@ func (a *SignalRScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "SignalR should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/signalr-service/",
 		},
@@ -56,10 +60,11 @@ func (a *SignalRScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "SignalR should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsignalr.ResourceInfo)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-signalr/howto-private-endpoints",
 		},
@@ -68,9 +73,9 @@ func (a *SignalRScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "SignalR SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsignalr.ResourceInfo)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://azure.microsoft.com/en-us/pricing/details/signalr-service/",
 		},
@@ -79,10 +84,10 @@ func (a *SignalRScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SignalR Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsignalr.ResourceInfo)
 				caf := strings.HasPrefix(*c.Name, "sigr")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -91,11 +96,77 @@ func (a *SignalRScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SignalR should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsignalr.ResourceInfo)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"sigr-010": {
+			Id:             "sigr-010",
+			Category:       scanners.RulesCategoryMonitoringAndAlerting,
+			Recommendation: "SignalR diagnostic settings should include the AllLogs log category",
+			Impact:         scanners.ImpactMedium,
+			Tags:           []string{"logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				service := target.(*armsignalr.ResourceInfo)
+				return scanners.IsLogCategoryMissing(scanContext, *service.ID, "AllLogs"), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-signalr/signalr-howto-diagnostic-logs",
+		},
+		"sigr-011": {
+			Id:             "sigr-011",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "SignalR upstream URLs should use HTTPS",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsignalr.ResourceInfo)
-				return len(c.Tags) == 0, ""
+				urls := []string{}
+				for _, t := range upstreamTemplates(c) {
+					if t.URLTemplate != nil && !strings.HasPrefix(strings.ToLower(*t.URLTemplate), "https://") {
+						urls = append(urls, *t.URLTemplate)
+					}
+				}
+				return len(urls) > 0, strings.Join(urls, ", "), nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url: "https://learn.microsoft.com/en-us/azure/azure-signalr/concept-upstream",
 		},
+		"sigr-012": {
+			Id:             "sigr-012",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "SignalR in Serverless mode should have upstream endpoints configured",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsignalr.ResourceInfo)
+				if !isServerless(c) {
+					return false, "", nil
+				}
+				return len(upstreamTemplates(c)) == 0, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-signalr/concept-upstream",
+		},
+	}
+}
+
+func upstreamTemplates(c *armsignalr.ResourceInfo) []*armsignalr.UpstreamTemplate {
+	if c.Properties == nil || c.Properties.Upstream == nil {
+		return nil
+	}
+	return c.Properties.Upstream.Templates
+}
+
+// isServerless - Returns true if the ServiceMode feature flag is set to Serverless
+func isServerless(c *armsignalr.ResourceInfo) bool {
+	if c.Properties == nil {
+		return false
+	}
+	for _, f := range c.Properties.Features {
+		if f != nil && f.Flag != nil && *f.Flag == armsignalr.FeatureFlagsServiceMode {
+			return f.Value != nil && strings.EqualFold(*f.Value, "Serverless")
+		}
 	}
+	return false
 }
@@ -4,6 +4,7 @@
 package sigr
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -123,12 +124,149 @@ func TestSignalRScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "SignalRScanner DiagnosticSettings AllLogs",
+			fields: fields{
+				rule: "sigr-010",
+				target: &armsignalr.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"AllLogs"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SignalRScanner DiagnosticSettings allLogs",
+			fields: fields{
+				rule: "sigr-010",
+				target: &armsignalr.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {"allLogs"},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SignalRScanner DiagnosticSettings without AllLogs",
+			fields: fields{
+				rule: "sigr-010",
+				target: &armsignalr.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					DiagnosticsSettings: map[string]bool{
+						"test": true,
+					},
+					DiagnosticsSettingsCategories: map[string][]string{
+						"test": {},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "SignalRScanner No DiagnosticSettings",
+			fields: fields{
+				rule: "sigr-010",
+				target: &armsignalr.ResourceInfo{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SignalRScanner upstream URL using HTTP",
+			fields: fields{
+				rule: "sigr-011",
+				target: &armsignalr.ResourceInfo{
+					Properties: &armsignalr.Properties{
+						Upstream: &armsignalr.ServerlessUpstreamSettings{
+							Templates: []*armsignalr.UpstreamTemplate{
+								{URLTemplate: to.Ptr("https://contoso.azurewebsites.net/api/{hub}")},
+								{URLTemplate: to.Ptr("http://contoso.azurewebsites.net/api/{event}")},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "http://contoso.azurewebsites.net/api/{event}",
+			},
+		},
+		{
+			name: "SignalRScanner Serverless without upstream",
+			fields: fields{
+				rule: "sigr-012",
+				target: &armsignalr.ResourceInfo{
+					Properties: &armsignalr.Properties{
+						Features: []*armsignalr.Feature{
+							{Flag: to.Ptr(armsignalr.FeatureFlagsServiceMode), Value: to.Ptr("Serverless")},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "SignalRScanner Default mode without upstream",
+			fields: fields{
+				rule: "sigr-012",
+				target: &armsignalr.ResourceInfo{
+					Properties: &armsignalr.Properties{
+						Features: []*armsignalr.Feature{
+							{Flag: to.Ptr(armsignalr.FeatureFlagsServiceMode), Value: to.Ptr("Default")},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SignalRScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SignalRScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
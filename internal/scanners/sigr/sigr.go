@@ -16,6 +16,9 @@ type SignalRScanner struct {
 
 // Init - Initializes the SignalRScanner
 func (c *SignalRScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.signalrClient, err = armsignalr.NewClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *SignalRScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 	results := []scanners.AzureServiceResult{}
 
 	for _, signalr := range signalr {
-		rr := engine.EvaluateRules(rules, signalr, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, signalr, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *signalr.Name,
+			ID:               *signalr.ID,
 			Type:             *signalr.Type,
 			Location:         *signalr.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(signalr),
 		})
 	}
 	return results, nil
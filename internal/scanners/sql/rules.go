@@ -4,6 +4,9 @@
 package sql
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -29,10 +32,11 @@ func (a *SQLScanner) getServerRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "SQL should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsql.Server)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 		},
 		"sql-006": {
@@ -40,10 +44,10 @@ func (a *SQLScanner) getServerRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SQL Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsql.Server)
 				caf := strings.HasPrefix(*c.Name, "sql")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -52,26 +56,79 @@ func (a *SQLScanner) getServerRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SQL should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsql.Server)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"sql-008": {
 			Id:             "sql-008",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "SQL should enforce TLS >= 1.2",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "data-protection"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.Sql/servers", "Microsoft.Sql/servers/minimalTlsVersion", "1.2"),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsql.Server)
-				return c.Properties.MinimalTLSVersion == nil || *c.Properties.MinimalTLSVersion != "1.2", ""
+				return c.Properties.MinimalTLSVersion == nil || *c.Properties.MinimalTLSVersion != "1.2", "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-sql/database/connectivity-settings?view=azuresql&tabs=azure-portal#minimal-tls-version",
+			Url:         "https://learn.microsoft.com/en-us/azure/azure-sql/database/connectivity-settings?view=azuresql&tabs=azure-portal#minimal-tls-version",
+			Remediation: "az sql server update --ids {resource_id} --minimal-tls-version 1.2",
+		},
+		"sql-009": {
+			Id:             "sql-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "SQL should have vulnerability assessment recurring scans enabled",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsql.Server)
+				scans, err := recurringScans(c, scanContext)
+				if err != nil {
+					return false, "", err
+				}
+				enabled := scans != nil && scans.IsEnabled != nil && *scans.IsEnabled
+				return !enabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-sql/database/threat-detection-overview",
+		},
+		"sql-010": {
+			Id:             "sql-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "SQL vulnerability assessment recurring scans should send notification emails",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsql.Server)
+				scans, err := recurringScans(c, scanContext)
+				if err != nil {
+					return false, "", err
+				}
+				// Only evaluated when recurring scans are enabled, sql-009 covers disabled scans
+				if scans == nil || scans.IsEnabled == nil || !*scans.IsEnabled {
+					return false, "", nil
+				}
+				return len(scans.Emails) == 0, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-sql/database/threat-detection-overview",
 		},
 	}
 }
 
+// recurringScans - Returns the recurring scans settings of the server vulnerability assessment
+func recurringScans(server *armsql.Server, scanContext *scanners.ScanContext) (*armsql.VulnerabilityAssessmentRecurringScansProperties, error) {
+	assessment, ok := scanContext.SQLVulnerabilityAssessments[strings.ToLower(*server.ID)]
+	if !ok {
+		return nil, fmt.Errorf("vulnerability assessment not available for %s", *server.Name)
+	}
+	if assessment == nil || assessment.Properties == nil {
+		return nil, nil
+	}
+	return assessment.Properties.RecurringScans, nil
+}
+
 func (a *SQLScanner) getDatabaseRules() map[string]scanners.AzureRule {
 	return map[string]scanners.AzureRule{
 		"sqldb-001": {
@@ -79,24 +136,27 @@ func (a *SQLScanner) getDatabaseRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "SQL Database should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armsql.Database)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"sqldb-002": {
 			Id:             "sqldb-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "SQL Database should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsql.Database)
 				zones := false
 				if i.Properties.ZoneRedundant != nil {
 					zones = *i.Properties.ZoneRedundant
 				}
-				return !zones, ""
+				return !zones, "", nil
 			},
 		},
 		"sqldb-003": {
@@ -104,13 +164,13 @@ func (a *SQLScanner) getDatabaseRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "SQL Database should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsql.Database)
 				sla := "99.99%"
 				if i.Properties.ZoneRedundant != nil && *i.Properties.ZoneRedundant && *i.SKU.Tier == "Premium" {
 					sla = "99.995%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 		},
 		"sqldb-005": {
@@ -118,9 +178,9 @@ func (a *SQLScanner) getDatabaseRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "SQL Database SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsql.Database)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://docs.microsoft.com/en-us/azure/azure-sql/database/service-tiers-vcore?tabs=azure-portal",
 		},
@@ -129,10 +189,10 @@ func (a *SQLScanner) getDatabaseRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SQL Database Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsql.Database)
 				caf := *c.Name == "master" || strings.HasPrefix(*c.Name, "sqldb")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -141,15 +201,62 @@ func (a *SQLScanner) getDatabaseRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SQL Database should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsql.Database)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"sqldb-008": {
+			Id:             "sqldb-008",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "SQL Database in Business Critical or Premium tier should have active geo-replication configured",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsql.Database)
+				if c.SKU == nil || c.SKU.Tier == nil {
+					return false, "", nil
+				}
+				tier := strings.ToLower(*c.SKU.Tier)
+				if tier != "businesscritical" && tier != "premium" {
+					return false, "", nil
+				}
+				links := scanContext.SQLGeoReplicationLinks[strings.ToLower(*c.ID)]
+				return links == 0, strconv.Itoa(links), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/azure-sql/database/active-geo-replication-overview",
+		},
+		"sqldb-009": {
+			Id:             "sqldb-009",
+			Category:       scanners.RulesCategoryDisasterRecovery,
+			Recommendation: "SQL Database should have long-term backup retention configured",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsql.Database)
-				return len(c.Tags) == 0, ""
+				policy, ok := scanContext.SQLLongTermRetentionPolicies[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("long-term retention policy not available for %s", *c.Name)
+				}
+				if policy == nil || policy.Properties == nil {
+					return true, "", nil
+				}
+				p := policy.Properties
+				configured := isRetentionSet(p.WeeklyRetention) || isRetentionSet(p.MonthlyRetention) || isRetentionSet(p.YearlyRetention)
+				return !configured, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url: "https://learn.microsoft.com/en-us/azure/azure-sql/database/long-term-retention-overview",
 		},
 	}
 }
 
+// isRetentionSet - Returns true if the ISO 8601 retention period is set (PT0S means disabled)
+func isRetentionSet(retention *string) bool {
+	return retention != nil && *retention != "" && *retention != "PT0S"
+}
+
 func (a *SQLScanner) getPoolRules() map[string]scanners.AzureRule {
 	return map[string]scanners.AzureRule{
 		"sqlep-001": {
@@ -157,9 +264,9 @@ func (a *SQLScanner) getPoolRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "SQL Elastic Pool SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsql.ElasticPool)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/azure-sql/database/elastic-pool-overview?view=azuresql",
 		},
@@ -168,10 +275,10 @@ func (a *SQLScanner) getPoolRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SQL Elastic Pool Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsql.ElasticPool)
 				caf := strings.HasPrefix(*c.Name, "sqlep")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -180,11 +287,14 @@ func (a *SQLScanner) getPoolRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "SQL Elastic Pool should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsql.ElasticPool)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 	}
 }
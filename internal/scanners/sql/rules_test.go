@@ -4,6 +4,7 @@
 package sql
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -77,12 +78,121 @@ func TestSQLScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "SQLScanner Vulnerability Assessment recurring scans",
+			fields: fields{
+				rule: "sql-009",
+				target: &armsql.Server{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("sql-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					SQLVulnerabilityAssessments: map[string]*armsql.ServerVulnerabilityAssessment{
+						"test": {
+							Properties: &armsql.ServerVulnerabilityAssessmentProperties{
+								RecurringScans: &armsql.VulnerabilityAssessmentRecurringScansProperties{
+									IsEnabled: to.Ptr(true),
+									Emails: []*string{
+										to.Ptr("admin@contoso.com"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SQLScanner Vulnerability Assessment recurring scans disabled",
+			fields: fields{
+				rule: "sql-009",
+				target: &armsql.Server{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("sql-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					SQLVulnerabilityAssessments: map[string]*armsql.ServerVulnerabilityAssessment{
+						"test": {
+							Properties: &armsql.ServerVulnerabilityAssessmentProperties{
+								RecurringScans: &armsql.VulnerabilityAssessmentRecurringScansProperties{
+									IsEnabled: to.Ptr(false),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "SQLScanner Vulnerability Assessment emails",
+			fields: fields{
+				rule: "sql-010",
+				target: &armsql.Server{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("sql-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					SQLVulnerabilityAssessments: map[string]*armsql.ServerVulnerabilityAssessment{
+						"test": {
+							Properties: &armsql.ServerVulnerabilityAssessmentProperties{
+								RecurringScans: &armsql.VulnerabilityAssessmentRecurringScansProperties{
+									IsEnabled: to.Ptr(true),
+									Emails: []*string{
+										to.Ptr("admin@contoso.com"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SQLScanner Vulnerability Assessment without emails",
+			fields: fields{
+				rule: "sql-010",
+				target: &armsql.Server{
+					ID:   to.Ptr("test"),
+					Name: to.Ptr("sql-test"),
+				},
+				scanContext: &scanners.ScanContext{
+					SQLVulnerabilityAssessments: map[string]*armsql.ServerVulnerabilityAssessment{
+						"test": {
+							Properties: &armsql.ServerVulnerabilityAssessmentProperties{
+								RecurringScans: &armsql.VulnerabilityAssessmentRecurringScansProperties{
+									IsEnabled: to.Ptr(true),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SQLScanner{}
 			rules := s.getServerRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SQLScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -206,12 +316,120 @@ func TestSQLScanner_DatabaseRules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "SQLScanner Geo-Replication",
+			fields: fields{
+				rule: "sqldb-008",
+				target: &armsql.Database{
+					ID: to.Ptr("test"),
+					SKU: &armsql.SKU{
+						Tier: to.Ptr("BusinessCritical"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					SQLGeoReplicationLinks: map[string]int{
+						"test": 1,
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "1",
+			},
+		},
+		{
+			name: "SQLScanner Geo-Replication not configured",
+			fields: fields{
+				rule: "sqldb-008",
+				target: &armsql.Database{
+					ID: to.Ptr("test"),
+					SKU: &armsql.SKU{
+						Tier: to.Ptr("Premium"),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					SQLGeoReplicationLinks: map[string]int{},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "0",
+			},
+		},
+		{
+			name: "SQLScanner Geo-Replication General Purpose",
+			fields: fields{
+				rule: "sqldb-008",
+				target: &armsql.Database{
+					ID: to.Ptr("test"),
+					SKU: &armsql.SKU{
+						Tier: to.Ptr("GeneralPurpose"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SQLScanner Long-Term Retention",
+			fields: fields{
+				rule: "sqldb-009",
+				target: &armsql.Database{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					SQLLongTermRetentionPolicies: map[string]*armsql.LongTermRetentionPolicy{
+						"test": {
+							Properties: &armsql.BaseLongTermRetentionPolicyProperties{
+								WeeklyRetention:  to.Ptr("P4W"),
+								MonthlyRetention: to.Ptr("PT0S"),
+								YearlyRetention:  to.Ptr("PT0S"),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SQLScanner Long-Term Retention not configured",
+			fields: fields{
+				rule: "sqldb-009",
+				target: &armsql.Database{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					SQLLongTermRetentionPolicies: map[string]*armsql.LongTermRetentionPolicy{
+						"test": {
+							Properties: &armsql.BaseLongTermRetentionPolicyProperties{
+								WeeklyRetention:  to.Ptr("PT0S"),
+								MonthlyRetention: to.Ptr("PT0S"),
+								YearlyRetention:  to.Ptr("PT0S"),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SQLScanner{}
 			rules := s.getDatabaseRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SQLScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -273,7 +491,10 @@ func TestSQLScanner_PoolRules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SQLScanner{}
 			rules := s.getPoolRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SQLScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
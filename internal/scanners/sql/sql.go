@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/sql/armsql"
+	"github.com/rs/zerolog/log"
 )
 
 // SQLScanner - Scanner for SQL
@@ -16,10 +17,16 @@ type SQLScanner struct {
 	sqlClient            *armsql.ServersClient
 	sqlDatabasedClient   *armsql.DatabasesClient
 	sqlElasticPoolClient *armsql.ElasticPoolsClient
+	replicationClient    *armsql.ReplicationLinksClient
+	retentionClient      *armsql.LongTermRetentionPoliciesClient
+	assessmentsClient    *armsql.ServerVulnerabilityAssessmentsClient
 }
 
 // Init - Initializes the SQLScanner
 func (c *SQLScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.sqlClient, err = armsql.NewServersClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -34,6 +41,18 @@ func (c *SQLScanner) Init(config *scanners.ScannerConfig) error {
 	if err != nil {
 		return err
 	}
+	c.replicationClient, err = armsql.NewReplicationLinksClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.retentionClient, err = armsql.NewLongTermRetentionPoliciesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.assessmentsClient, err = armsql.NewServerVulnerabilityAssessmentsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -51,16 +70,29 @@ func (c *SQLScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 	poolRules := c.getPoolRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.SQLGeoReplicationLinks = map[string]int{}
+	scanContext.SQLLongTermRetentionPolicies = map[string]*armsql.LongTermRetentionPolicy{}
+	scanContext.SQLVulnerabilityAssessments = map[string]*armsql.ServerVulnerabilityAssessment{}
+
 	for _, sql := range sql {
-		rr := engine.EvaluateRules(rules, sql, scanContext)
+		assessment, err := c.getVulnerabilityAssessment(resourceGroupName, *sql.Name)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to get vulnerability assessment for %s", *sql.ID)
+		} else {
+			scanContext.SQLVulnerabilityAssessments[strings.ToLower(*sql.ID)] = assessment
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, sql, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID: c.config.SubscriptionID,
 			ResourceGroup:  resourceGroupName,
 			ServiceName:    *sql.Name,
+			ID:             *sql.ID,
 			Type:           *sql.Type,
 			Location:       *sql.Location,
 			Rules:          rr,
+			CreatedAt:      scanners.GetCreatedAt(sql),
 		})
 
 		pools, err := c.listPools(resourceGroupName, *sql.Name)
@@ -68,16 +100,18 @@ func (c *SQLScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 			return nil, err
 		}
 		for _, pool := range pools {
-			rr := engine.EvaluateRules(poolRules, pool, scanContext)
+			rr := engine.EvaluateRules(c.config.Ctx, poolRules, pool, scanContext)
 
 			results = append(results, scanners.AzureServiceResult{
 				SubscriptionID:   c.config.SubscriptionID,
 				SubscriptionName: c.config.SubscriptionName,
 				ResourceGroup:    resourceGroupName,
 				ServiceName:      *pool.Name,
+				ID:               *pool.ID,
 				Type:             *pool.Type,
 				Location:         *pool.Location,
 				Rules:            rr,
+				CreatedAt:        scanners.GetCreatedAt(pool),
 			})
 		}
 
@@ -90,16 +124,32 @@ func (c *SQLScanner) Scan(resourceGroupName string, scanContext *scanners.ScanCo
 				continue
 			}
 
-			rr := engine.EvaluateRules(databaseRules, database, scanContext)
+			links, err := c.listReplicationLinks(resourceGroupName, *sql.Name, *database.Name)
+			if err != nil {
+				return nil, err
+			}
+			scanContext.SQLGeoReplicationLinks[strings.ToLower(*database.ID)] = countActiveGeoLinks(links)
+
+			policy, err := c.getLongTermRetentionPolicy(resourceGroupName, *sql.Name, *database.Name)
+			if err != nil {
+				// Long-term retention is not supported by every database (e.g. Hyperscale or DataWarehouse)
+				log.Debug().Err(err).Msgf("Failed to get long-term retention policy for %s", *database.ID)
+			} else {
+				scanContext.SQLLongTermRetentionPolicies[strings.ToLower(*database.ID)] = policy
+			}
+
+			rr := engine.EvaluateRules(c.config.Ctx, databaseRules, database, scanContext)
 
 			results = append(results, scanners.AzureServiceResult{
 				SubscriptionID:   c.config.SubscriptionID,
 				SubscriptionName: c.config.SubscriptionName,
 				ResourceGroup:    resourceGroupName,
 				ServiceName:      *database.Name,
+				ID:               *database.ID,
 				Type:             *database.Type,
 				Location:         *database.Location,
 				Rules:            rr,
+				CreatedAt:        scanners.GetCreatedAt(database),
 			})
 		}
 	}
@@ -135,6 +185,54 @@ func (c *SQLScanner) listDatabases(resourceGroupName, serverName string) ([]*arm
 	return databases, nil
 }
 
+func (c *SQLScanner) listReplicationLinks(resourceGroupName, serverName, databaseName string) ([]*armsql.ReplicationLink, error) {
+	pager := c.replicationClient.NewListByDatabasePager(resourceGroupName, serverName, databaseName, nil)
+
+	links := make([]*armsql.ReplicationLink, 0)
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, resp.Value...)
+	}
+	return links, nil
+}
+
+func (c *SQLScanner) getLongTermRetentionPolicy(resourceGroupName, serverName, databaseName string) (*armsql.LongTermRetentionPolicy, error) {
+	resp, err := c.retentionClient.Get(c.config.Ctx, resourceGroupName, serverName, databaseName, armsql.LongTermRetentionPolicyNameDefault, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.LongTermRetentionPolicy, nil
+}
+
+func (c *SQLScanner) getVulnerabilityAssessment(resourceGroupName, serverName string) (*armsql.ServerVulnerabilityAssessment, error) {
+	resp, err := c.assessmentsClient.Get(c.config.Ctx, resourceGroupName, serverName, armsql.VulnerabilityAssessmentNameDefault, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.ServerVulnerabilityAssessment, nil
+}
+
+// countActiveGeoLinks - Returns the number of geo-replication links that are not suspended
+func countActiveGeoLinks(links []*armsql.ReplicationLink) int {
+	count := 0
+	for _, l := range links {
+		if l.Properties == nil {
+			continue
+		}
+		if l.Properties.LinkType != nil && *l.Properties.LinkType != armsql.ReplicationLinkTypeGEO {
+			continue
+		}
+		if l.Properties.ReplicationState != nil && *l.Properties.ReplicationState == armsql.ReplicationStateSUSPENDED {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
 func (c *SQLScanner) listPools(resourceGroupName, serverName string) ([]*armsql.ElasticPool, error) {
 	pager := c.sqlElasticPoolClient.NewListByServerPager(resourceGroupName, serverName, nil)
 
@@ -4,6 +4,9 @@
 package st
 
 import (
+	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,26 +21,29 @@ func (a *StorageScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Storage should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armstorage.Account)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/storage/blobs/monitor-blob-storage",
+			Url:             "https://learn.microsoft.com/en-us/azure/storage/blobs/monitor-blob-storage",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"st-002": {
 			Id:             "st-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Storage should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armstorage.Account)
 				sku := string(*i.SKU.Name)
 				zones := false
 				if strings.Contains(sku, "ZRS") {
 					zones = true
 				}
-				return !zones, ""
+				return !zones, "", nil
 			},
 			Url: "https://learn.microsoft.com/EN-US/azure/reliability/migrate-storage",
 		},
@@ -46,7 +52,7 @@ func (a *StorageScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Storage should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armstorage.Account)
 				tier := ""
 				sku := string(*i.SKU.Name)
@@ -63,7 +69,7 @@ func (a *StorageScanner) GetRules() map[string]scanners.AzureRule {
 				} else if (strings.Contains(sku, "LRS") || strings.Contains(sku, "ZRS") || strings.Contains(sku, "GRS")) && strings.Contains(tier, "Hot") {
 					sla = "99.9%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/storage/",
 		},
@@ -72,10 +78,11 @@ func (a *StorageScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Storage should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armstorage.Account)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/storage/common/storage-private-endpoints",
 		},
@@ -84,9 +91,9 @@ func (a *StorageScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Storage SKU",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armstorage.Account)
-				return false, string(*i.SKU.Name)
+				return false, string(*i.SKU.Name), nil
 			},
 			Url: "https://learn.microsoft.com/en-us/rest/api/storagerp/srp_sku_types",
 		},
@@ -95,55 +102,63 @@ func (a *StorageScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Storage Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armstorage.Account)
 				caf := strings.HasPrefix(*c.Name, "st")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
 		"st-007": {
-			Id:             "st-007",
-			Category:       scanners.RulesCategorySecurity,
-			Recommendation: "Storage Account should use HTTPS only",
-			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Id:                  "st-007",
+			Category:            scanners.RulesCategorySecurity,
+			Recommendation:      "Storage Account should use HTTPS only",
+			Impact:              scanners.ImpactHigh,
+			PolicyDefinitionIDs: []string{"/providers/Microsoft.Authorization/policyDefinitions/404c3081-a854-4457-ae30-26a93ef643f9"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armstorage.Account)
 				h := *c.Properties.EnableHTTPSTrafficOnly
-				return !h, ""
+				return !h, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/storage/common/storage-require-secure-transfer",
+			Url:         "https://learn.microsoft.com/en-us/azure/storage/common/storage-require-secure-transfer",
+			Remediation: "az storage account update --ids {resource_id} --https-only true",
 		},
 		"st-008": {
 			Id:             "st-008",
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Storage Account should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armstorage.Account)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"st-009": {
 			Id:             "st-009",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Storage Account should enforce TLS >= 1.2",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "data-protection"},
+			Policy:         scanners.PolicyFieldNotEquals("Microsoft.Storage/storageAccounts", "Microsoft.Storage/storageAccounts/minimumTlsVersion", "TLS1_2"),
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armstorage.Account)
-				return c.Properties.MinimumTLSVersion == nil || *c.Properties.MinimumTLSVersion != armstorage.MinimumTLSVersionTLS12, ""
+				return c.Properties.MinimumTLSVersion == nil || *c.Properties.MinimumTLSVersion != armstorage.MinimumTLSVersionTLS12, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/storage/common/transport-layer-security-configure-minimum-version?tabs=portal",
+			Url:         "https://learn.microsoft.com/en-us/azure/storage/common/transport-layer-security-configure-minimum-version?tabs=portal",
+			Remediation: "az storage account update --ids {resource_id} --min-tls-version TLS1_2",
 		},
 		"st-010": {
 			Id:             "st-010",
 			Category:       scanners.RulesCategoryDisasterRecovery,
 			Recommendation: "Storage Account should have inmutable storage versioning enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armstorage.Account)
-				return c.Properties.ImmutableStorageWithVersioning == nil || c.Properties.ImmutableStorageWithVersioning.Enabled == nil || !*c.Properties.ImmutableStorageWithVersioning.Enabled, ""
+				return c.Properties.ImmutableStorageWithVersioning == nil || c.Properties.ImmutableStorageWithVersioning.Enabled == nil || !*c.Properties.ImmutableStorageWithVersioning.Enabled, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/storage-accounts/reliability",
 		},
@@ -152,15 +167,73 @@ func (a *StorageScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryDisasterRecovery,
 			Recommendation: "Storage Account should have soft delete enabled",
 			Impact:         scanners.ImpactMedium,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				broken := false
 				broken = scanContext.BlobServiceProperties != nil && (scanContext.BlobServiceProperties.BlobServiceProperties.BlobServiceProperties.ContainerDeleteRetentionPolicy == nil ||
 					scanContext.BlobServiceProperties.BlobServiceProperties.BlobServiceProperties.ContainerDeleteRetentionPolicy.Enabled == nil ||
 					!*scanContext.BlobServiceProperties.BlobServiceProperties.BlobServiceProperties.ContainerDeleteRetentionPolicy.Enabled)
 
-				return broken, ""
+				return broken, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/well-architected/service-guides/storage-accounts/reliability",
 		},
+		"st-012": {
+			Id:             "st-012",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Storage Account should have a lifecycle management policy",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armstorage.Account)
+				policy, ok := scanContext.StorageLifecyclePolicies[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("lifecycle management policy not available for %s", *c.Name)
+				}
+				rules := lifecycleRules(policy)
+				return len(rules) == 0, strconv.Itoa(len(rules)), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/storage/blobs/lifecycle-management-overview",
+		},
+		"st-013": {
+			Id:             "st-013",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Storage Account lifecycle management policy should move blobs to Cool tier after 30 days and Archive tier after 90 days",
+			Impact:         scanners.ImpactLow,
+			DependsOn:      []string{"st-012"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armstorage.Account)
+				policy, ok := scanContext.StorageLifecyclePolicies[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("lifecycle management policy not available for %s", *c.Name)
+				}
+				rules := lifecycleRules(policy)
+				for _, r := range rules {
+					if r.Enabled != nil && !*r.Enabled {
+						continue
+					}
+					if r.Definition == nil || r.Definition.Actions == nil || r.Definition.Actions.BaseBlob == nil {
+						continue
+					}
+					b := r.Definition.Actions.BaseBlob
+					if daysAfterModificationAtMost(b.TierToCool, 30) && daysAfterModificationAtMost(b.TierToArchive, 90) {
+						return false, strconv.Itoa(len(rules)), nil
+					}
+				}
+				return true, strconv.Itoa(len(rules)), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/storage/blobs/lifecycle-management-overview",
+		},
 	}
 }
+
+// lifecycleRules - Returns the rules of a lifecycle management policy
+func lifecycleRules(policy *armstorage.ManagementPolicy) []*armstorage.ManagementPolicyRule {
+	if policy == nil || policy.Properties == nil || policy.Properties.Policy == nil {
+		return nil
+	}
+	return policy.Properties.Policy.Rules
+}
+
+// daysAfterModificationAtMost - Returns true when the action runs at most days after the last modification
+func daysAfterModificationAtMost(action *armstorage.DateAfterModification, days float32) bool {
+	return action != nil && action.DaysAfterModificationGreaterThan != nil && *action.DaysAfterModificationGreaterThan <= days
+}
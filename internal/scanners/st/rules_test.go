@@ -4,6 +4,7 @@
 package st
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -222,12 +223,198 @@ func TestStorageScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "StorageScanner no lifecycle policy",
+			fields: fields{
+				rule: "st-012",
+				target: &armstorage.Account{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"),
+					Name: to.Ptr("st"),
+				},
+				scanContext: &scanners.ScanContext{
+					StorageLifecyclePolicies: map[string]*armstorage.ManagementPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.storage/storageaccounts/st": nil,
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "0",
+			},
+		},
+		{
+			name: "StorageScanner lifecycle policy",
+			fields: fields{
+				rule: "st-012",
+				target: &armstorage.Account{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"),
+					Name: to.Ptr("st"),
+				},
+				scanContext: &scanners.ScanContext{
+					StorageLifecyclePolicies: map[string]*armstorage.ManagementPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.storage/storageaccounts/st": {
+							Properties: &armstorage.ManagementPolicyProperties{
+								Policy: &armstorage.ManagementPolicySchema{
+									Rules: []*armstorage.ManagementPolicyRule{
+										{
+											Name:    to.Ptr("tiering"),
+											Enabled: to.Ptr(true),
+											Definition: &armstorage.ManagementPolicyDefinition{
+												Actions: &armstorage.ManagementPolicyAction{
+													BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+														TierToCool:    &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](30)},
+														TierToArchive: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](90)},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "1",
+			},
+		},
+		{
+			name: "StorageScanner lifecycle policy tiering",
+			fields: fields{
+				rule: "st-013",
+				target: &armstorage.Account{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"),
+					Name: to.Ptr("st"),
+				},
+				scanContext: &scanners.ScanContext{
+					StorageLifecyclePolicies: map[string]*armstorage.ManagementPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.storage/storageaccounts/st": {
+							Properties: &armstorage.ManagementPolicyProperties{
+								Policy: &armstorage.ManagementPolicySchema{
+									Rules: []*armstorage.ManagementPolicyRule{
+										{
+											Name:    to.Ptr("logs"),
+											Enabled: to.Ptr(true),
+											Definition: &armstorage.ManagementPolicyDefinition{
+												Actions: &armstorage.ManagementPolicyAction{
+													BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+														TierToCool:    &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](60)},
+														TierToArchive: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](180)},
+													},
+												},
+											},
+										},
+										{
+											Name:    to.Ptr("tiering"),
+											Enabled: to.Ptr(true),
+											Definition: &armstorage.ManagementPolicyDefinition{
+												Actions: &armstorage.ManagementPolicyAction{
+													BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+														TierToCool:    &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](30)},
+														TierToArchive: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](90)},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "2",
+			},
+		},
+		{
+			name: "StorageScanner lifecycle policy tiering too late",
+			fields: fields{
+				rule: "st-013",
+				target: &armstorage.Account{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"),
+					Name: to.Ptr("st"),
+				},
+				scanContext: &scanners.ScanContext{
+					StorageLifecyclePolicies: map[string]*armstorage.ManagementPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.storage/storageaccounts/st": {
+							Properties: &armstorage.ManagementPolicyProperties{
+								Policy: &armstorage.ManagementPolicySchema{
+									Rules: []*armstorage.ManagementPolicyRule{
+										{
+											Name:    to.Ptr("logs"),
+											Enabled: to.Ptr(true),
+											Definition: &armstorage.ManagementPolicyDefinition{
+												Actions: &armstorage.ManagementPolicyAction{
+													BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+														TierToCool:    &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](60)},
+														TierToArchive: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](180)},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "1",
+			},
+		},
+		{
+			name: "StorageScanner lifecycle policy tiering disabled",
+			fields: fields{
+				rule: "st-013",
+				target: &armstorage.Account{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"),
+					Name: to.Ptr("st"),
+				},
+				scanContext: &scanners.ScanContext{
+					StorageLifecyclePolicies: map[string]*armstorage.ManagementPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.storage/storageaccounts/st": {
+							Properties: &armstorage.ManagementPolicyProperties{
+								Policy: &armstorage.ManagementPolicySchema{
+									Rules: []*armstorage.ManagementPolicyRule{
+										{
+											Name:    to.Ptr("tiering"),
+											Enabled: to.Ptr(false),
+											Definition: &armstorage.ManagementPolicyDefinition{
+												Actions: &armstorage.ManagementPolicyAction{
+													BaseBlob: &armstorage.ManagementPolicyBaseBlob{
+														TierToCool:    &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](30)},
+														TierToArchive: &armstorage.DateAfterModification{DaysAfterModificationGreaterThan: to.Ptr[float32](90)},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "1",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &StorageScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("StorageScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -238,3 +425,19 @@ func TestStorageScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageScanner_LifecyclePolicy_NotAvailable(t *testing.T) {
+	target := &armstorage.Account{
+		ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st"),
+		Name: to.Ptr("st"),
+	}
+	scanContext := &scanners.ScanContext{StorageLifecyclePolicies: map[string]*armstorage.ManagementPolicy{}}
+
+	s := &StorageScanner{}
+	rules := s.GetRules()
+	for _, id := range []string{"st-012", "st-013"} {
+		if _, _, err := rules[id].Eval(context.Background(), target, scanContext); err == nil {
+			t.Errorf("StorageScanner Rule.Eval() %s error = nil, want an error when the policy is not available", id)
+		}
+	}
+}
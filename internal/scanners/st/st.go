@@ -4,8 +4,14 @@
 package st
 
 import (
+	"errors"
+	"net/http"
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+	"github.com/rs/zerolog/log"
 )
 
 // StorageScanner - Scanner for Storage
@@ -13,10 +19,14 @@ type StorageScanner struct {
 	config             *scanners.ScannerConfig
 	storageClient      *armstorage.AccountsClient
 	blobServicesClient *armstorage.BlobServicesClient
+	policiesClient     *armstorage.ManagementPoliciesClient
 }
 
 // Init - Initializes the StorageScanner
 func (c *StorageScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.storageClient, err = armstorage.NewAccountsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -24,6 +34,10 @@ func (c *StorageScanner) Init(config *scanners.ScannerConfig) error {
 		return err
 	}
 	c.blobServicesClient, err = armstorage.NewBlobServicesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	c.policiesClient, err = armstorage.NewManagementPoliciesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
 	return err
 }
 
@@ -39,6 +53,8 @@ func (c *StorageScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 	rules := c.GetRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.StorageLifecyclePolicies = map[string]*armstorage.ManagementPolicy{}
+
 	for _, storage := range storage {
 		scanContext.BlobServiceProperties = nil
 		blobServicesProperties, err := c.blobServicesClient.GetServiceProperties(c.config.Ctx, resourceGroupName, *storage.Name, nil)
@@ -46,16 +62,25 @@ func (c *StorageScanner) Scan(resourceGroupName string, scanContext *scanners.Sc
 			scanContext.BlobServiceProperties = &blobServicesProperties
 		}
 
-		rr := engine.EvaluateRules(rules, storage, scanContext)
+		policy, err := c.getLifecyclePolicy(resourceGroupName, *storage.Name)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Failed to get lifecycle management policy for %s", *storage.ID)
+		} else {
+			scanContext.StorageLifecyclePolicies[strings.ToLower(*storage.ID)] = policy
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, storage, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *storage.Name,
+			ID:               *storage.ID,
 			Type:             *storage.Type,
 			Location:         *storage.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(storage),
 		})
 	}
 	return results, nil
@@ -74,3 +99,16 @@ func (c *StorageScanner) listStorage(resourceGroupName string) ([]*armstorage.Ac
 	}
 	return staccounts, nil
 }
+
+// getLifecyclePolicy - Returns the lifecycle management policy of the account, nil when none is configured
+func (c *StorageScanner) getLifecyclePolicy(resourceGroupName, accountName string) (*armstorage.ManagementPolicy, error) {
+	resp, err := c.policiesClient.Get(c.config.Ctx, resourceGroupName, accountName, armstorage.ManagementPolicyNameDefault, nil)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &resp.ManagementPolicy, nil
+}
@@ -4,6 +4,8 @@
 package synw
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -28,22 +30,26 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Azure Synapse Workspace should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armsynapse.Workspace)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/data-factory/monitor-configure-diagnostics",
+			Url:             "https://learn.microsoft.com/en-us/azure/data-factory/monitor-configure-diagnostics",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"synw-002": {
 			Id:             "synw-002",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Synapse Workspace should have private endpoints enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "network-security"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				i := target.(*armsynapse.Workspace)
 				pe := len(i.Properties.PrivateEndpointConnections) > 0
-				return !pe, ""
+				return !pe, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/synapse-analytics/security/synapse-workspace-managed-private-endpoints",
 		},
@@ -52,8 +58,8 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Synapse Workspace SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -62,10 +68,10 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Synapse Workspace Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.Workspace)
 				caf := strings.HasPrefix(*c.Name, "synw")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -74,20 +80,23 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Synapse Workspace should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.Workspace)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"synw-006": {
 			Id:             "synw-006",
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Synapse Workspace should establish network segmentation boundaries",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.Workspace)
-				return c.Properties.ManagedVirtualNetwork == nil || strings.ToLower(*c.Properties.ManagedVirtualNetwork) != "default", ""
+				return c.Properties.ManagedVirtualNetwork == nil || strings.ToLower(*c.Properties.ManagedVirtualNetwork) != "default", "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
 		},
@@ -96,11 +105,31 @@ func (a *SynapseWorkspaceScanner) getWorkspaceRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Azure Synapse Workspace should disable public network access",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.Workspace)
-				return string(*c.Properties.PublicNetworkAccess) == "Enabled", ""
+				return string(*c.Properties.PublicNetworkAccess) == "Enabled", "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
+			Url:         "https://learn.microsoft.com/en-us/security/benchmark/azure/baselines/azure-synapse-analytics-security-baseline?toc=%2Fazure%2Fsynapse-analytics%2Ftoc.json",
+			Remediation: "az resource update --ids {resource_id} --set properties.publicNetworkAccess=Disabled",
+		},
+		"synw-008": {
+			Id:             "synw-008",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Synapse Workspace should have data exfiltration protection enabled",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsynapse.Workspace)
+				// Data exfiltration protection requires a managed VNET, so only the first failure is reported.
+				if c.Properties.ManagedVirtualNetwork == nil || *c.Properties.ManagedVirtualNetwork == "" {
+					return true, "Managed VNET not configured", nil
+				}
+				settings := c.Properties.ManagedVirtualNetworkSettings
+				if settings == nil || settings.PreventDataExfiltration == nil || !*settings.PreventDataExfiltration {
+					return true, "Data exfiltration protection disabled", nil
+				}
+				return false, fmt.Sprintf("%d allowed tenants", len(settings.AllowedAADTenantIDsForLinking)), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/synapse-analytics/security/workspace-data-exfiltration-protection",
 		},
 	}
 }
@@ -112,10 +141,10 @@ func (a *SynapseWorkspaceScanner) getSparkPoolRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Synapse Spark Pool Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.BigDataPoolResourceInfo)
 				caf := strings.HasPrefix(*c.Name, "synsp")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -124,8 +153,8 @@ func (a *SynapseWorkspaceScanner) getSparkPoolRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Synapse Spark Pool SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -134,11 +163,14 @@ func (a *SynapseWorkspaceScanner) getSparkPoolRules() map[string]scanners.AzureR
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Synapse Spark Pool should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.BigDataPoolResourceInfo)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 	}
 }
@@ -150,10 +182,10 @@ func (a *SynapseWorkspaceScanner) getSqlPoolRules() map[string]scanners.AzureRul
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Synapse Dedicated SQL Pool Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.SQLPool)
 				caf := strings.HasPrefix(*c.Name, "syndp")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -162,8 +194,8 @@ func (a *SynapseWorkspaceScanner) getSqlPoolRules() map[string]scanners.AzureRul
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Azure Synapse Dedicated SQL Pool SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.9%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.9%", nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -172,11 +204,62 @@ func (a *SynapseWorkspaceScanner) getSqlPoolRules() map[string]scanners.AzureRul
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Azure Synapse Dedicated SQL Pool should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsynapse.SQLPool)
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
+			},
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
+		},
+		"syndp-004": {
+			Id:             "syndp-004",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Synapse Dedicated SQL Pool should have transparent data encryption enabled",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsynapse.SQLPool)
+				encryption, ok := scanContext.SynapseSQLPoolEncryptions[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("transparent data encryption not available for %s", *c.Name)
+				}
+				enabled := encryption != nil && encryption.Properties != nil && encryption.Properties.Status != nil &&
+					*encryption.Properties.Status == armsynapse.TransparentDataEncryptionStatusEnabled
+				return !enabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/synapse-analytics/security/workspaces-encryption",
+		},
+		"syndp-005": {
+			Id:             "syndp-005",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "Azure Synapse Dedicated SQL Pool should have auditing enabled",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armsynapse.SQLPool)
+				policy, ok := scanContext.SynapseSQLPoolAuditingPolicies[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("auditing policy not available for %s", *c.Name)
+				}
+				enabled := policy != nil && policy.Properties != nil && policy.Properties.State != nil &&
+					*policy.Properties.State == armsynapse.BlobAuditingPolicyStateEnabled
+				return !enabled, "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/synapse-analytics/sql/sql-pool-auditing",
+		},
+		"syndp-006": {
+			Id:             "syndp-006",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Azure Synapse Dedicated SQL Pool SKU",
+			Impact:         scanners.ImpactLow,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armsynapse.SQLPool)
-				return len(c.Tags) == 0, ""
+				if c.SKU != nil && c.SKU.Name != nil {
+					return false, *c.SKU.Name, nil
+				}
+				return false, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url: "https://learn.microsoft.com/en-us/azure/synapse-analytics/sql-data-warehouse/memory-concurrency-limits",
 		},
 	}
 }
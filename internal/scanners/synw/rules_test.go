@@ -4,6 +4,7 @@
 package synw
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -142,12 +143,68 @@ func TestSynapseWorkspaceScanner_WorkspaceRules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "SynapseWorkspaceScanner Data Exfiltration without Managed VNET",
+			fields: fields{
+				rule: "synw-008",
+				target: &armsynapse.Workspace{
+					Properties: &armsynapse.WorkspaceProperties{},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "Managed VNET not configured",
+			},
+		},
+		{
+			name: "SynapseWorkspaceScanner Data Exfiltration disabled",
+			fields: fields{
+				rule: "synw-008",
+				target: &armsynapse.Workspace{
+					Properties: &armsynapse.WorkspaceProperties{
+						ManagedVirtualNetwork: to.Ptr("default"),
+						ManagedVirtualNetworkSettings: &armsynapse.ManagedVirtualNetworkSettings{
+							PreventDataExfiltration: to.Ptr(false),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "Data exfiltration protection disabled",
+			},
+		},
+		{
+			name: "SynapseWorkspaceScanner Data Exfiltration enabled",
+			fields: fields{
+				rule: "synw-008",
+				target: &armsynapse.Workspace{
+					Properties: &armsynapse.WorkspaceProperties{
+						ManagedVirtualNetwork: to.Ptr("default"),
+						ManagedVirtualNetworkSettings: &armsynapse.ManagedVirtualNetworkSettings{
+							PreventDataExfiltration:       to.Ptr(true),
+							AllowedAADTenantIDsForLinking: []*string{to.Ptr("tenant")},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "1 allowed tenants",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SynapseWorkspaceScanner{}
 			rules := s.getWorkspaceRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SynapseWorkspaceScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -205,7 +262,10 @@ func TestSynapseWorkspaceScanner_SparkPoolRules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SynapseWorkspaceScanner{}
 			rules := s.getSparkPoolRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SynapseSparkPoolScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -258,12 +318,123 @@ func TestSynapseWorkspaceScanner_SqlPoolRules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "SynapseSqlPoolScanner TDE disabled",
+			fields: fields{
+				rule: "syndp-004",
+				target: &armsynapse.SQLPool{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Synapse/workspaces/synw/sqlPools/syndp"),
+					Name: to.Ptr("syndp"),
+				},
+				scanContext: &scanners.ScanContext{
+					SynapseSQLPoolEncryptions: map[string]*armsynapse.TransparentDataEncryption{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.synapse/workspaces/synw/sqlpools/syndp": {
+							Properties: &armsynapse.TransparentDataEncryptionProperties{
+								Status: to.Ptr(armsynapse.TransparentDataEncryptionStatusDisabled),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "SynapseSqlPoolScanner TDE enabled",
+			fields: fields{
+				rule: "syndp-004",
+				target: &armsynapse.SQLPool{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Synapse/workspaces/synw/sqlPools/syndp"),
+					Name: to.Ptr("syndp"),
+				},
+				scanContext: &scanners.ScanContext{
+					SynapseSQLPoolEncryptions: map[string]*armsynapse.TransparentDataEncryption{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.synapse/workspaces/synw/sqlpools/syndp": {
+							Properties: &armsynapse.TransparentDataEncryptionProperties{
+								Status: to.Ptr(armsynapse.TransparentDataEncryptionStatusEnabled),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SynapseSqlPoolScanner auditing disabled",
+			fields: fields{
+				rule: "syndp-005",
+				target: &armsynapse.SQLPool{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Synapse/workspaces/synw/sqlPools/syndp"),
+					Name: to.Ptr("syndp"),
+				},
+				scanContext: &scanners.ScanContext{
+					SynapseSQLPoolAuditingPolicies: map[string]*armsynapse.SQLPoolBlobAuditingPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.synapse/workspaces/synw/sqlpools/syndp": {
+							Properties: &armsynapse.SQLPoolBlobAuditingPolicyProperties{
+								State: to.Ptr(armsynapse.BlobAuditingPolicyStateDisabled),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "SynapseSqlPoolScanner auditing enabled",
+			fields: fields{
+				rule: "syndp-005",
+				target: &armsynapse.SQLPool{
+					ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Synapse/workspaces/synw/sqlPools/syndp"),
+					Name: to.Ptr("syndp"),
+				},
+				scanContext: &scanners.ScanContext{
+					SynapseSQLPoolAuditingPolicies: map[string]*armsynapse.SQLPoolBlobAuditingPolicy{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.synapse/workspaces/synw/sqlpools/syndp": {
+							Properties: &armsynapse.SQLPoolBlobAuditingPolicyProperties{
+								State: to.Ptr(armsynapse.BlobAuditingPolicyStateEnabled),
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "SynapseSqlPoolScanner SKU",
+			fields: fields{
+				rule: "syndp-006",
+				target: &armsynapse.SQLPool{
+					SKU: &armsynapse.SKU{
+						Name: to.Ptr("DW100c"),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "DW100c",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &SynapseWorkspaceScanner{}
 			rules := s.getSqlPoolRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("SynapseSqlPoolScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -274,3 +445,17 @@ func TestSynapseWorkspaceScanner_SqlPoolRules(t *testing.T) {
 		})
 	}
 }
+
+func TestSynapseWorkspaceScanner_SqlPoolRules_NotAvailable(t *testing.T) {
+	pool := &armsynapse.SQLPool{
+		ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Synapse/workspaces/synw/sqlPools/syndp"),
+		Name: to.Ptr("syndp"),
+	}
+	s := &SynapseWorkspaceScanner{}
+	rules := s.getSqlPoolRules()
+	for _, id := range []string{"syndp-004", "syndp-005"} {
+		if _, _, err := rules[id].Eval(context.Background(), pool, &scanners.ScanContext{}); err == nil {
+			t.Errorf("SynapseSqlPoolScanner %s Eval() error = nil, want an error when the pool settings were not read", id)
+		}
+	}
+}
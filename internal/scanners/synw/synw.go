@@ -4,8 +4,11 @@
 package synw
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/synapse/armsynapse"
+	"github.com/rs/zerolog/log"
 )
 
 // SynapseWorkspaceScanner - Scanner for Synapse Analytics Workspace
@@ -14,10 +17,15 @@ type SynapseWorkspaceScanner struct {
 	workspacesClient *armsynapse.WorkspacesClient
 	sparkPoolClient  *armsynapse.BigDataPoolsClient
 	sqlPoolClient    *armsynapse.SQLPoolsClient
+	encryptionClient *armsynapse.SQLPoolTransparentDataEncryptionsClient
+	auditingClient   *armsynapse.SQLPoolBlobAuditingPoliciesClient
 }
 
 // Init - Initializes the SynapseWorkspaceScanner Scanner
 func (a *SynapseWorkspaceScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	a.config = config
 	var err error
 	a.workspacesClient, err = armsynapse.NewWorkspacesClient(config.SubscriptionID, a.config.Cred, a.config.ClientOptions)
@@ -32,6 +40,14 @@ func (a *SynapseWorkspaceScanner) Init(config *scanners.ScannerConfig) error {
 	if err != nil {
 		return err
 	}
+	a.encryptionClient, err = armsynapse.NewSQLPoolTransparentDataEncryptionsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
+	a.auditingClient, err = armsynapse.NewSQLPoolBlobAuditingPoliciesClient(config.SubscriptionID, config.Cred, config.ClientOptions)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -50,8 +66,11 @@ func (a *SynapseWorkspaceScanner) Scan(resourceGroupName string, scanContext *sc
 	sparkPoolRules := a.getSparkPoolRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.SynapseSQLPoolEncryptions = map[string]*armsynapse.TransparentDataEncryption{}
+	scanContext.SynapseSQLPoolAuditingPolicies = map[string]*armsynapse.SQLPoolBlobAuditingPolicy{}
+
 	for _, w := range workspaces {
-		rr := engine.EvaluateRules(rules, w, scanContext)
+		rr := engine.EvaluateRules(a.config.Ctx, rules, w, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   a.config.SubscriptionID,
@@ -60,7 +79,9 @@ func (a *SynapseWorkspaceScanner) Scan(resourceGroupName string, scanContext *sc
 			Location:         *w.Location,
 			Type:             *w.Type,
 			ServiceName:      *w.Name,
+			ID:               *w.ID,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(w),
 		})
 
 		sqlPools, err := a.listSqlPools(resourceGroupName, *w.Name)
@@ -69,17 +90,33 @@ func (a *SynapseWorkspaceScanner) Scan(resourceGroupName string, scanContext *sc
 		}
 
 		for _, s := range sqlPools {
+			encryption, err := a.getEncryption(resourceGroupName, *w.Name, *s.Name)
+			if err != nil {
+				log.Debug().Err(err).Msgf("Failed to get transparent data encryption for %s", *s.ID)
+			} else {
+				scanContext.SynapseSQLPoolEncryptions[strings.ToLower(*s.ID)] = encryption
+			}
+
+			auditing, err := a.getAuditingPolicy(resourceGroupName, *w.Name, *s.Name)
+			if err != nil {
+				log.Debug().Err(err).Msgf("Failed to get auditing policy for %s", *s.ID)
+			} else {
+				scanContext.SynapseSQLPoolAuditingPolicies[strings.ToLower(*s.ID)] = auditing
+			}
+
 			var result scanners.AzureServiceResult
-			rr := engine.EvaluateRules(sqlPoolRules, s, scanContext)
+			rr := engine.EvaluateRules(a.config.Ctx, sqlPoolRules, s, scanContext)
 
 			result = scanners.AzureServiceResult{
 				SubscriptionID:   a.config.SubscriptionID,
 				SubscriptionName: a.config.SubscriptionName,
 				ResourceGroup:    resourceGroupName,
 				ServiceName:      *s.Name,
+				ID:               *s.ID,
 				Type:             *s.Type,
 				Location:         *w.Location,
 				Rules:            rr,
+				CreatedAt:        scanners.GetCreatedAt(s),
 			}
 			results = append(results, result)
 		}
@@ -91,16 +128,18 @@ func (a *SynapseWorkspaceScanner) Scan(resourceGroupName string, scanContext *sc
 
 		for _, s := range sparkPools {
 			var result scanners.AzureServiceResult
-			rr := engine.EvaluateRules(sparkPoolRules, s, scanContext)
+			rr := engine.EvaluateRules(a.config.Ctx, sparkPoolRules, s, scanContext)
 
 			result = scanners.AzureServiceResult{
 				SubscriptionID:   a.config.SubscriptionID,
 				SubscriptionName: a.config.SubscriptionName,
 				ResourceGroup:    resourceGroupName,
 				ServiceName:      *s.Name,
+				ID:               *s.ID,
 				Type:             *s.Type,
 				Location:         *w.Location,
 				Rules:            rr,
+				CreatedAt:        scanners.GetCreatedAt(s),
 			}
 			results = append(results, result)
 		}
@@ -135,6 +174,22 @@ func (a *SynapseWorkspaceScanner) listSqlPools(resourceGroupName string, workspa
 	return results, nil
 }
 
+func (a *SynapseWorkspaceScanner) getEncryption(resourceGroupName, workspace, sqlPool string) (*armsynapse.TransparentDataEncryption, error) {
+	resp, err := a.encryptionClient.Get(a.config.Ctx, resourceGroupName, workspace, sqlPool, armsynapse.TransparentDataEncryptionNameCurrent, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.TransparentDataEncryption, nil
+}
+
+func (a *SynapseWorkspaceScanner) getAuditingPolicy(resourceGroupName, workspace, sqlPool string) (*armsynapse.SQLPoolBlobAuditingPolicy, error) {
+	resp, err := a.auditingClient.Get(a.config.Ctx, resourceGroupName, workspace, sqlPool, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.SQLPoolBlobAuditingPolicy, nil
+}
+
 func (a *SynapseWorkspaceScanner) listSparkPools(resourceGroupName string, workspace string) ([]*armsynapse.BigDataPoolResourceInfo, error) {
 	pager := a.sparkPoolClient.NewListByWorkspacePager(resourceGroupName, workspace, nil)
 	results := make([]*armsynapse.BigDataPoolResourceInfo, 0)
@@ -0,0 +1,67 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RequiredTag - Tag every resource must have. An empty AllowedValues accepts any non empty value.
+type RequiredTag struct {
+	Key           string   `yaml:"key"`
+	AllowedValues []string `yaml:"allowedValues,flow"`
+}
+
+// EvaluateTagPolicy - Returns true, with the missing and invalid tags, if the tags don't comply with
+// the required tags. Without required tags, resources only need at least one tag.
+// Keys and values are compared case insensitively.
+func EvaluateTagPolicy(tags map[string]*string, required []RequiredTag) (bool, string) {
+	if len(required) == 0 {
+		return len(tags) == 0, ""
+	}
+
+	missing := []string{}
+	invalid := []string{}
+	for _, r := range required {
+		value, ok := tagValue(tags, r.Key)
+		if !ok || value == "" {
+			missing = append(missing, r.Key)
+			continue
+		}
+		if len(r.AllowedValues) > 0 && !containsFold(r.AllowedValues, value) {
+			invalid = append(invalid, fmt.Sprintf("%s=%s", r.Key, value))
+		}
+	}
+
+	parts := []string{}
+	if len(missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing: %s", strings.Join(missing, ", ")))
+	}
+	if len(invalid) > 0 {
+		parts = append(parts, fmt.Sprintf("invalid: %s", strings.Join(invalid, ", ")))
+	}
+	return len(parts) > 0, strings.Join(parts, "; ")
+}
+
+func tagValue(tags map[string]*string, key string) (string, bool) {
+	for k, v := range tags {
+		if strings.EqualFold(k, key) {
+			if v == nil {
+				return "", true
+			}
+			return strings.TrimSpace(*v), true
+		}
+	}
+	return "", false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+
+package scanners
+
+import (
+	"testing"
+
+	"github.com/Azure/azqr/internal/to"
+)
+
+func TestEvaluateTagPolicy(t *testing.T) {
+	required := []RequiredTag{
+		{Key: "costcenter"},
+		{Key: "owner"},
+		{Key: "environment", AllowedValues: []string{"prod", "dev"}},
+	}
+	tests := []struct {
+		name       string
+		tags       map[string]*string
+		required   []RequiredTag
+		wantBroken bool
+		wantResult string
+	}{
+		{
+			name:       "no policy and no tags",
+			tags:       map[string]*string{},
+			wantBroken: true,
+		},
+		{
+			name:       "no policy and tags",
+			tags:       map[string]*string{"any": to.Ptr("value")},
+			wantBroken: false,
+		},
+		{
+			name:       "all required tags",
+			tags:       map[string]*string{"CostCenter": to.Ptr("1234"), "owner": to.Ptr("team"), "environment": to.Ptr("Prod")},
+			required:   required,
+			wantBroken: false,
+		},
+		{
+			name:       "missing tags",
+			tags:       map[string]*string{"owner": to.Ptr(" "), "environment": to.Ptr("dev")},
+			required:   required,
+			wantBroken: true,
+			wantResult: "missing: costcenter, owner",
+		},
+		{
+			name:       "wrong value",
+			tags:       map[string]*string{"costcenter": to.Ptr("1234"), "owner": to.Ptr("team"), "environment": to.Ptr("qa")},
+			required:   required,
+			wantBroken: true,
+			wantResult: "invalid: environment=qa",
+		},
+		{
+			name:       "missing and wrong value",
+			tags:       map[string]*string{"environment": to.Ptr("qa")},
+			required:   required,
+			wantBroken: true,
+			wantResult: "missing: costcenter, owner; invalid: environment=qa",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broken, result := EvaluateTagPolicy(tt.tags, tt.required)
+			if broken != tt.wantBroken || result != tt.wantResult {
+				t.Errorf("EvaluateTagPolicy() = %v, %q, want %v, %q", broken, result, tt.wantBroken, tt.wantResult)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package traf
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -19,20 +21,23 @@ func (a *TrafficManagerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Traffic Manager should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armtrafficmanager.Profile)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-diagnostic-logs",
+			Url:             "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-diagnostic-logs",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"traf-002": {
 			Id:             "traf-002",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Traffic Manager should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, ""
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/architecture/high-availability/reference-architecture-traffic-manager-application-gateway",
 		},
@@ -41,8 +46,8 @@ func (a *TrafficManagerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Traffic Manager should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
-				return false, "99.99%"
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				return false, "99.99%", nil
 			},
 			Url: "https://www.azure.cn/en-us/support/sla/traffic-manager/",
 		},
@@ -51,10 +56,10 @@ func (a *TrafficManagerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Traffic Manager Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armtrafficmanager.Profile)
 				caf := strings.HasPrefix(*c.Name, "traf")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -63,18 +68,21 @@ func (a *TrafficManagerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Traffic Manager should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armtrafficmanager.Profile)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"traf-008": {
 			Id:             "traf-008",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Traffic Manager should use at least 2 endpoints",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armtrafficmanager.Profile)
 				endpoints := 0
 				for _, endpoint := range c.Properties.Endpoints {
@@ -82,7 +90,7 @@ func (a *TrafficManagerScanner) GetRules() map[string]scanners.AzureRule {
 						endpoints++
 					}
 				}
-				return endpoints < 2, ""
+				return endpoints < 2, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-endpoint-types",
 		},
@@ -91,12 +99,42 @@ func (a *TrafficManagerScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategorySecurity,
 			Recommendation: "Traffic Manager: HTTP endpoints should be monitored using HTTPS",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armtrafficmanager.Profile)
 				httpMonitor := *c.Properties.MonitorConfig.Port == int64(80) || *c.Properties.MonitorConfig.Port == int64(443)
-				return httpMonitor && c.Properties.MonitorConfig.Protocol != to.Ptr(armtrafficmanager.MonitorProtocolHTTPS), ""
+				return httpMonitor && c.Properties.MonitorConfig.Protocol != to.Ptr(armtrafficmanager.MonitorProtocolHTTPS), "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-monitoring",
 		},
+		"traf-010": {
+			Id:             "traf-010",
+			Category:       scanners.RulesCategoryOtherBestPractices,
+			Recommendation: "Traffic Manager should not nest profiles more than 2 levels deep",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armtrafficmanager.Profile)
+				depth, ok := scanContext.TrafficManagerNestingDepth[strings.ToLower(*c.ID)]
+				if !ok {
+					return false, "", fmt.Errorf("nesting depth not available for %s", *c.Name)
+				}
+				return depth > 2, fmt.Sprintf("%d", depth), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-nested-profiles",
+		},
+		"traf-011": {
+			Id:             "traf-011",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Traffic Manager should tolerate failed health checks before failing over (3 recommended)",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				c := target.(*armtrafficmanager.Profile)
+				tolerated := int64(0)
+				if c.Properties != nil && c.Properties.MonitorConfig != nil && c.Properties.MonitorConfig.ToleratedNumberOfFailures != nil {
+					tolerated = *c.Properties.MonitorConfig.ToleratedNumberOfFailures
+				}
+				return tolerated == 0, fmt.Sprintf("%d", tolerated), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/traffic-manager/traffic-manager-monitoring#configure-endpoint-monitoring",
+		},
 	}
 }
@@ -4,6 +4,7 @@
 package traf
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -175,12 +176,81 @@ func TestTrafficManagerScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "TrafficManagerScanner nested profiles too deep",
+			fields: fields{
+				rule: "traf-010",
+				target: &armtrafficmanager.Profile{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					TrafficManagerNestingDepth: map[string]int{"test": 3},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "3",
+			},
+		},
+		{
+			name: "TrafficManagerScanner nested profiles",
+			fields: fields{
+				rule: "traf-010",
+				target: &armtrafficmanager.Profile{
+					ID: to.Ptr("test"),
+				},
+				scanContext: &scanners.ScanContext{
+					TrafficManagerNestingDepth: map[string]int{"test": 2},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "2",
+			},
+		},
+		{
+			name: "TrafficManagerScanner no tolerated failures",
+			fields: fields{
+				rule: "traf-011",
+				target: &armtrafficmanager.Profile{
+					Properties: &armtrafficmanager.ProfileProperties{
+						MonitorConfig: &armtrafficmanager.MonitorConfig{},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "0",
+			},
+		},
+		{
+			name: "TrafficManagerScanner tolerated failures",
+			fields: fields{
+				rule: "traf-011",
+				target: &armtrafficmanager.Profile{
+					Properties: &armtrafficmanager.ProfileProperties{
+						MonitorConfig: &armtrafficmanager.MonitorConfig{
+							ToleratedNumberOfFailures: to.Ptr(int64(3)),
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "3",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &TrafficManagerScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("TrafficManagerScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -191,3 +261,73 @@ func TestTrafficManagerScanner_Rules(t *testing.T) {
 		})
 	}
 }
+
+func TestNestingDepth(t *testing.T) {
+	nested := func(ids ...string) *armtrafficmanager.Profile {
+		endpoints := []*armtrafficmanager.Endpoint{
+			{
+				Type:       to.Ptr("Microsoft.Network/trafficManagerProfiles/azureEndpoints"),
+				Properties: &armtrafficmanager.EndpointProperties{TargetResourceID: to.Ptr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/app")},
+			},
+		}
+		for _, id := range ids {
+			endpoints = append(endpoints, &armtrafficmanager.Endpoint{
+				Type:       to.Ptr("Microsoft.Network/trafficManagerProfiles/nestedEndpoints"),
+				Properties: &armtrafficmanager.EndpointProperties{TargetResourceID: to.Ptr(id)},
+			})
+		}
+		return &armtrafficmanager.Profile{Properties: &armtrafficmanager.ProfileProperties{Endpoints: endpoints}}
+	}
+	profiles := map[string]*armtrafficmanager.Profile{
+		"level1":  nested("level2a", "level2b"),
+		"level2a": nested(),
+		"level2b": nested("level3"),
+		"level3":  nested("level4"),
+		"level4":  nested(),
+		"single":  nested("level4"),
+	}
+	calls := 0
+	get := func(id string) (*armtrafficmanager.Profile, error) {
+		calls++
+		return profiles[id], nil
+	}
+
+	tests := []struct {
+		profile   string
+		want      int
+		wantCalls int
+	}{
+		{profile: "level4", want: 1, wantCalls: 0},
+		{profile: "single", want: 2, wantCalls: 1},
+		{profile: "level2b", want: 3, wantCalls: 2},
+		{profile: "level1", want: 3, wantCalls: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			calls = 0
+			got, err := nestingDepth(profiles[tt.profile], get, maxNestingDepth)
+			if err != nil {
+				t.Fatalf("nestingDepth() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("nestingDepth() = %v, want %v", got, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("nestingDepth() resolved %d profiles, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestTrafficManagerScanner_NestingDepth_NotAvailable(t *testing.T) {
+	target := &armtrafficmanager.Profile{
+		ID:   to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/trafficManagerProfiles/tm"),
+		Name: to.Ptr("tm"),
+	}
+
+	s := &TrafficManagerScanner{}
+	rules := s.GetRules()
+	if _, _, err := rules["traf-010"].Eval(context.Background(), target, &scanners.ScanContext{}); err == nil {
+		t.Error("TrafficManagerScanner Rule.Eval() traf-010 error = nil, want an error when the nesting depth is not available")
+	}
+}
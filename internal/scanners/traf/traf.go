@@ -4,10 +4,17 @@
 package traf
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/trafficmanager/armtrafficmanager"
+	"github.com/rs/zerolog/log"
 )
 
+// maxNestingDepth - Depth at which nested profiles stop being resolved
+const maxNestingDepth = 3
+
 // TrafficManagerScanner - Scanner for TrafficManager
 type TrafficManagerScanner struct {
 	config *scanners.ScannerConfig
@@ -16,6 +23,9 @@ type TrafficManagerScanner struct {
 
 // Init - Initializes the TrafficManager
 func (c *TrafficManagerScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.client, err = armtrafficmanager.NewClientFactory(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +45,32 @@ func (c *TrafficManagerScanner) Scan(resourceGroupName string, scanContext *scan
 	results := []scanners.AzureServiceResult{}
 
 	for _, w := range vnets {
-		rr := engine.EvaluateRules(rules, w, scanContext)
+		// traf-010 is skipped for the profile when a nested profile can't be read (e.g. in another subscription)
+		depth, err := nestingDepth(w, c.getProfile, maxNestingDepth)
+		scanContext.Lock()
+		if scanContext.TrafficManagerNestingDepth == nil {
+			scanContext.TrafficManagerNestingDepth = map[string]int{}
+		}
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to get the nested profiles of Traffic Manager %s", *w.Name)
+			delete(scanContext.TrafficManagerNestingDepth, strings.ToLower(*w.ID))
+		} else {
+			scanContext.TrafficManagerNestingDepth[strings.ToLower(*w.ID)] = depth
+		}
+		scanContext.Unlock()
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, w, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
-			SubscriptionID: c.config.SubscriptionID,
+			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
-			ResourceGroup:  resourceGroupName,
-			ServiceName:    *w.Name,
-			Type:           *w.Type,
-			Location:       *w.Location,
-			Rules:          rr,
+			ResourceGroup:    resourceGroupName,
+			ServiceName:      *w.Name,
+			ID:               *w.ID,
+			Type:             *w.Type,
+			Location:         *w.Location,
+			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(w),
 		})
 	}
 	return results, nil
@@ -63,3 +89,50 @@ func (c *TrafficManagerScanner) list(resourceGroupName string) ([]*armtrafficman
 	}
 	return vnets, nil
 }
+
+func (c *TrafficManagerScanner) getProfile(profileID string) (*armtrafficmanager.Profile, error) {
+	id, err := arm.ParseResourceID(profileID)
+	if err != nil {
+		return nil, err
+	}
+	// Nested profiles may live in another subscription
+	client, err := armtrafficmanager.NewProfilesClient(id.SubscriptionID, c.config.Cred, c.config.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Get(c.config.Ctx, id.ResourceGroupName, id.Name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Profile, nil
+}
+
+// nestingDepth - Returns the number of levels of the profile hierarchy (1 without nested endpoints),
+// resolving the nested profiles with get up to maxDepth levels
+func nestingDepth(profile *armtrafficmanager.Profile, get func(profileID string) (*armtrafficmanager.Profile, error), maxDepth int) (int, error) {
+	if maxDepth <= 1 || profile.Properties == nil {
+		return 1, nil
+	}
+
+	depth := 1
+	for _, e := range profile.Properties.Endpoints {
+		if e.Type == nil || !strings.HasSuffix(strings.ToLower(*e.Type), "/nestedendpoints") {
+			continue
+		}
+		if e.Properties == nil || e.Properties.TargetResourceID == nil {
+			continue
+		}
+		child, err := get(*e.Properties.TargetResourceID)
+		if err != nil {
+			return 0, err
+		}
+		d, err := nestingDepth(child, get, maxDepth-1)
+		if err != nil {
+			return 0, err
+		}
+		if d+1 > depth {
+			depth = d + 1
+		}
+	}
+	return depth, nil
+}
@@ -4,6 +4,8 @@
 package vgw
 
 import (
+	"context"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -23,27 +25,30 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 			Category:       scanners.RulesCategoryMonitoringAndAlerting,
 			Recommendation: "Virtual Network Gateway should have diagnostic settings enabled",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Tags:           []string{"CIS", "logging"},
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				service := target.(*armnetwork.VirtualNetworkGateway)
 				_, ok := scanContext.DiagnosticsSettings[strings.ToLower(*service.ID)]
-				return !ok, ""
+				return !ok, "", nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/monitor-vpn-gateway",
+			Url:             "https://learn.microsoft.com/en-us/azure/vpn-gateway/monitor-vpn-gateway",
+			Remediation:     "az monitor diagnostic-settings create --name azqr --resource {resource_id} --workspace <workspace_id> --logs '[{\"categoryGroup\": \"allLogs\", \"enabled\": true}]'",
+			AutoRemediation: scanners.AutoRemediationDiagnosticSettings,
 		},
 		"vgw-002": {
 			Id:             "vgw-002",
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Virtual Network Gateway Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.VirtualNetworkGateway)
 				switch *c.Properties.GatewayType {
 				case armnetwork.VirtualNetworkGatewayTypeVPN:
-					return !strings.HasPrefix(*c.Name, "vpng"), ""
+					return !strings.HasPrefix(*c.Name, "vpng"), "", nil
 				case armnetwork.VirtualNetworkGatewayTypeExpressRoute:
-					return !strings.HasPrefix(*c.Name, "ergw"), ""
+					return !strings.HasPrefix(*c.Name, "ergw"), "", nil
 				default:
-					return !strings.HasPrefix(*c.Name, "lgw"), ""
+					return !strings.HasPrefix(*c.Name, "lgw"), "", nil
 				}
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
@@ -53,25 +58,28 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Virtual Network Gateway should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armnetwork.VirtualNetworkGateway)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"vgw-004": {
 			Id:             "vgw-004",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Virtual Network Gateway should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.VirtualNetworkGateway)
 				sku := string(*g.Properties.SKU.Tier)
 				sla := "99.9%"
 				if sku != string(armnetwork.VirtualNetworkGatewaySKUTierBasic) {
 					sla = "99.95%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services",
 		},
@@ -80,12 +88,118 @@ func (a *VirtualNetworkGatewayScanner) GetVirtualNetworkGatewayRules() map[strin
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Storage should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				g := target.(*armnetwork.VirtualNetworkGateway)
 				sku := string(*g.Properties.SKU.Name)
-				return !strings.HasSuffix(strings.ToLower(sku), "az"), ""
+				return !strings.HasSuffix(strings.ToLower(sku), "az"), "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/create-zone-redundant-vnet-gateway",
 		},
+		"vgw-006": {
+			Id:             "vgw-006",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Active-active VPN Gateway should have BGP enabled for route advertisement",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				if !isVPNGateway(g) || !isActiveActive(g) {
+					return false, "", nil
+				}
+				return !isBgpEnabled(g) || !hasBgpPeeringPerInstance(g), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/active-active-portal",
+		},
+		"vgw-007": {
+			Id:             "vgw-007",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "VPN Gateway with BGP enabled should be active-active",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				if !isVPNGateway(g) || !isBgpEnabled(g) {
+					return false, "", nil
+				}
+				return !isActiveActive(g), "", nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/vpn-gateway-highlyavailable",
+		},
+		"vgw-008": {
+			Id:             "vgw-008",
+			Category:       scanners.RulesCategoryHighAvailability,
+			Recommendation: "Active-active VPN Gateway should have an IP configuration for each instance",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				if !isVPNGateway(g) || !isActiveActive(g) {
+					return false, "", nil
+				}
+				count := len(g.Properties.IPConfigurations)
+				return count < 2, strconv.Itoa(count), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/active-active-portal",
+		},
+		"vgw-009": {
+			Id:             "vgw-009",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "VPN Gateway point-to-site configuration should use IKEv2",
+			Impact:         scanners.ImpactHigh,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				if !isVPNGateway(g) || g.Properties.VPNClientConfiguration == nil || len(g.Properties.VPNClientConfiguration.VPNClientProtocols) == 0 {
+					return false, "", nil
+				}
+				ikev2 := false
+				protocols := []string{}
+				for _, p := range g.Properties.VPNClientConfiguration.VPNClientProtocols {
+					if p == nil {
+						continue
+					}
+					if *p == armnetwork.VPNClientProtocolIkeV2 {
+						ikev2 = true
+					}
+					protocols = append(protocols, string(*p))
+				}
+				return !ikev2, strings.Join(protocols, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/point-to-site-about",
+		},
+		"vgw-010": {
+			Id:             "vgw-010",
+			Category:       scanners.RulesCategorySecurity,
+			Recommendation: "VPN Gateway site-to-site connections should use custom IPsec/IKE policies",
+			Impact:         scanners.ImpactMedium,
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
+				g := target.(*armnetwork.VirtualNetworkGateway)
+				if !isVPNGateway(g) {
+					return false, "", nil
+				}
+				defaults := []string{}
+				for _, c := range scanContext.VPNGatewayConnections[strings.ToLower(*g.ID)] {
+					if c.IPsecPolicies == 0 {
+						defaults = append(defaults, c.Name)
+					}
+				}
+				return len(defaults) > 0, strings.Join(defaults, ", "), nil
+			},
+			Url: "https://learn.microsoft.com/en-us/azure/vpn-gateway/ipsec-ike-policy-howto",
+		},
 	}
 }
+
+func isVPNGateway(g *armnetwork.VirtualNetworkGateway) bool {
+	return g.Properties != nil && g.Properties.GatewayType != nil && *g.Properties.GatewayType == armnetwork.VirtualNetworkGatewayTypeVPN
+}
+
+func isActiveActive(g *armnetwork.VirtualNetworkGateway) bool {
+	return g.Properties.Active != nil && *g.Properties.Active
+}
+
+func isBgpEnabled(g *armnetwork.VirtualNetworkGateway) bool {
+	return g.Properties.EnableBgp != nil && *g.Properties.EnableBgp
+}
+
+// hasBgpPeeringPerInstance - Active-active gateways need a BGP peering address for each instance
+func hasBgpPeeringPerInstance(g *armnetwork.VirtualNetworkGateway) bool {
+	bgp := g.Properties.BgpSettings
+	return bgp != nil && len(bgp.BgpPeeringAddresses) >= 2
+}
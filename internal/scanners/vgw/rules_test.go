@@ -4,6 +4,7 @@
 package vgw
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -130,12 +131,261 @@ func TestVirtualNetworkGatewayScanner_Rules(t *testing.T) {
 				result: "",
 			},
 		},
+		{
+			name: "VirtualNetworkGatewayScanner Active-active without BGP",
+			fields: fields{
+				rule: "vgw-006",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						Active:      to.Ptr(true),
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner Active-active with BGP",
+			fields: fields{
+				rule: "vgw-006",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						Active:      to.Ptr(true),
+						EnableBgp:   to.Ptr(true),
+						BgpSettings: &armnetwork.BgpSettings{
+							Asn: to.Ptr(int64(65515)),
+							BgpPeeringAddresses: []*armnetwork.IPConfigurationBgpPeeringAddress{
+								{},
+								{},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner Active-active with a single BGP peering address",
+			fields: fields{
+				rule: "vgw-006",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						Active:      to.Ptr(true),
+						EnableBgp:   to.Ptr(true),
+						BgpSettings: &armnetwork.BgpSettings{
+							Asn: to.Ptr(int64(65515)),
+							BgpPeeringAddresses: []*armnetwork.IPConfigurationBgpPeeringAddress{
+								{},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner BGP without active-active",
+			fields: fields{
+				rule: "vgw-007",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						Active:      to.Ptr(false),
+						EnableBgp:   to.Ptr(true),
+						BgpSettings: &armnetwork.BgpSettings{
+							Asn: to.Ptr(int64(65515)),
+							BgpPeeringAddresses: []*armnetwork.IPConfigurationBgpPeeringAddress{
+								{},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner BGP with active-active",
+			fields: fields{
+				rule: "vgw-007",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						Active:      to.Ptr(true),
+						EnableBgp:   to.Ptr(true),
+						BgpSettings: &armnetwork.BgpSettings{
+							Asn: to.Ptr(int64(65515)),
+							BgpPeeringAddresses: []*armnetwork.IPConfigurationBgpPeeringAddress{
+								{},
+								{},
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner Active-active with single IP configuration",
+			fields: fields{
+				rule: "vgw-008",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						Active:      to.Ptr(true),
+						IPConfigurations: []*armnetwork.VirtualNetworkGatewayIPConfiguration{
+							{},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "1",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner Active-active with IP configurations",
+			fields: fields{
+				rule: "vgw-008",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						Active:      to.Ptr(true),
+						IPConfigurations: []*armnetwork.VirtualNetworkGatewayIPConfiguration{
+							{},
+							{},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "2",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner point-to-site without IKEv2",
+			fields: fields{
+				rule: "vgw-009",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						VPNClientConfiguration: &armnetwork.VPNClientConfiguration{
+							VPNClientProtocols: []*armnetwork.VPNClientProtocol{
+								to.Ptr(armnetwork.VPNClientProtocolSSTP),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: true,
+				result: "SSTP",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner point-to-site with IKEv2",
+			fields: fields{
+				rule: "vgw-009",
+				target: &armnetwork.VirtualNetworkGateway{
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+						VPNClientConfiguration: &armnetwork.VPNClientConfiguration{
+							VPNClientProtocols: []*armnetwork.VPNClientProtocol{
+								to.Ptr(armnetwork.VPNClientProtocolIkeV2),
+								to.Ptr(armnetwork.VPNClientProtocolOpenVPN),
+							},
+						},
+					},
+				},
+				scanContext: &scanners.ScanContext{},
+			},
+			want: want{
+				broken: false,
+				result: "IkeV2, OpenVPN",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner site-to-site connections with default IPsec policies",
+			fields: fields{
+				rule: "vgw-010",
+				target: &armnetwork.VirtualNetworkGateway{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/virtualNetworkGateways/VGW"),
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					VPNGatewayConnections: map[string][]scanners.VPNGatewayConnection{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/virtualnetworkgateways/vgw": {
+							{Name: "branch1", IPsecPolicies: 0},
+							{Name: "branch2", IPsecPolicies: 1},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: true,
+				result: "branch1",
+			},
+		},
+		{
+			name: "VirtualNetworkGatewayScanner site-to-site connections with custom IPsec policies",
+			fields: fields{
+				rule: "vgw-010",
+				target: &armnetwork.VirtualNetworkGateway{
+					ID: to.Ptr("/subscriptions/x/resourceGroups/rg/providers/Microsoft.Network/virtualNetworkGateways/vgw"),
+					Properties: &armnetwork.VirtualNetworkGatewayPropertiesFormat{
+						GatewayType: to.Ptr(armnetwork.VirtualNetworkGatewayTypeVPN),
+					},
+				},
+				scanContext: &scanners.ScanContext{
+					VPNGatewayConnections: map[string][]scanners.VPNGatewayConnection{
+						"/subscriptions/x/resourcegroups/rg/providers/microsoft.network/virtualnetworkgateways/vgw": {
+							{Name: "branch1", IPsecPolicies: 1},
+						},
+					},
+				},
+			},
+			want: want{
+				broken: false,
+				result: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &VirtualNetworkGatewayScanner{}
 			rules := s.GetVirtualNetworkGatewayRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("VirtualNetworkGatewayScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
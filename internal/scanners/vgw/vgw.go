@@ -4,6 +4,8 @@
 package vgw
 
 import (
+	"strings"
+
 	"github.com/Azure/azqr/internal/scanners"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
 )
@@ -16,6 +18,9 @@ type VirtualNetworkGatewayScanner struct {
 
 // Init - Initializes the VPN Gateway
 func (c *VirtualNetworkGatewayScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.client, err = armnetwork.NewVirtualNetworkGatewaysClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -34,17 +39,29 @@ func (c *VirtualNetworkGatewayScanner) Scan(resourceGroupName string, scanContex
 	rules := c.GetVirtualNetworkGatewayRules()
 	results := []scanners.AzureServiceResult{}
 
+	scanContext.VPNGatewayConnections = map[string][]scanners.VPNGatewayConnection{}
+
 	for _, w := range vpns {
-		rr := engine.EvaluateRules(rules, w, scanContext)
+		if w.Properties != nil && w.Properties.GatewayType != nil && *w.Properties.GatewayType == armnetwork.VirtualNetworkGatewayTypeVPN {
+			connections, err := c.listConnections(resourceGroupName, *w.Name)
+			if err != nil {
+				return nil, err
+			}
+			scanContext.VPNGatewayConnections[strings.ToLower(*w.ID)] = connections
+		}
+
+		rr := engine.EvaluateRules(c.config.Ctx, rules, w, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *w.Name,
+			ID:               *w.ID,
 			Type:             *w.Type,
 			Location:         *w.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(w),
 		})
 	}
 	return results, nil
@@ -63,3 +80,27 @@ func (c *VirtualNetworkGatewayScanner) listVirtualNetworkGateways(resourceGroupN
 	}
 	return vpns, nil
 }
+
+// listConnections - Lists the site-to-site connections of a VPN Gateway
+func (c *VirtualNetworkGatewayScanner) listConnections(resourceGroupName, gatewayName string) ([]scanners.VPNGatewayConnection, error) {
+	pager := c.client.NewListConnectionsPager(resourceGroupName, gatewayName, nil)
+
+	connections := []scanners.VPNGatewayConnection{}
+	for pager.More() {
+		resp, err := pager.NextPage(c.config.Ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, conn := range resp.Value {
+			p := conn.Properties
+			if p == nil || p.ConnectionType == nil || *p.ConnectionType != armnetwork.VirtualNetworkGatewayConnectionTypeIPsec {
+				continue
+			}
+			connections = append(connections, scanners.VPNGatewayConnection{
+				Name:          *conn.Name,
+				IPsecPolicies: len(p.IPSecPolicies),
+			})
+		}
+	}
+	return connections, nil
+}
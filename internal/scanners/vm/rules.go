@@ -4,6 +4,7 @@
 package vm
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,10 +19,10 @@ func (a *VirtualMachineScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Virtual Machine should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				v := target.(*armcompute.VirtualMachine)
 				hasZones := v.Zones != nil && len(v.Zones) >= 1
-				return !hasZones, ""
+				return !hasZones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/availability#availability-zones",
 		},
@@ -30,7 +31,7 @@ func (a *VirtualMachineScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Virtual Machine should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				v := target.(*armcompute.VirtualMachine)
 				sla := "99.9%"
 				hasScaleSet := v.Properties.VirtualMachineScaleSet != nil && v.Properties.VirtualMachineScaleSet.ID != nil
@@ -41,7 +42,7 @@ func (a *VirtualMachineScanner) GetRules() map[string]scanners.AzureRule {
 				} else if hasZones {
 					sla = "99.99%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
@@ -50,10 +51,10 @@ func (a *VirtualMachineScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Virtual Machine Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcompute.VirtualMachine)
 				caf := strings.HasPrefix(*c.Name, "vm")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -62,21 +63,24 @@ func (a *VirtualMachineScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Virtual Machine should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcompute.VirtualMachine)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 		"vm-008": {
 			Id:             "vm-008",
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Virtual Machine should use managed disks",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcompute.VirtualMachine)
 				hasManagedDisks := c.Properties.StorageProfile.OSDisk.ManagedDisk != nil
-				return !hasManagedDisks, ""
+				return !hasManagedDisks, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/architecture/checklist/resiliency-per-service#virtual-machines",
 		},
@@ -85,10 +89,10 @@ func (a *VirtualMachineScanner) GetRules() map[string]scanners.AzureRule {
 			Category:       scanners.RulesCategoryScalability,
 			Recommendation: "Virtual Machine should host application or database data on a data disk",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcompute.VirtualMachine)
 				hasDataDisks := len(c.Properties.StorageProfile.DataDisks) > 0
-				return !hasDataDisks, ""
+				return !hasDataDisks, "", nil
 			},
 			Url: "https://learn.microsoft.com/azure/virtual-machines/managed-disks-overview#data-disk",
 		},
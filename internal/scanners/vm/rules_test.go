@@ -4,6 +4,7 @@
 package vm
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -156,7 +157,10 @@ func TestVirtualMachineScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &VirtualMachineScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("VirtualMachineScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
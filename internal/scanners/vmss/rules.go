@@ -4,6 +4,7 @@
 package vmss
 
 import (
+	"context"
 	"strings"
 
 	"github.com/Azure/azqr/internal/scanners"
@@ -18,10 +19,10 @@ func (a *VirtualMachineScaleSetScanner) GetRules() map[string]scanners.AzureRule
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Virtual Machine should have availability zones enabled",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				v := target.(*armcompute.VirtualMachineScaleSet)
 				hasZones := v.Zones != nil && len(v.Zones) > 1
-				return !hasZones, ""
+				return !hasZones, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/virtual-machines/availability#availability-zones",
 		},
@@ -30,14 +31,14 @@ func (a *VirtualMachineScaleSetScanner) GetRules() map[string]scanners.AzureRule
 			Category:       scanners.RulesCategoryHighAvailability,
 			Recommendation: "Virtual Machine should have a SLA",
 			Impact:         scanners.ImpactHigh,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				v := target.(*armcompute.VirtualMachineScaleSet)
 				sla := "99.95%"
 				hasZones := len(v.Zones) > 1
 				if hasZones {
 					sla = "99.99%"
 				}
-				return false, sla
+				return false, sla, nil
 			},
 			Url: "https://www.microsoft.com/licensing/docs/view/Service-Level-Agreements-SLA-for-Online-Services?lang=1",
 		},
@@ -46,10 +47,10 @@ func (a *VirtualMachineScaleSetScanner) GetRules() map[string]scanners.AzureRule
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Virtual Machine Scale Set Name should comply with naming conventions",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcompute.VirtualMachineScaleSet)
 				caf := strings.HasPrefix(*c.Name, "vmss")
-				return !caf, ""
+				return !caf, "", nil
 			},
 			Url: "https://learn.microsoft.com/en-us/azure/cloud-adoption-framework/ready/azure-best-practices/resource-abbreviations",
 		},
@@ -58,11 +59,14 @@ func (a *VirtualMachineScaleSetScanner) GetRules() map[string]scanners.AzureRule
 			Category:       scanners.RulesCategoryGovernance,
 			Recommendation: "Virtual Machine Scale Set should have tags",
 			Impact:         scanners.ImpactLow,
-			Eval: func(target interface{}, scanContext *scanners.ScanContext) (bool, string) {
+			Eval: func(ctx context.Context, target interface{}, scanContext *scanners.ScanContext) (bool, string, error) {
 				c := target.(*armcompute.VirtualMachineScaleSet)
-				return len(c.Tags) == 0, ""
+				broken, result := scanners.EvaluateTagPolicy(c.Tags, scanContext.RequiredTags)
+				return broken, result, nil
 			},
-			Url: "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Url:             "https://learn.microsoft.com/en-us/azure/azure-resource-manager/management/tag-resources?tabs=json",
+			Remediation:     "az tag update --resource-id {resource_id} --operation Merge --tags <key>=<value>",
+			AutoRemediation: scanners.AutoRemediationTags,
 		},
 	}
 }
@@ -4,6 +4,7 @@
 package vmss
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -84,7 +85,10 @@ func TestVirtualMachineScaleSetScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &VirtualMachineScaleSetScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("VirtualMachineScaleSetScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
@@ -16,6 +16,9 @@ type VirtualMachineScaleSetScanner struct {
 
 // Init - Initializes the VirtualMachineScaleSetScanner
 func (c *VirtualMachineScaleSetScanner) Init(config *scanners.ScannerConfig) error {
+	if err := scanners.ValidateScannerConfig(config); err != nil {
+		return err
+	}
 	c.config = config
 	var err error
 	c.client, err = armcompute.NewVirtualMachineScaleSetsClient(config.SubscriptionID, config.Cred, config.ClientOptions)
@@ -35,16 +38,18 @@ func (c *VirtualMachineScaleSetScanner) Scan(resourceGroupName string, scanConte
 	results := []scanners.AzureServiceResult{}
 
 	for _, w := range vmss {
-		rr := engine.EvaluateRules(rules, w, scanContext)
+		rr := engine.EvaluateRules(c.config.Ctx, rules, w, scanContext)
 
 		results = append(results, scanners.AzureServiceResult{
 			SubscriptionID:   c.config.SubscriptionID,
 			SubscriptionName: c.config.SubscriptionName,
 			ResourceGroup:    resourceGroupName,
 			ServiceName:      *w.Name,
+			ID:               *w.ID,
 			Type:             *w.Type,
 			Location:         *w.Location,
 			Rules:            rr,
+			CreatedAt:        scanners.GetCreatedAt(w),
 		})
 	}
 	return results, nil
@@ -4,6 +4,7 @@
 package vnet
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -156,7 +157,10 @@ func TestVirtualNetworkScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &VirtualNetworkScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("VirtualNetworkScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
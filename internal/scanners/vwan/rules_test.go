@@ -4,6 +4,7 @@
 package vwan
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -104,7 +105,10 @@ func TestVirtualWanScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &VirtualWanScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("VirtualWanScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,
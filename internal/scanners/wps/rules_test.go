@@ -4,6 +4,7 @@
 package wps
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -148,7 +149,10 @@ func TestWebPubSubScanner_Rules(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &WebPubSubScanner{}
 			rules := s.GetRules()
-			b, w := rules[tt.fields.rule].Eval(tt.fields.target, tt.fields.scanContext)
+			b, w, err := rules[tt.fields.rule].Eval(context.Background(), tt.fields.target, tt.fields.scanContext)
+			if err != nil {
+				t.Fatalf("WebPubSubScanner Rule.Eval() error = %v", err)
+			}
 			got := want{
 				broken: b,
 				result: w,